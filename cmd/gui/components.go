@@ -4,9 +4,14 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/if97"
+	"github.com/somepgs/steamprops/internal/chart"
 	"github.com/somepgs/steamprops/internal/steamprops"
+	"github.com/somepgs/steamprops/units"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/widget"
 )
@@ -15,17 +20,17 @@ import (
 func regionToString(r int) string {
 	switch r {
 	case 1:
-		return "Region 1 (Сжатая жидкость)"
+		return lang.T("Region 1 (Сжатая жидкость)")
 	case 2:
-		return "Region 2 (Перегретый пар)"
+		return lang.T("Region 2 (Перегретый пар)")
 	case 3:
-		return "Region 3 (Критическая область)"
+		return lang.T("Region 3 (Критическая область)")
 	case 4:
-		return "Region 4 (Линия насыщения)"
+		return lang.T("Region 4 (Линия насыщения)")
 	case 5:
-		return "Region 5 (Высокотемпературный газ)"
+		return lang.T("Region 5 (Высокотемпературный газ)")
 	default:
-		return "Неизвестный регион"
+		return lang.T("Неизвестный регион")
 	}
 }
 
@@ -37,15 +42,29 @@ type InputPanel struct {
 	pressureEntry    *widget.Entry
 	enthalpyEntry    *widget.Entry
 	entropyEntry     *widget.Entry
+	volumeEntry      *widget.Entry
+	energyEntry      *widget.Entry
+	densityEntry     *widget.Entry
+	rhoTempEntry     *widget.Entry
+	thTempEntry      *widget.Entry
+	thEnthalpyEntry  *widget.Entry
+	tsTempEntry      *widget.Entry
+	tsEntropyEntry   *widget.Entry
 
 	// Единицы измерения
 	tempUnitSelect     *widget.Select
 	pressureUnitSelect *widget.Select
+	energyUnitSelect   *widget.Select
+	entropyUnitSelect  *widget.Select
 
 	// Контейнеры
 	mainContainer *fyne.Container
 	tpContainer   *fyne.Container
 	hsContainer   *fyne.Container
+	vuContainer   *fyne.Container
+	rhoTContainer *fyne.Container
+	thContainer   *fyne.Container
+	tsContainer   *fyne.Container
 }
 
 // NewInputPanel создает новую панель ввода
@@ -59,7 +78,7 @@ func NewInputPanel() *InputPanel {
 
 func (ip *InputPanel) setupElements() {
 	// Режим расчета
-	ip.modeSelect = widget.NewSelect([]string{"TP", "HS"}, nil)
+	ip.modeSelect = widget.NewSelect([]string{"TP", "HS", "VU", "RhoT", "TH", "TS"}, nil)
 	ip.modeSelect.SetSelected("TP")
 
 	// Поля ввода
@@ -79,22 +98,76 @@ func (ip *InputPanel) setupElements() {
 	ip.entropyEntry.SetPlaceHolder("0.2965")
 	ip.entropyEntry.SetText("0.2965")
 
-	// Единицы измерения
-	ip.tempUnitSelect = widget.NewSelect([]string{"°C", "K", "°F"}, nil)
-	ip.tempUnitSelect.SetSelected("°C")
+	ip.volumeEntry = widget.NewEntry()
+	ip.volumeEntry.SetPlaceHolder("0.2")
+	ip.volumeEntry.SetText("0.2")
+
+	ip.energyEntry = widget.NewEntry()
+	ip.energyEntry.SetPlaceHolder("2600")
+	ip.energyEntry.SetText("2600")
+
+	ip.densityEntry = widget.NewEntry()
+	ip.densityEntry.SetPlaceHolder("500")
+	ip.densityEntry.SetText("500")
+
+	ip.rhoTempEntry = widget.NewEntry()
+	ip.rhoTempEntry.SetPlaceHolder("380.0")
+	ip.rhoTempEntry.SetText("380.0")
+
+	ip.thTempEntry = widget.NewEntry()
+	ip.thTempEntry.SetPlaceHolder("300.0")
+	ip.thTempEntry.SetText("300.0")
+
+	ip.thEnthalpyEntry = widget.NewEntry()
+	ip.thEnthalpyEntry.SetPlaceHolder("2900")
+	ip.thEnthalpyEntry.SetText("2900")
+
+	ip.tsTempEntry = widget.NewEntry()
+	ip.tsTempEntry.SetPlaceHolder("300.0")
+	ip.tsTempEntry.SetText("300.0")
+
+	ip.tsEntropyEntry = widget.NewEntry()
+	ip.tsEntropyEntry.SetPlaceHolder("6.0")
+	ip.tsEntropyEntry.SetText("6.0")
 
-	ip.pressureUnitSelect = widget.NewSelect([]string{"Pa", "kPa", "MPa", "bar", "atm"}, nil)
-	ip.pressureUnitSelect.SetSelected("Pa")
+	// Единицы измерения: обозначения пакета units
+	var names []string
+	for _, u := range units.TemperatureUnits {
+		names = append(names, u.String())
+	}
+	ip.tempUnitSelect = widget.NewSelect(names, nil)
+	ip.tempUnitSelect.SetSelected(units.Celsius.String())
+
+	names = nil
+	for _, u := range units.PressureUnits {
+		names = append(names, u.String())
+	}
+	ip.pressureUnitSelect = widget.NewSelect(names, nil)
+	ip.pressureUnitSelect.SetSelected(units.Pascal.String())
+
+	names = nil
+	for _, u := range units.SpecificEnergyUnits {
+		names = append(names, u.String())
+	}
+	ip.energyUnitSelect = widget.NewSelect(names, nil)
+	ip.energyUnitSelect.SetSelected(units.KilojoulePerKg.String())
+
+	names = nil
+	for _, u := range units.SpecificEntropyUnits {
+		names = append(names, u.String())
+	}
+	ip.entropyUnitSelect = widget.NewSelect(names, nil)
+	ip.entropyUnitSelect.SetSelected(units.KilojoulePerKgK.String())
 }
 
 func (ip *InputPanel) setupLayout() {
 	// TP режим
 	ip.tpContainer = container.NewVBox(
-		widget.NewCard("Температура", "", container.NewHBox(
+		widget.NewCard(lang.T("Температура"), "", container.NewHBox(
 			ip.temperatureEntry,
 			ip.tempUnitSelect,
 		)),
-		widget.NewCard("Давление", "", container.NewHBox(
+		widget.NewCard(lang.T("Давление"), "", container.NewHBox(
 			ip.pressureEntry,
 			ip.pressureUnitSelect,
 		)),
@@ -102,29 +175,86 @@ func (ip *InputPanel) setupLayout() {
 
 	// HS режим
 	ip.hsContainer = container.NewVBox(
-		widget.NewCard("Энтальпия", "", container.NewHBox(
+		widget.NewCard(lang.T("Энтальпия"), "", container.NewHBox(
 			ip.enthalpyEntry,
-			widget.NewLabel("кДж/кг"),
+			ip.energyUnitSelect,
 		)),
-		widget.NewCard("Энтропия", "", container.NewHBox(
+		widget.NewCard(lang.T("Энтропия"), "", container.NewHBox(
 			ip.entropyEntry,
-			widget.NewLabel("кДж/(кг·К)"),
+			ip.entropyUnitSelect,
+		)),
+		widget.NewCard(lang.T("Информация"), "", widget.NewLabel(lang.T("Режим HS работает для Region 3\nДля других регионов используйте режим TP"))),
+	)
+
+	// VU режим
+	ip.vuContainer = container.NewVBox(
+		widget.NewCard(lang.T("Удельный объем"), "", container.NewHBox(
+			ip.volumeEntry,
+			widget.NewLabel(lang.T("м³/кг")),
+		)),
+		widget.NewCard(lang.T("Внутренняя энергия"), "", container.NewHBox(
+			ip.energyEntry,
+			widget.NewLabel(lang.T("кДж/кг")),
+		)),
+	)
+
+	// RhoT режим
+	ip.rhoTContainer = container.NewVBox(
+		widget.NewCard(lang.T("Плотность"), "", container.NewHBox(
+			ip.densityEntry,
+			widget.NewLabel(lang.T("кг/м³")),
+		)),
+		widget.NewCard(lang.T("Температура"), "", container.NewHBox(
+			ip.rhoTempEntry,
+			widget.NewLabel("°C"),
+		)),
+	)
+
+	// TH режим
+	ip.thContainer = container.NewVBox(
+		widget.NewCard(lang.T("Температура"), "", container.NewHBox(
+			ip.thTempEntry,
+			widget.NewLabel("°C"),
+		)),
+		widget.NewCard(lang.T("Энтальпия"), "", container.NewHBox(
+			ip.thEnthalpyEntry,
+			widget.NewLabel(lang.T("кДж/кг")),
+		)),
+	)
+
+	// TS режим
+	ip.tsContainer = container.NewVBox(
+		widget.NewCard(lang.T("Температура"), "", container.NewHBox(
+			ip.tsTempEntry,
+			widget.NewLabel("°C"),
+		)),
+		widget.NewCard(lang.T("Энтропия"), "", container.NewHBox(
+			ip.tsEntropyEntry,
+			widget.NewLabel(lang.T("кДж/(кг·К)")),
 		)),
-		widget.NewCard("Информация", "", widget.NewLabel("Режим HS работает для Region 3\nДля других регионов используйте режим TP")),
 	)
 
 	// Основной контейнер
 	ip.mainContainer = container.NewVBox(
-		widget.NewCard("Режим расчета", "", ip.modeSelect),
+		widget.NewCard(lang.T("Режим расчета"), "", ip.modeSelect),
 		container.NewMax(ip.tpContainer),
 	)
 }
 
 func (ip *InputPanel) setupEventHandlers() {
 	ip.modeSelect.OnChanged = func(mode string) {
-		if mode == "HS" {
+		switch mode {
+		case "HS":
 			ip.mainContainer.Objects[1] = ip.hsContainer
-		} else {
+		case "VU":
+			ip.mainContainer.Objects[1] = ip.vuContainer
+		case "RhoT":
+			ip.mainContainer.Objects[1] = ip.rhoTContainer
+		case "TH":
+			ip.mainContainer.Objects[1] = ip.thContainer
+		case "TS":
+			ip.mainContainer.Objects[1] = ip.tsContainer
+		default:
 			ip.mainContainer.Objects[1] = ip.tpContainer
 		}
 		ip.mainContainer.Refresh()
@@ -135,73 +265,170 @@ func (ip *InputPanel) GetContainer() *fyne.Container {
 	return ip.mainContainer
 }
 
-func (ip *InputPanel) GetInputs() (*steamprops.InputData, error) {
+// stateInput описывает введенную пару параметров
+type stateInput struct {
+	Mode    string
+	Summary string                       // краткая запись входных данных для истории
+	Solve   func() (*if97.Result, error) // расчет состояния пакетом if97
+}
+
+// GetInputs читает поля выбранного режима
+func (ip *InputPanel) GetInputs() (*stateInput, error) {
 	mode := ip.modeSelect.Selected
 
-	if mode == "HS" {
+	switch mode {
+	case "VU":
+		v, err := strconv.ParseFloat(ip.volumeEntry.Text, 64)
+		if err != nil {
+			return nil, i18n.Errorf("неверное значение удельного объема: %v", err)
+		}
+
+		u, err := strconv.ParseFloat(ip.energyEntry.Text, 64)
+		if err != nil {
+			return nil, i18n.Errorf("неверное значение внутренней энергии: %v", err)
+		}
+
+		return &stateInput{
+			Mode:    mode,
+			Summary: fmt.Sprintf("v=%.6g m³/kg, u=%.3f kJ/kg", v, u),
+			Solve:   func() (*if97.Result, error) { return if97.VU(v, u) },
+		}, nil
+	case "RhoT":
+		rho, err := strconv.ParseFloat(ip.densityEntry.Text, 64)
+		if err != nil {
+			return nil, i18n.Errorf("неверное значение плотности: %v", err)
+		}
+
+		t, err := strconv.ParseFloat(ip.rhoTempEntry.Text, 64)
+		if err != nil {
+			return nil, i18n.Errorf("неверное значение температуры: %v", err)
+		}
+
+		return &stateInput{
+			Mode:    mode,
+			Summary: fmt.Sprintf("ρ=%.6g kg/m³, T=%.3f°C", rho, t),
+			Solve:   func() (*if97.Result, error) { return if97.RhoT(rho, t) },
+		}, nil
+	case "TH":
+		t, err := strconv.ParseFloat(ip.thTempEntry.Text, 64)
+		if err != nil {
+			return nil, i18n.Errorf("неверное значение температуры: %v", err)
+		}
+
+		h, err := strconv.ParseFloat(ip.thEnthalpyEntry.Text, 64)
+		if err != nil {
+			return nil, i18n.Errorf("неверное значение энтальпии: %v", err)
+		}
+
+		return &stateInput{
+			Mode:    mode,
+			Summary: fmt.Sprintf("T=%.3f°C, h=%.3f kJ/kg", t, h),
+			Solve:   func() (*if97.Result, error) { return if97.TH(t, h) },
+		}, nil
+	case "TS":
+		t, err := strconv.ParseFloat(ip.tsTempEntry.Text, 64)
+		if err != nil {
+			return nil, i18n.Errorf("неверное значение температуры: %v", err)
+		}
+
+		s, err := strconv.ParseFloat(ip.tsEntropyEntry.Text, 64)
+		if err != nil {
+			return nil, i18n.Errorf("неверное значение энтропии: %v", err)
+		}
+
+		return &stateInput{
+			Mode:    mode,
+			Summary: fmt.Sprintf("T=%.3f°C, s=%.4f kJ/(kg·K)", t, s),
+			Solve:   func() (*if97.Result, error) { return if97.TS(t, s) },
+		}, nil
+	case "HS":
 		h, err := strconv.ParseFloat(ip.enthalpyEntry.Text, 64)
 		if err != nil {
-			return nil, fmt.Errorf("неверное значение энтальпии: %v", err)
+			return nil, i18n.Errorf("неверное значение энтальпии: %v", err)
 		}
 
 		s, err := strconv.ParseFloat(ip.entropyEntry.Text, 64)
 		if err != nil {
-			return nil, fmt.Errorf("неверное значение энтропии: %v", err)
+			return nil, i18n.Errorf("неверное значение энтропии: %v", err)
+		}
+
+		// Конвертация единиц
+		h, err = ip.convertEnergy(h)
+		if err != nil {
+			return nil, err
+		}
+		s, err = ip.convertEntropy(s)
+		if err != nil {
+			return nil, err
 		}
 
-		return &steamprops.InputData{
-			Mode:     mode,
-			Enthalpy: h,
-			Entropy:  s,
+		return &stateInput{
+			Mode:    mode,
+			Summary: fmt.Sprintf("h=%.3f kJ/kg, s=%.3f kJ/(kg·K)", h, s),
+			Solve:   func() (*if97.Result, error) { return if97.HS(h, s) },
 		}, nil
 	}
 
 	// TP режим
 	t, err := strconv.ParseFloat(ip.temperatureEntry.Text, 64)
 	if err != nil {
-		return nil, fmt.Errorf("неверное значение температуры: %v", err)
+		return nil, i18n.Errorf("неверное значение температуры: %v", err)
 	}
 
 	p, err := strconv.ParseFloat(ip.pressureEntry.Text, 64)
 	if err != nil {
-		return nil, fmt.Errorf("неверное значение давления: %v", err)
+		return nil, i18n.Errorf("неверное значение давления: %v", err)
 	}
 
 	// Конвертация единиц
-	t = ip.convertTemperature(t)
-	p = ip.convertPressure(p)
+	if t, err = ip.convertTemperature(t); err != nil {
+		return nil, err
+	}
+	if p, err = ip.convertPressure(p); err != nil {
+		return nil, err
+	}
 
-	return &steamprops.InputData{
-		Mode:        mode,
-		Temperature: t,
-		Pressure:    p,
+	return &stateInput{
+		Mode:    mode,
+		Summary: fmt.Sprintf("T=%.3f°C, p=%.0f Pa", t, p),
+		Solve:   func() (*if97.Result, error) { return if97.TP(t, p) },
 	}, nil
 }
 
-func (ip *InputPanel) convertTemperature(t float64) float64 {
-	switch ip.tempUnitSelect.Selected {
-	case "K":
-		return t - 273.15
-	case "°F":
-		return (t - 32) * 5 / 9
-	default: // °C
-		return t
+// convertTemperature переводит температуру из выбранной единицы в °C
+func (ip *InputPanel) convertTemperature(t float64) (float64, error) {
+	u, err := units.ParseTemperatureUnit(ip.tempUnitSelect.Selected)
+	if err != nil {
+		return 0, err
+	}
+	return u.Of(t).Celsius(), nil
+}
+
+// convertPressure переводит давление из выбранной единицы в Па
+func (ip *InputPanel) convertPressure(p float64) (float64, error) {
+	u, err := units.ParsePressureUnit(ip.pressureUnitSelect.Selected)
+	if err != nil {
+		return 0, err
+	}
+	return u.Of(p).Pascals(), nil
+}
+
+// convertEnergy переводит энтальпию из выбранной единицы в кДж/кг
+func (ip *InputPanel) convertEnergy(h float64) (float64, error) {
+	u, err := units.ParseSpecificEnergyUnit(ip.energyUnitSelect.Selected)
+	if err != nil {
+		return 0, err
 	}
+	return u.Of(h).KilojoulesPerKg(), nil
 }
 
-func (ip *InputPanel) convertPressure(p float64) float64 {
-	switch ip.pressureUnitSelect.Selected {
-	case "kPa":
-		return p * 1000
-	case "MPa":
-		return p * 1000000
-	case "bar":
-		return p * 100000
-	case "atm":
-		return p * 101325
-	default: // Pa
-		return p
+// convertEntropy переводит энтропию из выбранной единицы в кДж/(кг·К)
+func (ip *InputPanel) convertEntropy(s float64) (float64, error) {
+	u, err := units.ParseSpecificEntropyUnit(ip.entropyUnitSelect.Selected)
+	if err != nil {
+		return 0, err
 	}
+	return u.Of(s).KilojoulesPerKgK(), nil
 }
 
 func (ip *InputPanel) Clear() {
@@ -217,6 +444,12 @@ type ResultsPanel struct {
 	regionLabel   *widget.Label
 	resultsTable  *widget.Table
 	mainContainer *fyne.Container
+
+	// Система единиц вывода и избыточное давление
+	systemSelect    *widget.Select
+	gaugeCheck      *widget.Check
+	atmosphereEntry *widget.Entry
+	last            *if97.Result // последний результат, пересчитывается при смене единиц
 }
 
 // NewResultsPanel создает новую панель результатов
@@ -228,10 +461,10 @@ func NewResultsPanel() *ResultsPanel {
 }
 
 func (rp *ResultsPanel) setupElements() {
-	rp.phaseLabel = widget.NewLabel("Фаза: Не определена")
+	rp.phaseLabel = widget.NewLabel(lang.T("Фаза: Не определена"))
 	rp.phaseLabel.TextStyle.Bold = true
 
-	rp.regionLabel = widget.NewLabel("Регион: Не определен")
+	rp.regionLabel = widget.NewLabel(lang.T("Регион: Не определен"))
 	rp.regionLabel.TextStyle.Italic = true
 
 	rp.resultsTable = widget.NewTable(
@@ -246,6 +479,41 @@ func (rp *ResultsPanel) setupElements() {
 	)
 	rp.resultsTable.SetColumnWidth(0, 200)
 	rp.resultsTable.SetColumnWidth(1, 150)
+
+	var titles []string
+	for _, sys := range units.Systems {
+		titles = append(titles, lang.T(sys.Title))
+	}
+	refresh := func() {
+		if rp.last != nil {
+			rp.UpdateResults(rp.last)
+		}
+	}
+	rp.systemSelect = widget.NewSelect(titles, func(string) { refresh() })
+	rp.systemSelect.SetSelected(lang.T(units.SI.Title))
+	rp.gaugeCheck = widget.NewCheck(lang.T("Избыточное давление"), func(bool) { refresh() })
+	rp.atmosphereEntry = widget.NewEntry()
+	rp.atmosphereEntry.SetText("101325 Pa")
+	rp.atmosphereEntry.OnSubmitted = func(string) { refresh() }
+}
+
+// system возвращает выбранную систему единиц вывода
+func (rp *ResultsPanel) system() units.System {
+	sys := units.SI
+	for _, s := range units.Systems {
+		if lang.T(s.Title) == rp.systemSelect.Selected {
+			sys = s
+		}
+	}
+	if rp.gaugeCheck.Checked {
+		// Некорректная запись атмосферного давления заменяется нормальным
+		atm, err := units.ParsePressure(rp.atmosphereEntry.Text, units.Pascal)
+		if err != nil {
+			atm = units.StandardAtmosphere
+		}
+		sys = sys.WithGauge(atm)
+	}
+	return sys
 }
 
 func (rp *ResultsPanel) setupLayout() {
@@ -255,8 +523,9 @@ func (rp *ResultsPanel) setupLayout() {
 	)
 
 	rp.mainContainer = container.NewVBox(
-		widget.NewCard("Результаты расчета", "", container.NewVBox(
+		widget.NewCard(lang.T("Результаты расчета"), "", container.NewVBox(
 			headerContainer,
+			container.NewHBox(rp.systemSelect, rp.gaugeCheck, rp.atmosphereEntry),
 			rp.resultsTable,
 		)),
 	)
@@ -266,24 +535,31 @@ func (rp *ResultsPanel) GetContainer() *fyne.Container {
 	return rp.mainContainer
 }
 
-func (rp *ResultsPanel) UpdateResults(result *steamprops.Result) {
-	rp.phaseLabel.SetText(fmt.Sprintf("Фаза: %s", result.Phase))
-	rp.regionLabel.SetText(fmt.Sprintf("Регион: %s", regionToString(int(result.Region))))
+func (rp *ResultsPanel) UpdateResults(result *if97.Result) {
+	rp.last = result
+	rp.phaseLabel.SetText(lang.T("Фаза: %s", lang.T(result.Phase)))
+	rp.regionLabel.SetText(lang.T("Регион: %s", regionToString(int(result.Region))))
 
+	sys := rp.system()
+	c := result.In(sys)
 	results := [][]string{
-		{"Температура", fmt.Sprintf("%.3f °C", result.Temperature)},
-		{"Давление", fmt.Sprintf("%.3f MPa (%.0f Pa)", result.Pressure/1e6, result.Pressure)},
-		{"Плотность", fmt.Sprintf("%.6g кг/м³", result.Properties.Density)},
-		{"Удельный объем", fmt.Sprintf("%.6g м³/кг", result.Properties.SpecificVolume)},
-		{"Энтальпия", fmt.Sprintf("%.6g кДж/кг", result.Properties.SpecificEnthalpy)},
-		{"Энтропия", fmt.Sprintf("%.6g кДж/(кг·К)", result.Properties.SpecificEntropy)},
-		{"Внутренняя энергия", fmt.Sprintf("%.6g кДж/кг", result.Properties.SpecificInternalEnergy)},
-		{"Изобарная теплоемкость", fmt.Sprintf("%.6g кДж/(кг·К)", result.Properties.SpecificIsobaricHeatCapacity)},
-		{"Изохорная теплоемкость", fmt.Sprintf("%.6g кДж/(кг·К)", result.Properties.SpecificIsochoricHeatCapacity)},
-		{"Скорость звука", fmt.Sprintf("%.6g м/с", result.Properties.SpeedOfSound)},
-		{"Динамическая вязкость", result.TransportProps["dynamic_viscosity"]},
-		{"Кинематическая вязкость", result.TransportProps["kinematic_viscosity"]},
-		{"Теплопроводность", result.TransportProps["thermal_conductivity"]},
+		{lang.T("Температура"), fmt.Sprintf("%.3f %s", c.Temperature, sys.Temperature)},
+		{lang.T("Давление"), fmt.Sprintf("%.6g %s", c.Pressure, sys.PressureSymbol())},
+		{lang.T("Плотность"), fmt.Sprintf("%.6g %s", c.Properties.Density, sys.Density)},
+		{lang.T("Удельный объем"), fmt.Sprintf("%.6g %s", c.Properties.SpecificVolume, sys.SpecificVolume)},
+		{lang.T("Энтальпия"), fmt.Sprintf("%.6g %s", c.Properties.SpecificEnthalpy, sys.SpecificEnergy)},
+		{lang.T("Энтропия"), fmt.Sprintf("%.6g %s", c.Properties.SpecificEntropy, sys.SpecificEntropy)},
+		{lang.T("Внутренняя энергия"), fmt.Sprintf("%.6g %s", c.Properties.SpecificInternalEnergy, sys.SpecificEnergy)},
+		{lang.T("Изобарная теплоемкость"), fmt.Sprintf("%.6g %s", c.Properties.SpecificIsobaricHeatCapacity, sys.SpecificEntropy)},
+		{lang.T("Изохорная теплоемкость"), fmt.Sprintf("%.6g %s", c.Properties.SpecificIsochoricHeatCapacity, sys.SpecificEntropy)},
+		{lang.T("Скорость звука"), fmt.Sprintf("%.6g %s", c.Properties.SpeedOfSound, sys.Speed)},
+	}
+	if tr := c.Transport; tr != nil {
+		results = append(results,
+			[]string{lang.T("Динамическая вязкость"), fmt.Sprintf("%.2e %s", tr.DynamicViscosity, sys.DynamicViscosity)},
+			[]string{lang.T("Кинематическая вязкость"), fmt.Sprintf("%.2e %s", tr.KinematicViscosity, sys.KinematicViscosity)},
+			[]string{lang.T("Теплопроводность"), fmt.Sprintf("%.4g %s", tr.ThermalConductivity, sys.ThermalConductivity)},
+		)
 	}
 
 	rp.resultsTable.UpdateCell = func(id widget.TableCellID, obj fyne.CanvasObject) {
@@ -299,8 +575,9 @@ func (rp *ResultsPanel) UpdateResults(result *steamprops.Result) {
 }
 
 func (rp *ResultsPanel) Clear() {
-	rp.phaseLabel.SetText("Фаза: Не определена")
-	rp.regionLabel.SetText("Регион: Не определен")
+	rp.last = nil
+	rp.phaseLabel.SetText(lang.T("Фаза: Не определена"))
+	rp.regionLabel.SetText(lang.T("Регион: Не определен"))
 	rp.resultsTable.Length = func() (int, int) { return 0, 2 }
 	rp.resultsTable.Refresh()
 }
@@ -321,10 +598,10 @@ func NewControlPanel() *ControlPanel {
 }
 
 func (cp *ControlPanel) setupElements() {
-	cp.calculateButton = widget.NewButton("Рассчитать", nil)
+	cp.calculateButton = widget.NewButton(lang.T("Рассчитать"), nil)
 	cp.calculateButton.Importance = widget.HighImportance
 
-	cp.clearButton = widget.NewButton("Очистить", nil)
+	cp.clearButton = widget.NewButton(lang.T("Очистить"), nil)
 }
 
 func (cp *ControlPanel) setupLayout() {
@@ -368,10 +645,10 @@ func NewHistoryPanel() *HistoryPanel {
 		},
 	)
 	// Кнопка очистки
-	h.clearButton = widget.NewButton("Очистить историю", func() { h.Clear() })
+	h.clearButton = widget.NewButton(lang.T("Очистить историю"), func() { h.Clear() })
 	// Компоновка панели
 	h.mainContainer = container.NewVBox(
-		widget.NewCard("История расчетов", "Последние результаты сессии", container.NewBorder(nil, h.clearButton, nil, nil, h.list)),
+		widget.NewCard(lang.T("История расчетов"), lang.T("Последние результаты сессии"), container.NewBorder(nil, h.clearButton, nil, nil, h.list)),
 	)
 	return h
 }
@@ -393,3 +670,126 @@ func (h *HistoryPanel) Clear() {
 	h.items = nil
 	h.list.Refresh()
 }
+
+// diagramTypes названия диаграмм в списке выбора (сообщения каталога i18n) и
+// их имена в пакете chart
+var diagramTypes = []struct{ title, name string }{
+	{i18n.N("h–s (Молье)"), "hs"},
+	{"T–s", "ts"},
+	{"lg p–h", "ph"},
+}
+
+// ChartPanel представляет панель диаграммы состояния с рассчитанными точками
+type ChartPanel struct {
+	calculator    *steamprops.Calculator
+	diagramSelect *widget.Select
+	connectCheck  *widget.Check
+	clearButton   *widget.Button
+	statusLabel   *widget.Label
+	image         *canvas.Image
+	mainContainer *fyne.Container
+
+	points []chart.PointSpec
+	charts map[string]*chart.Chart // изолинии, рассчитанные для каждого типа диаграммы
+}
+
+// NewChartPanel создает новую панель диаграммы
+func NewChartPanel(calculator *steamprops.Calculator) *ChartPanel {
+	cp := &ChartPanel{
+		calculator: calculator,
+		charts:     map[string]*chart.Chart{},
+	}
+
+	titles := make([]string, len(diagramTypes))
+	for i, d := range diagramTypes {
+		titles[i] = lang.T(d.title)
+	}
+	cp.diagramSelect = widget.NewSelect(titles, func(string) { cp.Render() })
+	cp.connectCheck = widget.NewCheck(lang.T("Соединять точки в процесс"), func(bool) { cp.Render() })
+	cp.clearButton = widget.NewButton(lang.T("Очистить точки"), func() {
+		cp.points = nil
+		cp.Render()
+	})
+	cp.statusLabel = widget.NewLabel("")
+
+	cp.image = canvas.NewImageFromImage(nil)
+	cp.image.FillMode = canvas.ImageFillContain
+	cp.image.SetMinSize(fyne.NewSize(600, 430))
+
+	cp.mainContainer = container.NewBorder(
+		container.NewHBox(cp.diagramSelect, cp.connectCheck, cp.clearButton),
+		cp.statusLabel, nil, nil,
+		cp.image,
+	)
+	cp.diagramSelect.SetSelected(titles[0])
+	return cp
+}
+
+// GetContainer возвращает контейнер панели диаграммы
+func (cp *ChartPanel) GetContainer() *fyne.Container { return cp.mainContainer }
+
+// AddState наносит рассчитанное состояние на диаграмму
+func (cp *ChartPanel) AddState(result *if97.Result) {
+	label := strconv.Itoa(len(cp.points) + 1)
+	cp.points = append(cp.points, chart.PointAt(label, result.Temperature, result.Pressure, result.Quality))
+	cp.Render()
+}
+
+// Render перерисовывает диаграмму выбранного типа
+func (cp *ChartPanel) Render() {
+	name := diagramTypes[0].name
+	for _, d := range diagramTypes {
+		if lang.T(d.title) == cp.diagramSelect.Selected {
+			name = d.name
+		}
+	}
+
+	base, ok := cp.charts[name]
+	if !ok {
+		d, err := chart.DiagramByName(name)
+		if err != nil {
+			cp.statusLabel.SetText(lang.Error(err))
+			return
+		}
+		opts := chart.DefaultOptions()
+		opts.Width, opts.Height = 900, 650
+		opts.Locale = lang
+		if base, err = chart.New(cp.calculator, d, opts); err != nil {
+			cp.statusLabel.SetText(lang.T("Ошибка построения диаграммы: %v", err))
+			return
+		}
+		cp.charts[name] = base
+	}
+
+	c := *base
+	c.Paths = nil
+	var groups [][]chart.PointSpec
+	if cp.connectCheck.Checked && len(cp.points) > 0 {
+		groups = append(groups, cp.points)
+	} else {
+		for _, p := range cp.points {
+			groups = append(groups, []chart.PointSpec{p})
+		}
+	}
+	for _, g := range groups {
+		label := ""
+		if len(g) > 1 {
+			label = lang.T("Процесс")
+		}
+		path, err := chart.NewPath(cp.calculator, label, g, 0)
+		if err != nil {
+			cp.statusLabel.SetText(lang.T("Ошибка построения процесса: %v", err))
+			return
+		}
+		c.AddPath(path)
+	}
+
+	img, err := c.Image()
+	if err != nil {
+		cp.statusLabel.SetText(lang.T("Ошибка вывода диаграммы: %v", err))
+		return
+	}
+	cp.image.Image = img
+	cp.image.Refresh()
+	cp.statusLabel.SetText(lang.T("Точек на диаграмме: %d", len(cp.points)))
+}
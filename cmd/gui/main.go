@@ -1,10 +1,13 @@
 package main
 
 import (
+	"errors"
 	"fmt"
-	"github.com/somepgs/steamprops/internal/steamprops"
 	"time"
 
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/internal/steamprops"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
@@ -12,6 +15,13 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
+// prefLang — ключ настройки языка интерфейса
+const prefLang = "lang"
+
+// lang — язык интерфейса: настройка приложения, иначе переменные окружения
+// STEAMPROPS_LANG, LC_ALL, LC_MESSAGES и LANG
+var lang = i18n.FromEnv()
+
 // Application представляет главное приложение SteamProps
 type Application struct {
 	app    fyne.App
@@ -22,6 +32,8 @@ type Application struct {
 	resultsPanel *ResultsPanel
 	controlPanel *ControlPanel
 	historyPanel *HistoryPanel
+	chartPanel   *ChartPanel
+	tabs         *container.AppTabs
 
 	// Вычислительное ядро
 	calculator *steamprops.Calculator
@@ -33,6 +45,9 @@ func NewApplication() *Application {
 		app:        app.NewWithID("com.steamprops"),
 		calculator: steamprops.NewCalculator(),
 	}
+	if l, ok := i18n.Parse(app.app.Preferences().String(prefLang)); ok {
+		lang = l
+	}
 
 	app.setupWindow()
 	app.setupComponents()
@@ -54,6 +69,7 @@ func (a *Application) setupComponents() {
 	a.resultsPanel = NewResultsPanel()
 	a.controlPanel = NewControlPanel()
 	a.historyPanel = NewHistoryPanel()
+	a.chartPanel = NewChartPanel(a.calculator)
 }
 
 func (a *Application) setupLayout() {
@@ -71,23 +87,64 @@ func (a *Application) setupLayout() {
 		a.controlPanel.GetContainer(),
 	)
 
+	// Выбор языка интерфейса
+	names := make([]string, len(i18n.Locales))
+	for i, l := range i18n.Locales {
+		names[i] = l.Name()
+	}
+	langSelect := widget.NewSelect(names, nil)
+	langSelect.SetSelected(lang.Name())
+	langSelect.OnChanged = func(name string) {
+		for _, l := range i18n.Locales {
+			if l.Name() == name && l != lang {
+				a.setLanguage(l)
+			}
+		}
+	}
+
 	// Вкладка "О программе"
-	aboutContent := widget.NewCard("О программе", "",
+	aboutContent := widget.NewCard(lang.T("О программе"), "",
 		container.NewVBox(
-			widget.NewLabel("SteamProps — калькулятор свойств воды и пара (IAPWS IF-97)."),
-			widget.NewLabel("Эта вкладка будет дополнена справкой, ссылками и горячими клавишами."),
+			widget.NewLabel(lang.T("SteamProps — калькулятор свойств воды и пара (IAPWS IF-97).")),
+			widget.NewLabel(lang.T("Эта вкладка будет дополнена справкой, ссылками и горячими клавишами.")),
+			widget.NewForm(widget.NewFormItem(lang.T("Язык интерфейса"), langSelect)),
 		),
 	)
 
 	// Вкладки приложения
-	tabs := container.NewAppTabs(
-		container.NewTabItem("Калькулятор", calculatorContent),
-		container.NewTabItem("История", a.historyPanel.GetContainer()),
-		container.NewTabItem("О программе", aboutContent),
+	a.tabs = container.NewAppTabs(
+		container.NewTabItem(lang.T("Калькулятор"), calculatorContent),
+		container.NewTabItem(lang.T("Диаграмма"), a.chartPanel.GetContainer()),
+		container.NewTabItem(lang.T("История"), a.historyPanel.GetContainer()),
+		container.NewTabItem(lang.T("О программе"), aboutContent),
 	)
-	tabs.SetTabLocation(container.TabLocationTop)
+	a.tabs.SetTabLocation(container.TabLocationTop)
+
+	a.window.SetContent(a.tabs)
+}
 
-	a.window.SetContent(tabs)
+// setLanguage сохраняет язык интерфейса в настройках и перестраивает окно.
+// История, точки диаграммы и последний результат сохраняются.
+func (a *Application) setLanguage(l i18n.Locale) {
+	lang = l
+	a.app.Preferences().SetString(prefLang, string(l))
+
+	history := a.historyPanel.items
+	points := a.chartPanel.points
+	last := a.resultsPanel.last
+
+	a.setupComponents()
+	a.setupLayout()
+	a.setupEventHandlers()
+
+	a.historyPanel.items = history
+	a.historyPanel.list.Refresh()
+	a.chartPanel.points = points
+	a.chartPanel.Render()
+	if last != nil {
+		a.resultsPanel.UpdateResults(last)
+	}
+	a.tabs.SelectIndex(len(a.tabs.Items) - 1)
 }
 
 func (a *Application) setupEventHandlers() {
@@ -102,34 +159,23 @@ func (a *Application) calculate() {
 	// Получаем входные данные
 	inputs, err := a.inputPanel.GetInputs()
 	if err != nil {
-		dialog.ShowError(err, a.window)
-		return
-	}
-
-	// Валидируем входные данные
-	if err := inputs.Validate(); err != nil {
-		dialog.ShowError(err, a.window)
+		dialog.ShowError(errors.New(lang.Error(err)), a.window)
 		return
 	}
 
 	// Выполняем расчет
-	result, err := a.calculator.Calculate(inputs)
+	result, err := inputs.Solve()
 	if err != nil {
-		dialog.ShowError(err, a.window)
+		dialog.ShowError(errors.New(lang.Error(err)), a.window)
 		return
 	}
 
 	// Отображаем результаты
 	a.resultsPanel.UpdateResults(result)
+	a.chartPanel.AddState(result)
 
 	// Добавляем запись в историю (краткое резюме)
-	var in string
-	if inputs.Mode == "TP" {
-		in = fmt.Sprintf("T=%.3f°C, p=%.0f Pa", inputs.Temperature, inputs.Pressure)
-	} else {
-		in = fmt.Sprintf("h=%.3f kJ/kg, s=%.3f kJ/(kg·K)", inputs.Enthalpy, inputs.Entropy)
-	}
-	summary := fmt.Sprintf("%s | %s | %s | %s", time.Now().Format("15:04:05"), inputs.Mode, in, regionToString(int(result.Region)))
+	summary := fmt.Sprintf("%s | %s | %s | %s", time.Now().Format("15:04:05"), inputs.Mode, inputs.Summary, regionToString(int(result.Region)))
 	if a.historyPanel != nil {
 		a.historyPanel.AddEntry(summary)
 	}
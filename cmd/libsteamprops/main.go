@@ -0,0 +1,405 @@
+// Команда libsteamprops — разделяемая библиотека с C ABI для программ на C
+// и Фортране:
+//
+//	go build -buildmode=c-shared -o libsteamprops.so ./cmd/libsteamprops
+//
+// Вместе с библиотекой сборка создает заголовок libsteamprops.h с
+// объявлениями функций, структуры sp_state и кодов ошибок. Единицы — те же,
+// что у пакета if97: °C, Па, кДж/кг, кДж/(кг·К); sp_props_si принимает и
+// возвращает СИ, как PropsSI. Каждая функция возвращает SP_OK или код
+// ошибки, текст последней ошибки копирует sp_last_error. Функции sp_state_n
+// и sp_props_si_n рассчитывают массивы точек одним пакетным вызовом и
+// возвращают коды строк в массиве codes. Язык текста
+// задается при загрузке библиотеки переменными STEAMPROPS_LANG, LC_ALL,
+// LC_MESSAGES и LANG.
+package main
+
+/*
+#include <stddef.h>
+
+// Версия ABI: меняется только при несовместимом изменении sp_state или
+// сигнатур функций
+#define SP_ABI_VERSION 1
+
+// Коды возврата
+enum {
+	SP_OK = 0,
+	SP_ERR_INPUT = 1,               // входные данные вне области IF-97, неверный режим sp_state_n
+	SP_ERR_CALCULATION = 2,         // состояние не найдено
+	SP_ERR_AMBIGUOUS = 3,           // несколько решений по (T,h) или (T,s)
+	SP_ERR_UNKNOWN_PARAMETER = 4,   // неизвестное имя параметра sp_props_si
+	SP_ERR_UNSUPPORTED_INPUTS = 5,  // неподдерживаемая пара параметров sp_props_si
+	SP_ERR_UNSUPPORTED_FLUID = 6,   // вещество не вода
+	SP_ERR_NULL_POINTER = 7,        // нулевой указатель на результат или строку
+	SP_ERR_INTERNAL = 8             // непредвиденная ошибка библиотеки
+};
+
+// Фазовое состояние
+enum {
+	SP_PHASE_LIQUID = 0,
+	SP_PHASE_VAPOR = 1,
+	SP_PHASE_TWO_PHASE = 2,
+	SP_PHASE_SUPERCRITICAL = 3
+};
+
+// Рассчитанное состояние
+typedef struct {
+	int region;                  // регион IF-97 1..5
+	int phase;                   // SP_PHASE_*
+	double quality;              // паросодержание 0..1 в регионе 4, -1 вне его
+	double temperature;          // °C
+	double pressure;             // Па
+	double specific_volume;      // м³/кг
+	double density;              // кг/м³
+	double internal_energy;      // кДж/кг
+	double enthalpy;             // кДж/кг
+	double entropy;              // кДж/(кг·К)
+	double cp;                   // кДж/(кг·К), 0 во влажном паре
+	double cv;                   // кДж/(кг·К), 0 во влажном паре
+	double speed_of_sound;       // м/с, 0 во влажном паре
+	double dynamic_viscosity;    // Па·с, 0 если не определена
+	double thermal_conductivity; // Вт/(м·К), 0 если не определена
+} sp_state;
+*/
+import "C"
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"unsafe"
+
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/if97"
+	"github.com/somepgs/steamprops/propserr"
+)
+
+func main() {}
+
+var (
+	lastErrorMu sync.Mutex
+	lastError   string
+)
+
+// lang — язык текста ошибок sp_last_error
+var lang = i18n.FromEnv()
+
+var errNullPointer = errors.New("нулевой указатель")
+
+// fail запоминает текст ошибки для sp_last_error и возвращает ее код
+func fail(err error) C.int {
+	lastErrorMu.Lock()
+	lastError = lang.Error(err)
+	lastErrorMu.Unlock()
+	return errorCode(err)
+}
+
+func errorCode(err error) C.int {
+	var (
+		inputErr    *if97.InputError
+		calcErr     *if97.CalculationError
+		ambiguous   *if97.AmbiguousStateError
+		unknown     *if97.UnknownParameterError
+		unsupported *if97.UnsupportedInputsError
+		fluid       *if97.UnsupportedFluidError
+	)
+	switch {
+	case errors.Is(err, errNullPointer):
+		return C.SP_ERR_NULL_POINTER
+	case errors.As(err, &inputErr), propserr.CodeOf(err) == propserr.InvalidInput:
+		return C.SP_ERR_INPUT
+	case errors.As(err, &calcErr):
+		return C.SP_ERR_CALCULATION
+	case errors.As(err, &ambiguous):
+		return C.SP_ERR_AMBIGUOUS
+	case errors.As(err, &unknown):
+		return C.SP_ERR_UNKNOWN_PARAMETER
+	case errors.As(err, &unsupported):
+		return C.SP_ERR_UNSUPPORTED_INPUTS
+	case errors.As(err, &fluid):
+		return C.SP_ERR_UNSUPPORTED_FLUID
+	}
+	return C.SP_ERR_INTERNAL
+}
+
+// guard превращает панику в SP_ERR_INTERNAL: паника не должна завершать
+// вызывающую программу
+func guard(code *C.int) {
+	if r := recover(); r != nil {
+		*code = fail(i18n.Errorf("внутренняя ошибка: %v", r))
+	}
+}
+
+// calculate рассчитывает состояние и заполняет out
+func calculate(out *C.sp_state, build func() (*if97.State, error)) (code C.int) {
+	defer guard(&code)
+	if out == nil {
+		return fail(errNullPointer)
+	}
+	st, err := build()
+	if err != nil {
+		return fail(err)
+	}
+	*out = C.sp_state{
+		region:          C.int(st.Region()),
+		phase:           phaseCode(st.Phase()),
+		quality:         C.double(st.Quality()),
+		temperature:     C.double(st.Temperature()),
+		pressure:        C.double(st.Pressure()),
+		specific_volume: C.double(st.SpecificVolume()),
+		density:         C.double(st.Density()),
+		internal_energy: C.double(st.SpecificInternalEnergy()),
+		enthalpy:        C.double(st.SpecificEnthalpy()),
+		entropy:         C.double(st.SpecificEntropy()),
+		cp:              C.double(st.SpecificIsobaricHeatCapacity()),
+		cv:              C.double(st.SpecificIsochoricHeatCapacity()),
+		speed_of_sound:  C.double(st.SpeedOfSound()),
+	}
+	if tr := st.Transport(); tr != nil {
+		out.dynamic_viscosity = C.double(tr.DynamicViscosity)
+		out.thermal_conductivity = C.double(tr.ThermalConductivity)
+	}
+	return C.SP_OK
+}
+
+// phaseCode переводит фазу в SP_PHASE_*, не завися от порядка констант Go
+func phaseCode(p if97.Phase) C.int {
+	switch p {
+	case if97.PhaseVapor:
+		return C.SP_PHASE_VAPOR
+	case if97.PhaseTwoPhase:
+		return C.SP_PHASE_TWO_PHASE
+	case if97.PhaseSupercritical:
+		return C.SP_PHASE_SUPERCRITICAL
+	}
+	return C.SP_PHASE_LIQUID
+}
+
+//export sp_abi_version
+func sp_abi_version() C.int { return C.SP_ABI_VERSION }
+
+//export sp_tp
+func sp_tp(t, p C.double, out *C.sp_state) C.int {
+	return calculate(out, func() (*if97.State, error) { return if97.FromTP(float64(t), float64(p)) })
+}
+
+//export sp_tp_region
+func sp_tp_region(region C.int, t, p C.double, out *C.sp_state) C.int {
+	return calculate(out, func() (*if97.State, error) {
+		return if97.FromTPRegion(if97.Region(region), float64(t), float64(p))
+	})
+}
+
+//export sp_ph
+func sp_ph(p, h C.double, out *C.sp_state) C.int {
+	return calculate(out, func() (*if97.State, error) { return if97.FromPH(float64(p), float64(h)) })
+}
+
+//export sp_ps
+func sp_ps(p, s C.double, out *C.sp_state) C.int {
+	return calculate(out, func() (*if97.State, error) { return if97.FromPS(float64(p), float64(s)) })
+}
+
+//export sp_hs
+func sp_hs(h, s C.double, out *C.sp_state) C.int {
+	return calculate(out, func() (*if97.State, error) { return if97.FromHS(float64(h), float64(s)) })
+}
+
+//export sp_px
+func sp_px(p, x C.double, out *C.sp_state) C.int {
+	return calculate(out, func() (*if97.State, error) { return if97.FromPX(float64(p), float64(x)) })
+}
+
+//export sp_tx
+func sp_tx(t, x C.double, out *C.sp_state) C.int {
+	return calculate(out, func() (*if97.State, error) { return if97.FromTX(float64(t), float64(x)) })
+}
+
+//export sp_vu
+func sp_vu(v, u C.double, out *C.sp_state) C.int {
+	return calculate(out, func() (*if97.State, error) { return if97.FromVU(float64(v), float64(u)) })
+}
+
+//export sp_rhot
+func sp_rhot(rho, t C.double, out *C.sp_state) C.int {
+	return calculate(out, func() (*if97.State, error) { return if97.FromRhoT(float64(rho), float64(t)) })
+}
+
+//export sp_th
+func sp_th(t, h C.double, out *C.sp_state) C.int {
+	return calculate(out, func() (*if97.State, error) { return if97.FromTH(float64(t), float64(h)) })
+}
+
+//export sp_ts
+func sp_ts(t, s C.double, out *C.sp_state) C.int {
+	return calculate(out, func() (*if97.State, error) { return if97.FromTS(float64(t), float64(s)) })
+}
+
+//export sp_props_si
+func sp_props_si(output, name1 *C.char, value1 C.double, name2 *C.char, value2 C.double, fluid *C.char, result *C.double) (code C.int) {
+	defer guard(&code)
+	if output == nil || name1 == nil || name2 == nil || fluid == nil || result == nil {
+		return fail(errNullPointer)
+	}
+	v, err := if97.PropsSI(C.GoString(output), C.GoString(name1), float64(value1), C.GoString(name2), float64(value2), C.GoString(fluid))
+	if err != nil {
+		return fail(err)
+	}
+	*result = C.double(v)
+	return C.SP_OK
+}
+
+// batchModes — столбцы пакета для аргументов a и b функции sp_state_n
+var batchModes = map[string]func(*if97.Batch) (a, b *[]float64){
+	"TP":   func(b *if97.Batch) (*[]float64, *[]float64) { return &b.Temperature, &b.Pressure },
+	"PH":   func(b *if97.Batch) (*[]float64, *[]float64) { return &b.Pressure, &b.Enthalpy },
+	"PS":   func(b *if97.Batch) (*[]float64, *[]float64) { return &b.Pressure, &b.Entropy },
+	"HS":   func(b *if97.Batch) (*[]float64, *[]float64) { return &b.Enthalpy, &b.Entropy },
+	"PX":   func(b *if97.Batch) (*[]float64, *[]float64) { return &b.Pressure, &b.Quality },
+	"TX":   func(b *if97.Batch) (*[]float64, *[]float64) { return &b.Temperature, &b.Quality },
+	"VU":   func(b *if97.Batch) (*[]float64, *[]float64) { return &b.SpecificVolume, &b.InternalEnergy },
+	"RhoT": func(b *if97.Batch) (*[]float64, *[]float64) { return &b.Density, &b.Temperature },
+	"TH":   func(b *if97.Batch) (*[]float64, *[]float64) { return &b.Temperature, &b.Enthalpy },
+	"TS":   func(b *if97.Batch) (*[]float64, *[]float64) { return &b.Temperature, &b.Entropy },
+}
+
+// doubles возвращает копию n элементов массива C
+func doubles(p *C.double, n int) []float64 {
+	v := make([]float64, n)
+	for i, x := range unsafe.Slice(p, n) {
+		v[i] = float64(x)
+	}
+	return v
+}
+
+// rowCodes записывает коды строк в codes, если он задан, и запоминает для
+// sp_last_error текст ошибки первой неудачной строки
+func rowCodes(errs []error, codes *C.int) {
+	var dst []C.int
+	if codes != nil {
+		dst = unsafe.Slice(codes, len(errs))
+	}
+	failed := false
+	for i, err := range errs {
+		code := C.int(C.SP_OK)
+		if err != nil {
+			if failed {
+				code = errorCode(err)
+			} else {
+				code, failed = fail(err), true
+			}
+		}
+		if dst != nil {
+			dst[i] = code
+		}
+	}
+}
+
+// sp_state_n рассчитывает n состояний режима mode ("TP", "PH", "PS", "HS",
+// "PX", "TX", "VU", "RhoT", "TH", "TS") по парам a[i], b[i] в порядке имени
+// режима, например T и p для "TP", и заполняет out[i]. Возвращает код
+// ошибки вызова; ошибки строк записываются в codes[i] (codes может быть
+// NULL), а строка с ошибкой получает region 0, phase -1 и NaN в остальных
+// полях. sp_last_error хранит текст ошибки первой неудачной строки.
+//
+//export sp_state_n
+func sp_state_n(mode *C.char, a, b *C.double, n C.size_t, out *C.sp_state, codes *C.int) (code C.int) {
+	defer guard(&code)
+	if mode == nil || n > 0 && (a == nil || b == nil || out == nil) {
+		return fail(errNullPointer)
+	}
+	batch := &if97.Batch{Mode: C.GoString(mode)}
+	if columns, ok := batchModes[batch.Mode]; ok {
+		colA, colB := columns(batch)
+		*colA, *colB = doubles(a, int(n)), doubles(b, int(n))
+	}
+	res, err := if97.CalculateBatch(batch, if97.WithTransport())
+	if err != nil {
+		return fail(err)
+	}
+
+	// defined заменяет NaN неопределенного свойства нулем, как в sp_tp
+	defined := func(v float64) C.double {
+		if math.IsNaN(v) {
+			return 0
+		}
+		return C.double(v)
+	}
+	nan := C.double(math.NaN())
+	states := unsafe.Slice(out, int(n))
+	for i := range states {
+		st := &states[i]
+		if res.Errors[i] != nil {
+			*st = C.sp_state{
+				region: 0, phase: -1, quality: nan, temperature: nan, pressure: nan,
+				specific_volume: nan, density: nan, internal_energy: nan, enthalpy: nan,
+				entropy: nan, cp: nan, cv: nan, speed_of_sound: nan,
+				dynamic_viscosity: nan, thermal_conductivity: nan,
+			}
+			continue
+		}
+		*st = C.sp_state{
+			region:               C.int(res.Region[i]),
+			phase:                phaseCode(res.Phase[i]),
+			quality:              C.double(res.Quality[i]),
+			temperature:          C.double(res.Temperature[i]),
+			pressure:             C.double(res.Pressure[i]),
+			specific_volume:      C.double(res.SpecificVolume[i]),
+			density:              C.double(res.Density[i]),
+			internal_energy:      C.double(res.SpecificInternalEnergy[i]),
+			enthalpy:             C.double(res.SpecificEnthalpy[i]),
+			entropy:              C.double(res.SpecificEntropy[i]),
+			cp:                   C.double(res.SpecificIsobaricHeatCapacity[i]),
+			cv:                   C.double(res.SpecificIsochoricHeatCapacity[i]),
+			speed_of_sound:       C.double(res.SpeedOfSound[i]),
+			dynamic_viscosity:    defined(res.DynamicViscosity[i]),
+			thermal_conductivity: defined(res.ThermalConductivity[i]),
+		}
+	}
+	rowCodes(res.Errors, codes)
+	return C.SP_OK
+}
+
+// sp_props_si_n рассчитывает out[i] = sp_props_si(output, name1, value1[i],
+// name2, value2[i], fluid) для n точек одним пакетным вызовом. Возвращает
+// код ошибки вызова (имена параметров, вещество); ошибки строк
+// записываются в codes[i] (codes может быть NULL) с NaN в out[i].
+// sp_last_error хранит текст ошибки первой неудачной строки.
+//
+//export sp_props_si_n
+func sp_props_si_n(output, name1 *C.char, value1 *C.double, name2 *C.char, value2 *C.double, n C.size_t, fluid *C.char, out *C.double, codes *C.int) (code C.int) {
+	defer guard(&code)
+	if output == nil || name1 == nil || name2 == nil || fluid == nil ||
+		n > 0 && (value1 == nil || value2 == nil || out == nil) {
+		return fail(errNullPointer)
+	}
+	v, errs, err := if97.PropsSIBatch(C.GoString(output), C.GoString(name1), doubles(value1, int(n)),
+		C.GoString(name2), doubles(value2, int(n)), C.GoString(fluid))
+	if err != nil {
+		return fail(err)
+	}
+	dst := unsafe.Slice(out, int(n))
+	for i, x := range v {
+		dst[i] = C.double(x)
+	}
+	rowCodes(errs, codes)
+	return C.SP_OK
+}
+
+// sp_last_error копирует в buf текст последней ошибки библиотеки в UTF-8 с
+// завершающим нулем, обрезая его до size-1 байт, и возвращает полную длину
+// текста, как snprintf. Ошибка общая для процесса: в многопоточной
+// программе надежен только код возврата.
+//
+//export sp_last_error
+func sp_last_error(buf *C.char, size C.size_t) C.int {
+	lastErrorMu.Lock()
+	msg := lastError
+	lastErrorMu.Unlock()
+	if buf != nil && size > 0 {
+		dst := unsafe.Slice((*byte)(unsafe.Pointer(buf)), int(size))
+		n := copy(dst[:len(dst)-1], msg)
+		dst[n] = 0
+	}
+	return C.int(len(msg))
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// TestCABI собирает библиотеку в режиме c-shared, компилирует программу
+// testdata/capi_test.c с созданным заголовком и запускает ее
+func TestCABI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("сборка c-shared пропускается в режиме -short")
+	}
+	if runtime.GOOS != "linux" {
+		t.Skip("программа проверки собирается только в Linux")
+	}
+	cc, err := exec.LookPath("cc")
+	if err != nil {
+		t.Skip("нет компилятора C")
+	}
+	dir := t.TempDir()
+	run := func(name string, args ...string) string {
+		t.Helper()
+		out, err := exec.Command(name, args...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("%s %s: %v\n%s", name, strings.Join(args, " "), err, out)
+		}
+		return string(out)
+	}
+	run("go", "build", "-buildmode=c-shared", "-o", filepath.Join(dir, "libsteamprops.so"), ".")
+	bin := filepath.Join(dir, "capi_test")
+	run(cc, "-Wall", "-Werror", "-I"+dir, filepath.Join("testdata", "capi_test.c"),
+		"-L"+dir, "-lsteamprops", "-Wl,-rpath,"+dir, "-lm", "-o", bin)
+	if out := run(bin); strings.TrimSpace(out) != "ok" {
+		t.Errorf("capi_test: %s", out)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+ * Проверка C ABI libsteamprops: контрольные точки IF-97 и коды ошибок.
+ *
+ *   go build -buildmode=c-shared -o build/libsteamprops.so ./cmd/libsteamprops
+ *   cc -Ibuild cmd/libsteamprops/testdata/capi_test.c -Lbuild -lsteamprops \
+ *      -Wl,-rpath,build -lm -o build/capi_test && build/capi_test
+ */
+#include <math.h>
+#include <stdio.h>
+#include <string.h>
+
+#include "libsteamprops.h"
+
+static int failures = 0;
+
+static void check(int ok, const char *what) {
+	if (!ok) {
+		fprintf(stderr, "FAIL: %s\n", what);
+		failures++;
+	}
+}
+
+static int near(double got, double want, double rel) {
+	return fabs(got - want) <= rel * fabs(want);
+}
+
+static void check_code(int got, int want, const char *what) {
+	char msg[256];
+	if (got != want) {
+		sp_last_error(msg, sizeof msg);
+		fprintf(stderr, "FAIL: %s: code %d, want %d (%s)\n", what, got, want, msg);
+		failures++;
+	}
+}
+
+int main(void) {
+	sp_state st, sts[3];
+	double v, vs[3];
+	int codes[3];
+	char msg[256];
+	int n;
+
+	check(sp_abi_version() == SP_ABI_VERSION, "sp_abi_version");
+
+	/* IF-97, таблица 5: T = 300 К, p = 3 МПа */
+	check_code(sp_tp(26.85, 3e6, &st), SP_OK, "sp_tp");
+	check(st.region == 1 && st.phase == SP_PHASE_LIQUID && st.quality == -1, "sp_tp region");
+	check(near(st.enthalpy, 115.331273, 1e-6), "sp_tp enthalpy");
+	check(near(st.speed_of_sound, 1507.73921, 1e-6), "sp_tp speed of sound");
+	check(st.dynamic_viscosity > 0 && st.thermal_conductivity > 0, "sp_tp transport");
+
+	check_code(sp_ph(3e6, 115.331273, &st), SP_OK, "sp_ph");
+	check(near(st.temperature, 26.85, 1e-6), "sp_ph temperature");
+
+	/* IF-97, таблица 15: T = 700 К, p = 30 МПа */
+	check_code(sp_ps(30e6, 5.17540298, &st), SP_OK, "sp_ps");
+	check(st.region == 2 && near(st.enthalpy, 2631.49474, 1e-6), "sp_ps enthalpy");
+	check_code(sp_hs(2631.49474, 5.17540298, &st), SP_OK, "sp_hs");
+	check(near(st.pressure, 30e6, 1e-6), "sp_hs pressure");
+
+	check_code(sp_px(1e6, 0.5, &st), SP_OK, "sp_px");
+	check(st.region == 4 && st.phase == SP_PHASE_TWO_PHASE && st.quality == 0.5, "sp_px state");
+	check(st.cp == 0 && st.dynamic_viscosity == 0, "sp_px undefined properties");
+
+	check_code(sp_props_si("H", "T", 300, "P", 3e6, "Water", &v), SP_OK, "sp_props_si");
+	check(near(v, 115331.273, 1e-6), "sp_props_si value");
+
+	/* Массивы: строка с ошибкой не прерывает расчет */
+	double ts[] = {26.85, 426.85, -10}, ps[] = {3e6, 30e6, 1e5};
+	check_code(sp_state_n("TP", ts, ps, 3, sts, codes), SP_OK, "sp_state_n");
+	check(codes[0] == SP_OK && codes[1] == SP_OK && codes[2] == SP_ERR_INPUT, "sp_state_n codes");
+	check(near(sts[0].enthalpy, 115.331273, 1e-6) && sts[0].dynamic_viscosity > 0, "sp_state_n row 0");
+	check(sts[1].region == 2 && near(sts[1].enthalpy, 2631.49474, 1e-6), "sp_state_n row 1");
+	check(sts[2].region == 0 && sts[2].phase == -1 && isnan(sts[2].enthalpy), "sp_state_n failed row");
+	check(sp_last_error(msg, sizeof msg) > 0, "sp_state_n last error");
+	double px[] = {1e6}, xs[] = {0.5};
+	check_code(sp_state_n("PX", px, xs, 1, sts, NULL), SP_OK, "sp_state_n PX");
+	check(sts[0].region == 4 && sts[0].cp == 0 && sts[0].dynamic_viscosity == 0, "sp_state_n undefined properties");
+	check_code(sp_state_n("XY", px, xs, 1, sts, codes), SP_ERR_INPUT, "sp_state_n mode XY");
+
+	double tk[] = {300, 700, 300}, pa[] = {3e6, 30e6, -1};
+	check_code(sp_props_si_n("H", "T", tk, "P", pa, 3, "Water", vs, codes), SP_OK, "sp_props_si_n");
+	check(near(vs[0], 115331.273, 1e-6) && near(vs[1], 2631494.74, 1e-6), "sp_props_si_n values");
+	check(codes[0] == SP_OK && codes[2] == SP_ERR_INPUT && isnan(vs[2]), "sp_props_si_n failed row");
+	check_code(sp_props_si_n("H", "P", pa, "U", tk, 3, "Water", vs, codes), SP_ERR_UNSUPPORTED_INPUTS, "sp_props_si_n P,U");
+	check_code(sp_props_si_n("H", "T", NULL, "P", NULL, 0, "Water", NULL, NULL), SP_OK, "sp_props_si_n n = 0");
+
+	/* Коды ошибок и текст последней ошибки */
+	check_code(sp_tp(NAN, 1e5, &st), SP_ERR_INPUT, "sp_tp(NaN)");
+	n = sp_last_error(msg, sizeof msg);
+	check(n > 0 && (size_t)n == strlen(msg), "sp_last_error length");
+	check(sp_last_error(msg, 4) == n && strlen(msg) == 3, "sp_last_error truncation");
+
+	check_code(sp_hs(100, 9, &st), SP_ERR_CALCULATION, "sp_hs(100, 9)");
+	check_code(sp_th(100, 430, &st), SP_ERR_AMBIGUOUS, "sp_th(100, 430)");
+	check_code(sp_props_si("Z", "T", 300, "P", 1e5, "Water", &v), SP_ERR_UNKNOWN_PARAMETER, "output Z");
+	check_code(sp_props_si("H", "P", 1e5, "U", 2e6, "Water", &v), SP_ERR_UNSUPPORTED_INPUTS, "inputs P,U");
+	check_code(sp_props_si("H", "T", 300, "P", 1e5, "R134a", &v), SP_ERR_UNSUPPORTED_FLUID, "R134a");
+	check_code(sp_tp(26.85, 3e6, NULL), SP_ERR_NULL_POINTER, "NULL state");
+
+	if (failures) {
+		return 1;
+	}
+	printf("ok\n");
+	return 0;
+}
@@ -1,83 +1,399 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
-	"github.com/somepgs/steamprops/internal/calc_core"
-	"github.com/somepgs/steamprops/internal/calc_core/region1"
-	"github.com/somepgs/steamprops/internal/calc_core/region2"
-	"github.com/somepgs/steamprops/internal/calc_core/region3"
-	"github.com/somepgs/steamprops/internal/calc_core/region5"
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/if97"
+	"github.com/somepgs/steamprops/internal/chart"
+	"github.com/somepgs/steamprops/internal/process/nozzle"
+	"github.com/somepgs/steamprops/internal/process/pipe"
+	"github.com/somepgs/steamprops/internal/steamprops"
+	"github.com/somepgs/steamprops/units"
 )
 
+// lang — язык сообщений: флаг -lang, иначе переменные окружения
+// STEAMPROPS_LANG, LC_ALL, LC_MESSAGES и LANG
+var lang = i18n.FromEnv()
+
+// langFromArgs находит флаг -lang до разбора остальных флагов, чтобы их
+// описания в -help выводились на выбранном языке
+func langFromArgs(args []string, def i18n.Locale) i18n.Locale {
+	for i, a := range args {
+		if a == "--" {
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(a, "-"), "=")
+		if !strings.HasPrefix(a, "-") || name != "lang" {
+			continue
+		}
+		if !hasValue && i+1 < len(args) {
+			value = args[i+1]
+		}
+		if l, ok := i18n.Parse(value); ok {
+			return l
+		}
+	}
+	return def
+}
+
+// fatal выводит ошибку на языке lang и завершает программу
+func fatal(err error) {
+	log.Fatal(lang.Error(err))
+}
+
 func main() {
-	mode := flag.String("mode", "tp", "Режим: tp (по T и p) или hs (по h и s → p)")
-	tC := flag.Float64("t", 200.0, "Температура, ℃")
-	pPa := flag.Float64("p", 40_000_000.0, "Давление, Па")
-	h := flag.Float64("h", 2000.0, "Энтальпия, кДж/кг (для режима hs)")
-	s := flag.Float64("s", 5.0, "Энтропия, кДж/(кг*К) (для режима hs)")
-	region := flag.String("region", "auto", "Регион IF-97: auto, 1, 2, 3, 5")
+	lang = langFromArgs(os.Args[1:], lang)
+
+	langName := flag.String("lang", string(lang), lang.T("Язык сообщений: ru или en; по умолчанию — из STEAMPROPS_LANG, LC_ALL, LC_MESSAGES или LANG"))
+	mode := flag.String("mode", "tp", lang.T("Режим: tp (по T и p), hs (по h и s), vu (по v и u), rhot (по ρ и T), th (по T и h), ts (по T и s), nozzle (критическое истечение), pipe (гидравлика трубы) или chart (диаграмма)"))
+	tC := temperatureFlag("t", 200.0, lang.T("Температура, ℃; допускается единица: 392°F, 473.15K"))
+	pPa := pressureFlag("p", 40_000_000.0, lang.T("Давление, Па; допускается единица: 40MPa, 10bar, 150psi"))
+	h := specificEnergyFlag("h", 2000.0, lang.T("Энтальпия, кДж/кг или с единицей, например 1200BTU/lb (для режимов hs и th)"))
+	s := specificEntropyFlag("s", 5.0, lang.T("Энтропия, кДж/(кг*К) или с единицей, например 1.6BTU/(lb·°F) (для режимов hs и ts)"))
+	v := flag.Float64("v", 0.2, lang.T("Удельный объем, м3/кг (для режима vu)"))
+	u := specificEnergyFlag("u", 2600.0, lang.T("Удельная внутренняя энергия, кДж/кг или с единицей (для режима vu)"))
+	rho := flag.Float64("rho", 500.0, lang.T("Плотность, кг/м3 (для режима rhot)"))
+	region := flag.String("region", "auto", lang.T("Регион IF-97: auto, 1, 2, 3, 5"))
+	x := flag.Float64("x", -1, lang.T("Паросодержание на входе 0..1 (для режимов nozzle и pipe; -1 — задать по T и p)"))
+	pb := pressureFlag("pb", 0, lang.T("Противодавление, Па или с единицей (для режима nozzle)"))
+	area := flag.Float64("area", 0, lang.T("Проходное сечение клапана, м² (для режима nozzle)"))
+	kd := flag.Float64("kd", 1.0, lang.T("Коэффициент расхода клапана (для режима nozzle)"))
+	mdot := flag.Float64("mdot", 1.0, lang.T("Массовый расход, кг/с (для режима pipe)"))
+	length := flag.Float64("length", 100.0, lang.T("Длина трубы, м (для режима pipe)"))
+	diameter := flag.Float64("diameter", 0.1, lang.T("Внутренний диаметр трубы, м (для режима pipe)"))
+	roughness := flag.Float64("roughness", 4.5e-5, lang.T("Шероховатость трубы, м (для режима pipe)"))
+	dz := flag.Float64("dz", 0, lang.T("Перепад высот выход-вход, м (для режима pipe)"))
+	qloss := flag.Float64("qloss", 0, lang.T("Тепловые потери, Вт/м (для режима pipe)"))
+	diagram := flag.String("diagram", "hs", lang.T("Тип диаграммы: hs, ts или ph (для режима chart)"))
+	out := flag.String("out", "", lang.T("Файл диаграммы .svg или .png; пусто — SVG в stdout (для режима chart)"))
+	isobars := flag.String("isobars", "", lang.T("Изобары через запятую, МПа; пусто — стандартный набор, none — без линий (для режима chart)"))
+	isotherms := flag.String("isotherms", "", lang.T("Изотермы через запятую, ℃; пусто — стандартный набор, none — без линий (для режима chart)"))
+	qualities := flag.String("qualities", "", lang.T("Линии степени сухости через запятую; пусто — стандартный набор, none — без линий (для режима chart)"))
+	version := flag.Bool("version", false, lang.T("Вывести версию библиотеки и формуляции и выйти"))
+	system := flag.String("units", "si", lang.T("Система единиц вывода: si (°C, Па, кДж/кг), si-eng (°C, бар, кДж/кг) или us (°F, psia, BTU/lb)"))
+	gauge := flag.Bool("gauge", false, lang.T("Выводить избыточное давление вместо абсолютного"))
+	patm := pressureFlag("patm", float64(units.StandardAtmosphere), lang.T("Атмосферное давление для избыточного давления, Па или с единицей"))
 	flag.Parse()
 
+	if l, ok := i18n.Parse(*langName); ok {
+		lang = l
+	} else {
+		log.Fatal(lang.T("некорректное значение --lang: ожидается ru или en"))
+	}
+
+	sys, err := units.SystemByName(*system)
+	if err != nil {
+		fatal(err)
+	}
+	if *gauge {
+		sys = sys.WithGauge(units.Pascal.Of(*patm))
+	}
+	output = sys
+
+	if *version {
+		fmt.Printf("steamprops if97 %s (%s)\n", if97.Version, if97.Formulation)
+		return
+	}
+
 	switch strings.ToLower(*mode) {
 	case "hs":
-		p, err := region3.PressureFromHS(*h, *s)
-		if err != nil {
-			log.Fatal(err)
-		}
-		fmt.Printf("Давление по (h,s): %.6f Па\n", p)
+		printState(if97.HS(*h, *s))
+		return
+	case "vu":
+		printState(if97.VU(*v, *u))
+		return
+	case "rhot":
+		printState(if97.RhoT(*rho, *tC))
+		return
+	case "th":
+		printState(if97.TH(*tC, *h))
+		return
+	case "ts":
+		printState(if97.TS(*tC, *s))
+		return
+	case "nozzle":
+		runNozzle(*tC, *pPa, *x, *pb, *area, *kd)
+		return
+	case "pipe":
+		runPipe(*tC, *pPa, *x, *mdot, &pipe.Pipe{
+			Length:            *length,
+			Diameter:          *diameter,
+			Roughness:         *roughness,
+			Elevation:         *dz,
+			HeatLossPerLength: *qloss,
+		})
+		return
+	case "chart":
+		runChart(*diagram, *out, *isobars, *isotherms, *qualities)
 		return
 	case "tp":
 		// fallthrough to existing tp flow
 	default:
 		if *mode != "tp" {
-			log.Fatal("некорректный режим --mode: ожидается tp или hs")
+			log.Fatal(lang.T("некорректный режим --mode: ожидается tp, hs, vu, rhot, th, ts, nozzle, pipe или chart"))
 		}
 	}
 
-	var props calc_core.Properties
-	var err error
-
 	switch strings.ToLower(*region) {
-	case "1":
-		props, err = region1.Calculate(*tC, *pPa)
-	case "2":
-		props, err = region2.Calculate(*tC, *pPa)
-	case "3":
-		props, err = region3.Calculate(*tC, *pPa)
-	case "5":
-		props, err = region5.Calculate(*tC, *pPa)
 	case "auto":
-		T := *tC + 273.15
-		reg := calc_core.RegionFromTP(T, *pPa)
-		switch reg {
-		case calc_core.Region1:
-			props, err = region1.Calculate(*tC, *pPa)
-		case calc_core.Region2:
-			props, err = region2.Calculate(*tC, *pPa)
-		case calc_core.Region5:
-			props, err = region5.Calculate(*tC, *pPa)
-		case calc_core.Region3:
-			props, err = region3.Calculate(*tC, *pPa)
-		default:
-			props, err = region2.Calculate(*tC, *pPa)
-		}
+		printState(if97.TP(*tC, *pPa))
+	case "1", "2", "3", "5":
+		printState(if97.TPRegion(if97.Region((*region)[0]-'0'), *tC, *pPa))
 	default:
-		log.Fatal("некорректное значение --region: ожидается auto, 1, 2, 3 или 5")
+		log.Fatal(lang.T("некорректное значение --region: ожидается auto, 1, 2, 3 или 5"))
 	}
+}
+
+// quantityFlag — числовой флаг, принимающий значение с единицей измерения
+// пакета units; число без единицы задано в единице по умолчанию
+type quantityFlag struct {
+	value *float64
+	parse func(string) (float64, error)
+}
+
+func (f quantityFlag) String() string {
+	if f.value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f.value, 'g', -1, 64)
+}
+
+func (f quantityFlag) Set(s string) error {
+	v, err := f.parse(s)
 	if err != nil {
-		log.Fatal(err)
+		return errors.New(lang.Error(err))
 	}
+	*f.value = v
+	return nil
+}
 
-	fmt.Printf("Удельный объем: %.12f м3/кг\n", props.SpecificVolume)
-	fmt.Printf("Плотность: %.12f кг/м3\n", props.Density)
-	fmt.Printf("Удельная внутренняя энергия: %.12f кДж/кг\n", props.SpecificInternalEnergy)
-	fmt.Printf("Удельная энтропия: %.12f кДж/кг*К\n", props.SpecificEntropy)
-	fmt.Printf("Удельная энтальпия: %.12f кДж/кг\n", props.SpecificEnthalpy)
-	fmt.Printf("Удельная изохорная теплоемкость: %.12f кДж/кг*К\n", props.SpecificIsochoricHeatCapacity)
-	fmt.Printf("Удельная изобарная теплоемкость: %.12f кДж/кг*К\n", props.SpecificIsobaricHeatCapacity)
-	fmt.Printf("Скорость звука: %.12f м/с\n", props.SpeedOfSound)
+// temperatureFlag объявляет флаг температуры, значение — в ℃
+func temperatureFlag(name string, value float64, usage string) *float64 {
+	p := &value
+	flag.Var(quantityFlag{p, func(s string) (float64, error) {
+		t, err := units.ParseTemperature(s, units.Celsius)
+		return t.Celsius(), err
+	}}, name, usage)
+	return p
+}
+
+// pressureFlag объявляет флаг давления, значение — в Па
+func pressureFlag(name string, value float64, usage string) *float64 {
+	p := &value
+	flag.Var(quantityFlag{p, func(s string) (float64, error) {
+		pr, err := units.ParsePressure(s, units.Pascal)
+		return pr.Pascals(), err
+	}}, name, usage)
+	return p
+}
+
+// specificEnergyFlag объявляет флаг энтальпии или внутренней энергии, значение — в кДж/кг
+func specificEnergyFlag(name string, value float64, usage string) *float64 {
+	p := &value
+	flag.Var(quantityFlag{p, func(s string) (float64, error) {
+		e, err := units.ParseSpecificEnergy(s, units.KilojoulePerKg)
+		return e.KilojoulesPerKg(), err
+	}}, name, usage)
+	return p
+}
+
+// specificEntropyFlag объявляет флаг энтропии, значение — в кДж/(кг·К)
+func specificEntropyFlag(name string, value float64, usage string) *float64 {
+	p := &value
+	flag.Var(quantityFlag{p, func(s string) (float64, error) {
+		e, err := units.ParseSpecificEntropy(s, units.KilojoulePerKgK)
+		return e.KilojoulesPerKgK(), err
+	}}, name, usage)
+	return p
+}
+
+// output — система единиц вывода, задается флагами -units, -gauge и -patm
+var output = units.SI
+
+// formatPressure выводит давление (Па) в единицах output с видом отсчета
+func formatPressure(p float64) string {
+	return fmt.Sprintf("%.6g %s", output.PressureValue(units.Pascal.Of(p)), output.PressureSymbol())
+}
+
+// formatPressureDifference выводит разность давлений (Па) в единицах output
+func formatPressureDifference(dp float64) string {
+	return fmt.Sprintf("%.6g %s", units.Pascal.Of(dp).In(output.Pressure), output.Pressure)
+}
+
+// formatTemperature выводит температуру (℃) в единицах output
+func formatTemperature(t float64) string {
+	return fmt.Sprintf("%.3f %s", units.Celsius.Of(t).In(output.Temperature), output.Temperature)
+}
+
+// printState выводит рассчитанное состояние вместе с давлением, температурой,
+// паросодержанием и транспортными свойствами
+func printState(state *if97.Result, err error) {
+	if err != nil {
+		fatal(err)
+	}
+	c := state.In(output)
+	props := c.Properties
+	fmt.Print(lang.T("Регион IF-97: %d (%s)\n", int(state.Region), lang.T(state.Phase)))
+	fmt.Print(lang.T("Давление: %.6f %s\n", c.Pressure, output.PressureSymbol()))
+	fmt.Print(lang.T("Температура: %.9f %s\n", c.Temperature, output.Temperature))
+	if state.Quality >= 0 {
+		fmt.Print(lang.T("Паросодержание: %.9f\n", state.Quality))
+	}
+	fmt.Print(lang.T("Удельный объем: %.12f %s\n", props.SpecificVolume, output.SpecificVolume))
+	fmt.Print(lang.T("Плотность: %.12f %s\n", props.Density, output.Density))
+	fmt.Print(lang.T("Удельная внутренняя энергия: %.12f %s\n", props.SpecificInternalEnergy, output.SpecificEnergy))
+	fmt.Print(lang.T("Удельная энтропия: %.12f %s\n", props.SpecificEntropy, output.SpecificEntropy))
+	fmt.Print(lang.T("Удельная энтальпия: %.12f %s\n", props.SpecificEnthalpy, output.SpecificEnergy))
+	if state.Quality < 0 {
+		fmt.Print(lang.T("Удельная изохорная теплоемкость: %.12f %s\n", props.SpecificIsochoricHeatCapacity, output.SpecificEntropy))
+		fmt.Print(lang.T("Удельная изобарная теплоемкость: %.12f %s\n", props.SpecificIsobaricHeatCapacity, output.SpecificEntropy))
+		fmt.Print(lang.T("Скорость звука: %.12f %s\n", props.SpeedOfSound, output.Speed))
+	}
+	if tr := c.Transport; tr != nil {
+		fmt.Print(lang.T("Динамическая вязкость: %.6e %s\n", tr.DynamicViscosity, output.DynamicViscosity))
+		fmt.Print(lang.T("Кинематическая вязкость: %.6e %s\n", tr.KinematicViscosity, output.KinematicViscosity))
+		fmt.Print(lang.T("Теплопроводность: %.6f %s\n", tr.ThermalConductivity, output.ThermalConductivity))
+	}
+}
+
+// inletState рассчитывает состояние по T и p либо по p и паросодержанию x (если x >= 0)
+func inletState(calc *steamprops.Calculator, tC, pPa, x float64) *steamprops.Result {
+	var state *steamprops.Result
+	var err error
+	if x >= 0 {
+		state, err = calc.CalculatePX(pPa, x)
+	} else {
+		state, err = calc.Calculate(&steamprops.InputData{Mode: "TP", Temperature: tC, Pressure: pPa})
+	}
+	if err != nil {
+		fatal(err)
+	}
+	return state
+}
+
+// runNozzle рассчитывает критическое истечение через сопло и пропускную способность клапана
+func runNozzle(tC, pPa, x, pb, area, kd float64) {
+	calc := steamprops.NewCalculator()
+	stagnation := inletState(calc, tC, pPa, x)
+
+	res, err := nozzle.Calculate(calc, stagnation, pb)
+	if err != nil {
+		fatal(err)
+	}
+
+	fmt.Print(lang.T("Параметры торможения: p0=%s, T0=%s, h0=%.3f %s\n",
+		formatPressure(stagnation.Pressure), formatTemperature(stagnation.Temperature),
+		units.KilojoulePerKg.Of(stagnation.Properties.SpecificEnthalpy).In(output.SpecificEnergy), output.SpecificEnergy))
+	if res.Choked {
+		fmt.Println(lang.T("Истечение: критическое (запертое)"))
+	} else {
+		fmt.Println(lang.T("Истечение: докритическое (горло при противодавлении)"))
+	}
+	fmt.Print(lang.T("Критическое отношение давлений: %.6f\n", res.CriticalPressureRatio))
+	fmt.Print(lang.T("Давление в горле: %s\n", formatPressure(res.Throat.Pressure)))
+	fmt.Print(lang.T("Температура в горле: %s\n", formatTemperature(res.Throat.Temperature)))
+	if res.Throat.Quality >= 0 {
+		fmt.Print(lang.T("Паросодержание в горле: %.6f\n", res.Throat.Quality))
+	}
+	fmt.Print(lang.T("Плотность в горле: %.6f %s\n", units.KgPerCubicMetre.Of(res.Throat.Properties.Density).In(output.Density), output.Density))
+	fmt.Print(lang.T("Скорость в горле: %.3f %s\n", units.MetrePerSecond.Of(res.ThroatVelocity).In(output.Speed), output.Speed))
+	fmt.Print(lang.T("Плотность потока массы: %.3f %s\n",
+		units.KgPerSquareMetreSecond.Of(res.MassFlux).In(output.MassFlux), output.MassFlux))
+	if area > 0 {
+		fmt.Print(lang.T("Пропускная способность: %.6g %s\n",
+			units.KgPerSecond.Of(res.Capacity(area, kd)).In(output.MassFlow), output.MassFlow))
+	}
+}
+
+// runPipe рассчитывает падение давления и тепловые потери в трубе
+func runPipe(tC, pPa, x, mdot float64, p *pipe.Pipe) {
+	calc := steamprops.NewCalculator()
+	inlet := inletState(calc, tC, pPa, x)
+
+	res, err := pipe.Calculate(calc, p, inlet, mdot)
+	if err != nil {
+		fatal(err)
+	}
+
+	first := res.Nodes[0]
+	fmt.Print(lang.T("Вход: p=%s, T=%s, w=%.3f %s, Re=%.4g, λ=%.5f\n",
+		formatPressure(inlet.Pressure), formatTemperature(inlet.Temperature),
+		units.MetrePerSecond.Of(first.Velocity).In(output.Speed), output.Speed, first.Reynolds, first.FrictionFactor))
+	fmt.Print(lang.T("Выход: p=%s, T=%s, w=%.3f %s\n",
+		formatPressure(res.Outlet.Pressure), formatTemperature(res.Outlet.Temperature),
+		units.MetrePerSecond.Of(res.Nodes[len(res.Nodes)-1].Velocity).In(output.Speed), output.Speed))
+	if res.Outlet.Quality >= 0 {
+		fmt.Print(lang.T("Паросодержание на выходе: %.6f\n", res.Outlet.Quality))
+	}
+	fmt.Print(lang.T("Падение давления: %s\n", formatPressureDifference(res.PressureDrop)))
+	fmt.Print(lang.T("  на трение: %s\n", formatPressureDifference(res.FrictionLoss)))
+	fmt.Print(lang.T("  на подъем: %s\n", formatPressureDifference(res.ElevationLoss)))
+	fmt.Print(lang.T("  на ускорение: %s\n", formatPressureDifference(res.AccelerationLoss)))
+	fmt.Print(lang.T("Тепловые потери: %.3f %s\n", units.Watt.Of(res.HeatLoss).In(output.Power), output.Power))
+}
+
+// runChart строит диаграмму состояния и сохраняет ее в SVG или PNG
+func runChart(name, out, isobars, isotherms, qualities string) {
+	d, err := chart.DiagramByName(name)
+	if err != nil {
+		fatal(err)
+	}
+	opts := chart.DefaultOptions()
+	opts.Locale = lang
+	for _, l := range []struct {
+		value  string
+		factor float64
+		dest   *[]float64
+	}{
+		{isobars, 1e6, &opts.Isobars},
+		{isotherms, 1, &opts.Isotherms},
+		{qualities, 1, &opts.Qualities},
+	} {
+		switch l.value {
+		case "":
+			continue
+		case "none":
+			*l.dest = nil
+			continue
+		}
+		if *l.dest, err = chart.ParseList(l.value, l.factor); err != nil {
+			fatal(err)
+		}
+	}
+
+	c, err := chart.New(steamprops.NewCalculator(), d, opts)
+	if err != nil {
+		fatal(err)
+	}
+
+	if out == "" {
+		if err := c.WriteSVG(os.Stdout); err != nil {
+			fatal(err)
+		}
+		return
+	}
+	f, err := os.Create(out)
+	if err != nil {
+		fatal(err)
+	}
+	if strings.EqualFold(filepath.Ext(out), ".png") {
+		err = c.WritePNG(f)
+	} else {
+		err = c.WriteSVG(f)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		fatal(err)
+	}
+	fmt.Print(lang.T("Диаграмма сохранена в %s\n", out))
 }
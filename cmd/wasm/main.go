@@ -0,0 +1,46 @@
+//go:build js && wasm
+
+// Команда wasm — калькулятор для расчета в браузере без сервера:
+//
+//	GOOS=js GOARCH=wasm go build -o web/static/wasm/steamprops.wasm ./cmd/wasm
+//
+// Модуль регистрирует глобальную функцию steampropsCalculate(request),
+// которая принимает строку JSON в формате тела POST /api/calculate и
+// возвращает строку JSON ответа. Загрузку модуля выполняет
+// web/static/js/wasm.js. Сообщения выводятся на языке поля lang запроса,
+// иначе на языке браузера (navigator.language).
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"syscall/js"
+
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/internal/webapi"
+)
+
+// lang — язык сообщений по умолчанию
+var lang = i18n.Default
+
+func main() {
+	if nav := js.Global().Get("navigator"); nav.Truthy() {
+		lang = i18n.FromAcceptLanguage(nav.Get("language").String(), i18n.Default)
+	}
+	js.Global().Set("steampropsCalculate", js.FuncOf(calculate))
+	// Функции модуля вызываются из JavaScript, пока страница открыта
+	select {}
+}
+
+// calculate выполняет расчет по запросу JSON из args[0]
+func calculate(this js.Value, args []js.Value) interface{} {
+	var request string
+	if len(args) > 0 && args[0].Type() == js.TypeString {
+		request = args[0].String()
+	}
+	data, err := json.Marshal(webapi.CalculateJSON(strings.NewReader(request), lang))
+	if err != nil {
+		data, _ = json.Marshal(webapi.CalculationResponse{Error: lang.T("Ошибка формирования ответа: %v", err)})
+	}
+	return string(data)
+}
@@ -2,26 +2,38 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/if97"
+	"github.com/somepgs/steamprops/internal/chart"
+	"github.com/somepgs/steamprops/internal/process/nozzle"
 	"github.com/somepgs/steamprops/internal/steamprops"
+	"github.com/somepgs/steamprops/internal/webapi"
+	"github.com/somepgs/steamprops/propserr"
+	"github.com/somepgs/steamprops/units"
 )
 
 // WebServer представляет веб-сервер приложения
 type WebServer struct {
 	calculator *steamprops.Calculator
 	templates  *template.Template
+	lang       i18n.Locale // язык по умолчанию: переменные окружения сервера
 }
 
 // NewWebServer создает новый веб-сервер
 func NewWebServer() *WebServer {
 	ws := &WebServer{
 		calculator: steamprops.NewCalculator(),
+		lang:       i18n.FromEnv(),
 	}
 
 	// Загружаем HTML шаблоны
@@ -30,27 +42,30 @@ func NewWebServer() *WebServer {
 	return ws
 }
 
-// CalculationRequest представляет запрос на расчет
-type CalculationRequest struct {
-	Mode        string  `json:"mode"`
-	Temperature float64 `json:"temperature"`
-	Pressure    float64 `json:"pressure"`
-	Enthalpy    float64 `json:"enthalpy"`
-	Entropy     float64 `json:"entropy"`
-	Region      string  `json:"region"`
+// locale выбирает язык ответа: явно заданный в запросе lang, иначе язык
+// заголовка Accept-Language, иначе язык сервера
+func (ws *WebServer) locale(r *http.Request, lang string) i18n.Locale {
+	if l, ok := i18n.Parse(lang); ok {
+		return l
+	}
+	return i18n.FromAcceptLanguage(r.Header.Get("Accept-Language"), ws.lang)
 }
 
-// CalculationResponse представляет ответ с результатами расчета
-type CalculationResponse struct {
-	Success    bool                   `json:"success"`
-	Error      string                 `json:"error,omitempty"`
-	Result     *steamprops.Result     `json:"result,omitempty"`
-	Properties map[string]interface{} `json:"properties,omitempty"`
+// page — данные шаблона index.html: язык страницы и переводы сообщений app.js
+type page struct {
+	i18n.Locale
+	Locales  []i18n.Locale
+	Messages map[string]string
 }
 
-// handleIndex обрабатывает главную страницу
+// handleIndex обрабатывает главную страницу. Язык задается параметром
+// ?lang=ru|en или заголовком Accept-Language.
 func (ws *WebServer) handleIndex(w http.ResponseWriter, r *http.Request) {
-	if err := ws.templates.ExecuteTemplate(w, "index.html", nil); err != nil {
+	loc := ws.locale(r, r.URL.Query().Get("lang"))
+	w.Header().Set("Content-Language", string(loc))
+	w.Header().Set("Vary", "Accept-Language")
+	data := page{Locale: loc, Locales: i18n.Locales, Messages: loc.Messages()}
+	if err := ws.templates.ExecuteTemplate(w, "index.html", data); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -65,79 +80,336 @@ func (ws *WebServer) handleCalculate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var req CalculationRequest
+	json.NewEncoder(w).Encode(webapi.CalculateJSON(r.Body, ws.locale(r, r.URL.Query().Get("lang"))))
+}
+
+// handleVersion возвращает версию библиотеки и реализованную формуляцию
+func (ws *WebServer) handleVersion(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"version":     if97.Version,
+		"formulation": if97.Formulation,
+	})
+}
+
+// NozzleRequest представляет запрос на расчет критического истечения.
+// Units и Output задают единицы давлений и температуры запроса и систему
+// единиц ответа, как в запросе /api/calculate.
+type NozzleRequest struct {
+	Pressure             float64            `json:"pressure"`              // Pa, давление торможения
+	Temperature          float64            `json:"temperature"`           // °C, температура торможения
+	Quality              *float64           `json:"quality,omitempty"`     // паросодержание торможения вместо температуры
+	BackPressure         float64            `json:"back_pressure"`         // Pa
+	Area                 float64            `json:"area"`                  // м², проходное сечение клапана
+	DischargeCoefficient float64            `json:"discharge_coefficient"` // коэффициент расхода
+	Units                webapi.InputUnits  `json:"units"`
+	Output               webapi.OutputUnits `json:"output"`
+	Lang                 string             `json:"lang,omitempty"` // язык сообщений: ru или en
+}
+
+// convertUnits переводит давления и температуру запроса в Па и °C
+func (req *NozzleRequest) convertUnits() error {
+	var err error
+	if req.Pressure, err = req.Units.PressureOf(req.Pressure); err != nil {
+		return err
+	}
+	if req.BackPressure, err = req.Units.PressureOf(req.BackPressure); err != nil {
+		return err
+	}
+	req.Temperature, err = req.Units.TemperatureOf(req.Temperature)
+	return err
+}
+
+// NozzleResponse представляет ответ с результатами расчета истечения
+type NozzleResponse struct {
+	Success bool                   `json:"success"`
+	Error   string                 `json:"error,omitempty"`
+	Code    propserr.Code          `json:"code,omitempty"` // код ошибки, например "out_of_range"
+	Result  map[string]interface{} `json:"result,omitempty"`
+	Units   map[string]string      `json:"units,omitempty"` // обозначения единиц значений result
+}
+
+// handleNozzle обрабатывает API запросы на расчет критического истечения
+func (ws *WebServer) handleNozzle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req NozzleRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response := CalculationResponse{
+		json.NewEncoder(w).Encode(NozzleResponse{
 			Success: false,
-			Error:   fmt.Sprintf("Ошибка парсинга JSON: %v", err),
-		}
-		json.NewEncoder(w).Encode(response)
+			Error:   ws.locale(r, "").T("Ошибка парсинга JSON: %v", err),
+			Code:    propserr.InvalidInput,
+		})
+		return
+	}
+	loc := ws.locale(r, req.Lang)
+	sys, err := req.Output.UnitSystem()
+	if err == nil {
+		err = req.convertUnits()
+	}
+	if err != nil {
+		json.NewEncoder(w).Encode(NozzleResponse{
+			Success: false,
+			Error:   loc.T("Ошибка валидации: %v", err),
+			Code:    propserr.InvalidInput,
+		})
 		return
 	}
 
-	// Создаем InputData
-	var inputData *steamprops.InputData
-	if req.Mode == "HS" {
-		inputData = &steamprops.InputData{
-			Mode:     req.Mode,
-			Enthalpy: req.Enthalpy,
-			Entropy:  req.Entropy,
-		}
+	var stagnation *steamprops.Result
+	if req.Quality != nil {
+		stagnation, err = ws.calculator.CalculatePX(req.Pressure, *req.Quality)
 	} else {
-		inputData = &steamprops.InputData{
-			Mode:        req.Mode,
+		inputData := &steamprops.InputData{
+			Mode:        "TP",
 			Temperature: req.Temperature,
 			Pressure:    req.Pressure,
 		}
+		if err = inputData.Validate(); err == nil {
+			stagnation, err = ws.calculator.Calculate(inputData)
+		}
 	}
-
-	// Валидируем входные данные
-	if err := inputData.Validate(); err != nil {
-		response := CalculationResponse{
+	if err != nil {
+		json.NewEncoder(w).Encode(NozzleResponse{
 			Success: false,
-			Error:   fmt.Sprintf("Ошибка валидации: %v", err),
-		}
-		json.NewEncoder(w).Encode(response)
+			Error:   loc.T("Ошибка расчета параметров торможения: %v", err),
+			Code:    propserr.CodeOf(err),
+		})
 		return
 	}
 
-	// Выполняем расчет
-	result, err := ws.calculator.Calculate(inputData)
+	res, err := nozzle.Calculate(ws.calculator, stagnation, req.BackPressure)
 	if err != nil {
-		response := CalculationResponse{
+		json.NewEncoder(w).Encode(NozzleResponse{
 			Success: false,
-			Error:   fmt.Sprintf("Ошибка расчета: %v", err),
-		}
-		json.NewEncoder(w).Encode(response)
+			Error:   loc.T("Ошибка расчета истечения: %v", err),
+			Code:    propserr.CodeOf(err),
+		})
+		return
+	}
+
+	kd := req.DischargeCoefficient
+	if kd <= 0 {
+		kd = 1.0
+	}
+	// Формируем ответ в выбранной системе единиц
+	result := map[string]interface{}{
+		"choked":                  res.Choked,
+		"critical_pressure_ratio": res.CriticalPressureRatio,
+		"throat_pressure":         sys.PressureValue(units.Pascal.Of(res.Throat.Pressure)),
+		"throat_temperature":      units.Celsius.Of(res.Throat.Temperature).In(sys.Temperature),
+		"throat_quality":          res.Throat.Quality,
+		"throat_density":          units.KgPerCubicMetre.Of(res.Throat.Properties.Density).In(sys.Density),
+		"throat_enthalpy":         units.KilojoulePerKg.Of(res.Throat.Properties.SpecificEnthalpy).In(sys.SpecificEnergy),
+		"throat_velocity":         units.MetrePerSecond.Of(res.ThroatVelocity).In(sys.Speed),
+		"mass_flux":               units.KgPerSquareMetreSecond.Of(res.MassFlux).In(sys.MassFlux),
+		"stagnation_enthalpy":     units.KilojoulePerKg.Of(stagnation.Properties.SpecificEnthalpy).In(sys.SpecificEnergy),
+		"stagnation_entropy":      units.KilojoulePerKgK.Of(stagnation.Properties.SpecificEntropy).In(sys.SpecificEntropy),
+	}
+	if req.Area > 0 {
+		result["capacity"] = units.KgPerSecond.Of(res.Capacity(req.Area, kd)).In(sys.MassFlow)
+	}
+
+	json.NewEncoder(w).Encode(NozzleResponse{
+		Success: true,
+		Result:  result,
+		Units: map[string]string{
+			"temperature":      sys.Temperature.String(),
+			"pressure":         sys.PressureSymbol(),
+			"density":          sys.Density.String(),
+			"specific_energy":  sys.SpecificEnergy.String(),
+			"specific_entropy": sys.SpecificEntropy.String(),
+			"speed":            sys.Speed.String(),
+			"mass_flux":        sys.MassFlux.String(),
+			"mass_flow":        sys.MassFlow.String(),
+		},
+	})
+}
+
+// ChartRequest представляет запрос на построение диаграммы
+type ChartRequest struct {
+	Format    string      `json:"format"`    // svg, png или json
+	Width     int         `json:"width"`     // пикселей
+	Height    int         `json:"height"`    // пикселей
+	Isobars   *[]float64  `json:"isobars"`   // МПа; не задано — стандартный набор
+	Isotherms *[]float64  `json:"isotherms"` // °C
+	Qualities *[]float64  `json:"qualities"` // 0..1
+	Paths     []ChartPath `json:"paths"`     // процессы поверх изолиний
+	Lang      string      `json:"lang"`      // язык подписей и сообщений: ru или en
+}
+
+// ChartPath процесс, наносимый на диаграмму
+type ChartPath struct {
+	Label  string            `json:"label"`
+	Points []chart.PointSpec `json:"points"`
+}
+
+// Ограничения запроса диаграммы: каждая линия и каждый процесс — сотни
+// расчетов свойств
+const (
+	maxChartBody  = 1 << 20 // байт, тело POST-запроса
+	maxChartPaths = 20
+)
+
+// ChartError — ответ JSON /api/chart при ошибке
+type ChartError struct {
+	Success bool          `json:"success"` // всегда false
+	Error   string        `json:"error"`
+	Code    propserr.Code `json:"code"`
+}
+
+// chartError отвечает на запрос диаграммы ошибкой message со статусом
+// status. Ошибки без кода вызваны параметрами запроса и получают код
+// invalid_input.
+func chartError(w http.ResponseWriter, status int, message string, err error) {
+	code := propserr.InvalidInput
+	var c propserr.Coder
+	if errors.As(err, &c) {
+		code = c.ErrorCode()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ChartError{Error: message, Code: code})
+}
+
+// handleChart строит диаграмму состояния: /api/chart/{hs,ts,ph}.
+// Формат json возвращает линии в координатах осей для интерактивной диаграммы.
+// GET принимает параметры запроса format, width, height, isobars (МПа),
+// isotherms (°C), qualities — списки через запятую; пустой список убирает линии;
+// lang — язык подписей.
+// POST принимает ChartRequest, в том числе процессы для наложения.
+// Списки линий ограничены chart.MaxCurves значениями, процессы — maxChartPaths
+// процессами по chart.MaxPathPoints точек, тело запроса — maxChartBody байтами.
+// Ошибки возвращаются в JSON ChartError.
+func (ws *WebServer) handleChart(w http.ResponseWriter, r *http.Request) {
+	loc := ws.locale(r, r.URL.Query().Get("lang"))
+	d, err := chart.DiagramByName(strings.TrimPrefix(r.URL.Path, "/api/chart/"))
+	if err != nil {
+		chartError(w, http.StatusNotFound, loc.Error(err), err)
 		return
 	}
 
-	// Формируем ответ
-	properties := map[string]interface{}{
-		"temperature":                      result.Temperature,
-		"pressure":                         result.Pressure,
-		"density":                          result.Properties.Density,
-		"specific_volume":                  result.Properties.SpecificVolume,
-		"specific_enthalpy":                result.Properties.SpecificEnthalpy,
-		"specific_entropy":                 result.Properties.SpecificEntropy,
-		"specific_internal_energy":         result.Properties.SpecificInternalEnergy,
-		"specific_isobaric_heat_capacity":  result.Properties.SpecificIsobaricHeatCapacity,
-		"specific_isochoric_heat_capacity": result.Properties.SpecificIsochoricHeatCapacity,
-		"speed_of_sound":                   result.Properties.SpeedOfSound,
-		"dynamic_viscosity":                result.TransportProps["dynamic_viscosity"],
-		"kinematic_viscosity":              result.TransportProps["kinematic_viscosity"],
-		"thermal_conductivity":             result.TransportProps["thermal_conductivity"],
-		"phase":                            result.Phase,
-		"region":                           result.Region,
+	var req ChartRequest
+	switch r.Method {
+	case http.MethodGet:
+		req, err = chartRequestFromQuery(r.URL.Query())
+	case http.MethodPost:
+		err = json.NewDecoder(http.MaxBytesReader(w, r.Body, maxChartBody)).Decode(&req)
+	default:
+		chartError(w, http.StatusMethodNotAllowed, "Method not allowed", nil)
+		return
+	}
+	if err != nil {
+		chartError(w, http.StatusBadRequest, loc.T("Ошибка разбора запроса: %v", err), err)
+		return
+	}
+	if l, ok := i18n.Parse(req.Lang); ok {
+		loc = l
+	}
+	if len(req.Paths) > maxChartPaths {
+		chartError(w, http.StatusBadRequest, loc.T("слишком много процессов (%d): допускается не более %d", len(req.Paths), maxChartPaths), nil)
+		return
 	}
 
-	response := CalculationResponse{
-		Success:    true,
-		Result:     result,
-		Properties: properties,
+	opts := chart.DefaultOptions()
+	opts.Locale = loc
+	if req.Width != 0 {
+		opts.Width = req.Width
+	}
+	if req.Height != 0 {
+		opts.Height = req.Height
+	}
+	if opts.Width < 100 || opts.Width > 4000 || opts.Height < 100 || opts.Height > 4000 {
+		chartError(w, http.StatusBadRequest, loc.T("некорректный размер %dx%d: ожидается 100..4000", opts.Width, opts.Height), nil)
+		return
+	}
+	if req.Isobars != nil {
+		opts.Isobars = nil
+		for _, p := range *req.Isobars {
+			opts.Isobars = append(opts.Isobars, p*1e6)
+		}
+	}
+	if req.Isotherms != nil {
+		opts.Isotherms = *req.Isotherms
+	}
+	if req.Qualities != nil {
+		opts.Qualities = *req.Qualities
 	}
 
-	json.NewEncoder(w).Encode(response)
+	c, err := chart.New(ws.calculator, d, opts)
+	if err != nil {
+		chartError(w, http.StatusBadRequest, loc.Error(err), err)
+		return
+	}
+	for _, p := range req.Paths {
+		path, err := chart.NewPath(ws.calculator, p.Label, p.Points, 0)
+		if err != nil {
+			chartError(w, http.StatusBadRequest, loc.Error(err), err)
+			return
+		}
+		c.AddPath(path)
+	}
+
+	switch req.Format {
+	case "", "svg":
+		w.Header().Set("Content-Type", "image/svg+xml")
+		err = c.WriteSVG(w)
+	case "png":
+		w.Header().Set("Content-Type", "image/png")
+		err = c.WritePNG(w)
+	case "json":
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(c.Data())
+	default:
+		chartError(w, http.StatusBadRequest, loc.T("некорректный формат: ожидается svg, png или json"), nil)
+		return
+	}
+	if err != nil {
+		log.Print(ws.lang.T("Ошибка вывода диаграммы: %v", err))
+	}
+}
+
+// chartRequestFromQuery разбирает параметры GET-запроса диаграммы
+func chartRequestFromQuery(query url.Values) (ChartRequest, error) {
+	req := ChartRequest{Format: query.Get("format"), Lang: query.Get("lang")}
+	for _, l := range []struct {
+		name string
+		dest **[]float64
+	}{
+		{"isobars", &req.Isobars},
+		{"isotherms", &req.Isotherms},
+		{"qualities", &req.Qualities},
+	} {
+		if !query.Has(l.name) {
+			continue
+		}
+		values, err := chart.ParseList(query.Get(l.name), 1)
+		if err != nil {
+			return req, err
+		}
+		*l.dest = &values
+	}
+	for _, dim := range []struct {
+		name string
+		dest *int
+	}{
+		{"width", &req.Width},
+		{"height", &req.Height},
+	} {
+		if v := query.Get(dim.name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return req, i18n.Errorf("некорректный размер %s=%q", dim.name, v)
+			}
+			*dim.dest = n
+		}
+	}
+	return req, nil
 }
 
 // handleStatic обрабатывает статические файлы
@@ -150,13 +422,16 @@ func (ws *WebServer) Run(port int) {
 	// Настраиваем маршруты
 	http.HandleFunc("/", ws.handleIndex)
 	http.HandleFunc("/api/calculate", ws.handleCalculate)
+	http.HandleFunc("/api/nozzle", ws.handleNozzle)
+	http.HandleFunc("/api/version", ws.handleVersion)
+	http.HandleFunc("/api/chart/", ws.handleChart)
 	http.HandleFunc("/static/", ws.handleStatic)
 
-	log.Printf("Веб-сервер запущен на порту %d", port)
-	log.Printf("Откройте http://localhost:%d в браузере", port)
+	log.Print(ws.lang.T("Веб-сервер запущен на порту %d", port))
+	log.Print(ws.lang.T("Откройте http://localhost:%d в браузере", port))
 
 	if err := http.ListenAndServe(fmt.Sprintf(":%d", port), nil); err != nil {
-		log.Fatal("Ошибка запуска сервера:", err)
+		log.Fatal(ws.lang.T("Ошибка запуска сервера: %v", err))
 	}
 }
 
@@ -0,0 +1,436 @@
+package i18n
+
+// english — английский каталог: русский текст сообщения (формат fmt) и его
+// перевод с теми же глаголами в том же порядке
+var english = map[string]string{
+	// Коды ошибок propserr
+	"недопустимое значение":                                 "invalid value",
+	"значение вне области применимости IF-97":               "value outside the IF-97 range of validity",
+	"точка вне области уравнения региона":                   "point outside the region's equation domain",
+	"расчет в регионе не поддерживается":                    "calculation is not supported in this region",
+	"нет состояния с заданными параметрами в области IF-97": "no state with the given properties within IF-97",
+	"нет сходимости":                                        "no convergence",
+	"нефизичный результат":                                  "non-physical result",
+	"неоднозначное состояние":                               "ambiguous state",
+	"неизвестный параметр":                                  "unknown parameter",
+	"неподдерживаемая пара входных параметров":              "unsupported input pair",
+	"вещество не поддерживается":                            "fluid is not supported",
+	"внутренняя ошибка":                                     "internal error",
+
+	// Фазы и регионы
+	"Жидкость":    "Liquid",
+	"Пар":         "Vapour",
+	"Влажный пар": "Wet steam",
+	"Сверхкритический флюид":               "Supercritical fluid",
+	"Сжатая жидкость":                      "Compressed liquid",
+	"Перегретый пар":                       "Superheated steam",
+	"Критическая/сверхкритическая область": "Critical/supercritical region",
+	"Критическая область":                  "Critical region",
+	"Двухфазная область":                   "Two-phase region",
+	"Высокотемпературный газ":              "High-temperature gas",
+	"Линия насыщения":                      "Saturation line",
+	"Неопределенная фаза":                  "Undetermined phase",
+	"Неизвестный регион":                   "Unknown region",
+	"Region 1 (Сжатая жидкость)":           "Region 1 (Compressed liquid)",
+	"Region 2 (Перегретый пар)":            "Region 2 (Superheated steam)",
+	"Region 3 (Критическая область)":       "Region 3 (Critical region)",
+	"Region 4 (Линия насыщения)":           "Region 4 (Saturation line)",
+	"Region 5 (Высокотемпературный газ)":   "Region 5 (High-temperature gas)",
+
+	// Входные данные steamprops
+	"температура содержит недопустимое значение":                            "temperature is not a valid number",
+	"давление содержит недопустимое значение":                               "pressure is not a valid number",
+	"энтальпия содержит недопустимое значение":                              "enthalpy is not a valid number",
+	"энтропия содержит недопустимое значение":                               "entropy is not a valid number",
+	"плотность содержит недопустимое значение":                              "density is not a valid number",
+	"удельный объем содержит недопустимое значение":                         "specific volume is not a valid number",
+	"внутренняя энергия содержит недопустимое значение":                     "internal energy is not a valid number",
+	"паросодержание содержит недопустимое значение":                         "vapour quality is not a valid number",
+	"температура насыщения содержит недопустимое значение":                  "saturation temperature is not a valid number",
+	"температура ниже абсолютного нуля":                                     "temperature is below absolute zero",
+	"температура ниже минимальной для IF-97":                                "temperature is below the IF-97 minimum",
+	"температура превышает максимальную для IF-97":                          "temperature exceeds the IF-97 maximum",
+	"температура вне диапазона IF-97":                                       "temperature outside the IF-97 range",
+	"температура ниже линии насыщения":                                      "temperature is below the saturation line",
+	"температура выше линии насыщения: влажного пара нет":                   "temperature is above the saturation line: no wet steam",
+	"температура насыщения ниже тройной точки":                              "saturation temperature is below the triple point",
+	"температура насыщения не ниже критической":                             "saturation temperature is not below the critical temperature",
+	"давление должно быть положительным":                                    "pressure must be positive",
+	"давление ниже минимального для IF-97":                                  "pressure is below the IF-97 minimum",
+	"давление превышает максимальное для IF-97":                             "pressure exceeds the IF-97 maximum",
+	"давление ниже границы B23":                                             "pressure is below the B23 boundary",
+	"давление выше критического: влажного пара нет":                         "pressure is above the critical pressure: no wet steam",
+	"давление при ρ=%.4g кг/м³ и T=%.2f°C превышает максимальное для IF-97": "pressure at ρ=%.4g kg/m³ and T=%.2f°C exceeds the IF-97 maximum",
+	"энтальпия не может быть отрицательной":                                 "enthalpy cannot be negative",
+	"энтальпия превышает разумный максимум для IF-97":                       "enthalpy exceeds a reasonable IF-97 maximum",
+	"энтальпия вне диапазона 0..4000 кДж/кг":                                "enthalpy outside 0..4000 kJ/kg",
+	"энтропия не может быть отрицательной":                                  "entropy cannot be negative",
+	"энтропия превышает разумный максимум для IF-97":                        "entropy exceeds a reasonable IF-97 maximum",
+	"энтропия вне диапазона 0..10 кДж/(кг·К)":                               "entropy outside 0..10 kJ/(kg·K)",
+	"плотность должна быть положительной":                                   "density must be positive",
+	"удельный объем должен быть положительным":                              "specific volume must be positive",
+	"паросодержание вне диапазона 0..1":                                     "vapour quality outside 0..1",
+	"свойство не определено во влажном паре":                                "property is not defined for wet steam",
+	"значение %.6g вне диапазона %.6g..%.6g при p=%.0f Па":                  "value %.6g outside %.6g..%.6g at p=%.0f Pa",
+	"плотность вне диапазона %.6g..%.6g при T=%.2f°C":                       "density outside %.6g..%.6g at T=%.2f°C",
+
+	// Ошибки расчета steamprops
+	"ошибка расчета по %s: %w":                                                                 "calculation error for %s: %w",
+	"ошибка расчета по %s: %w при T=%.2f°C":                                                    "calculation error for %s: %w at T=%.2f°C",
+	"ошибка расчета по %s: недопустимое значение свойства: %v":                                 "calculation error for %s: invalid property value: %v",
+	"ошибка расчета по %s: нет состояния со значением %.6g при T=%.2f°C в области IF-97":       "calculation error for %s: no state with value %.6g at T=%.2f°C within IF-97",
+	"ошибка расчета по T,P: %w":                                                                "calculation error for T,P: %w",
+	"ошибка расчета по p,h: %w":                                                                "calculation error for p,h: %w",
+	"ошибка расчета по p,s: %w":                                                                "calculation error for p,s: %w",
+	"ошибка расчета по ρ,T: %w":                                                                "calculation error for ρ,T: %w",
+	"ошибка расчета по h,s (предполагаемый регион: %d)":                                        "calculation error for h,s (presumed region: %d)",
+	"ошибка расчета по h,s: недопустимые значения h=%v, s=%v":                                  "calculation error for h,s: invalid values h=%v, s=%v",
+	"ошибка расчета по h,s: нет состояния с h=%.2f кДж/кг и s=%.4f кДж/(кг·К) в области IF-97": "calculation error for h,s: no state with h=%.2f kJ/kg and s=%.4f kJ/(kg·K) within IF-97",
+	"не удалось найти решение для h=%.2f кДж/кг, s=%.2f кДж/(кг·К). Возможно, точка находится в Region %d": "no solution found for h=%.2f kJ/kg, s=%.2f kJ/(kg·K). The point may lie in Region %d",
+	"ошибка расчета по v,u: %w":                                                           "calculation error for v,u: %w",
+	"ошибка расчета по v,u: недопустимые значения v=%v, u=%v":                             "calculation error for v,u: invalid values v=%v, u=%v",
+	"ошибка расчета по v,u: нет состояния с v=%.6g м³/кг и u=%.2f кДж/кг в области IF-97": "calculation error for v,u: no state with v=%.6g m³/kg and u=%.2f kJ/kg within IF-97",
+	"ошибка расчета по v,u: нет сходимости для v=%.6g м³/кг, u=%.2f кДж/кг":               "calculation error for v,u: no convergence for v=%.6g m³/kg, u=%.2f kJ/kg",
+	"ошибка расчета на изоэнтропе при p=%.0f Па: %w":                                      "calculation error on the isentrope at p=%.0f Pa: %w",
+	"ошибка расчета давления насыщения: %w":                                               "saturation pressure calculation error: %w",
+	"ошибка расчета температуры насыщения: %w":                                            "saturation temperature calculation error: %w",
+	"ошибка расчета насыщенной жидкости: %w":                                              "saturated liquid calculation error: %w",
+	"ошибка расчета насыщенного пара: %w":                                                 "saturated vapour calculation error: %w",
+	"ошибка расчета плотностей насыщения: %w":                                             "saturation densities calculation error: %w",
+	"нет однофазного состояния с ρ=%.6g кг/м³ при T=%.2f°C":                               "no single-phase state with ρ=%.6g kg/m³ at T=%.2f°C",
+	"нет сходимости по давлению для ρ=%.6g кг/м³ при T=%.2f°C":                            "no pressure convergence for ρ=%.6g kg/m³ at T=%.2f°C",
+	"изохора v=%.6g м³/кг прерывается при T=%.2f°C":                                       "the isochore v=%.6g m³/kg breaks off at T=%.2f°C",
+	"расчет по T,P возможен только в регионах 1, 2, 3 и 5":                                "calculation from T,P is only possible in regions 1, 2, 3 and 5",
+	"недопустимое значение свойства: %v":                                                  "invalid property value: %v",
+	"неверный режим расчета: %s":                                                          "invalid calculation mode: %s",
+	"неизвестный вычислитель: %v":                                                         "unknown evaluator: %v",
+	"не задан калькулятор":                                                                "calculator is not set",
+	"столбцы режима %s имеют разную длину: %d и %d":                                       "columns for mode %s have different lengths: %d and %d",
+	"массивы входных параметров имеют разную длину: %d и %d":                              "input arrays have different lengths: %d and %d",
+	"неоднозначное состояние по %s: %d решения (%s)":                                      "ambiguous state for %s: %d solutions (%s)",
+	"p=%.6g Па, x=%.4g":    "p=%.6g Pa, x=%.4g",
+	"p=%.6g Па, %s":        "p=%.6g Pa, %s",
+	"p=%.6g Па, Region %d": "p=%.6g Pa, Region %d",
+
+	// Уравнения IF-97 (calc_core)
+	"точка вне области уравнения региона при T = %.2f °C, p = %.0f Pa":     "point outside the region's equation domain at T = %.2f °C, p = %.0f Pa",
+	"уравнения не определены при p=%.6g Па":                                "equations are not defined at p=%.6g Pa",
+	"уравнение линии насыщения не определено":                              "saturation line equation is not defined",
+	"линия насыщения Region 3 определена при 623.15 ≤ T < %.3f K":          "the Region 3 saturation line is defined for 623.15 ≤ T < %.3f K",
+	"нет температуры на границе B23":                                       "no temperature on the B23 boundary",
+	"нет плотности при T = %.2f K":                                         "no density at T = %.2f K",
+	"давление за спинодалью при T = %.2f K":                                "pressure beyond the spinodal at T = %.2f K",
+	"механически неустойчивое состояние при T = %.2f K":                    "mechanically unstable state at T = %.2f K",
+	"неизвестная подобласть":                                               "unknown subregion",
+	"нет корня на интервале":                                               "no root in the interval",
+	"нечисловые значения в границах интервала":                             "non-numeric values at the interval bounds",
+	"нечисловые значения свойств":                                          "non-numeric property values",
+	"неположительные плотность, объем или скорость звука":                  "non-positive density, volume or speed of sound",
+	"неположительные теплоемкости: точка, вероятно, вне области уравнения": "non-positive heat capacities: the point is probably outside the equation domain",
+	"недопустимая изоэнтропная сжимаемость":                                "invalid isentropic compressibility",
+	"недопустимая производная dT/ds для cp":                                "invalid derivative dT/ds for cp",
+	"не удалось вычислить производную T(p,s) для cp":                       "failed to compute the derivative T(p,s) for cp",
+	"недопустимый удельный объем v(p,s)":                                   "invalid specific volume v(p,s)",
+	"обратное уравнение p(h,s) подобласти 3a дало недопустимое давление":   "the backward equation p(h,s) for subregion 3a gave an invalid pressure",
+	"обратное уравнение p(h,s) подобласти 3b дало недопустимое давление":   "the backward equation p(h,s) for subregion 3b gave an invalid pressure",
+	"обратное уравнение p(h,s) подобласти 3b не определено":                "the backward equation p(h,s) for subregion 3b is not defined",
+	"состояние вне области применимости корреляций переноса":               "state outside the range of the transport correlations",
+	"состояние у линии насыщения не входит в таблицы SBTL":                 "states next to the saturation line are not covered by the SBTL tables",
+	"состояние вне таблиц SBTL":                                            "state outside the SBTL tables",
+	"SBTL: нет сходимости при p = %g Pa":                                   "SBTL: no convergence at p = %g Pa",
+	"ошибка построения таблиц SBTL: %w":                                    "failed to build the SBTL tables: %w",
+	"недопустимое число Рейнольдса: %v":                                    "invalid Reynolds number: %v",
+	"относительная шероховатость %v не может быть отрицательной":           "relative roughness %v cannot be negative",
+
+	// Пакет if97
+	"недопустимые входные данные (%s): %v": "invalid inputs (%s): %v",
+	"ошибка расчета (%s): %v":              "calculation error (%s): %v",
+	"ошибка расчета %s (%s): %v":           "calculation error for %s (%s): %v",
+	"неизвестный входной параметр %q":      "unknown input parameter %q",
+	"неизвестный выходной параметр %q":     "unknown output parameter %q",
+	"неподдерживаемая пара входных параметров (%s,%s): ожидается T–P, P–H, P–S, H–S, P–Q, T–Q, D–U, D–T, T–H или T–S": "unsupported input pair (%s,%s): expected T–P, P–H, P–S, H–S, P–Q, T–Q, D–U, D–T, T–H or T–S",
+	"вещество %q не поддерживается: IF-97 описывает только воду (Water)":                                              "fluid %q is not supported: IF-97 covers water (Water) only",
+
+	// Единицы измерения
+	"СИ (°C, Па, кДж/кг)":           "SI (°C, Pa, kJ/kg)",
+	"Техническая (°C, бар, кДж/кг)": "Engineering (°C, bar, kJ/kg)",
+	"США (°F, psia, BTU/lb)":        "US (°F, psia, BTU/lb)",
+	"неизвестная система единиц %q: ожидается si, si-eng или us": "unknown unit system %q: expected si, si-eng or us",
+	"неизвестная единица давления %q":                            "unknown pressure unit %q",
+	"неизвестная единица температуры %q":                         "unknown temperature unit %q",
+	"неизвестная единица удельной энергии %q":                    "unknown specific energy unit %q",
+	"неизвестная единица удельной энтропии %q":                   "unknown specific entropy unit %q",
+	"кДж/кг":      "kJ/kg",
+	"кДж/(кг·К)":  "kJ/(kg·K)",
+	"Дж/кг":       "J/kg",
+	"Дж/(кг·К)":   "J/(kg·K)",
+	"кг/м³":       "kg/m³",
+	"м³/кг":       "m³/kg",
+	"кгс/см²":     "kgf/cm²",
+	"мм рт. ст.":  "mmHg",
+	"мм вод. ст.": "mmH₂O",
+
+	// Диаграммы
+	"h–s диаграмма воды и водяного пара (IAPWS IF-97)":    "h–s diagram of water and steam (IAPWS IF-97)",
+	"T–s диаграмма воды и водяного пара (IAPWS IF-97)":    "T–s diagram of water and steam (IAPWS IF-97)",
+	"lg p–h диаграмма воды и водяного пара (IAPWS IF-97)": "lg p–h diagram of water and steam (IAPWS IF-97)",
+	"h–s (Молье)":   "h–s (Mollier)",
+	"s, кДж/(кг·К)": "s, kJ/(kg·K)",
+	"h, кДж/кг":     "h, kJ/kg",
+	"p, МПа":        "p, MPa",
+	"%g МПа":        "%g MPa",
+	"%g кПа":        "%g kPa",
+	"%g Па":         "%g Pa",
+	"Процесс":       "Process",
+	"не удалось построить изобару p = %.0f Па":                           "failed to build the isobar p = %.0f Pa",
+	"не удалось построить изотерму t = %.2f°C":                           "failed to build the isotherm t = %.2f°C",
+	"не удалось построить линию x = %.2f":                                "failed to build the line x = %.2f",
+	"давление изобары %.0f Па вне диапазона IF-97":                       "isobar pressure %.0f Pa outside the IF-97 range",
+	"температура изотермы %.2f°C вне диапазона IF-97":                    "isotherm temperature %.2f°C outside the IF-97 range",
+	"температура %.2f°C вне диапазона построения":                        "temperature %.2f°C outside the plotting range",
+	"степень сухости %.4f вне диапазона 0..1":                            "dryness fraction %.4f outside 0..1",
+	"число шагов %d должно быть не меньше 2":                             "number of steps %d must be at least 2",
+	"для оси %q не задана координата":                                    "no coordinate is defined for axis %q",
+	"логарифмическая ось %q должна начинаться с положительного значения": "logarithmic axis %q must start at a positive value",
+	"некорректный диапазон оси %q: %g..%g":                               "invalid range for axis %q: %g..%g",
+	"размер изображения %dx%d должен быть положительным":                 "image size %dx%d must be positive",
+	"неизвестная диаграмма %q: ожидается hs, ts или ph":                  "unknown diagram %q: expected hs, ts or ph",
+	"неизвестный режим точки %q: ожидается TP, PX, PH или PS":            "unknown point mode %q: expected TP, PX, PH or PS",
+	"процесс %q не содержит точек":                                       "process %q has no points",
+	"процесс %q содержит %d точек: допускается не более %d":              "process %q has %d points: at most %d are allowed",
+	"слишком много линий одного вида (%d): допускается не более %d":      "too many lines of one kind (%d): at most %d are allowed",
+	"точка %d процесса %q: %w":                                           "point %d of process %q: %w",
+	"ошибка вывода текста: %w":                                           "text rendering error: %w",
+	"ошибка загрузки шрифта: %w":                                         "font loading error: %w",
+
+	// Процессы
+	"не заданы калькулятор или входное состояние потока":                                          "calculator or inlet flow state is not set",
+	"не заданы калькулятор или параметры котла":                                                   "calculator or boiler parameters are not set",
+	"не заданы калькулятор или параметры насоса":                                                  "calculator or pump parameters are not set",
+	"не заданы калькулятор или параметры торможения":                                              "calculator or stagnation parameters are not set",
+	"не заданы калькулятор, труба или входное состояние":                                          "calculator, pipe or inlet state is not set",
+	"не заданы теплоносители":                                                                     "heat exchanger streams are not set",
+	"состояние на входе: %w":                                                                      "inlet state: %w",
+	"давление насыщения на входе: %w":                                                             "inlet saturation pressure: %w",
+	"изоэнтропное состояние на выходе: %w":                                                        "isentropic outlet state: %w",
+	"действительное состояние на выходе: %w":                                                      "actual outlet state: %w",
+	"состояние на выходе вне Region 1 (Region %d)":                                                "outlet state outside Region 1 (Region %d)",
+	"на входе насоса должна быть сжатая жидкость (Region 1), получен Region %d":                   "the pump inlet must be compressed liquid (Region 1), got Region %d",
+	"давление на выходе %.0f Па должно превышать давление на входе %.0f Па":                       "outlet pressure %.0f Pa must exceed inlet pressure %.0f Pa",
+	"КПД %.4f вне диапазона (0, 1]":                                                               "efficiency %.4f outside (0, 1]",
+	"противодавление %.0f Па не ниже давления торможения %.0f Па":                                 "back pressure %.0f Pa is not below the stagnation pressure %.0f Pa",
+	"скорость на входе %.3f м/с не может быть отрицательной":                                      "inlet velocity %.3f m/s cannot be negative",
+	"диаметр трубы %.4f м должен быть положительным":                                              "pipe diameter %.4f m must be positive",
+	"длина трубы %.3f м должна быть положительной":                                                "pipe length %.3f m must be positive",
+	"шероховатость %.6f м не может быть отрицательной":                                            "roughness %.6f m cannot be negative",
+	"массовый расход %.4f кг/с должен быть положительным":                                         "mass flow rate %.4f kg/s must be positive",
+	"число участков %d должно быть положительным":                                                 "number of segments %d must be positive",
+	"число участков %d не может быть отрицательным":                                               "number of segments %d cannot be negative",
+	"участок %d (%.2f м): %w":                                                                     "segment %d (%.2f m): %w",
+	"тепловая нагрузка %.3f кВт должна быть положительной":                                        "heat duty %.3f kW must be positive",
+	"коэффициент теплопередачи %.3f Вт/(м²·К) не может быть отрицательным":                        "heat transfer coefficient %.3f W/(m²·K) cannot be negative",
+	"неизвестная схема движения теплоносителей: %d":                                               "unknown flow arrangement: %d",
+	"горячий теплоноситель при Q=%.3f кВт: %w":                                                    "hot stream at Q=%.3f kW: %w",
+	"холодный теплоноситель при Q=%.3f кВт: %w":                                                   "cold stream at Q=%.3f kW: %w",
+	"пересечение температур при Q=%.3f кВт: Tгор=%.3f°C, Tхол=%.3f°C":                             "temperature cross at Q=%.3f kW: Thot=%.3f°C, Tcold=%.3f°C",
+	"паропроизводительность %.4f кг/с должна быть положительной":                                  "steam output %.4f kg/s must be positive",
+	"давления в барабане %.0f Па и питательной воды %.0f Па должны быть положительными":           "drum pressure %.0f Pa and feedwater pressure %.0f Pa must be positive",
+	"давление в расширителе %.0f Па должно быть ниже давления в барабане %.0f Па":                 "flash tank pressure %.0f Pa must be below the drum pressure %.0f Pa",
+	"доля продувки %.4f вне диапазона 0..1":                                                       "blowdown fraction %.4f outside 0..1",
+	"расход топлива %.4f кг/с и теплота сгорания %.1f кДж/кг не могут быть отрицательными":        "fuel flow %.4f kg/s and heating value %.1f kJ/kg cannot be negative",
+	"расход %.4f кг/с и теплоемкость %.4f кДж/(кг·К) газа должны быть положительными":             "gas flow %.4f kg/s and heat capacity %.4f kJ/(kg·K) must be positive",
+	"питательная вода (h=%.2f кДж/кг) не должна быть горячее котловой воды (h=%.2f кДж/кг)":       "feedwater (h=%.2f kJ/kg) must not be hotter than boiler water (h=%.2f kJ/kg)",
+	"энтальпия пара на выходе %.2f кДж/кг ниже энтальпии насыщенного пара в барабане %.2f кДж/кг": "outlet steam enthalpy %.2f kJ/kg is below the drum saturated vapour enthalpy %.2f kJ/kg",
+	"питательная вода: %w":                                                                        "feedwater: %w",
+	"котловая вода: %w":                                                                           "boiler water: %w",
+	"насыщенный пар: %w":                                                                          "saturated steam: %w",
+	"перегретый пар: %w":                                                                          "superheated steam: %w",
+	"расширитель продувки: %w":                                                                    "blowdown flash tank: %w",
+
+	// Командная строка
+	"Режим: tp (по T и p), hs (по h и s), vu (по v и u), rhot (по ρ и T), th (по T и h), ts (по T и s), nozzle (критическое истечение), pipe (гидравлика трубы) или chart (диаграмма)": "Mode: tp (from T and p), hs (from h and s), vu (from v and u), rhot (from ρ and T), th (from T and h), ts (from T and s), nozzle (critical flow), pipe (pipe hydraulics) or chart (diagram)",
+	"Температура, ℃; допускается единица: 392°F, 473.15K":                                                 "Temperature, ℃; a unit is allowed: 392°F, 473.15K",
+	"Давление, Па; допускается единица: 40MPa, 10bar, 150psi":                                             "Pressure, Pa; a unit is allowed: 40MPa, 10bar, 150psi",
+	"Энтальпия, кДж/кг или с единицей, например 1200BTU/lb (для режимов hs и th)":                         "Enthalpy, kJ/kg or with a unit, e.g. 1200BTU/lb (for modes hs and th)",
+	"Энтропия, кДж/(кг*К) или с единицей, например 1.6BTU/(lb·°F) (для режимов hs и ts)":                  "Entropy, kJ/(kg*K) or with a unit, e.g. 1.6BTU/(lb·°F) (for modes hs and ts)",
+	"Удельный объем, м3/кг (для режима vu)":                                                               "Specific volume, m3/kg (for mode vu)",
+	"Удельная внутренняя энергия, кДж/кг или с единицей (для режима vu)":                                  "Specific internal energy, kJ/kg or with a unit (for mode vu)",
+	"Плотность, кг/м3 (для режима rhot)":                                                                  "Density, kg/m3 (for mode rhot)",
+	"Регион IF-97: auto, 1, 2, 3, 5":                                                                      "IF-97 region: auto, 1, 2, 3, 5",
+	"Система единиц вывода: si (°C, Па, кДж/кг), si-eng (°C, бар, кДж/кг) или us (°F, psia, BTU/lb)":      "Output unit system: si (°C, Pa, kJ/kg), si-eng (°C, bar, kJ/kg) or us (°F, psia, BTU/lb)",
+	"Выводить избыточное давление вместо абсолютного":                                                     "Print gauge pressure instead of absolute",
+	"Атмосферное давление для избыточного давления, Па или с единицей":                                    "Atmospheric pressure for gauge pressure, Pa or with a unit",
+	"Противодавление, Па или с единицей (для режима nozzle)":                                              "Back pressure, Pa or with a unit (for mode nozzle)",
+	"Паросодержание на входе 0..1 (для режимов nozzle и pipe; -1 — задать по T и p)":                      "Inlet vapour quality 0..1 (for modes nozzle and pipe; -1 — from T and p)",
+	"Проходное сечение клапана, м² (для режима nozzle)":                                                   "Valve flow area, m² (for mode nozzle)",
+	"Коэффициент расхода клапана (для режима nozzle)":                                                     "Valve discharge coefficient (for mode nozzle)",
+	"Внутренний диаметр трубы, м (для режима pipe)":                                                       "Pipe inner diameter, m (for mode pipe)",
+	"Длина трубы, м (для режима pipe)":                                                                    "Pipe length, m (for mode pipe)",
+	"Шероховатость трубы, м (для режима pipe)":                                                            "Pipe roughness, m (for mode pipe)",
+	"Массовый расход, кг/с (для режима pipe)":                                                             "Mass flow rate, kg/s (for mode pipe)",
+	"Перепад высот выход-вход, м (для режима pipe)":                                                       "Elevation change outlet minus inlet, m (for mode pipe)",
+	"Тепловые потери, Вт/м (для режима pipe)":                                                             "Heat loss, W/m (for mode pipe)",
+	"Тип диаграммы: hs, ts или ph (для режима chart)":                                                     "Diagram type: hs, ts or ph (for mode chart)",
+	"Изобары через запятую, МПа; пусто — стандартный набор, none — без линий (для режима chart)":          "Comma-separated isobars, MPa; empty — default set, none — no lines (for mode chart)",
+	"Изотермы через запятую, ℃; пусто — стандартный набор, none — без линий (для режима chart)":           "Comma-separated isotherms, ℃; empty — default set, none — no lines (for mode chart)",
+	"Линии степени сухости через запятую; пусто — стандартный набор, none — без линий (для режима chart)": "Comma-separated dryness fraction lines; empty — default set, none — no lines (for mode chart)",
+	"Файл диаграммы .svg или .png; пусто — SVG в stdout (для режима chart)":                               "Diagram file .svg or .png; empty — SVG to stdout (for mode chart)",
+	"Вывести версию библиотеки и формуляции и выйти":                                                      "Print the library and formulation versions and exit",
+	"Язык сообщений: ru или en; по умолчанию — из STEAMPROPS_LANG, LC_ALL, LC_MESSAGES или LANG":          "Message language: ru or en; defaults to STEAMPROPS_LANG, LC_ALL, LC_MESSAGES or LANG",
+	"некорректное значение --lang: ожидается ru или en":                                                   "invalid --lang value: expected ru or en",
+	"некорректное значение --region: ожидается auto, 1, 2, 3 или 5":                                       "invalid --region value: expected auto, 1, 2, 3 or 5",
+	"некорректный режим --mode: ожидается tp, hs, vu, rhot, th, ts, nozzle, pipe или chart":               "invalid --mode: expected tp, hs, vu, rhot, th, ts, nozzle, pipe or chart",
+	"некорректное число %q в списке %q":                                                                   "invalid number %q in list %q",
+	"некорректное число в %q":                                                                             "invalid number in %q",
+	"Регион IF-97: %d (%s)\n":                              "IF-97 region: %d (%s)\n",
+	"Температура: %.9f %s\n":                               "Temperature: %.9f %s\n",
+	"Давление: %.6f %s\n":                                  "Pressure: %.6f %s\n",
+	"Паросодержание: %.9f\n":                               "Vapour quality: %.9f\n",
+	"Удельный объем: %.12f %s\n":                           "Specific volume: %.12f %s\n",
+	"Плотность: %.12f %s\n":                                "Density: %.12f %s\n",
+	"Удельная энтальпия: %.12f %s\n":                       "Specific enthalpy: %.12f %s\n",
+	"Удельная энтропия: %.12f %s\n":                        "Specific entropy: %.12f %s\n",
+	"Удельная внутренняя энергия: %.12f %s\n":              "Specific internal energy: %.12f %s\n",
+	"Удельная изобарная теплоемкость: %.12f %s\n":          "Specific isobaric heat capacity: %.12f %s\n",
+	"Удельная изохорная теплоемкость: %.12f %s\n":          "Specific isochoric heat capacity: %.12f %s\n",
+	"Скорость звука: %.12f %s\n":                           "Speed of sound: %.12f %s\n",
+	"Динамическая вязкость: %.6e %s\n":                     "Dynamic viscosity: %.6e %s\n",
+	"Кинематическая вязкость: %.6e %s\n":                   "Kinematic viscosity: %.6e %s\n",
+	"Теплопроводность: %.6f %s\n":                          "Thermal conductivity: %.6f %s\n",
+	"Параметры торможения: p0=%s, T0=%s, h0=%.3f %s\n":     "Stagnation state: p0=%s, T0=%s, h0=%.3f %s\n",
+	"Истечение: критическое (запертое)":                    "Flow: critical (choked)",
+	"Истечение: докритическое (горло при противодавлении)": "Flow: subcritical (throat at back pressure)",
+	"Критическое отношение давлений: %.6f\n":               "Critical pressure ratio: %.6f\n",
+	"Давление в горле: %s\n":                               "Throat pressure: %s\n",
+	"Температура в горле: %s\n":                            "Throat temperature: %s\n",
+	"Паросодержание в горле: %.6f\n":                       "Throat vapour quality: %.6f\n",
+	"Плотность в горле: %.6f %s\n":                         "Throat density: %.6f %s\n",
+	"Скорость в горле: %.3f %s\n":                          "Throat velocity: %.3f %s\n",
+	"Плотность потока массы: %.3f %s\n":                    "Mass flux: %.3f %s\n",
+	"Пропускная способность: %.6g %s\n":                    "Flow capacity: %.6g %s\n",
+	"Вход: p=%s, T=%s, w=%.3f %s, Re=%.4g, λ=%.5f\n":       "Inlet: p=%s, T=%s, w=%.3f %s, Re=%.4g, λ=%.5f\n",
+	"Выход: p=%s, T=%s, w=%.3f %s\n":                       "Outlet: p=%s, T=%s, w=%.3f %s\n",
+	"Падение давления: %s\n":                               "Pressure drop: %s\n",
+	"  на трение: %s\n":                                    "  friction: %s\n",
+	"  на ускорение: %s\n":                                 "  acceleration: %s\n",
+	"  на подъем: %s\n":                                    "  elevation: %s\n",
+	"Паросодержание на выходе: %.6f\n":                     "Outlet vapour quality: %.6f\n",
+	"Тепловые потери: %.3f %s\n":                           "Heat loss: %.3f %s\n",
+	"Диаграмма сохранена в %s\n":                           "Diagram saved to %s\n",
+	"нулевой указатель":                                    "null pointer",
+	"внутренняя ошибка: %v":                                "internal error: %v",
+
+	// Веб-сервер и API
+	"Ошибка парсинга JSON: %v":                              "JSON parse error: %v",
+	"Ошибка валидации":                                      "Validation error",
+	"Ошибка валидации: %v":                                  "Validation error: %v",
+	"Ошибка валидации: %w":                                  "Validation error: %w",
+	"Ошибка валидации: неверный режим расчета: %s":          "Validation error: invalid calculation mode: %s",
+	"Ошибка расчета: %w":                                    "Calculation error: %w",
+	"Ошибка расчета параметров торможения: %v":              "Stagnation state calculation error: %v",
+	"Ошибка расчета истечения: %v":                          "Flow calculation error: %v",
+	"Ошибка разбора запроса: %v":                            "Request parse error: %v",
+	"некорректный размер %dx%d: ожидается 100..4000":        "invalid size %dx%d: expected 100..4000",
+	"некорректный размер %s=%q":                             "invalid size %s=%q",
+	"некорректный формат: ожидается svg, png или json":      "invalid format: expected svg, png or json",
+	"слишком много процессов (%d): допускается не более %d": "too many processes (%d): at most %d are allowed",
+	"Ошибка вывода диаграммы: %v":                           "Diagram output error: %v",
+	"Ошибка формирования ответа: %v":                        "Response encoding error: %v",
+	"Веб-сервер запущен на порту %d":                        "Web server listening on port %d",
+	"Откройте http://localhost:%d в браузере":               "Open http://localhost:%d in a browser",
+	"Ошибка запуска сервера: %v":                            "Server start error: %v",
+	"неверное значение температуры: %v":                     "invalid temperature value: %v",
+	"неверное значение давления: %v":                        "invalid pressure value: %v",
+	"неверное значение энтальпии: %v":                       "invalid enthalpy value: %v",
+	"неверное значение энтропии: %v":                        "invalid entropy value: %v",
+	"неверное значение удельного объема: %v":                "invalid specific volume value: %v",
+	"неверное значение внутренней энергии: %v":              "invalid internal energy value: %v",
+	"неверное значение плотности: %v":                       "invalid density value: %v",
+
+	// Веб-интерфейс и GUI
+	"Калькулятор термодинамических свойств воды и пара (IAPWS IF-97)":      "Thermodynamic properties calculator for water and steam (IAPWS IF-97)",
+	"SteamProps — калькулятор свойств воды и пара (IAPWS IF-97).":          "SteamProps — water and steam properties calculator (IAPWS IF-97).",
+	"Эта вкладка будет дополнена справкой, ссылками и горячими клавишами.": "This tab will be extended with help, links and keyboard shortcuts.",
+	"Калькулятор":                            "Calculator",
+	"Диаграмма":                              "Diagram",
+	"Диаграмма состояния":                    "State diagram",
+	"История":                                "History",
+	"История расчетов":                       "Calculation history",
+	"История пуста":                          "History is empty",
+	"О программе":                            "About",
+	"Информация":                             "Information",
+	"Язык интерфейса":                        "Interface language",
+	"Параметры расчета":                      "Calculation parameters",
+	"Режим расчета":                          "Calculation mode",
+	"Режим расчета:":                         "Calculation mode:",
+	"TP (Температура-Давление)":              "TP (Temperature-Pressure)",
+	"HS (Энтальпия-Энтропия)":                "HS (Enthalpy-Entropy)",
+	"VU (Удельный объем-Внутренняя энергия)": "VU (Specific volume-Internal energy)",
+	"RhoT (Плотность-Температура)":           "RhoT (Density-Temperature)",
+	"TH (Температура-Энтальпия)":             "TH (Temperature-Enthalpy)",
+	"TS (Температура-Энтропия)":              "TS (Temperature-Entropy)",
+	"Режим HS работает для Region 3\nДля других регионов используйте режим TP": "HS mode works for Region 3\nUse TP mode for other regions",
+	"Регион IF-97:":                      "IF-97 region:",
+	"Регионы IF-97:":                     "IF-97 regions:",
+	"Автоматический выбор":               "Automatic",
+	"Единицы измерения:":                 "Units:",
+	"Единицы результатов:":               "Result units:",
+	"Избыточное давление":                "Gauge pressure",
+	"Па (атмосферное)":                   "Pa (atmospheric)",
+	"Расчет:":                            "Calculation:",
+	"На сервере":                         "On the server",
+	"В браузере (WebAssembly, без сети)": "In the browser (WebAssembly, offline)",
+	"Температура":                        "Temperature",
+	"Температура:":                       "Temperature:",
+	"Давление":                           "Pressure",
+	"Давление:":                          "Pressure:",
+	"Энтальпия":                          "Enthalpy",
+	"Энтальпия:":                         "Enthalpy:",
+	"Энтропия":                           "Entropy",
+	"Энтропия:":                          "Entropy:",
+	"Удельный объем":                     "Specific volume",
+	"Удельный объем:":                    "Specific volume:",
+	"Внутренняя энергия":                 "Internal energy",
+	"Внутренняя энергия:":                "Internal energy:",
+	"Плотность":                          "Density",
+	"Плотность:":                         "Density:",
+	"Изобарная теплоемкость":             "Isobaric heat capacity",
+	"Изохорная теплоемкость":             "Isochoric heat capacity",
+	"Теплоемкость":                       "Heat capacity",
+	"Скорость звука":                     "Speed of sound",
+	"Динамическая вязкость":              "Dynamic viscosity",
+	"Кинематическая вязкость":            "Kinematic viscosity",
+	"Теплопроводность":                   "Thermal conductivity",
+	"Рассчитать":                         "Calculate",
+	"Выполняется расчет...":              "Calculating...",
+	"Результаты расчета":                 "Calculation results",
+	"Значения свойств":                   "Property values",
+	"Значение":                           "Value",
+	"Регион:":                            "Region:",
+	"Регион: %s":                         "Region: %s",
+	"Регион: Не определен":               "Region: Undetermined",
+	"Фаза:":                              "Phase:",
+	"Фаза: %s":                           "Phase: %s",
+	"Фаза: Не определена":                "Phase: Undetermined",
+	"График свойств":                     "Property chart",
+	"Последние результаты сессии":        "Latest results of the session",
+	"Очистить":                           "Clear",
+	"Очистить историю":                   "Clear history",
+	"Очистить точки":                     "Clear points",
+	"Соединять точки в процесс":          "Connect points into a process",
+	"Точек на диаграмме: %d":             "Points on the diagram: %d",
+	"Щелкните по h–s или lg p–h диаграмме, чтобы рассчитать состояние в этой точке":  "Click the h–s or lg p–h diagram to calculate the state at that point",
+	"На этой диаграмме выбор точки недоступен: нет режима расчета по ее координатам": "Picking a point is not available on this diagram: there is no calculation mode for its coordinates",
+	"Ошибка построения диаграммы":                                                   "Diagram error",
+	"Ошибка построения диаграммы: %v":                                               "Diagram error: %v",
+	"Ошибка построения процесса: %v":                                                "Process error: %v",
+	"Ошибка соединения":                                                             "Connection error",
+	"Пожалуйста, введите корректные значения температуры и давления":                "Please enter valid temperature and pressure values",
+	"Пожалуйста, введите корректные значения энтальпии и энтропии":                  "Please enter valid enthalpy and entropy values",
+	"Пожалуйста, введите корректные значения удельного объема и внутренней энергии": "Please enter valid specific volume and internal energy values",
+	"Пожалуйста, введите корректные значения плотности и температуры":               "Please enter valid density and temperature values",
+	"Пожалуйста, введите корректные значения температуры и энтальпии":               "Please enter valid temperature and enthalpy values",
+	"Пожалуйста, введите корректные значения температуры и энтропии":                "Please enter valid temperature and entropy values",
+	"модуль WebAssembly не собран: выполните make build-wasm":                       "WebAssembly module is not built: run make build-wasm",
+	"модуль WebAssembly не зарегистрировал steampropsCalculate":                     "WebAssembly module did not register steampropsCalculate",
+}
@@ -0,0 +1,295 @@
+// Пакет i18n — перевод сообщений пользователю на русский и английский языки.
+//
+// Исходный язык сообщений — русский: идентификатор сообщения совпадает с его
+// русским текстом (форматом fmt), а каталог english содержит переводы
+// этих форматов. Сообщение без перевода выводится по-русски.
+//
+// Ошибки, созданные Errorf, и ошибки пакета propserr хранят формат и
+// аргументы сообщения отдельно, поэтому Locale.Error переводит всю цепочку:
+//
+//	loc := i18n.FromEnv()
+//	_, err := if97.TP(2100, 1e6)
+//	fmt.Println(loc.Error(err))
+//
+// Язык выбирается переменными окружения (FromEnv), заголовком HTTP
+// Accept-Language (FromAcceptLanguage) или настройкой интерфейса (Parse).
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/somepgs/steamprops/propserr"
+)
+
+// Locale — язык сообщений
+type Locale string
+
+const (
+	Russian Locale = "ru"
+	English Locale = "en"
+
+	// Default — язык, если он не задан окружением или запросом
+	Default = Russian
+)
+
+// Locales — поддерживаемые языки в порядке показа в списке выбора
+var Locales = []Locale{Russian, English}
+
+// Name возвращает название языка на нем самом
+func (l Locale) Name() string {
+	switch l {
+	case English:
+		return "English"
+	case Russian:
+		return "Русский"
+	}
+	return string(l)
+}
+
+// Parse распознает язык по тегу BCP 47 или локали POSIX: "en", "en-GB",
+// "ru_RU.UTF-8". ok = false для других языков.
+func Parse(tag string) (l Locale, ok bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_.@"); i >= 0 {
+		tag = tag[:i]
+	}
+	switch tag {
+	case "ru":
+		return Russian, true
+	case "en":
+		return English, true
+	}
+	return Default, false
+}
+
+// envVariables — переменные окружения, задающие язык, в порядке приоритета
+var envVariables = []string{"STEAMPROPS_LANG", "LC_ALL", "LC_MESSAGES", "LANG"}
+
+// FromEnv выбирает язык по переменным STEAMPROPS_LANG, LC_ALL, LC_MESSAGES и
+// LANG. Локали C и POSIX язык не задают; для языка, отличного от русского и
+// английского, выбирается английский.
+func FromEnv() Locale {
+	return fromLookup(os.Getenv)
+}
+
+func fromLookup(getenv func(string) string) Locale {
+	for _, name := range envVariables {
+		v := getenv(name)
+		if v == "" || v == "C" || v == "POSIX" || strings.HasPrefix(v, "C.") {
+			continue
+		}
+		if l, ok := Parse(v); ok {
+			return l
+		}
+		return English
+	}
+	return Default
+}
+
+// FromAcceptLanguage выбирает язык по заголовку HTTP Accept-Language с
+// учетом весов q. Если заголовок пуст, возвращается def; если в нем нет
+// поддерживаемого языка — английский.
+func FromAcceptLanguage(header string, def Locale) Locale {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		for _, f := range fields[1:] {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(f), "q="); ok {
+				if w, err := strconv.ParseFloat(v, 64); err == nil {
+					q = w
+				}
+			}
+		}
+		if q > 0 {
+			tags = append(tags, weighted{tag, q})
+		}
+	}
+	if len(tags) == 0 {
+		return def
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+	for _, t := range tags {
+		if l, ok := Parse(t.tag); ok {
+			return l
+		}
+	}
+	return English
+}
+
+// Translatable — значение, текст которого строится по формату из каталога.
+// Формат — сообщение на русском языке с глаголами fmt; аргументы, которые
+// сами Translatable или error, переводятся рекурсивно.
+type Translatable interface {
+	Message() (format string, args []interface{})
+}
+
+// Msg — сообщение каталога с аргументами, переводимое при выводе. Msg
+// используется для частей составных сообщений, например списка решений.
+type Msg struct {
+	Format string
+	Args   []interface{}
+}
+
+// M создает сообщение по формату каталога
+func M(format string, args ...interface{}) Msg { return Msg{format, args} }
+
+// Message возвращает формат и аргументы сообщения
+func (m Msg) Message() (string, []interface{}) { return m.Format, m.Args }
+
+// String возвращает сообщение на русском языке
+func (m Msg) String() string { return Russian.T(m.Format, m.Args...) }
+
+// Join соединяет сообщения через sep; каждое переводится отдельно
+func Join(sep string, msgs []Msg) Msg {
+	args := make([]interface{}, len(msgs))
+	for i, m := range msgs {
+		args[i] = m
+	}
+	verbs := make([]string, len(msgs))
+	for i := range verbs {
+		verbs[i] = "%v"
+	}
+	return Msg{strings.Join(verbs, strings.ReplaceAll(sep, "%", "%%")), args}
+}
+
+// N помечает строку как сообщение каталога, не переводя ее: так
+// помечаются названия, которые переводятся при выводе через Locale.T
+func N(msg string) string { return msg }
+
+// Messages возвращает переводы сообщений каталога на язык l: русский
+// текст — перевод. Для русского языка возвращается nil.
+func (l Locale) Messages() map[string]string {
+	if l != English {
+		return nil
+	}
+	m := make(map[string]string, len(english))
+	for k, v := range english {
+		m[k] = v
+	}
+	return m
+}
+
+// lookup возвращает перевод формата или сам формат
+func (l Locale) lookup(format string) string {
+	if l == English {
+		if s, ok := english[format]; ok {
+			return s
+		}
+	}
+	return format
+}
+
+// T переводит сообщение и подставляет аргументы, как fmt.Sprintf
+func (l Locale) T(format string, args ...interface{}) string {
+	format = l.lookup(format)
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(strings.ReplaceAll(format, "%w", "%v"), l.args(args)...)
+}
+
+// args переводит аргументы сообщения: ошибки и Translatable заменяются текстом
+func (l Locale) args(args []interface{}) []interface{} {
+	out := make([]interface{}, len(args))
+	for i, a := range args {
+		switch v := a.(type) {
+		case error:
+			out[i] = l.Error(v)
+		case Translatable:
+			f, a := v.Message()
+			out[i] = l.T(f, a...)
+		default:
+			out[i] = a
+		}
+	}
+	return out
+}
+
+// Error переводит сообщение об ошибке вместе с ее причинами. Для русского
+// языка результат совпадает с err.Error().
+func (l Locale) Error(err error) string {
+	switch e := err.(type) {
+	case nil:
+		return ""
+	case *propserr.Error:
+		return l.propsError(e)
+	case propserr.Code:
+		return l.T(e.Error())
+	case Translatable:
+		f, a := e.Message()
+		return l.T(f, a...)
+	}
+	// Обертка fmt.Errorf("префикс: %w"): префикс ищется в каталоге целиком
+	if inner := errors.Unwrap(err); inner != nil {
+		if prefix, ok := strings.CutSuffix(err.Error(), ": "+inner.Error()); ok {
+			return l.lookup(prefix) + ": " + l.Error(inner)
+		}
+	}
+	return l.lookup(err.Error())
+}
+
+// propsError переводит ошибку пакета propserr в том же виде, что и
+// (*propserr.Error).Error
+func (l Locale) propsError(e *propserr.Error) string {
+	var b strings.Builder
+	if e.Region != 0 {
+		fmt.Fprintf(&b, "Region %d: ", e.Region)
+	}
+	if e.Detail != "" {
+		b.WriteString(l.T(e.Detail, e.Args...))
+	} else {
+		b.WriteString(l.T(e.Code.Error()))
+	}
+	if e.Quantity != "" {
+		fmt.Fprintf(&b, " (%s = %.6g", e.Quantity, e.Value)
+		if e.Unit != "" {
+			b.WriteString(" " + e.Unit)
+		}
+		switch e.Bound {
+		case propserr.Min:
+			fmt.Fprintf(&b, " < %.6g", e.Limit)
+		case propserr.Max:
+			fmt.Fprintf(&b, " > %.6g", e.Limit)
+		}
+		b.WriteString(")")
+	}
+	if e.Err != nil {
+		b.WriteString(": " + l.Error(e.Err))
+	}
+	return b.String()
+}
+
+// message — ошибка Errorf с форматом и аргументами для перевода
+type message struct {
+	format string
+	args   []interface{}
+	err    error // fmt.Errorf(format, args...)
+}
+
+// Errorf создает ошибку, как fmt.Errorf (включая %w), сохраняя формат и
+// аргументы для перевода сообщения через Locale.Error
+func Errorf(format string, args ...interface{}) error {
+	return &message{format: format, args: args, err: fmt.Errorf(format, args...)}
+}
+
+func (m *message) Error() string { return m.err.Error() }
+
+// Unwrap возвращает ошибку, переданную через %w
+func (m *message) Unwrap() error { return errors.Unwrap(m.err) }
+
+// Message возвращает формат и аргументы сообщения
+func (m *message) Message() (string, []interface{}) { return m.format, m.args }
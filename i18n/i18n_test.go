@@ -0,0 +1,277 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/somepgs/steamprops/propserr"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want Locale
+		ok   bool
+	}{
+		{"ru", Russian, true},
+		{"ru_RU.UTF-8", Russian, true},
+		{"EN-gb", English, true},
+		{"en_US@euro", English, true},
+		{"de-DE", Default, false},
+		{"", Default, false},
+	}
+	for _, tt := range tests {
+		if got, ok := Parse(tt.tag); got != tt.want || ok != tt.ok {
+			t.Errorf("Parse(%q) = %s, %v; want %s, %v", tt.tag, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		want Locale
+	}{
+		{map[string]string{}, Default},
+		{map[string]string{"LANG": "en_US.UTF-8"}, English},
+		{map[string]string{"LANG": "ru_RU.UTF-8", "LC_ALL": "C.UTF-8"}, Russian},
+		{map[string]string{"LANG": "ru_RU.UTF-8", "LC_MESSAGES": "en_GB"}, English},
+		{map[string]string{"LANG": "en_US", "STEAMPROPS_LANG": "ru"}, Russian},
+		{map[string]string{"LANG": "de_DE.UTF-8"}, English},
+		{map[string]string{"LC_ALL": "POSIX"}, Default},
+	}
+	for _, tt := range tests {
+		got := fromLookup(func(name string) string { return tt.env[name] })
+		if got != tt.want {
+			t.Errorf("fromLookup(%v) = %s, want %s", tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestFromAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		def    Locale
+		want   Locale
+	}{
+		{"", Russian, Russian},
+		{"", English, English},
+		{"en-US,en;q=0.9,ru;q=0.8", Russian, English},
+		{"de-DE, ru;q=0.5, en;q=0.7", Russian, English},
+		{"de-DE, ru;q=0.9, en;q=0.7", English, Russian},
+		{"ru;q=0, en", Russian, English},
+		{"de, fr", Russian, English},
+		{"*", Russian, Russian},
+	}
+	for _, tt := range tests {
+		if got := FromAcceptLanguage(tt.header, tt.def); got != tt.want {
+			t.Errorf("FromAcceptLanguage(%q, %s) = %s, want %s", tt.header, tt.def, got, tt.want)
+		}
+	}
+}
+
+func TestError(t *testing.T) {
+	limit := propserr.Range(propserr.OutOfRange, 0, "T", 2100, "°C", propserr.Max, 2000)
+	limit.Detail = "температура превышает максимальную для IF-97"
+	tests := []struct {
+		err     error
+		english string
+	}{
+		{limit, "temperature exceeds the IF-97 maximum (T = 2100 °C > 2000)"},
+		{Errorf("ошибка расчета по T,P: %w", limit), "calculation error for T,P: temperature exceeds the IF-97 maximum (T = 2100 °C > 2000)"},
+		{&propserr.Error{Code: propserr.NoSolution, Detail: "ошибка расчета по h,s (предполагаемый регион: %d)", Args: []interface{}{3}, Err: propserr.NoConvergence},
+			"calculation error for h,s (presumed region: 3): no convergence"},
+		{propserr.Newf(propserr.NoSolution, "не удалось построить изотерму t = %.2f°C", 100.0), "failed to build the isotherm t = 100.00°C"},
+		{&propserr.Error{Code: propserr.NonPhysical, Region: 3}, "Region 3: non-physical result"},
+		{propserr.NoConvergence, "no convergence"},
+	}
+	for _, tt := range tests {
+		if got, want := Russian.Error(tt.err), tt.err.Error(); got != want {
+			t.Errorf("Russian.Error() = %q, want %q", got, want)
+		}
+		if got := English.Error(tt.err); got != tt.english {
+			t.Errorf("English.Error() = %q, want %q", got, tt.english)
+		}
+	}
+	if got := English.Error(nil); got != "" {
+		t.Errorf("English.Error(nil) = %q", got)
+	}
+}
+
+func TestMsg(t *testing.T) {
+	m := M("неоднозначное состояние по %s: %d решения (%s)", "T,h", 2,
+		Join("; ", []Msg{M("p=%.6g Па, x=%.4g", 1e5, 0.5), M("p=%.6g Па, Region %d", 2e6, 1)}))
+	if got, want := m.String(), "неоднозначное состояние по T,h: 2 решения (p=100000 Па, x=0.5; p=2e+06 Па, Region 1)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := English.T("%v", m), "ambiguous state for T,h: 2 solutions (p=100000 Pa, x=0.5; p=2e+06 Pa, Region 1)"; got != want {
+		t.Errorf("English = %q, want %q", got, want)
+	}
+}
+
+// verbs возвращает глаголы формата fmt; %w считается %v
+var verbPattern = regexp.MustCompile(`%[-+# 0]*[0-9*]*(\.[0-9*]*)?[a-zA-Z%]`)
+
+func verbs(format string) []string {
+	var out []string
+	for _, v := range verbPattern.FindAllString(format, -1) {
+		out = append(out, strings.Replace(v, "%w", "%v", 1))
+	}
+	return out
+}
+
+var cyrillic = regexp.MustCompile(`[\p{Cyrillic}]`)
+
+// messageIDs собирает сообщения каталога из исходного кода модуля, шаблона
+// и скриптов веб-интерфейса
+func messageIDs(t *testing.T) map[string]string {
+	t.Helper()
+	ids := map[string]string{}
+	add := func(s, where string) {
+		if cyrillic.MatchString(s) {
+			ids[s] = where
+		}
+	}
+	literal := func(e ast.Expr) (string, bool) {
+		lit, ok := e.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return "", false
+		}
+		s, err := strconv.Unquote(lit.Value)
+		return s, err == nil
+	}
+	// Имя функции или метода и номер аргумента с сообщением
+	messageArgs := map[string]int{
+		"Errorf": 0, "T": 0, "N": 0, "M": 0, "Newf": 1, "Wrap": 1,
+		"limitError": 1, "invalidValue": 0, "New": 0,
+	}
+
+	fset := token.NewFileSet()
+	root := ".."
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && (d.Name() == "testdata" || strings.HasPrefix(d.Name(), ".")) && path != root {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				var name string
+				switch fn := n.Fun.(type) {
+				case *ast.Ident:
+					name = fn.Name
+				case *ast.SelectorExpr:
+					name = fn.Sel.Name
+					if x, ok := fn.X.(*ast.Ident); ok && x.Name == "fmt" {
+						return true
+					}
+				}
+				if i, ok := messageArgs[name]; ok && i < len(n.Args) {
+					if s, ok := literal(n.Args[i]); ok {
+						add(s, fset.Position(n.Pos()).String())
+					}
+				}
+			case *ast.KeyValueExpr:
+				if k, ok := n.Key.(*ast.Ident); ok && (k.Name == "Detail" || k.Name == "Title" || k.Name == "Label") {
+					if s, ok := literal(n.Value); ok {
+						add(s, fset.Position(n.Pos()).String())
+					}
+				}
+			case *ast.AssignStmt:
+				if sel, ok := n.Lhs[0].(*ast.SelectorExpr); ok && sel.Sel.Name == "Detail" && len(n.Rhs) == 1 {
+					if s, ok := literal(n.Rhs[0]); ok {
+						add(s, fset.Position(n.Pos()).String())
+					}
+				}
+			case *ast.FuncDecl:
+				if n.Name.Name != "Message" || n.Body == nil {
+					return true
+				}
+				ast.Inspect(n.Body, func(m ast.Node) bool {
+					if r, ok := m.(*ast.ReturnStmt); ok && len(r.Results) > 0 {
+						if s, ok := literal(r.Results[0]); ok {
+							add(s, fset.Position(r.Pos()).String())
+						}
+					}
+					return true
+				})
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, src := range []struct {
+		glob    string
+		pattern *regexp.Regexp
+	}{
+		{"../web/templates/*.html", regexp.MustCompile(`\{\{\.T "([^"]*)"\}\}`)},
+		{"../web/static/js/*.js", regexp.MustCompile(`\bt\('([^']*)'\)`)},
+	} {
+		files, _ := filepath.Glob(src.glob)
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, m := range src.pattern.FindAllStringSubmatch(string(data), -1) {
+				add(m[1], file)
+			}
+		}
+	}
+
+	for _, c := range propserr.Codes {
+		add(c.Error(), "propserr")
+	}
+	return ids
+}
+
+// TestCatalogue проверяет, что у каждого сообщения есть английский перевод с
+// теми же глаголами fmt и что в каталоге нет неиспользуемых сообщений
+func TestCatalogue(t *testing.T) {
+	ids := messageIDs(t)
+	var missing []string
+	for id, where := range ids {
+		en, ok := english[id]
+		if !ok {
+			missing = append(missing, where+": "+strconv.Quote(id))
+			continue
+		}
+		if cyrillic.MatchString(en) {
+			t.Errorf("перевод %q содержит кириллицу: %q", id, en)
+		}
+		if got, want := verbs(en), verbs(id); strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("глаголы перевода %q: %v, want %v", en, got, want)
+		}
+	}
+	sort.Strings(missing)
+	for _, m := range missing {
+		t.Errorf("нет перевода: %s", m)
+	}
+	for id := range english {
+		if _, ok := ids[id]; !ok {
+			t.Errorf("сообщение каталога не используется: %q", id)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package if97
+
+import (
+	"sync"
+
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/internal/steamprops"
+)
+
+// Batch — входные данные пакетного расчета по столбцам. Mode — имя функции
+// пакета для пары параметров: "TP", "PH", "PS", "HS", "PX", "TX", "VU",
+// "RhoT", "TH" или "TS". Строка i состоит из i-х элементов столбцов этой
+// пары; остальные столбцы не читаются и могут быть пустыми.
+type Batch struct {
+	Mode           string
+	Temperature    []float64 // °C
+	Pressure       []float64 // Па
+	Enthalpy       []float64 // кДж/кг
+	Entropy        []float64 // кДж/(кг·К)
+	SpecificVolume []float64 // м³/кг
+	InternalEnergy []float64 // кДж/кг
+	Density        []float64 // кг/м³
+	Quality        []float64 // паросодержание 0..1
+}
+
+// BatchResult — результаты пакетного расчета по столбцам. Для строки с
+// ошибкой Errors[i] != nil, Region[i] == 0, а числовые столбцы содержат NaN.
+// Ошибки строк — те же типы, что возвращает функция Mode для одной точки.
+type BatchResult struct {
+	Region                        []Region
+	Phase                         []Phase   // в строке с ошибкой не определена
+	Temperature                   []float64 // °C
+	Pressure                      []float64 // Па
+	Quality                       []float64 // 0..1 в Region 4, -1 для однофазных состояний
+	SpecificVolume                []float64 // м³/кг
+	Density                       []float64 // кг/м³
+	SpecificInternalEnergy        []float64 // кДж/кг
+	SpecificEntropy               []float64 // кДж/(кг·К)
+	SpecificEnthalpy              []float64 // кДж/кг
+	SpecificIsochoricHeatCapacity []float64 // кДж/(кг·К)
+	SpecificIsobaricHeatCapacity  []float64 // кДж/(кг·К)
+	SpeedOfSound                  []float64 // м/с
+	// Транспортные свойства заполняются с опцией WithTransport и равны NaN
+	// во влажном паре и вне области применимости корреляций
+	DynamicViscosity    []float64 // Па·с
+	ThermalConductivity []float64 // Вт/(м·К)
+	Errors              []error
+}
+
+// Len возвращает число строк
+func (r *BatchResult) Len() int {
+	return len(r.Errors)
+}
+
+// Failed возвращает число строк с ошибкой
+func (r *BatchResult) Failed() int {
+	n := 0
+	for _, err := range r.Errors {
+		if err != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Option задает параметр пакетного расчета CalculateBatch
+type Option func(*batchOptions)
+
+type batchOptions struct {
+	steamprops.BatchOptions
+	sbtl bool
+}
+
+// Workers распределяет строки между n горутинами; при n ≤ 1 расчет идет в
+// вызывающей горутине
+func Workers(n int) Option {
+	return func(o *batchOptions) { o.Workers = n }
+}
+
+// WithTransport включает расчет вязкости и теплопроводности
+func WithTransport() Option {
+	return func(o *batchOptions) { o.Transport = true }
+}
+
+// UseSBTL рассчитывает строки PH и VU по сплайновым таблицам SBTL вместо
+// итерационного обращения уравнений IF-97: на порядок быстрее, отклонение от
+// IF-97 в пределах допусков таблиц. Таблицы строятся один раз на процесс при
+// первом расчете с этой опцией (несколько секунд); остальные режимы опция не
+// меняет.
+func UseSBTL() Option {
+	return func(o *batchOptions) { o.sbtl = true }
+}
+
+// sbtlCalc считает пакеты с опцией UseSBTL; создается при первом вызове
+var (
+	sbtlOnce sync.Once
+	sbtlCalc *steamprops.Calculator
+	sbtlErr  error
+)
+
+func sbtlCalculator() (*steamprops.Calculator, error) {
+	sbtlOnce.Do(func() {
+		c := steamprops.NewCalculator()
+		if sbtlErr = c.SetEvaluator(steamprops.EvaluatorSBTL); sbtlErr == nil {
+			sbtlCalc = c
+		}
+	})
+	return sbtlCalc, sbtlErr
+}
+
+// batchInputs — пара параметров режима для ошибок строк
+var batchInputs = map[string]string{
+	"TP": "T,p", "PH": "p,h", "PS": "p,s", "HS": "h,s", "PX": "p,x",
+	"TX": "T,x", "VU": "v,u", "RhoT": "ρ,T", "TH": "T,h", "TS": "T,s",
+}
+
+// CalculateBatch рассчитывает все строки b. Ошибка строки не прерывает пакет
+// и возвращается в BatchResult.Errors; CalculateBatch возвращает ошибку с
+// кодом propserr.InvalidInput только для неизвестного режима или столбцов
+// разной длины. Без UseSBTL результаты строк совпадают с функциями TP, PH
+// и другими.
+func CalculateBatch(b *Batch, opts ...Option) (*BatchResult, error) {
+	var o batchOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	c := calc
+	if o.sbtl {
+		var err error
+		if c, err = sbtlCalculator(); err != nil {
+			return nil, i18n.Errorf("ошибка построения таблиц SBTL: %w", err)
+		}
+	}
+
+	in := &steamprops.Batch{
+		Mode:           b.Mode,
+		Temperature:    b.Temperature,
+		Pressure:       b.Pressure,
+		Enthalpy:       b.Enthalpy,
+		Entropy:        b.Entropy,
+		SpecificVolume: b.SpecificVolume,
+		InternalEnergy: b.InternalEnergy,
+		Density:        b.Density,
+		Quality:        b.Quality,
+	}
+	r, err := c.CalculateBatch(in, o.BatchOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &BatchResult{
+		Region:                        make([]Region, r.Len()),
+		Phase:                         make([]Phase, r.Len()),
+		Temperature:                   r.Temperature,
+		Pressure:                      r.Pressure,
+		Quality:                       r.Quality,
+		SpecificVolume:                r.SpecificVolume,
+		Density:                       r.Density,
+		SpecificInternalEnergy:        r.SpecificInternalEnergy,
+		SpecificEntropy:               r.SpecificEntropy,
+		SpecificEnthalpy:              r.SpecificEnthalpy,
+		SpecificIsochoricHeatCapacity: r.SpecificIsochoricHeatCapacity,
+		SpecificIsobaricHeatCapacity:  r.SpecificIsobaricHeatCapacity,
+		SpeedOfSound:                  r.SpeedOfSound,
+		DynamicViscosity:              r.DynamicViscosity,
+		ThermalConductivity:           r.ThermalConductivity,
+		Errors:                        r.Errors,
+	}
+	inputs := batchInputs[b.Mode]
+	for i, region := range r.Region {
+		res.Region[i] = Region(region)
+		res.Phase[i] = Phase(steamprops.PhaseOf(region, r.Temperature[i], r.Pressure[i], r.Density[i]))
+		if err := r.Errors[i]; err != nil {
+			// Строка не прошла проверку, если ее отвергает Validate
+			row := b.row(i)
+			if row.Validate() != nil {
+				res.Errors[i] = &InputError{Inputs: inputs, Err: err}
+			} else {
+				res.Errors[i] = calculationError(inputs, err)
+			}
+		}
+	}
+	return res, nil
+}
+
+// row собирает входные данные строки i
+func (b *Batch) row(i int) steamprops.InputData {
+	at := func(col []float64) float64 {
+		if i < len(col) {
+			return col[i]
+		}
+		return 0
+	}
+	return steamprops.InputData{
+		Mode:           b.Mode,
+		Temperature:    at(b.Temperature),
+		Pressure:       at(b.Pressure),
+		Enthalpy:       at(b.Enthalpy),
+		Entropy:        at(b.Entropy),
+		SpecificVolume: at(b.SpecificVolume),
+		InternalEnergy: at(b.InternalEnergy),
+		Density:        at(b.Density),
+		Quality:        at(b.Quality),
+	}
+}
@@ -0,0 +1,80 @@
+package if97_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/somepgs/steamprops/if97"
+	"github.com/somepgs/steamprops/propserr"
+)
+
+func TestCalculateBatch(t *testing.T) {
+	b := &if97.Batch{
+		Mode:        "TP",
+		Temperature: []float64{20, 300, 400, -10, 1500},
+		Pressure:    []float64{1e5, 1e6, 30e6, 1e5, 80e6},
+	}
+	res, err := if97.CalculateBatch(b, if97.Workers(2), if97.WithTransport())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Len() != 5 || res.Failed() != 2 {
+		t.Fatalf("Len() = %d, Failed() = %d, want 5 and 2", res.Len(), res.Failed())
+	}
+	for i := 0; i < 3; i++ {
+		want, err := if97.TP(b.Temperature[i], b.Pressure[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Errors[i] != nil || res.Region[i] != want.Region || res.SpecificEnthalpy[i] != want.Properties.SpecificEnthalpy {
+			t.Errorf("row %d: %s h=%v (%v), want %s h=%v", i, res.Region[i], res.SpecificEnthalpy[i], res.Errors[i],
+				want.Region, want.Properties.SpecificEnthalpy)
+		}
+		if res.DynamicViscosity[i] != want.Transport.DynamicViscosity {
+			t.Errorf("row %d: viscosity %v, want %v", i, res.DynamicViscosity[i], want.Transport.DynamicViscosity)
+		}
+	}
+
+	// Ошибки строк имеют те же типы, что у функции TP
+	var inputErr *if97.InputError
+	if !errors.As(res.Errors[3], &inputErr) || inputErr.Inputs != "T,p" || !errors.Is(res.Errors[3], propserr.OutOfRange) {
+		t.Errorf("row 3: %v, want *InputError with OutOfRange", res.Errors[3])
+	}
+	var calcErr *if97.CalculationError
+	if !errors.As(res.Errors[4], &calcErr) || res.Region[4] != 0 || !math.IsNaN(res.Density[4]) {
+		t.Errorf("row 4: %v, region %d, density %v, want *CalculationError", res.Errors[4], res.Region[4], res.Density[4])
+	}
+
+	if _, err := if97.CalculateBatch(&if97.Batch{Mode: "XY"}); !errors.Is(err, propserr.InvalidInput) {
+		t.Errorf("unknown mode: %v, want InvalidInput", err)
+	}
+	if _, err := if97.CalculateBatch(&if97.Batch{Mode: "PH", Pressure: []float64{1e6}}); !errors.Is(err, propserr.InvalidInput) {
+		t.Errorf("columns of different length: %v, want InvalidInput", err)
+	}
+}
+
+func TestCalculateBatchSBTL(t *testing.T) {
+	b := &if97.Batch{
+		Mode:     "PH",
+		Pressure: []float64{1e6, 1e6, 5e6, 25e6},
+		Enthalpy: []float64{500, 2000, 3200, 2000},
+	}
+	res, err := if97.CalculateBatch(b, if97.UseSBTL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range b.Pressure {
+		want, err := if97.PH(b.Pressure[i], b.Enthalpy[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Errors[i] != nil || res.Region[i] != want.Region {
+			t.Fatalf("row %d: %s (%v), want %s", i, res.Region[i], res.Errors[i], want.Region)
+		}
+		// Таблицы отклоняются от IF-97 не более чем на 5 мК
+		if math.Abs(res.Temperature[i]-want.Temperature) > 5e-3 {
+			t.Errorf("row %d: T = %.6f°C, want %.6f°C", i, res.Temperature[i], want.Temperature)
+		}
+	}
+}
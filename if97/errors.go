@@ -0,0 +1,160 @@
+package if97
+
+import (
+	"errors"
+
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/propserr"
+)
+
+// InputError — входные данные вне области применимости IF-97: температура
+// или давление вне диапазона, NaN или бесконечность, паросодержание вне 0..1.
+// Причина Err — обычно *propserr.Error с нарушенным пределом.
+type InputError struct {
+	Inputs string // пара параметров, например "T,p"
+	Err    error  // причина
+}
+
+func (e *InputError) Error() string { return i18n.Russian.Error(e) }
+
+// Message возвращает формат и аргументы сообщения для перевода (i18n.Locale.Error)
+func (e *InputError) Message() (string, []interface{}) {
+	return "недопустимые входные данные (%s): %v", []interface{}{e.Inputs, e.Err}
+}
+
+// Unwrap возвращает причину ошибки
+func (e *InputError) Unwrap() error { return e.Err }
+
+// ErrorCode возвращает код причины: propserr.OutOfRange для значения вне
+// диапазона IF-97, propserr.InvalidInput для NaN и других недопустимых значений
+func (e *InputError) ErrorCode() propserr.Code { return causeCode(e.Err, propserr.InvalidInput) }
+
+// Is сообщает, что ошибка имеет код target
+func (e *InputError) Is(target error) bool { return target == e.ErrorCode() }
+
+// CalculationError — допустимые входные данные, для которых состояние не
+// найдено: точка вне областей уравнений или итерации не сошлись.
+type CalculationError struct {
+	Inputs string // пара параметров, например "h,s"
+	Output string // выходной параметр PropsSI, например "C"; пусто вне PropsSI
+	Err    error  // причина
+}
+
+func (e *CalculationError) Error() string { return i18n.Russian.Error(e) }
+
+// Message возвращает формат и аргументы сообщения для перевода
+func (e *CalculationError) Message() (string, []interface{}) {
+	if e.Output != "" {
+		return "ошибка расчета %s (%s): %v", []interface{}{e.Output, e.Inputs, e.Err}
+	}
+	return "ошибка расчета (%s): %v", []interface{}{e.Inputs, e.Err}
+}
+
+// Unwrap возвращает причину ошибки
+func (e *CalculationError) Unwrap() error { return e.Err }
+
+// ErrorCode возвращает код причины, например propserr.NoConvergence или
+// propserr.RegionNotApplicable; причина без кода дает propserr.NoSolution
+func (e *CalculationError) ErrorCode() propserr.Code { return causeCode(e.Err, propserr.NoSolution) }
+
+// Is сообщает, что ошибка имеет код target
+func (e *CalculationError) Is(target error) bool { return target == e.ErrorCode() }
+
+// causeCode возвращает код первой ошибки с кодом в цепочке err или def
+func causeCode(err error, def propserr.Code) propserr.Code {
+	var c propserr.Coder
+	if errors.As(err, &c) {
+		return c.ErrorCode()
+	}
+	return def
+}
+
+// AmbiguousStateError — заданной паре (T,h) или (T,s) соответствует
+// несколько состояний. Например, энтальпии чуть выше энтальпии насыщенной
+// жидкости отвечают и влажный пар при давлении насыщения, и сжатая жидкость
+// при высоком давлении. Вызывающий код выбирает решение сам.
+type AmbiguousStateError struct {
+	Inputs    string    // "T,h" или "T,s"
+	Solutions []*Result // в порядке возрастания давления
+}
+
+func (e *AmbiguousStateError) Error() string { return i18n.Russian.Error(e) }
+
+// Message возвращает формат и аргументы сообщения для перевода
+func (e *AmbiguousStateError) Message() (string, []interface{}) {
+	parts := make([]i18n.Msg, len(e.Solutions))
+	for i, r := range e.Solutions {
+		if r.Region == Region4 {
+			parts[i] = i18n.M("p=%.6g Па, x=%.4g", r.Pressure, r.Quality)
+		} else {
+			parts[i] = i18n.M("p=%.6g Па, %s", r.Pressure, r.Region)
+		}
+	}
+	return "неоднозначное состояние по %s: %d решения (%s)", []interface{}{e.Inputs, len(e.Solutions), i18n.Join("; ", parts)}
+}
+
+// ErrorCode возвращает propserr.Ambiguous
+func (e *AmbiguousStateError) ErrorCode() propserr.Code { return propserr.Ambiguous }
+
+// Is сообщает, что target — propserr.Ambiguous
+func (e *AmbiguousStateError) Is(target error) bool { return target == propserr.Ambiguous }
+
+// UnknownParameterError — имя параметра PropsSI, которого нет среди имен
+// CoolProp, поддерживаемых пакетом
+type UnknownParameterError struct {
+	Name  string // имя параметра как задано
+	Input bool   // входной параметр, иначе выходной
+}
+
+func (e *UnknownParameterError) Error() string { return i18n.Russian.Error(e) }
+
+// Message возвращает формат и аргументы сообщения для перевода
+func (e *UnknownParameterError) Message() (string, []interface{}) {
+	if e.Input {
+		return "неизвестный входной параметр %q", []interface{}{e.Name}
+	}
+	return "неизвестный выходной параметр %q", []interface{}{e.Name}
+}
+
+// ErrorCode возвращает propserr.UnknownParameter
+func (e *UnknownParameterError) ErrorCode() propserr.Code { return propserr.UnknownParameter }
+
+// Is сообщает, что target — propserr.UnknownParameter
+func (e *UnknownParameterError) Is(target error) bool { return target == propserr.UnknownParameter }
+
+// UnsupportedInputsError — пара входных параметров PropsSI, по которой
+// состояние не рассчитывается, например P и U
+type UnsupportedInputsError struct {
+	Name1, Name2 string
+}
+
+func (e *UnsupportedInputsError) Error() string { return i18n.Russian.Error(e) }
+
+// Message возвращает формат и аргументы сообщения для перевода
+func (e *UnsupportedInputsError) Message() (string, []interface{}) {
+	return "неподдерживаемая пара входных параметров (%s,%s): ожидается T–P, P–H, P–S, H–S, P–Q, T–Q, D–U, D–T, T–H или T–S", []interface{}{e.Name1, e.Name2}
+}
+
+// ErrorCode возвращает propserr.UnsupportedInputs
+func (e *UnsupportedInputsError) ErrorCode() propserr.Code { return propserr.UnsupportedInputs }
+
+// Is сообщает, что target — propserr.UnsupportedInputs
+func (e *UnsupportedInputsError) Is(target error) bool { return target == propserr.UnsupportedInputs }
+
+// UnsupportedFluidError — вещество, отличное от воды
+type UnsupportedFluidError struct {
+	Fluid string
+}
+
+func (e *UnsupportedFluidError) Error() string { return i18n.Russian.Error(e) }
+
+// Message возвращает формат и аргументы сообщения для перевода
+func (e *UnsupportedFluidError) Message() (string, []interface{}) {
+	return "вещество %q не поддерживается: IF-97 описывает только воду (Water)", []interface{}{e.Fluid}
+}
+
+// ErrorCode возвращает propserr.UnsupportedFluid
+func (e *UnsupportedFluidError) ErrorCode() propserr.Code { return propserr.UnsupportedFluid }
+
+// Is сообщает, что target — propserr.UnsupportedFluid
+func (e *UnsupportedFluidError) Is(target error) bool { return target == propserr.UnsupportedFluid }
@@ -0,0 +1,119 @@
+package if97_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/somepgs/steamprops/if97"
+	"github.com/somepgs/steamprops/propserr"
+	"github.com/somepgs/steamprops/units"
+)
+
+func ExampleTP() {
+	r, err := if97.TP(26.85, 3e6)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(r.Region)
+	fmt.Printf("h = %.6f кДж/кг\n", r.Properties.SpecificEnthalpy)
+	fmt.Printf("w = %.4f м/с\n", r.Properties.SpeedOfSound)
+	// Output:
+	// Region 1
+	// h = 115.331273 кДж/кг
+	// w = 1507.7392 м/с
+}
+
+func ExampleAmbiguousStateError() {
+	_, err := if97.TH(100, 430)
+	var ambiguous *if97.AmbiguousStateError
+	if errors.As(err, &ambiguous) {
+		for _, s := range ambiguous.Solutions {
+			fmt.Printf("%s, p = %.4g МПа\n", s.Region, s.Pressure/1e6)
+		}
+	}
+}
+
+func ExampleFromTP() {
+	st, err := if97.FromTP(400, 30e6)
+	if err != nil {
+		panic(err)
+	}
+	// Ряды уравнения Region 3 просуммированы один раз в FromTP
+	fmt.Println(st.Region(), st.Subregion(), st.Phase())
+	fmt.Printf("h = %.2f кДж/кг, cp = %.3f кДж/(кг·К)\n", st.SpecificEnthalpy(), st.SpecificIsobaricHeatCapacity())
+	// Output:
+	// Region 3 3b Сверхкритический флюид
+	// h = 2152.37 кДж/кг, cp = 25.797 кДж/(кг·К)
+}
+
+func ExampleResult_Quantities() {
+	t, _ := units.ParseTemperature("572 °F", units.Celsius)
+	p, _ := units.ParsePressure("145 psi", units.Pascal)
+	r, err := if97.TP(t.Celsius(), p.Pascals())
+	if err != nil {
+		panic(err)
+	}
+	q := r.Quantities()
+	fmt.Printf("h = %.2f %s\n", q.SpecificEnthalpy.In(units.BTUPerLb), units.BTUPerLb)
+	fmt.Printf("s = %.4f %s\n", q.SpecificEntropy.In(units.BTUPerLbF), units.BTUPerLbF)
+	// Output:
+	// h = 1312.00 BTU/lb
+	// s = 1.7017 BTU/(lb·°F)
+}
+
+func ExamplePropsSI() {
+	// Как CoolProp.PropsSI("H", "T", 573.15, "P", 1e6, "Water"): все в СИ
+	h, err := if97.PropsSI("H", "T", 573.15, "P", 1e6, "Water")
+	if err != nil {
+		panic(err)
+	}
+	tsat, _ := if97.PropsSI("T", "P", 1e6, "Q", 1, "Water")
+	fmt.Printf("h = %.1f Дж/кг\n", h)
+	fmt.Printf("Ts = %.3f К\n", tsat)
+
+	_, err = if97.PropsSI("H", "P", 1e6, "U", 2e6, "Water")
+	fmt.Println(err)
+	// Output:
+	// h = 3051703.2 Дж/кг
+	// Ts = 453.036 К
+	// неподдерживаемая пара входных параметров (P,U): ожидается T–P, P–H, P–S, H–S, P–Q, T–Q, D–U, D–T, T–H или T–S
+}
+
+func ExampleCalculateBatch() {
+	b := &if97.Batch{
+		Mode:     "PH",
+		Pressure: []float64{1e6, 1e6, -1},
+		Enthalpy: []float64{500, 2800, 2800},
+	}
+	res, err := if97.CalculateBatch(b, if97.Workers(2))
+	if err != nil {
+		panic(err) // неизвестный режим или столбцы разной длины
+	}
+	for i := 0; i < res.Len(); i++ {
+		if res.Errors[i] != nil {
+			fmt.Println("ошибка:", errors.Is(res.Errors[i], propserr.InvalidInput))
+			continue
+		}
+		fmt.Printf("%s, T = %.2f°C\n", res.Region[i], res.Temperature[i])
+	}
+	// Output:
+	// Region 1, T = 118.98°C
+	// Region 2, T = 188.61°C
+	// ошибка: true
+}
+
+func ExampleUseSBTL() {
+	// Таблицы SBTL строятся при первом вызове, далее строки PH и VU
+	// рассчитываются без итераций
+	res, err := if97.CalculateBatch(&if97.Batch{
+		Mode:     "PH",
+		Pressure: []float64{1e6},
+		Enthalpy: []float64{3000},
+	}, if97.UseSBTL())
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("%s, T = %.2f°C\n", res.Region[0], res.Temperature[0])
+	// Output:
+	// Region 2, T = 275.97°C
+}
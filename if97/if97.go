@@ -0,0 +1,289 @@
+// Package if97 — публичный API библиотеки steamprops: термодинамические и
+// транспортные свойства воды и водяного пара по промышленной формуляции
+// IAPWS-IF97.
+//
+// Состояние задается парой параметров, для каждой пары есть своя функция:
+// TP, PH, PS, HS, PX, TX, VU, RhoT, TH и TS. Линия насыщения рассчитывается
+// функциями SaturationT и SaturationP.
+//
+// Единицы измерения во всем пакете: температура — °C, давление — Па,
+// удельные энтальпия и внутренняя энергия — кДж/кг, энтропия и теплоемкости —
+// кДж/(кг·К), удельный объем — м³/кг, плотность — кг/м³, скорость звука — м/с.
+// Величины в других единицах переводятся пакетом units: аргумент
+// if97.TP(t.Celsius(), p.Pascals()) для t и p любых единиц, а
+// Result.Quantities возвращает свойства для вывода в нужных единицах.
+//
+// Ошибки типизированы: недопустимые входные данные возвращаются как
+// *InputError, неудачный расчет — как *CalculationError, несколько решений
+// для пар (T,h) и (T,s) — как *AmbiguousStateError. Тип ошибки проверяется
+// через errors.As. Все ошибки имеют машиночитаемый код пакета propserr:
+// errors.Is(err, propserr.OutOfRange) или propserr.CodeOf(err), а нарушенный
+// предел доступен через errors.As(err, &e) с e типа *propserr.Error.
+// Сообщения ошибок и названия фаз русские; пакет i18n переводит их на
+// английский: i18n.English.Error(err), i18n.English.T(result.Phase).
+//
+// Функции возвращают *Result со всеми свойствами сразу. Тип State хранит
+// безразмерные производные основного уравнения региона и рассчитывает
+// свойства по запросу: его создают конструкторы FromTP, FromPH и другие.
+//
+// CalculateBatch рассчитывает массивы состояний по столбцам Batch с ошибкой
+// на каждую строку; опции Workers, WithTransport и UseSBTL задают число
+// горутин, расчет транспортных свойств и сплайновые таблицы SBTL.
+//
+// Функции пакета не имеют изменяемого состояния и безопасны для одновременного
+// вызова из нескольких горутин.
+package if97
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/somepgs/steamprops/internal/calc_core"
+	"github.com/somepgs/steamprops/internal/calc_core/transport"
+	"github.com/somepgs/steamprops/internal/steamprops"
+)
+
+// Version — версия публичного API пакета (семантическое версионирование):
+// несовместимые изменения API увеличивают старший номер.
+const Version = "1.0.0"
+
+// Formulation — реализованная формуляция IAPWS
+const Formulation = "IAPWS R7-97(2012)"
+
+// Region — регион IF-97
+type Region int
+
+const (
+	Region1 Region = Region(calc_core.Region1) // сжатая жидкость
+	Region2 Region = Region(calc_core.Region2) // перегретый пар
+	Region3 Region = Region(calc_core.Region3) // околокритическая область
+	Region4 Region = Region(calc_core.Region4) // двухфазная область (влажный пар)
+	Region5 Region = Region(calc_core.Region5) // высокотемпературный пар
+)
+
+// String возвращает название региона, например "Region 1"
+func (r Region) String() string {
+	return fmt.Sprintf("Region %d", int(r))
+}
+
+// Properties — термодинамические свойства состояния. Во влажном паре
+// (Region 4) теплоемкости и скорость звука не определены и равны нулю.
+type Properties struct {
+	SpecificVolume                float64 // м³/кг
+	Density                       float64 // кг/м³
+	SpecificInternalEnergy        float64 // кДж/кг
+	SpecificEntropy               float64 // кДж/(кг·К)
+	SpecificEnthalpy              float64 // кДж/кг
+	SpecificIsochoricHeatCapacity float64 // кДж/(кг·К)
+	SpecificIsobaricHeatCapacity  float64 // кДж/(кг·К)
+	SpeedOfSound                  float64 // м/с
+}
+
+// Transport — транспортные свойства по рекомендациям IAPWS R12-08 и R15-11
+type Transport struct {
+	DynamicViscosity    float64 // Па·с
+	KinematicViscosity  float64 // м²/с
+	ThermalConductivity float64 // Вт/(м·К)
+}
+
+// Result — рассчитанное состояние воды или пара
+type Result struct {
+	Region      Region
+	Phase       string  // название фазы на русском языке; перевод — i18n.Locale.T(Phase)
+	Temperature float64 // °C
+	Pressure    float64 // Па
+	Quality     float64 // паросодержание 0..1 в Region 4, -1 для однофазных состояний
+	Properties  Properties
+	// Transport равен nil во влажном паре и вне области применимости
+	// корреляций вязкости и теплопроводности
+	Transport *Transport
+}
+
+// Saturation — насыщенная жидкость и насыщенный пар на линии насыщения
+type Saturation struct {
+	Temperature float64 // °C
+	Pressure    float64 // Па
+	Liquid      Properties
+	Vapor       Properties
+}
+
+// calc выполняет все расчеты пакета: Calculator не меняет своего состояния
+// при расчете, поэтому один экземпляр обслуживает все горутины
+var calc = steamprops.NewCalculator()
+
+// TP рассчитывает состояние по температуре (°C) и давлению (Па). Регион
+// определяется по границам IF-97; на линии насыщения выбирается жидкость.
+func TP(temperature, pressure float64) (*Result, error) {
+	return calculate("T,p", &steamprops.InputData{Mode: "TP", Temperature: temperature, Pressure: pressure})
+}
+
+// TPRegion рассчитывает состояние по температуре (°C) и давлению (Па) по
+// уравнению заданного региона 1, 2, 3 или 5 вместо определения региона по
+// границам IF-97. Уравнения регионов сами отклоняют точки вне своих областей.
+func TPRegion(region Region, temperature, pressure float64) (*Result, error) {
+	in := &steamprops.InputData{Mode: "TP", Temperature: temperature, Pressure: pressure}
+	if err := in.Validate(); err != nil {
+		return nil, &InputError{Inputs: "T,p", Err: err}
+	}
+	r, err := calc.CalculateInRegion(calc_core.Region(region), temperature, pressure)
+	if err != nil {
+		return nil, &CalculationError{Inputs: "T,p", Err: err}
+	}
+	return newResult(r), nil
+}
+
+// PH рассчитывает состояние по давлению (Па) и энтальпии (кДж/кг)
+func PH(pressure, enthalpy float64) (*Result, error) {
+	return calculate("p,h", &steamprops.InputData{Mode: "PH", Pressure: pressure, Enthalpy: enthalpy})
+}
+
+// PS рассчитывает состояние по давлению (Па) и энтропии (кДж/(кг·К))
+func PS(pressure, entropy float64) (*Result, error) {
+	return calculate("p,s", &steamprops.InputData{Mode: "PS", Pressure: pressure, Entropy: entropy})
+}
+
+// HS рассчитывает состояние по энтальпии (кДж/кг) и энтропии (кДж/(кг·К))
+// во всех регионах IF-97
+func HS(enthalpy, entropy float64) (*Result, error) {
+	return calculate("h,s", &steamprops.InputData{Mode: "HS", Enthalpy: enthalpy, Entropy: entropy})
+}
+
+// PX рассчитывает влажный пар по давлению (Па) не выше критического и
+// паросодержанию 0..1
+func PX(pressure, quality float64) (*Result, error) {
+	return calculate("p,x", &steamprops.InputData{Mode: "PX", Pressure: pressure, Quality: quality})
+}
+
+// TX рассчитывает влажный пар по температуре (°C) ниже критической и
+// паросодержанию 0..1
+func TX(temperature, quality float64) (*Result, error) {
+	return calculate("T,x", &steamprops.InputData{Mode: "TX", Temperature: temperature, Quality: quality})
+}
+
+// VU рассчитывает состояние по удельному объему (м³/кг) и удельной внутренней
+// энергии (кДж/кг)
+func VU(specificVolume, internalEnergy float64) (*Result, error) {
+	return calculate("v,u", &steamprops.InputData{Mode: "VU", SpecificVolume: specificVolume, InternalEnergy: internalEnergy})
+}
+
+// RhoT рассчитывает состояние по плотности (кг/м³) и температуре (°C)
+func RhoT(density, temperature float64) (*Result, error) {
+	return calculate("ρ,T", &steamprops.InputData{Mode: "RhoT", Density: density, Temperature: temperature})
+}
+
+// TH рассчитывает состояние по температуре (°C) и энтальпии (кДж/кг). Если
+// заданной паре соответствует несколько состояний, возвращается
+// *AmbiguousStateError со всеми решениями.
+func TH(temperature, enthalpy float64) (*Result, error) {
+	return calculate("T,h", &steamprops.InputData{Mode: "TH", Temperature: temperature, Enthalpy: enthalpy})
+}
+
+// TS рассчитывает состояние по температуре (°C) и энтропии (кДж/(кг·К)).
+// Неоднозначность сообщается так же, как в TH.
+func TS(temperature, entropy float64) (*Result, error) {
+	return calculate("T,s", &steamprops.InputData{Mode: "TS", Temperature: temperature, Entropy: entropy})
+}
+
+// SaturationT рассчитывает линию насыщения при температуре (°C) от тройной
+// точки до критической
+func SaturationT(temperature float64) (*Saturation, error) {
+	in := &steamprops.InputData{Mode: "TX", Temperature: temperature}
+	if err := in.Validate(); err != nil {
+		return nil, &InputError{Inputs: "T", Err: err}
+	}
+	s, err := calc.SaturationAtTemperature(temperature)
+	if err != nil {
+		return nil, &CalculationError{Inputs: "T", Err: err}
+	}
+	return newSaturation(s), nil
+}
+
+// SaturationP рассчитывает линию насыщения при давлении (Па) до критического
+func SaturationP(pressure float64) (*Saturation, error) {
+	in := &steamprops.InputData{Mode: "PX", Pressure: pressure}
+	if err := in.Validate(); err != nil {
+		return nil, &InputError{Inputs: "p", Err: err}
+	}
+	s, err := calc.SaturationAtPressure(pressure)
+	if err != nil {
+		return nil, &CalculationError{Inputs: "p", Err: err}
+	}
+	return newSaturation(s), nil
+}
+
+// calculate проверяет входные данные и выполняет расчет, оборачивая ошибки
+// внутреннего калькулятора в типы пакета
+func calculate(inputs string, in *steamprops.InputData) (*Result, error) {
+	if err := in.Validate(); err != nil {
+		return nil, &InputError{Inputs: inputs, Err: err}
+	}
+	var r *steamprops.Result
+	var err error
+	if in.Mode == "HS" {
+		// Режим HS калькулятора ограничен Region 3, (h,s) во всех регионах рассчитывает CalculateHS
+		r, err = calc.CalculateHS(in.Enthalpy, in.Entropy)
+	} else {
+		r, err = calc.Calculate(in)
+	}
+	if err != nil {
+		return nil, calculationError(inputs, err)
+	}
+	return newResult(r), nil
+}
+
+// calculationError оборачивает ошибку внутреннего расчета в тип пакета
+func calculationError(inputs string, err error) error {
+	var ambiguous *steamprops.AmbiguousStateError
+	if errors.As(err, &ambiguous) {
+		e := &AmbiguousStateError{Inputs: inputs, Solutions: make([]*Result, len(ambiguous.Solutions))}
+		for i, s := range ambiguous.Solutions {
+			e.Solutions[i] = newResult(s)
+		}
+		return e
+	}
+	return &CalculationError{Inputs: inputs, Err: err}
+}
+
+// newResult переводит результат внутреннего калькулятора в публичный тип и
+// рассчитывает транспортные свойства
+func newResult(r *steamprops.Result) *Result {
+	res := &Result{
+		Region:      Region(r.Region),
+		Phase:       r.Phase,
+		Temperature: r.Temperature,
+		Pressure:    r.Pressure,
+		Quality:     r.Quality,
+		Properties:  Properties(r.Properties),
+	}
+	if r.Region != calc_core.Region4 {
+		res.Transport = transportAt(r.Temperature+273.15, r.Properties.Density)
+	}
+	return res
+}
+
+// transportAt рассчитывает транспортные свойства при T (K) и ρ (кг/м³);
+// nil, если хотя бы одна корреляция неприменима
+func transportAt(tK, rho float64) *Transport {
+	mu, err := transport.DynamicViscosity(tK, rho)
+	if err != nil {
+		return nil
+	}
+	nu, err := transport.KinematicViscosity(tK, rho)
+	if err != nil {
+		return nil
+	}
+	lambda, err := transport.ThermalConductivity(tK, rho)
+	if err != nil {
+		return nil
+	}
+	return &Transport{DynamicViscosity: mu, KinematicViscosity: nu, ThermalConductivity: lambda}
+}
+
+func newSaturation(s *steamprops.SaturationState) *Saturation {
+	return &Saturation{
+		Temperature: s.Temperature,
+		Pressure:    s.Pressure,
+		Liquid:      Properties(s.Liquid),
+		Vapor:       Properties(s.Vapor),
+	}
+}
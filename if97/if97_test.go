@@ -0,0 +1,227 @@
+package if97_test
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/somepgs/steamprops/if97"
+	"github.com/somepgs/steamprops/propserr"
+	"github.com/somepgs/steamprops/units"
+)
+
+func near(got, want, rel float64) bool {
+	return math.Abs(got-want) <= rel*math.Abs(want)
+}
+
+// Контрольные значения IF-97 (таблицы 5 и 15) и их обращения через другие пары
+func TestConstructors(t *testing.T) {
+	const (
+		t1 = 300 - 273.15 // Region 1, p = 3 МПа
+		h1 = 115.331273
+		s1 = 0.392294792
+		v1 = 0.00100215168
+		t2 = 700 - 273.15 // Region 2, p = 30 МПа
+		h2 = 2631.49474
+		s2 = 5.17540298
+	)
+	tests := []struct {
+		name   string
+		calc   func() (*if97.Result, error)
+		region if97.Region
+		h, s   float64
+	}{
+		{"TP region1", func() (*if97.Result, error) { return if97.TP(t1, 3e6) }, if97.Region1, h1, s1},
+		{"TP region2", func() (*if97.Result, error) { return if97.TP(t2, 30e6) }, if97.Region2, h2, s2},
+		{"PH", func() (*if97.Result, error) { return if97.PH(3e6, h1) }, if97.Region1, h1, s1},
+		{"PS", func() (*if97.Result, error) { return if97.PS(30e6, s2) }, if97.Region2, h2, s2},
+		{"HS", func() (*if97.Result, error) { return if97.HS(h2, s2) }, if97.Region2, h2, s2},
+		{"VU", func() (*if97.Result, error) { return if97.VU(v1, h1-3e6*v1/1000) }, if97.Region1, h1, s1},
+		{"RhoT", func() (*if97.Result, error) { return if97.RhoT(1/v1, t1) }, if97.Region1, h1, s1},
+		{"TS", func() (*if97.Result, error) { return if97.TS(t2, s2) }, if97.Region2, h2, s2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := tt.calc()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Region != tt.region {
+				t.Errorf("region = %v, want %v", r.Region, tt.region)
+			}
+			if !near(r.Properties.SpecificEnthalpy, tt.h, 1e-6) || !near(r.Properties.SpecificEntropy, tt.s, 1e-6) {
+				t.Errorf("h = %.9g, s = %.9g, want %.9g, %.9g", r.Properties.SpecificEnthalpy, r.Properties.SpecificEntropy, tt.h, tt.s)
+			}
+			if r.Quality != -1 || r.Transport == nil {
+				t.Errorf("single-phase state: quality = %g, transport = %v", r.Quality, r.Transport)
+			}
+		})
+	}
+}
+
+func TestWetSteam(t *testing.T) {
+	sat, err := if97.SaturationP(1e6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	px, err := if97.PX(1e6, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := if97.TX(sat.Temperature, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := (sat.Liquid.SpecificEnthalpy + sat.Vapor.SpecificEnthalpy) / 2
+	for _, r := range []*if97.Result{px, tx} {
+		if r.Region != if97.Region4 || r.Transport != nil {
+			t.Errorf("region = %v, transport = %v, want Region 4 without transport", r.Region, r.Transport)
+		}
+		if !near(r.Properties.SpecificEnthalpy, want, 1e-9) {
+			t.Errorf("h = %.9g, want %.9g", r.Properties.SpecificEnthalpy, want)
+		}
+	}
+	byT, err := if97.SaturationT(sat.Temperature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !near(byT.Pressure, 1e6, 1e-9) {
+		t.Errorf("psat = %.6f Pa, want 1e6", byT.Pressure)
+	}
+}
+
+func TestTPRegion(t *testing.T) {
+	r, err := if97.TPRegion(if97.Region1, 150, 10e6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auto, err := if97.TP(150, 10e6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Region != if97.Region1 || r.Properties != auto.Properties {
+		t.Errorf("TPRegion = %+v, want %+v", r, auto)
+	}
+	if _, err := if97.TPRegion(if97.Region4, 150, 1e6); err == nil {
+		t.Error("Region 4 accepted")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	var inputErr *if97.InputError
+	if _, err := if97.TP(math.NaN(), 1e6); !errors.As(err, &inputErr) || inputErr.Inputs != "T,p" {
+		t.Errorf("TP(NaN): %v, want *InputError", err)
+	}
+	if _, err := if97.PX(1e6, 1.5); !errors.As(err, &inputErr) {
+		t.Errorf("PX(x=1.5): %v, want *InputError", err)
+	}
+	if _, err := if97.SaturationT(400); !errors.As(err, &inputErr) {
+		t.Errorf("SaturationT(400): %v, want *InputError", err)
+	}
+
+	var calcErr *if97.CalculationError
+	if _, err := if97.HS(100, 9); !errors.As(err, &calcErr) || calcErr.Unwrap() == nil {
+		t.Errorf("HS(100, 9): %v, want *CalculationError", err)
+	}
+
+	var ambiguous *if97.AmbiguousStateError
+	_, err := if97.TH(100, 430)
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("TH(100, 430): %v, want *AmbiguousStateError", err)
+	}
+	if len(ambiguous.Solutions) != 2 || ambiguous.Solutions[0].Region != if97.Region4 || ambiguous.Solutions[1].Region != if97.Region1 {
+		t.Errorf("solutions: %v", ambiguous)
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	_, err := if97.TP(700, 150e6)
+	if !errors.Is(err, propserr.OutOfRange) {
+		t.Fatalf("TP(700, 150 МПа): %v, want out_of_range", err)
+	}
+	var e *propserr.Error
+	if !errors.As(err, &e) || e.Quantity != "p" || e.Bound != propserr.Max || e.Limit != 100e6 || e.Value != 150e6 {
+		t.Errorf("TP(700, 150 МПа): %+v, want p > 100e6", e)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		want propserr.Code
+	}{
+		{"TP(NaN)", func() error { _, err := if97.TP(math.NaN(), 1e6); return err }, propserr.InvalidInput},
+		{"TP(T<0)", func() error { _, err := if97.TP(-10, 1e6); return err }, propserr.OutOfRange},
+		{"PX(x=1.5)", func() error { _, err := if97.PX(1e6, 1.5); return err }, propserr.OutOfRange},
+		{"TPRegion(5, 300°C)", func() error { _, err := if97.TPRegion(if97.Region5, 300, 1e6); return err }, propserr.RegionNotApplicable},
+		{"TPRegion(4)", func() error { _, err := if97.TPRegion(if97.Region4, 300, 1e6); return err }, propserr.RegionNotSupported},
+		{"HS(100, 9)", func() error { _, err := if97.HS(100, 9); return err }, propserr.NoSolution},
+		{"TH(100, 430)", func() error { _, err := if97.TH(100, 430); return err }, propserr.Ambiguous},
+		{"PropsSI(X)", func() error { _, err := if97.PropsSI("X", "T", 300, "P", 1e5, "Water"); return err }, propserr.UnknownParameter},
+		{"PropsSI(P,U)", func() error { _, err := if97.PropsSI("H", "P", 1e5, "U", 1e6, "Water"); return err }, propserr.UnsupportedInputs},
+		{"PropsSI(Air)", func() error { _, err := if97.PropsSI("H", "T", 300, "P", 1e5, "Air"); return err }, propserr.UnsupportedFluid},
+	}
+	for _, tt := range tests {
+		err := tt.call()
+		if got := propserr.CodeOf(err); got != tt.want {
+			t.Errorf("%s: code %s (%v), want %s", tt.name, string(got), err, string(tt.want))
+		}
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: errors.Is(err, %s) = false", tt.name, string(tt.want))
+		}
+	}
+}
+
+func TestConcurrentUse(t *testing.T) {
+	want, err := if97.PH(10e6, 3000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				got, err := if97.PH(10e6, 3000)
+				if err != nil || got.Temperature != want.Temperature {
+					t.Errorf("PH = %v, %v; want T = %g", got, err, want.Temperature)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestResultIn(t *testing.T) {
+	r, err := if97.TP(300, 1e6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if si := r.In(units.SI); si.Temperature != r.Temperature || si.Pressure != r.Pressure || si.Properties != r.Properties || *si.Transport != *r.Transport {
+		t.Errorf("In(SI) = %+v, want the result unchanged", si)
+	}
+
+	us := r.In(units.USCustomary.WithGauge(0))
+	p := r.Properties
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"T, °F", us.Temperature, 572},
+		{"p, psig", us.Pressure, (1e6 - 101325) / 6894.757293168361},
+		{"h, BTU/lb", us.Properties.SpecificEnthalpy, p.SpecificEnthalpy / 2.326},
+		{"s, BTU/(lb·°F)", us.Properties.SpecificEntropy, p.SpecificEntropy / 4.1868},
+		{"v, ft³/lb", us.Properties.SpecificVolume, p.SpecificVolume * 16.018463373960138},
+		{"w, ft/s", us.Properties.SpeedOfSound, p.SpeedOfSound / 0.3048},
+		{"μ, lb/(ft·h)", us.Transport.DynamicViscosity, r.Transport.DynamicViscosity / 4.1337887321376497e-4},
+		{"ν, ft²/h", us.Transport.KinematicViscosity, r.Transport.KinematicViscosity / 2.58064e-5},
+		{"λ, BTU/(h·ft·°F)", us.Transport.ThermalConductivity, r.Transport.ThermalConductivity / 1.7307346663713914},
+	}
+	for _, c := range checks {
+		if !near(c.got, c.want, 1e-12) {
+			t.Errorf("%s = %.12g, want %.12g", c.name, c.got, c.want)
+		}
+	}
+}
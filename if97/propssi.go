@@ -0,0 +1,401 @@
+package if97
+
+import (
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/somepgs/steamprops/propserr"
+)
+
+// propsParam — параметр PropsSI
+type propsParam int
+
+const (
+	propsT propsParam = iota
+	propsP
+	propsH
+	propsS
+	propsU
+	propsD
+	propsQ
+	propsCp
+	propsCv
+	propsA
+	propsV
+	propsL
+	propsPrandtl
+	propsTcrit
+	propsPcrit
+	propsRhocrit
+	propsTtriple
+	propsPtriple
+	propsM
+)
+
+// propsParams — имена параметров CoolProp и их синонимы. Как и в CoolProp,
+// однобуквенные имена чувствительны к регистру.
+var propsParams = map[string]propsParam{
+	"T": propsT,
+	"P": propsP,
+	"H": propsH, "Hmass": propsH,
+	"S": propsS, "Smass": propsS,
+	"U": propsU, "Umass": propsU,
+	"D": propsD, "Dmass": propsD,
+	"Q": propsQ,
+	"C": propsCp, "Cpmass": propsCp,
+	"O": propsCv, "Cvmass": propsCv,
+	"A": propsA, "speed_of_sound": propsA,
+	"V": propsV, "viscosity": propsV,
+	"L": propsL, "conductivity": propsL,
+	"Prandtl": propsPrandtl,
+	"Tcrit":   propsTcrit,
+	"pcrit":   propsPcrit, "P_critical": propsPcrit,
+	"rhocrit": propsRhocrit, "rhomass_critical": propsRhocrit,
+	"Ttriple": propsTtriple, "T_triple": propsTtriple,
+	"ptriple": propsPtriple, "p_triple": propsPtriple,
+	"M": propsM, "molar_mass": propsM,
+}
+
+// propsConstants — постоянные воды, не зависящие от состояния (СИ)
+var propsConstants = map[propsParam]float64{
+	propsTcrit:   647.096,
+	propsPcrit:   22.064e6,
+	propsRhocrit: 322,
+	propsTtriple: 273.16,
+	propsPtriple: 611.657,
+	propsM:       0.018015268,
+}
+
+// PropsSI рассчитывает свойство output воды по двум параметрам состояния
+// в единицах СИ, как функция PropsSI библиотеки CoolProp:
+//
+//	h, err := if97.PropsSI("H", "T", 573.15, "P", 1e6, "Water") // Дж/кг
+//
+// Имена параметров — имена CoolProp: T (К), P (Па), H, U (Дж/кг), S, C, O
+// (Дж/(кг·К)), D (кг/м³), Q (0..1, -1 вне влажного пара), A (м/с), V (Па·с),
+// L (Вт/(м·К)), Prandtl, а также синонимы Hmass, Smass, Cpmass и другие.
+// Входные пары: T–P, P–H, P–S, H–S, P–Q, T–Q, D–U, D–T, T–H и T–S в любом
+// порядке. Постоянные Tcrit, pcrit, rhocrit, Ttriple, ptriple и M не
+// зависят от входных параметров. Вещество — "Water", "H2O" или "IF97::Water".
+//
+// Неизвестное имя параметра возвращается как *UnknownParameterError,
+// неподдерживаемая пара входных параметров — как *UnsupportedInputsError,
+// другое вещество — как *UnsupportedFluidError; ошибки расчета — те же, что
+// у функций TP, PH и других.
+func PropsSI(output, name1 string, value1 float64, name2 string, value2 float64, fluid string) (float64, error) {
+	if !isWater(fluid) {
+		return 0, &UnsupportedFluidError{Fluid: fluid}
+	}
+	out, ok := propsParams[output]
+	if !ok {
+		return 0, &UnknownParameterError{Name: output}
+	}
+	if v, ok := propsConstants[out]; ok {
+		return v, nil
+	}
+	in1, ok := propsParams[name1]
+	if !ok {
+		return 0, &UnknownParameterError{Name: name1, Input: true}
+	}
+	in2, ok := propsParams[name2]
+	if !ok {
+		return 0, &UnknownParameterError{Name: name2, Input: true}
+	}
+
+	st, err := propsState(in1, value1, in2, value2)
+	if err != nil {
+		if errors.Is(err, errUnsupportedInputs) {
+			return 0, &UnsupportedInputsError{Name1: name1, Name2: name2}
+		}
+		return 0, err
+	}
+	return propsValue(st, out, name1+","+name2, output)
+}
+
+// Props1SI возвращает постоянную воды (Tcrit, pcrit, rhocrit, Ttriple,
+// ptriple, M) в единицах СИ, как одноименная функция CoolProp
+func Props1SI(fluid, output string) (float64, error) {
+	if !isWater(fluid) {
+		return 0, &UnsupportedFluidError{Fluid: fluid}
+	}
+	if v, ok := propsConstants[propsParams[output]]; ok {
+		return v, nil
+	}
+	return 0, &UnknownParameterError{Name: output}
+}
+
+func isWater(fluid string) bool {
+	switch strings.ToLower(strings.TrimPrefix(fluid, "IF97::")) {
+	case "water", "h2o":
+		return true
+	}
+	return false
+}
+
+var errUnsupportedInputs = errors.New("неподдерживаемая пара входных параметров")
+
+// propsState создает состояние по паре параметров CoolProp, переводя их из
+// СИ в единицы пакета
+func propsState(in1 propsParam, value1 float64, in2 propsParam, value2 float64) (*State, error) {
+	// value возвращает значение параметра p, если он задан одним из входов
+	value := func(p propsParam) (float64, bool) {
+		switch p {
+		case in1:
+			return value1, true
+		case in2:
+			return value2, true
+		}
+		return 0, false
+	}
+	t, hasT := value(propsT)
+	p, hasP := value(propsP)
+	h, hasH := value(propsH)
+	s, hasS := value(propsS)
+	u, hasU := value(propsU)
+	d, hasD := value(propsD)
+	q, hasQ := value(propsQ)
+	t -= 273.15
+	h /= 1000
+	s /= 1000
+	u /= 1000
+
+	switch {
+	case in1 == in2:
+	case hasT && hasP:
+		return FromTP(t, p)
+	case hasP && hasH:
+		return FromPH(p, h)
+	case hasP && hasS:
+		return FromPS(p, s)
+	case hasH && hasS:
+		return FromHS(h, s)
+	case hasP && hasQ:
+		return FromPX(p, q)
+	case hasT && hasQ:
+		return FromTX(t, q)
+	case hasD && hasU:
+		return FromVU(1/d, u)
+	case hasD && hasT:
+		return FromRhoT(d, t)
+	case hasT && hasH:
+		return FromTH(t, h)
+	case hasT && hasS:
+		return FromTS(t, s)
+	}
+	return nil, errUnsupportedInputs
+}
+
+// propsSource — состояние, из которого propsValue выбирает свойство:
+// *State или строка результата CalculateBatch
+type propsSource interface {
+	Region() Region
+	Temperature() float64
+	Pressure() float64
+	SpecificEnthalpy() float64
+	SpecificEntropy() float64
+	SpecificInternalEnergy() float64
+	Density() float64
+	Quality() float64
+	SpecificIsobaricHeatCapacity() float64
+	SpecificIsochoricHeatCapacity() float64
+	SpeedOfSound() float64
+	Transport() *Transport
+}
+
+// propsValue возвращает свойство состояния в единицах СИ; inputs и output —
+// имена входных параметров и выходного параметра для ошибки расчета
+func propsValue(st propsSource, out propsParam, inputs, output string) (float64, error) {
+	switch out {
+	case propsT:
+		return st.Temperature() + 273.15, nil
+	case propsP:
+		return st.Pressure(), nil
+	case propsH:
+		return st.SpecificEnthalpy() * 1000, nil
+	case propsS:
+		return st.SpecificEntropy() * 1000, nil
+	case propsU:
+		return st.SpecificInternalEnergy() * 1000, nil
+	case propsD:
+		return st.Density(), nil
+	case propsQ:
+		return st.Quality(), nil
+	}
+
+	if st.Region() == Region4 {
+		return 0, &CalculationError{Inputs: inputs, Output: output, Err: &propserr.Error{Code: propserr.RegionNotSupported, Region: int(Region4), Detail: "свойство не определено во влажном паре"}}
+	}
+	switch out {
+	case propsCp:
+		return st.SpecificIsobaricHeatCapacity() * 1000, nil
+	case propsCv:
+		return st.SpecificIsochoricHeatCapacity() * 1000, nil
+	case propsA:
+		return st.SpeedOfSound(), nil
+	}
+
+	tr := st.Transport()
+	if tr == nil {
+		return 0, &CalculationError{Inputs: inputs, Output: output, Err: &propserr.Error{Code: propserr.OutOfRange, Detail: "состояние вне области применимости корреляций переноса"}}
+	}
+	switch out {
+	case propsV:
+		return tr.DynamicViscosity, nil
+	case propsL:
+		return tr.ThermalConductivity, nil
+	}
+	return st.SpecificIsobaricHeatCapacity() * 1000 * tr.DynamicViscosity / tr.ThermalConductivity, nil
+}
+
+// PropsSIBatch рассчитывает свойство output для каждой пары value1[i],
+// value2[i] с теми же именами параметров и ошибками, что и PropsSI, но одним
+// пакетным расчетом CalculateBatch; opts задают его параметры. Ошибки имен
+// параметров, пары, вещества и разной длины массивов возвращаются в err,
+// ошибки строк — в errs[i] с NaN в out[i].
+func PropsSIBatch(output, name1 string, value1 []float64, name2 string, value2 []float64, fluid string, opts ...Option) (out []float64, errs []error, err error) {
+	if !isWater(fluid) {
+		return nil, nil, &UnsupportedFluidError{Fluid: fluid}
+	}
+	outParam, ok := propsParams[output]
+	if !ok {
+		return nil, nil, &UnknownParameterError{Name: output}
+	}
+	if len(value1) != len(value2) {
+		return nil, nil, propserr.Newf(propserr.InvalidInput, "массивы входных параметров имеют разную длину: %d и %d", len(value1), len(value2))
+	}
+	n := len(value1)
+	out = make([]float64, n)
+	errs = make([]error, n)
+	if v, ok := propsConstants[outParam]; ok {
+		for i := range out {
+			out[i] = v
+		}
+		return out, errs, nil
+	}
+	in1, ok := propsParams[name1]
+	if !ok {
+		return nil, nil, &UnknownParameterError{Name: name1, Input: true}
+	}
+	in2, ok := propsParams[name2]
+	if !ok {
+		return nil, nil, &UnknownParameterError{Name: name2, Input: true}
+	}
+
+	b, err := propsBatch(in1, value1, in2, value2)
+	if err != nil {
+		return nil, nil, &UnsupportedInputsError{Name1: name1, Name2: name2}
+	}
+	switch outParam {
+	case propsV, propsL, propsPrandtl:
+		opts = append(opts, WithTransport())
+	}
+	res, err := CalculateBatch(b, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	inputs := name1 + "," + name2
+	for i := range out {
+		if errs[i] = res.Errors[i]; errs[i] == nil {
+			out[i], errs[i] = propsValue(batchRow{res, i}, outParam, inputs, output)
+		}
+		if errs[i] != nil {
+			out[i] = math.NaN()
+		}
+	}
+	return out, errs, nil
+}
+
+// propsBatch строит пакет по паре параметров CoolProp, переводя столбцы из
+// СИ в единицы пакета, как propsState
+func propsBatch(in1 propsParam, value1 []float64, in2 propsParam, value2 []float64) (*Batch, error) {
+	// column возвращает столбец параметра p в единицах пакета, если он задан
+	column := func(p propsParam, convert func(float64) float64) ([]float64, bool) {
+		var src []float64
+		switch p {
+		case in1:
+			src = value1
+		case in2:
+			src = value2
+		default:
+			return nil, false
+		}
+		col := make([]float64, len(src))
+		for i, v := range src {
+			col[i] = convert(v)
+		}
+		return col, true
+	}
+	same := func(v float64) float64 { return v }
+	kilo := func(v float64) float64 { return v / 1000 }
+	t, hasT := column(propsT, func(v float64) float64 { return v - 273.15 })
+	p, hasP := column(propsP, same)
+	h, hasH := column(propsH, kilo)
+	s, hasS := column(propsS, kilo)
+	u, hasU := column(propsU, kilo)
+	d, hasD := column(propsD, same)
+	q, hasQ := column(propsQ, same)
+
+	switch {
+	case in1 == in2:
+	case hasT && hasP:
+		return &Batch{Mode: "TP", Temperature: t, Pressure: p}, nil
+	case hasP && hasH:
+		return &Batch{Mode: "PH", Pressure: p, Enthalpy: h}, nil
+	case hasP && hasS:
+		return &Batch{Mode: "PS", Pressure: p, Entropy: s}, nil
+	case hasH && hasS:
+		return &Batch{Mode: "HS", Enthalpy: h, Entropy: s}, nil
+	case hasP && hasQ:
+		return &Batch{Mode: "PX", Pressure: p, Quality: q}, nil
+	case hasT && hasQ:
+		return &Batch{Mode: "TX", Temperature: t, Quality: q}, nil
+	case hasD && hasU:
+		v, _ := column(propsD, func(d float64) float64 { return 1 / d })
+		return &Batch{Mode: "VU", SpecificVolume: v, InternalEnergy: u}, nil
+	case hasD && hasT:
+		return &Batch{Mode: "RhoT", Density: d, Temperature: t}, nil
+	case hasT && hasH:
+		return &Batch{Mode: "TH", Temperature: t, Enthalpy: h}, nil
+	case hasT && hasS:
+		return &Batch{Mode: "TS", Temperature: t, Entropy: s}, nil
+	}
+	return nil, errUnsupportedInputs
+}
+
+// batchRow — строка i результата CalculateBatch как источник свойств PropsSI
+type batchRow struct {
+	r *BatchResult
+	i int
+}
+
+func (b batchRow) Region() Region                  { return b.r.Region[b.i] }
+func (b batchRow) Temperature() float64            { return b.r.Temperature[b.i] }
+func (b batchRow) Pressure() float64               { return b.r.Pressure[b.i] }
+func (b batchRow) SpecificEnthalpy() float64       { return b.r.SpecificEnthalpy[b.i] }
+func (b batchRow) SpecificEntropy() float64        { return b.r.SpecificEntropy[b.i] }
+func (b batchRow) SpecificInternalEnergy() float64 { return b.r.SpecificInternalEnergy[b.i] }
+func (b batchRow) Density() float64                { return b.r.Density[b.i] }
+func (b batchRow) Quality() float64                { return b.r.Quality[b.i] }
+func (b batchRow) SpeedOfSound() float64           { return b.r.SpeedOfSound[b.i] }
+
+func (b batchRow) SpecificIsobaricHeatCapacity() float64 {
+	return b.r.SpecificIsobaricHeatCapacity[b.i]
+}
+
+func (b batchRow) SpecificIsochoricHeatCapacity() float64 {
+	return b.r.SpecificIsochoricHeatCapacity[b.i]
+}
+
+// Transport возвращает nil, как State.Transport, если хотя бы одна
+// корреляция неприменима
+func (b batchRow) Transport() *Transport {
+	if b.i >= len(b.r.DynamicViscosity) {
+		return nil
+	}
+	mu, lambda := b.r.DynamicViscosity[b.i], b.r.ThermalConductivity[b.i]
+	if math.IsNaN(mu) || math.IsNaN(lambda) {
+		return nil
+	}
+	return &Transport{DynamicViscosity: mu, KinematicViscosity: mu / b.r.Density[b.i], ThermalConductivity: lambda}
+}
@@ -0,0 +1,147 @@
+package if97_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/somepgs/steamprops/if97"
+	"github.com/somepgs/steamprops/propserr"
+)
+
+// Контрольная точка IF-97 Region 1 (таблица 5): T = 300 К, p = 3 МПа
+func TestPropsSI(t *testing.T) {
+	const (
+		h = 115331.273 // Дж/кг
+		s = 392.294792 // Дж/(кг·К)
+		v = 0.00100215168
+	)
+	tests := []struct {
+		output, name1 string
+		value1        float64
+		name2         string
+		value2        float64
+		want          float64
+	}{
+		{"H", "T", 300, "P", 3e6, h},
+		{"Hmass", "P", 3e6, "T", 300, h},
+		{"S", "T", 300, "P", 3e6, s},
+		{"D", "T", 300, "P", 3e6, 1 / v},
+		{"C", "T", 300, "P", 3e6, 4173.01243},
+		{"A", "T", 300, "P", 3e6, 1507.73921},
+		{"T", "P", 3e6, "H", h, 300},
+		{"T", "S", s, "P", 3e6, 300},
+		{"P", "D", 1 / v, "T", 300, 3e6},
+		{"P", "T", 300, "Smass", s, 3e6},
+		{"T", "Dmass", 1 / v, "Umass", h - 3e6*v, 300},
+		{"T", "P", 1e6, "Q", 0.5, 453.035632},
+		{"Q", "T", 453.035632, "Q", 0.25, 0.25},
+		{"Q", "T", 300, "P", 3e6, -1},
+		{"Q", "P", 1e6, "H", 762.682 * 1000, 0},
+	}
+	for _, tt := range tests {
+		got, err := if97.PropsSI(tt.output, tt.name1, tt.value1, tt.name2, tt.value2, "Water")
+		if err != nil {
+			t.Errorf("PropsSI(%s, %s, %s): %v", tt.output, tt.name1, tt.name2, err)
+			continue
+		}
+		if !near(got, tt.want, 1e-5) && !(tt.want == 0 && got < 1e-5) {
+			t.Errorf("PropsSI(%s, %s, %s) = %.9g, want %.9g", tt.output, tt.name1, tt.name2, got, tt.want)
+		}
+	}
+
+	r, err := if97.TP(300-273.15, 3e6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]float64{
+		"V":       r.Transport.DynamicViscosity,
+		"L":       r.Transport.ThermalConductivity,
+		"Prandtl": r.Properties.SpecificIsobaricHeatCapacity * 1000 * r.Transport.DynamicViscosity / r.Transport.ThermalConductivity,
+	} {
+		if got, err := if97.PropsSI(name, "T", 300, "P", 3e6, "IF97::Water"); err != nil || !near(got, want, 1e-12) {
+			t.Errorf("PropsSI(%s) = %g, %v; want %g", name, got, err, want)
+		}
+	}
+
+	if got, err := if97.PropsSI("Tcrit", "", 0, "", 0, "water"); err != nil || got != 647.096 {
+		t.Errorf("PropsSI(Tcrit) = %g, %v", got, err)
+	}
+	if got, err := if97.Props1SI("H2O", "pcrit"); err != nil || got != 22.064e6 {
+		t.Errorf("Props1SI(pcrit) = %g, %v", got, err)
+	}
+}
+
+func TestPropsSIErrors(t *testing.T) {
+	var unknown *if97.UnknownParameterError
+	if _, err := if97.PropsSI("Z", "T", 300, "P", 1e5, "Water"); !errors.As(err, &unknown) || unknown.Input {
+		t.Errorf("output Z: %v, want *UnknownParameterError", err)
+	}
+	if _, err := if97.PropsSI("H", "t", 300, "P", 1e5, "Water"); !errors.As(err, &unknown) || !unknown.Input || unknown.Name != "t" {
+		t.Errorf("input t: %v, want *UnknownParameterError", err)
+	}
+
+	var pair *if97.UnsupportedInputsError
+	for _, in := range [][2]string{{"P", "U"}, {"T", "T"}, {"H", "D"}} {
+		if _, err := if97.PropsSI("H", in[0], 1, in[1], 1, "Water"); !errors.As(err, &pair) || pair.Name1 != in[0] {
+			t.Errorf("inputs %v: %v, want *UnsupportedInputsError", in, err)
+		}
+	}
+
+	var fluid *if97.UnsupportedFluidError
+	if _, err := if97.PropsSI("H", "T", 300, "P", 1e5, "R134a"); !errors.As(err, &fluid) || fluid.Fluid != "R134a" {
+		t.Errorf("R134a: %v, want *UnsupportedFluidError", err)
+	}
+
+	var inputErr *if97.InputError
+	if _, err := if97.PropsSI("H", "T", 300, "P", -1, "Water"); !errors.As(err, &inputErr) {
+		t.Errorf("P = -1: %v, want *InputError", err)
+	}
+	var calcErr *if97.CalculationError
+	if _, err := if97.PropsSI("C", "P", 1e6, "Q", 0.5, "Water"); !errors.As(err, &calcErr) {
+		t.Errorf("cp of wet steam: %v, want *CalculationError", err)
+	} else if calcErr.Inputs != "P,Q" || calcErr.Output != "C" {
+		t.Errorf("cp of wet steam: Inputs = %q, Output = %q, want \"P,Q\" and \"C\"", calcErr.Inputs, calcErr.Output)
+	}
+}
+
+func TestPropsSIBatch(t *testing.T) {
+	tests := []struct {
+		output, name1 string
+		value1        []float64
+		name2         string
+		value2        []float64
+	}{
+		{"H", "T", []float64{300, 500, 700, 300}, "P", []float64{3e6, 1e6, 25e6, -1}},
+		{"T", "P", []float64{1e6, 5e6, 25e6}, "H", []float64{5e5, 2e6, 2e6}},
+		{"S", "D", []float64{996.5, 20, 500}, "T", []float64{300, 500, 700}},
+		{"P", "Dmass", []float64{996.5, 0.5}, "Umass", []float64{1.1e5, 2.5e6}},
+		{"C", "P", []float64{1e6, 1e6}, "Q", []float64{0.5, 1}},
+		{"Prandtl", "T", []float64{300, 1500}, "P", []float64{3e6, 1e5}},
+		{"Tcrit", "T", []float64{300}, "P", []float64{1e5}},
+	}
+	for _, tt := range tests {
+		got, errs, err := if97.PropsSIBatch(tt.output, tt.name1, tt.value1, tt.name2, tt.value2, "Water", if97.Workers(2))
+		if err != nil {
+			t.Fatalf("PropsSIBatch(%s, %s, %s): %v", tt.output, tt.name1, tt.name2, err)
+		}
+		for i := range tt.value1 {
+			want, wantErr := if97.PropsSI(tt.output, tt.name1, tt.value1[i], tt.name2, tt.value2[i], "Water")
+			if (errs[i] == nil) != (wantErr == nil) || errs[i] != nil && errs[i].Error() != wantErr.Error() {
+				t.Errorf("PropsSIBatch(%s, %s, %s) row %d: %v, want %v", tt.output, tt.name1, tt.name2, i, errs[i], wantErr)
+				continue
+			}
+			if wantErr == nil && !near(got[i], want, 1e-12) || wantErr != nil && !math.IsNaN(got[i]) {
+				t.Errorf("PropsSIBatch(%s, %s, %s) row %d = %.12g, want %.12g", tt.output, tt.name1, tt.name2, i, got[i], want)
+			}
+		}
+	}
+
+	var pair *if97.UnsupportedInputsError
+	if _, _, err := if97.PropsSIBatch("H", "P", []float64{1}, "U", []float64{1}, "Water"); !errors.As(err, &pair) {
+		t.Errorf("inputs P,U: %v, want *UnsupportedInputsError", err)
+	}
+	if _, _, err := if97.PropsSIBatch("H", "T", []float64{300}, "P", nil, "Water"); !errors.Is(err, propserr.InvalidInput) {
+		t.Errorf("arrays of different length: %v, want InvalidInput", err)
+	}
+}
@@ -0,0 +1,195 @@
+package if97
+
+import (
+	"github.com/somepgs/steamprops/internal/calc_core"
+	"github.com/somepgs/steamprops/internal/steamprops"
+)
+
+// Subregion — подобласть IF-97: 2a, 2b и 2c в Region 2, 3a и 3b в Region 3
+type Subregion int
+
+const (
+	SubregionNone Subregion = Subregion(steamprops.SubregionNone) // регион без подобластей
+	Subregion2a   Subregion = Subregion(steamprops.Subregion2a)   // p ≤ 4 МПа
+	Subregion2b   Subregion = Subregion(steamprops.Subregion2b)   // p > 4 МПа, ниже границы B2bc
+	Subregion2c   Subregion = Subregion(steamprops.Subregion2c)   // p > 4 МПа, выше границы B2bc
+	Subregion3a   Subregion = Subregion(steamprops.Subregion3a)   // s ≤ sc
+	Subregion3b   Subregion = Subregion(steamprops.Subregion3b)   // s > sc
+)
+
+// String возвращает обозначение подобласти, например "2b", или "-"
+func (s Subregion) String() string { return steamprops.Subregion(s).String() }
+
+// Phase — фазовое состояние воды
+type Phase int
+
+const (
+	PhaseLiquid        Phase = Phase(steamprops.PhaseLiquid)        // жидкость
+	PhaseVapor         Phase = Phase(steamprops.PhaseVapor)         // пар или газ
+	PhaseTwoPhase      Phase = Phase(steamprops.PhaseTwoPhase)      // влажный пар
+	PhaseSupercritical Phase = Phase(steamprops.PhaseSupercritical) // сверхкритический флюид
+)
+
+// String возвращает название фазы на русском языке; перевод —
+// i18n.Locale.T(p.String())
+func (p Phase) String() string { return steamprops.Phase(p).String() }
+
+// State — состояние воды или пара с расчетом свойств по запросу.
+// Конструктор один раз суммирует ряды уравнения региона, а каждое свойство
+// затем получается из сохраненных производных несколькими арифметическими
+// операциями. State не изменяется и безопасен для одновременного чтения.
+type State struct {
+	s *steamprops.State
+}
+
+// FromTP создает состояние по температуре (°C) и давлению (Па)
+func FromTP(temperature, pressure float64) (*State, error) {
+	return newState("T,p", &steamprops.InputData{Mode: "TP", Temperature: temperature, Pressure: pressure},
+		func() (*steamprops.State, error) { return steamprops.FromTP(temperature, pressure) })
+}
+
+// FromTPRegion создает состояние по температуре (°C) и давлению (Па) по
+// уравнению заданного региона 1, 2, 3 или 5, как TPRegion
+func FromTPRegion(region Region, temperature, pressure float64) (*State, error) {
+	return newState("T,p", &steamprops.InputData{Mode: "TP", Temperature: temperature, Pressure: pressure},
+		func() (*steamprops.State, error) {
+			return steamprops.FromTPRegion(calc_core.Region(region), temperature, pressure)
+		})
+}
+
+// FromPH создает состояние по давлению (Па) и энтальпии (кДж/кг)
+func FromPH(pressure, enthalpy float64) (*State, error) {
+	return newState("p,h", &steamprops.InputData{Mode: "PH", Pressure: pressure, Enthalpy: enthalpy},
+		func() (*steamprops.State, error) { return steamprops.FromPH(pressure, enthalpy) })
+}
+
+// FromPS создает состояние по давлению (Па) и энтропии (кДж/(кг·К))
+func FromPS(pressure, entropy float64) (*State, error) {
+	return newState("p,s", &steamprops.InputData{Mode: "PS", Pressure: pressure, Entropy: entropy},
+		func() (*steamprops.State, error) { return steamprops.FromPS(pressure, entropy) })
+}
+
+// FromHS создает состояние по энтальпии (кДж/кг) и энтропии (кДж/(кг·К))
+// во всех регионах IF-97
+func FromHS(enthalpy, entropy float64) (*State, error) {
+	return newState("h,s", &steamprops.InputData{Mode: "HS", Enthalpy: enthalpy, Entropy: entropy},
+		func() (*steamprops.State, error) { return steamprops.FromHS(enthalpy, entropy) })
+}
+
+// FromPX создает состояние влажного пара по давлению (Па) и паросодержанию
+func FromPX(pressure, quality float64) (*State, error) {
+	return newState("p,x", &steamprops.InputData{Mode: "PX", Pressure: pressure, Quality: quality},
+		func() (*steamprops.State, error) { return steamprops.FromPX(pressure, quality) })
+}
+
+// FromTX создает состояние влажного пара по температуре (°C) и паросодержанию
+func FromTX(temperature, quality float64) (*State, error) {
+	return newState("T,x", &steamprops.InputData{Mode: "TX", Temperature: temperature, Quality: quality},
+		func() (*steamprops.State, error) { return steamprops.FromTX(temperature, quality) })
+}
+
+// FromVU создает состояние по удельному объему (м³/кг) и внутренней энергии (кДж/кг)
+func FromVU(specificVolume, internalEnergy float64) (*State, error) {
+	return newState("v,u", &steamprops.InputData{Mode: "VU", SpecificVolume: specificVolume, InternalEnergy: internalEnergy},
+		func() (*steamprops.State, error) { return steamprops.FromVU(specificVolume, internalEnergy) })
+}
+
+// FromRhoT создает состояние по плотности (кг/м³) и температуре (°C)
+func FromRhoT(density, temperature float64) (*State, error) {
+	return newState("ρ,T", &steamprops.InputData{Mode: "RhoT", Density: density, Temperature: temperature},
+		func() (*steamprops.State, error) { return steamprops.FromRhoT(density, temperature) })
+}
+
+// FromTH создает состояние по температуре (°C) и энтальпии (кДж/кг); при
+// нескольких решениях возвращается *AmbiguousStateError
+func FromTH(temperature, enthalpy float64) (*State, error) {
+	return newState("T,h", &steamprops.InputData{Mode: "TH", Temperature: temperature, Enthalpy: enthalpy},
+		func() (*steamprops.State, error) { return steamprops.FromTH(temperature, enthalpy) })
+}
+
+// FromTS создает состояние по температуре (°C) и энтропии (кДж/(кг·К)); при
+// нескольких решениях возвращается *AmbiguousStateError
+func FromTS(temperature, entropy float64) (*State, error) {
+	return newState("T,s", &steamprops.InputData{Mode: "TS", Temperature: temperature, Entropy: entropy},
+		func() (*steamprops.State, error) { return steamprops.FromTS(temperature, entropy) })
+}
+
+// newState проверяет входные данные и создает состояние, оборачивая ошибки
+// так же, как функции расчета пакета
+func newState(inputs string, in *steamprops.InputData, build func() (*steamprops.State, error)) (*State, error) {
+	if err := in.Validate(); err != nil {
+		return nil, &InputError{Inputs: inputs, Err: err}
+	}
+	s, err := build()
+	if err != nil {
+		return nil, calculationError(inputs, err)
+	}
+	return &State{s: s}, nil
+}
+
+// Region возвращает регион IF-97
+func (s *State) Region() Region { return Region(s.s.Region()) }
+
+// Subregion возвращает подобласть; SubregionNone в регионах 1, 4 и 5
+func (s *State) Subregion() Subregion { return Subregion(s.s.Subregion()) }
+
+// Phase возвращает фазовое состояние
+func (s *State) Phase() Phase { return Phase(s.s.Phase()) }
+
+// Quality возвращает паросодержание 0..1 во влажном паре и -1 вне его
+func (s *State) Quality() float64 { return s.s.Quality() }
+
+// Temperature возвращает температуру, °C
+func (s *State) Temperature() float64 { return s.s.Temperature() }
+
+// Pressure возвращает давление, Па
+func (s *State) Pressure() float64 { return s.s.Pressure() }
+
+// SpecificVolume возвращает удельный объем, м³/кг
+func (s *State) SpecificVolume() float64 { return s.s.SpecificVolume() }
+
+// Density возвращает плотность, кг/м³
+func (s *State) Density() float64 { return s.s.Density() }
+
+// SpecificInternalEnergy возвращает удельную внутреннюю энергию, кДж/кг
+func (s *State) SpecificInternalEnergy() float64 { return s.s.SpecificInternalEnergy() }
+
+// SpecificEnthalpy возвращает удельную энтальпию, кДж/кг
+func (s *State) SpecificEnthalpy() float64 { return s.s.SpecificEnthalpy() }
+
+// SpecificEntropy возвращает удельную энтропию, кДж/(кг·К)
+func (s *State) SpecificEntropy() float64 { return s.s.SpecificEntropy() }
+
+// SpecificIsobaricHeatCapacity возвращает изобарную теплоемкость,
+// кДж/(кг·К); во влажном паре 0
+func (s *State) SpecificIsobaricHeatCapacity() float64 { return s.s.SpecificIsobaricHeatCapacity() }
+
+// SpecificIsochoricHeatCapacity возвращает изохорную теплоемкость,
+// кДж/(кг·К); во влажном паре 0
+func (s *State) SpecificIsochoricHeatCapacity() float64 { return s.s.SpecificIsochoricHeatCapacity() }
+
+// SpeedOfSound возвращает скорость звука, м/с; во влажном паре 0
+func (s *State) SpeedOfSound() float64 { return s.s.SpeedOfSound() }
+
+// Properties возвращает все термодинамические свойства
+func (s *State) Properties() Properties { return Properties(s.s.Properties()) }
+
+// Saturation возвращает насыщенные фазы влажного пара; nil вне Region 4
+func (s *State) Saturation() *Saturation {
+	if sat := s.s.Saturation(); sat != nil {
+		return newSaturation(sat)
+	}
+	return nil
+}
+
+// Transport рассчитывает транспортные свойства; nil во влажном паре и вне
+// области применимости корреляций
+func (s *State) Transport() *Transport {
+	if s.s.Region() == calc_core.Region4 {
+		return nil
+	}
+	return transportAt(s.s.Temperature()+273.15, s.s.Density())
+}
+
+// Result возвращает состояние со всеми свойствами, как функции TP, PH и другие
+func (s *State) Result() *Result { return newResult(s.s.Result()) }
@@ -0,0 +1,45 @@
+package if97_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/somepgs/steamprops/if97"
+)
+
+func TestState(t *testing.T) {
+	st, err := if97.FromTP(400, 20e6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := if97.TP(400, 20e6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Region() != if97.Region2 || st.Subregion() != if97.Subregion2c || st.Phase() != if97.PhaseVapor {
+		t.Errorf("%s, %s, %s", st.Region(), st.Subregion(), st.Phase())
+	}
+	if st.Properties() != r.Properties || st.SpecificEnthalpy() != r.Properties.SpecificEnthalpy {
+		t.Errorf("Properties() = %+v, want %+v", st.Properties(), r.Properties)
+	}
+	if got := st.Result(); got.Phase != r.Phase || got.Transport == nil || *got.Transport != *r.Transport {
+		t.Errorf("Result() = %+v, want %+v", got, r)
+	}
+
+	wet, err := if97.FromPX(1e6, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wet.Phase() != if97.PhaseTwoPhase || wet.Quality() != 0.5 || wet.Saturation() == nil || wet.Transport() != nil {
+		t.Errorf("wet steam: %s, x = %g", wet.Phase(), wet.Quality())
+	}
+
+	var inputErr *if97.InputError
+	if _, err := if97.FromPX(1e6, 2); !errors.As(err, &inputErr) {
+		t.Errorf("FromPX(x=2) error = %v, want *InputError", err)
+	}
+	var ambiguous *if97.AmbiguousStateError
+	if _, err := if97.FromTH(100, 430); !errors.As(err, &ambiguous) {
+		t.Errorf("FromTH error = %v, want *AmbiguousStateError", err)
+	}
+}
@@ -0,0 +1,73 @@
+package if97
+
+import "github.com/somepgs/steamprops/units"
+
+// Quantities — параметры и свойства состояния как величины пакета units для
+// вывода в любых единицах: r.Quantities().SpecificEnthalpy.In(units.BTUPerLb)
+type Quantities struct {
+	Temperature                   units.Temperature
+	Pressure                      units.Pressure
+	SpecificInternalEnergy        units.SpecificEnergy
+	SpecificEnthalpy              units.SpecificEnergy
+	SpecificEntropy               units.SpecificEntropy
+	SpecificIsochoricHeatCapacity units.SpecificEntropy
+	SpecificIsobaricHeatCapacity  units.SpecificEntropy
+	SpecificVolume                units.SpecificVolume
+	Density                       units.Density
+	SpeedOfSound                  units.Speed
+}
+
+// Quantities возвращает параметры и свойства результата как величины
+func (r *Result) Quantities() Quantities {
+	p := r.Properties
+	return Quantities{
+		Temperature:                   units.Celsius.Of(r.Temperature),
+		Pressure:                      units.Pascal.Of(r.Pressure),
+		SpecificInternalEnergy:        units.KilojoulePerKg.Of(p.SpecificInternalEnergy),
+		SpecificEnthalpy:              units.KilojoulePerKg.Of(p.SpecificEnthalpy),
+		SpecificEntropy:               units.KilojoulePerKgK.Of(p.SpecificEntropy),
+		SpecificIsochoricHeatCapacity: units.KilojoulePerKgK.Of(p.SpecificIsochoricHeatCapacity),
+		SpecificIsobaricHeatCapacity:  units.KilojoulePerKgK.Of(p.SpecificIsobaricHeatCapacity),
+		SpecificVolume:                units.CubicMetrePerKg.Of(p.SpecificVolume),
+		Density:                       units.KgPerCubicMetre.Of(p.Density),
+		SpeedOfSound:                  units.MetrePerSecond.Of(p.SpeedOfSound),
+	}
+}
+
+// Converted — температура, давление и свойства результата в единицах
+// системы System
+type Converted struct {
+	System      units.System
+	Temperature float64
+	Pressure    float64 // избыточное, если System.Gauge
+	Properties  Properties
+	Transport   *Transport // nil, если r.Transport == nil
+}
+
+// In переводит результат в систему единиц sys для вывода
+func (r *Result) In(sys units.System) Converted {
+	q := r.Quantities()
+	c := Converted{
+		System:      sys,
+		Temperature: q.Temperature.In(sys.Temperature),
+		Pressure:    sys.PressureValue(q.Pressure),
+		Properties: Properties{
+			SpecificVolume:                q.SpecificVolume.In(sys.SpecificVolume),
+			Density:                       q.Density.In(sys.Density),
+			SpecificInternalEnergy:        q.SpecificInternalEnergy.In(sys.SpecificEnergy),
+			SpecificEntropy:               q.SpecificEntropy.In(sys.SpecificEntropy),
+			SpecificEnthalpy:              q.SpecificEnthalpy.In(sys.SpecificEnergy),
+			SpecificIsochoricHeatCapacity: q.SpecificIsochoricHeatCapacity.In(sys.SpecificEntropy),
+			SpecificIsobaricHeatCapacity:  q.SpecificIsobaricHeatCapacity.In(sys.SpecificEntropy),
+			SpeedOfSound:                  q.SpeedOfSound.In(sys.Speed),
+		},
+	}
+	if tr := r.Transport; tr != nil {
+		c.Transport = &Transport{
+			DynamicViscosity:    units.PascalSecond.Of(tr.DynamicViscosity).In(sys.DynamicViscosity),
+			KinematicViscosity:  units.SquareMetrePerSecond.Of(tr.KinematicViscosity).In(sys.KinematicViscosity),
+			ThermalConductivity: units.WattPerMetreK.Of(tr.ThermalConductivity).In(sys.ThermalConductivity),
+		}
+	}
+	return c
+}
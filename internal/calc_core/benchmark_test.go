@@ -0,0 +1,59 @@
+package calc_core_test
+
+import (
+	"testing"
+
+	"github.com/somepgs/steamprops/internal/calc_core/bounds"
+	"github.com/somepgs/steamprops/internal/calc_core/region1"
+	"github.com/somepgs/steamprops/internal/calc_core/region2"
+	"github.com/somepgs/steamprops/internal/calc_core/region3"
+	"github.com/somepgs/steamprops/internal/calc_core/region4"
+	"github.com/somepgs/steamprops/internal/calc_core/region5"
+)
+
+// hotPaths are the evaluations that batch jobs run per state: the Gibbs
+// equations of Regions 1, 2 and 5, the Helmholtz equation and backward
+// equations of Region 3, the saturation line and the B23 boundary.
+var hotPaths = []struct {
+	name string
+	eval func() error
+}{
+	{"region1", func() error { _, err := region1.Calculate(150, 10e6); return err }},
+	{"region2", func() error { _, err := region2.Calculate(400, 1e6); return err }},
+	{"region5", func() error { _, err := region5.Calculate(1500, 10e6); return err }},
+	{"region3/rhoT", func() error { _, _, err := region3.PropertiesRhoT(500, 376.85); return err }},
+	{"region3/TP", func() error { _, err := region3.Calculate(380, 30e6); return err }},
+	{"region3/hs", func() error { _, _, _, err := region3.PropertiesFromHS(2000, 4); return err }},
+	{"region4/psat", func() error { _, err := region4.SaturationPressure(453.15); return err }},
+	{"region4/Tsat", func() error { _, err := region4.SaturationTemperature(1e6); return err }},
+	{"b23", func() error { _, err := bounds.B23P(650); return err }},
+}
+
+// BenchmarkRegions reports ns/op and allocs/op for every hot path, e.g.
+// go test -bench Regions ./internal/calc_core
+func BenchmarkRegions(b *testing.B) {
+	for _, hp := range hotPaths {
+		b.Run(hp.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := hp.eval(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// TestHotPathsDoNotAllocate keeps the forward equations allocation-free
+func TestHotPathsDoNotAllocate(t *testing.T) {
+	for _, hp := range hotPaths {
+		allocs := testing.AllocsPerRun(100, func() {
+			if err := hp.eval(); err != nil {
+				t.Fatal(err)
+			}
+		})
+		if allocs != 0 {
+			t.Errorf("%s: %.0f allocs/op, want 0", hp.name, allocs)
+		}
+	}
+}
@@ -11,7 +11,7 @@ func almostEqual(a, b, rel float64) bool {
 }
 
 func TestB23Roundtrip(t *testing.T) {
-	press := []float64{1.0, 5.0, 10.0, 20.0} // MPa
+	press := []float64{16.529, 20.0, 50.0, 100.0} // MPa
 	for _, p := range press {
 		T, err := B23T(p)
 		if err != nil {
@@ -26,3 +26,31 @@ func TestB23Roundtrip(t *testing.T) {
 		}
 	}
 }
+
+func TestB23Reference(t *testing.T) {
+	// IF-97, section 4: T = 623.15 K <-> p = 16.5291643 MPa
+	p, err := B23P(623.15)
+	if err != nil {
+		t.Fatalf("B23P error: %v", err)
+	}
+	if !almostEqual(p, 16.5291643, 1e-8) {
+		t.Fatalf("B23P(623.15 K) = %.9g MPa, want 16.5291643", p)
+	}
+	T, err := B23T(16.5291643)
+	if err != nil {
+		t.Fatalf("B23T error: %v", err)
+	}
+	if !almostEqual(T, 623.15, 1e-8) {
+		t.Fatalf("B23T(16.5291643 MPa) = %.9g K, want 623.15", T)
+	}
+	if _, err := B23T(10); err == nil {
+		t.Fatalf("B23T(10 MPa): expected error below the boundary")
+	}
+}
+
+func TestB2bcReference(t *testing.T) {
+	// IF-97, section 6.3.1: h = 3516.004323 kJ/kg <-> p = 100 MPa
+	if p := B2bcP(3516.004323); !almostEqual(p, 100, 1e-8) {
+		t.Fatalf("B2bcP(3516.004323 kJ/kg) = %.9g MPa, want 100", p)
+	}
+}
@@ -0,0 +1,15 @@
+package bounds
+
+// Coefficients of the B2bc boundary equation, IF-97 Eq. (20)
+const (
+	b2bcN1 = 0.90584278514723e3
+	b2bcN2 = -0.67955786399241
+	b2bcN3 = 0.12809002730136e-3
+)
+
+// B2bcP returns pressure (MPa) on the boundary between subregions 2b and 2c
+// for given enthalpy (kJ/kg): p = n1 + n2*h + n3*h^2. States of Region 2
+// above 4 MPa with a higher pressure belong to subregion 2c.
+func B2bcP(h float64) float64 {
+	return b2bcN1 + b2bcN2*h + b2bcN3*h*h
+}
@@ -18,7 +18,7 @@ func TestB23T_EdgeCases(t *testing.T) {
 		{
 			name:    "Low pressure",
 			pMPa:    0.1,
-			wantErr: false,
+			wantErr: true, // B23 is defined above 13.92 MPa only
 		},
 		{
 			name:    "High pressure",
@@ -142,17 +142,10 @@ func TestB23Roundtrip_EdgeCases(t *testing.T) {
 	}
 }
 
-func TestLoadOnce_EdgeCases(t *testing.T) {
-	// Test that loadOnce can be called multiple times
-	err := loadOnce()
-	if err != nil {
-		t.Errorf("loadOnce() error = %v", err)
-	}
-
-	// Call again to test idempotency
-	err = loadOnce()
-	if err != nil {
-		t.Errorf("loadOnce() second call error = %v", err)
+func TestCoefficients(t *testing.T) {
+	// IF-97, table 1: n1 and n5
+	if n[1] != 0.34805185628969e3 || n[5] != 0.13918839778870e2 {
+		t.Errorf("n[1] = %v, n[5] = %v", n[1], n[5])
 	}
 }
 
@@ -0,0 +1,82 @@
+package calc_core_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/somepgs/steamprops/internal/calc_core/bounds"
+	"github.com/somepgs/steamprops/internal/calc_core/region1"
+	"github.com/somepgs/steamprops/internal/calc_core/region2"
+	"github.com/somepgs/steamprops/internal/calc_core/region3"
+	"github.com/somepgs/steamprops/internal/calc_core/region4"
+	"github.com/somepgs/steamprops/internal/calc_core/region5"
+)
+
+// evaluations touches every coefficient table of the region packages.
+var evaluations = []func() (string, error){
+	func() (string, error) { r, err := region1.Calculate(20, 1e6); return fmt.Sprint(r), err },
+	func() (string, error) { r, err := region2.Calculate(300, 1e6); return fmt.Sprint(r), err },
+	func() (string, error) { r, err := region3.Calculate(380, 30e6); return fmt.Sprint(r), err },
+	func() (string, error) {
+		p, r, err := region3.PropertiesRhoT(500, 376.85)
+		return fmt.Sprint(p, r), err
+	},
+	func() (string, error) {
+		p, t, r, err := region3.PropertiesFromHS(2000, 4)
+		return fmt.Sprint(p, t, r), err
+	},
+	func() (string, error) {
+		rhoL, rhoV, err := region3.SaturatedDensities(360)
+		return fmt.Sprint(rhoL, rhoV), err
+	},
+	func() (string, error) { p, err := region4.SaturationPressure(373.15); return fmt.Sprint(p), err },
+	func() (string, error) { t, err := region4.SaturationTemperature(1e6); return fmt.Sprint(t), err },
+	func() (string, error) { r, err := region5.Calculate(1500, 10e6); return fmt.Sprint(r), err },
+	func() (string, error) { p, err := bounds.B23P(650); return fmt.Sprint(p), err },
+	func() (string, error) { t, err := bounds.B23T(50); return fmt.Sprint(t), err },
+}
+
+// TestConcurrentFirstUse starts all goroutines at once on a fresh process.
+// The tables are compiled in and the evaluators keep their scratch power
+// tables on the stack; run with -race to check that no shared mutable state
+// creeps back in.
+func TestConcurrentFirstUse(t *testing.T) {
+	const goroutines = 32
+	start := make(chan struct{})
+	results := make([][]string, goroutines)
+	errs := make(chan error, goroutines*len(evaluations))
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			<-start
+			for k := range evaluations {
+				// Each goroutine walks the tables in a different order
+				e := evaluations[(k+g)%len(evaluations)]
+				r, err := e()
+				if err != nil {
+					errs <- err
+				}
+				results[g] = append(results[g], r)
+			}
+		}(g)
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	// All goroutines must get the same results
+	for k, e := range evaluations {
+		want, _ := e()
+		for g := 0; g < goroutines; g++ {
+			if got := results[g][(k-g%len(evaluations)+len(evaluations))%len(evaluations)]; got != want {
+				t.Fatalf("goroutine %d, evaluation %d: got %s, want %s", g, k, got, want)
+			}
+		}
+	}
+}
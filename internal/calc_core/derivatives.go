@@ -0,0 +1,156 @@
+package calc_core
+
+import "math"
+
+// Gibbs holds the dimensionless Gibbs free energy γ(π, τ) of Regions 1, 2
+// and 5 and its partial derivatives at one state. Every property is an
+// algebraic combination of these six values, so once the series have been
+// summed any number of properties can be read without evaluating them again.
+// For Regions 2 and 5 the derivatives are the sums of the ideal-gas and
+// residual parts.
+type Gibbs struct {
+	T   float64 // K
+	P   float64 // Pa
+	R   float64 // kJ/(kg*K)
+	Pi  float64 // reduced pressure π
+	Tau float64 // inverse reduced temperature τ
+
+	G, GPi, GPiPi, GTau, GTauTau, GPiTau float64
+}
+
+// SpecificVolume returns v in m^3/kg
+func (g *Gibbs) SpecificVolume() float64 {
+	return g.Pi * g.GPi * (g.R * g.T / (g.P / 1000.0))
+}
+
+// Density returns ρ in kg/m^3
+func (g *Gibbs) Density() float64 {
+	return (g.P / 1000.0) / (g.R * g.T * (g.Pi * g.GPi))
+}
+
+// SpecificInternalEnergy returns u in kJ/kg
+func (g *Gibbs) SpecificInternalEnergy() float64 {
+	return g.R * g.T * (g.Tau*g.GTau - g.Pi*g.GPi)
+}
+
+// SpecificEntropy returns s in kJ/(kg*K)
+func (g *Gibbs) SpecificEntropy() float64 {
+	return g.R * (g.Tau*g.GTau - g.G)
+}
+
+// SpecificEnthalpy returns h in kJ/kg
+func (g *Gibbs) SpecificEnthalpy() float64 {
+	return g.R * g.T * g.Tau * g.GTau
+}
+
+// SpecificIsochoricHeatCapacity returns cv in kJ/(kg*K)
+func (g *Gibbs) SpecificIsochoricHeatCapacity() float64 {
+	x := g.GPi - g.Tau*g.GPiTau
+	return g.R * (-(g.Tau*g.Tau)*g.GTauTau + x*x/g.GPiPi)
+}
+
+// SpecificIsobaricHeatCapacity returns cp in kJ/(kg*K)
+func (g *Gibbs) SpecificIsobaricHeatCapacity() float64 {
+	return g.R * (-(g.Tau * g.Tau) * g.GTauTau)
+}
+
+// SpeedOfSound returns w in m/s from
+// w² = R T γπ² / |γππ - (γπ - τ γπτ)² / (τ² γττ)|.
+// It returns NaN when the denominator vanishes (|d| < 1e-10).
+func (g *Gibbs) SpeedOfSound() float64 {
+	x := g.GPi - g.Tau*g.GPiTau
+	d := g.GPiPi - x*x/(g.Tau*g.Tau*g.GTauTau)
+	if math.Abs(d) < 1e-10 {
+		return math.NaN()
+	}
+	return math.Sqrt(g.R * 1000.0 * g.T * (g.GPi * g.GPi / math.Abs(d)))
+}
+
+// Properties returns all properties of the state
+func (g *Gibbs) Properties() Properties {
+	return Properties{
+		SpecificVolume:                g.SpecificVolume(),
+		Density:                       g.Density(),
+		SpecificInternalEnergy:        g.SpecificInternalEnergy(),
+		SpecificEntropy:               g.SpecificEntropy(),
+		SpecificEnthalpy:              g.SpecificEnthalpy(),
+		SpecificIsochoricHeatCapacity: g.SpecificIsochoricHeatCapacity(),
+		SpecificIsobaricHeatCapacity:  g.SpecificIsobaricHeatCapacity(),
+		SpeedOfSound:                  g.SpeedOfSound(),
+	}
+}
+
+// Helmholtz holds the dimensionless Helmholtz free energy φ(δ, τ) of
+// Region 3 and its partial derivatives at one state, the counterpart of
+// Gibbs for an equation with density and temperature as variables.
+type Helmholtz struct {
+	T     float64 // K
+	Rho   float64 // kg/m^3
+	R     float64 // kJ/(kg*K)
+	Delta float64 // reduced density δ
+	Tau   float64 // inverse reduced temperature τ
+
+	F, FDelta, FDeltaDelta, FTau, FTauTau, FDeltaTau float64
+}
+
+// Pressure returns p in Pa
+func (f *Helmholtz) Pressure() float64 {
+	return f.Rho * f.R * f.T * f.Delta * f.FDelta * 1000.0
+}
+
+// SpecificVolume returns v in m^3/kg
+func (f *Helmholtz) SpecificVolume() float64 { return 1.0 / f.Rho }
+
+// SpecificInternalEnergy returns u in kJ/kg
+func (f *Helmholtz) SpecificInternalEnergy() float64 {
+	return f.R * f.T * f.Tau * f.FTau
+}
+
+// SpecificEntropy returns s in kJ/(kg*K)
+func (f *Helmholtz) SpecificEntropy() float64 {
+	return f.R * (f.Tau*f.FTau - f.F)
+}
+
+// SpecificEnthalpy returns h in kJ/kg
+func (f *Helmholtz) SpecificEnthalpy() float64 {
+	return f.R * f.T * (f.Tau*f.FTau + f.Delta*f.FDelta)
+}
+
+// SpecificIsochoricHeatCapacity returns cv in kJ/(kg*K)
+func (f *Helmholtz) SpecificIsochoricHeatCapacity() float64 {
+	return -f.R * f.Tau * f.Tau * f.FTauTau
+}
+
+// SpecificIsobaricHeatCapacity returns cp in kJ/(kg*K)
+func (f *Helmholtz) SpecificIsobaricHeatCapacity() float64 {
+	x := f.Delta*f.FDelta - f.Delta*f.Tau*f.FDeltaTau
+	y := 2*f.Delta*f.FDelta + f.Delta*f.Delta*f.FDeltaDelta
+	return f.SpecificIsochoricHeatCapacity() + f.R*x*x/y
+}
+
+// SpeedOfSoundSquared returns w² in m^2/s^2; it is not positive in
+// mechanically unstable states inside the spinodals.
+func (f *Helmholtz) SpeedOfSoundSquared() float64 {
+	x := f.Delta*f.FDelta - f.Delta*f.Tau*f.FDeltaTau
+	y := 2*f.Delta*f.FDelta + f.Delta*f.Delta*f.FDeltaDelta
+	return f.R * f.T * 1000.0 * (y - x*x/(f.Tau*f.Tau*f.FTauTau))
+}
+
+// SpeedOfSound returns w in m/s
+func (f *Helmholtz) SpeedOfSound() float64 {
+	return math.Sqrt(f.SpeedOfSoundSquared())
+}
+
+// Properties returns all properties of the state
+func (f *Helmholtz) Properties() Properties {
+	return Properties{
+		SpecificVolume:                f.SpecificVolume(),
+		Density:                       f.Rho,
+		SpecificInternalEnergy:        f.SpecificInternalEnergy(),
+		SpecificEntropy:               f.SpecificEntropy(),
+		SpecificEnthalpy:              f.SpecificEnthalpy(),
+		SpecificIsochoricHeatCapacity: f.SpecificIsochoricHeatCapacity(),
+		SpecificIsobaricHeatCapacity:  f.SpecificIsobaricHeatCapacity(),
+		SpeedOfSound:                  f.SpeedOfSound(),
+	}
+}
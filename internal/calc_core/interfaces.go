@@ -1,11 +1,11 @@
 package calc_core
 
 import (
-	"fmt"
 	"math"
 
 	"github.com/somepgs/steamprops/internal/calc_core/bounds"
 	"github.com/somepgs/steamprops/internal/calc_core/region4"
+	"github.com/somepgs/steamprops/propserr"
 )
 
 // Properties represent thermodynamic properties of water/steam
@@ -86,7 +86,7 @@ func (rf *RegionFactory) RegisterCalculator(region Region, calculator RegionCalc
 func (rf *RegionFactory) GetCalculator(region Region) (RegionCalculator, error) {
 	calculator, exists := rf.calculators[region]
 	if !exists {
-		return nil, &RegionNotSupportedError{Region: region}
+		return nil, &propserr.Error{Code: propserr.RegionNotSupported, Region: int(region)}
 	}
 	return calculator, nil
 }
@@ -103,36 +103,17 @@ func (rf *RegionFactory) CalculateProperties(tCelsius, pPascal float64, region R
 	}
 
 	if !calculator.IsApplicable(tCelsius, pPascal) {
-		return Properties{}, &RegionNotApplicableError{
-			Region: region,
-			T:      tCelsius,
-			P:      pPascal,
+		return Properties{}, &propserr.Error{
+			Code:   propserr.RegionNotApplicable,
+			Region: int(region),
+			Detail: "точка вне области уравнения региона при T = %.2f °C, p = %.0f Pa",
+			Args:   []interface{}{tCelsius, pPascal},
 		}
 	}
 
 	return calculator.Calculate(tCelsius, pPascal)
 }
 
-// RegionNotSupportedError represents an error when a region is not supported
-type RegionNotSupportedError struct {
-	Region Region
-}
-
-func (e *RegionNotSupportedError) Error() string {
-	return fmt.Sprintf("region %d is not supported", e.Region)
-}
-
-// RegionNotApplicableError represents an error when a region is not applicable
-type RegionNotApplicableError struct {
-	Region Region
-	T      float64
-	P      float64
-}
-
-func (e *RegionNotApplicableError) Error() string {
-	return fmt.Sprintf("region %d is not applicable for T=%.2f°C, p=%.0f Pa", e.Region, e.T, e.P)
-}
-
 // RegionFromTP returns the correct region using official IF-97 boundaries.
 // Inputs: T in K, p in Pa.
 func RegionFromTP(T float64, p float64) Region {
@@ -146,16 +127,10 @@ func RegionFromTP(T float64, p float64) Region {
 		return Region5
 	}
 
-	// Region 3: T >= 623.15 K and p >= 16.529 MPa
-	if T >= 623.15 && p >= 16.529e6 {
-		// Check B23 boundary for T > 623.15 K
-		if T > 623.15 {
-			tB23, err := bounds.B23T(p / 1e6) // p in MPa
-			if err == nil && T >= tB23 {
-				return Region3
-			}
-		} else {
-			// At T = 623.15 K, Region 3 applies for p >= 16.529 MPa
+	// Region 3: 623.15 K <= T <= T_B23(p), i.e. p >= p_B23(T)
+	if T >= 623.15 && T <= 863.15 {
+		pB23, err := bounds.B23P(T) // MPa
+		if err == nil && p >= pB23*1e6 {
 			return Region3
 		}
 	}
@@ -184,7 +159,7 @@ func RegionFromTP(T float64, p float64) Region {
 	// This includes:
 	// - T < 647.096 K and p < psat
 	// - T >= 647.096 K and T < 1073.15 K
-	// - T >= 623.15 K and p < 16.529 MPa
+	// - T >= 623.15 K and p < p_B23(T)
 	return Region2
 }
 
@@ -210,10 +185,10 @@ func NewValidationService() *ValidationService {
 func (vs *ValidationService) ValidateTemperature(tCelsius float64) error {
 	tKelvin := tCelsius + 273.15
 	if tKelvin < vs.minTemperature {
-		return fmt.Errorf("temperature %.2f°C is below minimum %.2f°C", tCelsius, vs.minTemperature-273.15)
+		return propserr.Range(propserr.OutOfRange, 0, "T", tCelsius, "°C", propserr.Min, vs.minTemperature-273.15)
 	}
 	if tKelvin > vs.maxTemperature {
-		return fmt.Errorf("temperature %.2f°C is above maximum %.2f°C", tCelsius, vs.maxTemperature-273.15)
+		return propserr.Range(propserr.OutOfRange, 0, "T", tCelsius, "°C", propserr.Max, vs.maxTemperature-273.15)
 	}
 	return nil
 }
@@ -221,26 +196,32 @@ func (vs *ValidationService) ValidateTemperature(tCelsius float64) error {
 // ValidatePressure validates pressure input
 func (vs *ValidationService) ValidatePressure(pPascal float64) error {
 	if pPascal < vs.minPressure {
-		return fmt.Errorf("pressure %.0f Pa is below minimum %.0f Pa", pPascal, vs.minPressure)
+		return propserr.Range(propserr.OutOfRange, 0, "p", pPascal, "Pa", propserr.Min, vs.minPressure)
 	}
 	if pPascal > vs.maxPressure {
-		return fmt.Errorf("pressure %.0f Pa is above maximum %.0f Pa", pPascal, vs.maxPressure)
+		return propserr.Range(propserr.OutOfRange, 0, "p", pPascal, "Pa", propserr.Max, vs.maxPressure)
 	}
 	return nil
 }
 
 // ValidateEnthalpy validates enthalpy input
 func (vs *ValidationService) ValidateEnthalpy(h float64) error {
-	if h < 0 || h > 5000 {
-		return fmt.Errorf("enthalpy %.2f kJ/kg is outside valid range [0, 5000]", h)
+	if h < 0 {
+		return propserr.Range(propserr.OutOfRange, 0, "h", h, "kJ/kg", propserr.Min, 0)
+	}
+	if h > 5000 {
+		return propserr.Range(propserr.OutOfRange, 0, "h", h, "kJ/kg", propserr.Max, 5000)
 	}
 	return nil
 }
 
 // ValidateEntropy validates entropy input
 func (vs *ValidationService) ValidateEntropy(s float64) error {
-	if s < 0 || s > 15 {
-		return fmt.Errorf("entropy %.2f kJ/(kg·K) is outside valid range [0, 15]", s)
+	if s < 0 {
+		return propserr.Range(propserr.OutOfRange, 0, "s", s, "kJ/(kg·K)", propserr.Min, 0)
+	}
+	if s > 15 {
+		return propserr.Range(propserr.OutOfRange, 0, "s", s, "kJ/(kg·K)", propserr.Max, 15)
 	}
 	return nil
 }
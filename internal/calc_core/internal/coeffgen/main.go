@@ -0,0 +1,258 @@
+// Command coeffgen converts the IF-97 coefficient CSV files of a calc_core
+// package into typed Go tables, so that the equations need no file I/O or
+// parsing at run time. It is run by go generate from the package directory:
+//
+//	//go:generate go run ../internal/coeffgen name=file.csv:kind ...
+//
+// Each argument declares one table. The kind selects the CSV layout:
+//
+//	ijn  rows "i,I,J,n" -> var name []term
+//	jn   rows "i,J,n"   -> var name []termJ
+//	n    rows "i,n"     -> var name [N+1]float64, indexed as in IF-97 (name[0] unused)
+//
+// Fields are separated by ';' or ','. A first line whose last field is not a
+// number is a header and is skipped. The index column of ijn and jn tables is
+// informational; for n tables it must count 1, 2, ... Exponents must be
+// integers. An empty exponent pair is accepted only in the first row of an ijn
+// table (the n1·ln δ term of Region 3) and is written as 0.
+//
+// For every term table the exponent ranges are emitted as constants
+// (nameMinI, nameMaxI, nameMinJ, nameMaxJ) so that evaluators can size power
+// tables at compile time. Any malformed value makes the generator fail, so bad
+// data can never reach the compiled tables.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// defaultOutput is the file written next to the CSV files
+const defaultOutput = "coefficients_gen.go"
+
+// table is one parsed CSV file
+type table struct {
+	name, file, kind string
+	i, j             []int
+	n                []float64
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("coeffgen: ")
+	out, src, err := run(".", os.Getenv("GOPACKAGE"), os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run parses the command line and returns the output path and the formatted
+// source. CSV paths are resolved relative to dir.
+func run(dir, pkg string, args []string) (string, []byte, error) {
+	fs := flag.NewFlagSet("coeffgen", flag.ContinueOnError)
+	output := fs.String("o", defaultOutput, "output file")
+	fs.StringVar(&pkg, "pkg", pkg, "package name (default $GOPACKAGE)")
+	if err := fs.Parse(args); err != nil {
+		return "", nil, err
+	}
+	if pkg == "" {
+		return "", nil, fmt.Errorf("package name is not set")
+	}
+	if fs.NArg() == 0 {
+		return "", nil, fmt.Errorf("no tables given")
+	}
+
+	var tables []*table
+	for _, spec := range fs.Args() {
+		t, err := parseSpec(spec)
+		if err != nil {
+			return "", nil, err
+		}
+		data, err := os.ReadFile(filepath.Join(dir, t.file))
+		if err != nil {
+			return "", nil, err
+		}
+		if err := t.parse(string(data)); err != nil {
+			return "", nil, fmt.Errorf("%s: %w", t.file, err)
+		}
+		tables = append(tables, t)
+	}
+	src, err := generate(pkg, tables)
+	if err != nil {
+		return "", nil, err
+	}
+	return filepath.Join(dir, *output), src, nil
+}
+
+// parseSpec parses "name=file:kind"
+func parseSpec(spec string) (*table, error) {
+	name, rest, ok := strings.Cut(spec, "=")
+	if !ok {
+		return nil, fmt.Errorf("invalid table %q: want name=file:kind", spec)
+	}
+	i := strings.LastIndex(rest, ":")
+	if i < 0 {
+		return nil, fmt.Errorf("invalid table %q: want name=file:kind", spec)
+	}
+	t := &table{name: name, file: rest[:i], kind: rest[i+1:]}
+	switch t.kind {
+	case "ijn", "jn", "n":
+	default:
+		return nil, fmt.Errorf("invalid table %q: unknown kind %q", spec, t.kind)
+	}
+	return t, nil
+}
+
+// columns returns the number of CSV fields for the table kind
+func (t *table) columns() int {
+	switch t.kind {
+	case "ijn":
+		return 4
+	case "jn":
+		return 3
+	}
+	return 2
+}
+
+func (t *table) parse(data string) error {
+	lines := strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n")
+	row := 0
+	for k, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		sep := ","
+		if strings.Contains(line, ";") {
+			sep = ";"
+		}
+		parts := strings.Split(line, sep)
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		if len(parts) != t.columns() {
+			return fmt.Errorf("line %d: want %d fields, got %d", k+1, t.columns(), len(parts))
+		}
+		n, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+		if err != nil {
+			if k == 0 {
+				continue // header
+			}
+			return fmt.Errorf("line %d: coefficient: %w", k+1, err)
+		}
+		row++
+
+		switch t.kind {
+		case "ijn":
+			if row == 1 && parts[1] == "" && parts[2] == "" {
+				t.i, t.j = append(t.i, 0), append(t.j, 0)
+				break
+			}
+			i, err := strconv.Atoi(parts[1])
+			if err != nil {
+				return fmt.Errorf("line %d: exponent I: %w", k+1, err)
+			}
+			j, err := strconv.Atoi(parts[2])
+			if err != nil {
+				return fmt.Errorf("line %d: exponent J: %w", k+1, err)
+			}
+			t.i, t.j = append(t.i, i), append(t.j, j)
+		case "jn":
+			j, err := strconv.Atoi(parts[1])
+			if err != nil {
+				return fmt.Errorf("line %d: exponent J: %w", k+1, err)
+			}
+			t.j = append(t.j, j)
+		case "n":
+			idx, err := strconv.Atoi(parts[0])
+			if err != nil || idx != row {
+				return fmt.Errorf("line %d: index %q, want %d", k+1, parts[0], row)
+			}
+		}
+		t.n = append(t.n, n)
+	}
+	if row == 0 {
+		return fmt.Errorf("no coefficients")
+	}
+	return nil
+}
+
+// generate renders the tables as a gofmt-ed Go file
+func generate(pkg string, tables []*table) ([]byte, error) {
+	var b bytes.Buffer
+	var files []string
+	hasKind := map[string]bool{}
+	for _, t := range tables {
+		files = append(files, t.file)
+		hasKind[t.kind] = true
+	}
+	fmt.Fprintf(&b, "// Code generated by coeffgen from %s; DO NOT EDIT.\n\n", strings.Join(files, ", "))
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+
+	if hasKind["ijn"] {
+		b.WriteString("// term is one term n·x^I·y^J of an IF-97 series.\n")
+		b.WriteString("type term struct {\n\tI, J int\n\tN    float64\n}\n\n")
+	}
+	if hasKind["jn"] {
+		b.WriteString("// termJ is one term n·x^J of an IF-97 series.\n")
+		b.WriteString("type termJ struct {\n\tJ int\n\tN float64\n}\n\n")
+	}
+
+	for _, t := range tables {
+		switch t.kind {
+		case "ijn":
+			fmt.Fprintf(&b, "// %s holds the %d terms of %s.\n", t.name, len(t.n), t.file)
+			fmt.Fprintf(&b, "var %s = []term{\n", t.name)
+			for k, n := range t.n {
+				fmt.Fprintf(&b, "\t{%d, %d, %s},\n", t.i[k], t.j[k], literal(n))
+			}
+			b.WriteString("}\n\n")
+			writeRange(&b, t.name, "I", t.i)
+			writeRange(&b, t.name, "J", t.j)
+		case "jn":
+			fmt.Fprintf(&b, "// %s holds the %d terms of %s.\n", t.name, len(t.n), t.file)
+			fmt.Fprintf(&b, "var %s = []termJ{\n", t.name)
+			for k, n := range t.n {
+				fmt.Fprintf(&b, "\t{%d, %s},\n", t.j[k], literal(n))
+			}
+			b.WriteString("}\n\n")
+			writeRange(&b, t.name, "J", t.j)
+		case "n":
+			fmt.Fprintf(&b, "// %s holds the coefficients n1..n%d of %s; %s[0] is unused.\n", t.name, len(t.n), t.file, t.name)
+			fmt.Fprintf(&b, "var %s = [%d]float64{\n\t0,\n", t.name, len(t.n)+1)
+			for _, n := range t.n {
+				fmt.Fprintf(&b, "\t%s,\n", literal(n))
+			}
+			b.WriteString("}\n\n")
+		}
+	}
+	return format.Source(b.Bytes())
+}
+
+// writeRange emits the smallest and largest exponent of a table
+func writeRange(b *bytes.Buffer, name, exp string, values []int) {
+	lo, hi := values[0], values[0]
+	for _, v := range values[1:] {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	fmt.Fprintf(b, "const (\n\t%sMin%s = %d\n\t%sMax%s = %d\n)\n\n", name, exp, lo, name, exp, hi)
+}
+
+// literal formats n as the shortest literal that parses back to the same value
+func literal(n float64) string {
+	s := strconv.FormatFloat(n, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
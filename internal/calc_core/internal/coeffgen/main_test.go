@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGeneratedUpToDate reruns every coeffgen directive of calc_core and
+// compares the result with the committed file, so that an edited CSV without
+// go generate fails the tests.
+func TestGeneratedUpToDate(t *testing.T) {
+	files, err := filepath.Glob("../../*/*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const directive = "//go:generate go run ../internal/coeffgen "
+	found := 0
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			args, ok := strings.CutPrefix(line, directive)
+			if !ok {
+				continue
+			}
+			found++
+			dir := filepath.Dir(file)
+			out, want, err := run(dir, filepath.Base(dir), strings.Fields(args))
+			if err != nil {
+				t.Errorf("%s: %v", file, err)
+				continue
+			}
+			got, err := os.ReadFile(out)
+			if err != nil {
+				t.Errorf("%s: %v", file, err)
+				continue
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s is stale, run go generate ./internal/calc_core/...", out)
+			}
+		}
+	}
+	// region1..5, bounds and transport
+	if found != 7 {
+		t.Errorf("found %d coeffgen directives, want 7", found)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name, kind, data string
+	}{
+		{"bad coefficient", "ijn", "i,I,J,n\n1,0,0,1.5\n2,1,1,x\n"},
+		{"fractional exponent", "ijn", "i,I,J,n\n1,0.5,0,1.5\n"},
+		{"empty exponent after first row", "ijn", "i,I,J,n\n1,0,0,1.5\n2,,,2\n"},
+		{"missing field", "jn", "i,J,n\n1,0\n"},
+		{"index gap", "n", "i,n\n1,1.0\n3,2.0\n"},
+		{"no rows", "n", "i,n\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tab := &table{name: "x", file: "x.csv", kind: tt.kind}
+			if err := tab.parse(tt.data); err == nil {
+				t.Errorf("no error for %q", tt.data)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	tab := &table{name: "terms", file: "x.csv", kind: "ijn"}
+	if err := tab.parse("1;;;1.5\n2;-2;3;-0.25E1\n"); err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate("x", []*table{tab})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"{0, 0, 1.5},",
+		"{-2, 3, -2.5},",
+		"termsMinI = -2",
+		"termsMaxJ = 3",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated source lacks %q:\n%s", want, src)
+		}
+	}
+}
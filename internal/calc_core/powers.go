@@ -0,0 +1,26 @@
+package calc_core
+
+// IntPowers fills dst with consecutive integer powers of x, dst[k] = x^(lo+k).
+// The powers are built by repeated multiplication outward from x^0 (by 1/x
+// for negative exponents), so a full table costs one multiplication per entry
+// instead of one math.Pow call per term. x must be non-zero if lo < 0.
+func IntPowers(dst []float64, x float64, lo int) {
+	hi := lo + len(dst) - 1
+	p := 1.0
+	for k := 0; k <= hi; k++ {
+		if k >= lo {
+			dst[k-lo] = p
+		}
+		p *= x
+	}
+	if lo < 0 {
+		inv := 1 / x
+		p = inv
+		for k := -1; k >= lo; k-- {
+			if k <= hi {
+				dst[k-lo] = p
+			}
+			p *= inv
+		}
+	}
+}
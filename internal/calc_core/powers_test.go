@@ -0,0 +1,22 @@
+package calc_core
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIntPowers(t *testing.T) {
+	for _, x := range []float64{0.003, -0.7, 1.5, 6.9} {
+		// Ranges below, around and above zero
+		for _, r := range [][2]int{{-43, 17}, {-12, -3}, {0, 32}, {5, 24}} {
+			dst := make([]float64, r[1]-r[0]+1)
+			IntPowers(dst, x, r[0])
+			for k, got := range dst {
+				want := math.Pow(x, float64(r[0]+k))
+				if math.Abs(got-want) > 1e-13*math.Abs(want) {
+					t.Errorf("%g^%d = %g, want %g", x, r[0]+k, got, want)
+				}
+			}
+		}
+	}
+}
@@ -1,16 +1,11 @@
 package region1
 
 import (
-	"bufio"
-	"embed"
-	"errors"
-	"fmt"
 	"math"
-	"strconv"
-	"strings"
 
 	"github.com/somepgs/steamprops/internal/calc_core"
 	"github.com/somepgs/steamprops/internal/calc_core/region4"
+	"github.com/somepgs/steamprops/propserr"
 )
 
 const (
@@ -19,87 +14,52 @@ const (
 	referR = 0.461526 // kJ/kg*K
 )
 
-//go:embed iapws-if97-region1.csv
-var coeffRegion1 embed.FS
+//go:generate go run ../internal/coeffgen terms=iapws-if97-region1.csv:ijn
 
-type tableData struct {
-	I  int
-	Ii float64
-	Ji float64
-	Ni float64
-}
-
-var (
-	rows           []tableData
-	rowsLoadedOnce bool
-)
-
-func loadRowsOnce() error {
-	if rowsLoadedOnce {
-		return nil
-	}
-	f, err := coeffRegion1.Open("iapws-if97-region1.csv")
+// Calculate computes Region 1 properties for T in Celsius and P in Pascals.
+func Calculate(tCelsius, pPascal float64) (calc_core.Properties, error) {
+	g, err := Derivatives(tCelsius, pPascal)
 	if err != nil {
-		return err
+		return calc_core.Properties{}, err
 	}
-	defer f.Close()
+	p := g.Properties()
 
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		line := scanner.Text()
-		parts := strings.Split(line, ";")
-		if len(parts) != 4 {
-			return fmt.Errorf("invalid line in CSV: %s", line)
-		}
-		i, err := strconv.Atoi(strings.TrimSpace(parts[0]))
-		if err != nil {
-			return err
-		}
-		ii, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
-		if err != nil {
-			return err
-		}
-		ji, err := strconv.ParseFloat(strings.TrimSpace(parts[2]), 64)
-		if err != nil {
-			return err
-		}
-		ni, err := strconv.ParseFloat(strings.TrimSpace(parts[3]), 64)
-		if err != nil {
-			return err
-		}
-		rows = append(rows, tableData{i, ii, ji, ni})
+	// Sanity validation; SpeedOfSound is NaN where its denominator vanishes
+	if !finiteAll(p.SpecificVolume, p.Density, p.SpecificInternalEnergy, p.SpecificEntropy,
+		p.SpecificEnthalpy, p.SpecificIsochoricHeatCapacity, p.SpecificIsobaricHeatCapacity, p.SpeedOfSound) {
+		return calc_core.Properties{}, &propserr.Error{Code: propserr.NonPhysical, Region: 1, Detail: "нечисловые значения свойств"}
 	}
-	if err := scanner.Err(); err != nil {
-		return err
+	if p.Density <= 0 || p.SpecificVolume <= 0 || p.SpeedOfSound <= 0 {
+		return calc_core.Properties{}, &propserr.Error{Code: propserr.NonPhysical, Region: 1, Detail: "неположительные плотность, объем или скорость звука"}
 	}
-	rowsLoadedOnce = true
-	return nil
+	if p.SpecificIsobaricHeatCapacity <= 0 || p.SpecificIsochoricHeatCapacity <= 0 {
+		return calc_core.Properties{}, &propserr.Error{Code: propserr.NonPhysical, Region: 1, Detail: "неположительные теплоемкости: точка, вероятно, вне области уравнения"}
+	}
+	return p, nil
 }
 
-// Calculate computes Region 1 properties for T in Celsius and P in Pascals.
-func Calculate(tCelsius, pPascal float64) (calc_core.Properties, error) {
-	if err := loadRowsOnce(); err != nil {
-		return calc_core.Properties{}, err
-	}
+// Derivatives checks the Region 1 range and evaluates γ(π, τ) and its
+// derivatives for T in Celsius and P in Pascals.
+func Derivatives(tCelsius, pPascal float64) (calc_core.Gibbs, error) {
 	if tCelsius < -273.15 {
-		return calc_core.Properties{}, errors.New("temperature below absolute zero")
+		return calc_core.Gibbs{}, propserr.Range(propserr.InvalidInput, 1, "T", tCelsius, "°C", propserr.Min, -273.15)
 	}
 	if pPascal <= 0 {
-		return calc_core.Properties{}, errors.New("pressure must be positive")
+		return calc_core.Gibbs{}, propserr.Range(propserr.InvalidInput, 1, "p", pPascal, "Pa", propserr.Min, 0)
 	}
 
 	T := tCelsius + 273.15
 	// Region 1 applicability (simplified): T <= 623.15 K, p <= 100 MPa, p >= psat(T)
 	if T > 623.15 {
-		return calc_core.Properties{}, fmt.Errorf("Region 1 not applicable: T=%.2f K exceeds 623.15 K", T)
+		return calc_core.Gibbs{}, propserr.Range(propserr.RegionNotApplicable, 1, "T", T, "K", propserr.Max, 623.15)
 	}
 	if pPascal > 100e6 {
-		return calc_core.Properties{}, fmt.Errorf("Region 1 not applicable: p=%.0f Pa exceeds 100 MPa", pPascal)
+		return calc_core.Gibbs{}, propserr.Range(propserr.RegionNotApplicable, 1, "p", pPascal, "Pa", propserr.Max, 100e6)
 	}
 	if T < 647.096 {
 		if ps, err := region4.SaturationPressure(T); err == nil {
 			if pPascal < ps {
-				return calc_core.Properties{}, fmt.Errorf("Region 1 not applicable: p < psat(%.2f K)", T)
+				return calc_core.Gibbs{}, propserr.Range(propserr.RegionNotApplicable, 1, "p", pPascal, "Pa", propserr.Min, ps)
 			}
 		}
 	}
@@ -109,69 +69,28 @@ func Calculate(tCelsius, pPascal float64) (calc_core.Properties, error) {
 	pi := PMPa / referP
 	tau := referT / T
 
-	var g, gPi, gPiPi, gTau, gTauTau, gPiTau float64
-	for _, row := range rows {
-		powPi := math.Pow(7.1-pi, row.Ii)
-		powTau := math.Pow(tau-1.222, row.Ji)
-		g += row.Ni * powPi * powTau
-	}
-	for _, row := range rows {
-		gPi += (-row.Ni) * row.Ii * math.Pow(7.1-pi, row.Ii-1) * math.Pow(tau-1.222, row.Ji)
-	}
-	for _, row := range rows {
-		gPiPi += row.Ni * row.Ii * (row.Ii - 1) * math.Pow(7.1-pi, row.Ii-2) * math.Pow(tau-1.222, row.Ji)
-	}
-	for _, row := range rows {
-		gTau += row.Ni * row.Ji * math.Pow(7.1-pi, row.Ii) * math.Pow(tau-1.222, row.Ji-1)
-	}
-	for _, row := range rows {
-		gTauTau += row.Ni * row.Ji * (row.Ji - 1) * math.Pow(7.1-pi, row.Ii) * math.Pow(tau-1.222, row.Ji-2)
-	}
-	for _, row := range rows {
-		gPiTau += (-row.Ni) * row.Ii * row.Ji * math.Pow(7.1-pi, row.Ii-1) * math.Pow(tau-1.222, row.Ji-1)
-	}
-
-	R := referR
-	pKPa := pPascal / 1000.0
+	// Powers of 7.1-π and τ-1.222 for exponents from min-2 up to max, so that
+	// γ and all its derivatives are summed in one pass without math.Pow.
+	var pp [termsMaxI - termsMinI + 3]float64
+	var tp [termsMaxJ - termsMinJ + 3]float64
+	calc_core.IntPowers(pp[:], 7.1-pi, termsMinI-2)
+	calc_core.IntPowers(tp[:], tau-1.222, termsMinJ-2)
 
-	v := pi * gPi * (R * T / pKPa)
-	ro := pKPa / (R * T * (pi * gPi))
-	u := R * T * (tau*gTau - pi*gPi)
-	s := R * (tau*gTau - g)
-	h := R * T * tau * gTau
-	cv := R * (-(tau*tau)*gTauTau + (math.Pow(gPi-tau*gPiTau, 2) / gPiPi))
-	cp := R * (-(tau*tau)*gTauTau + (math.Pow(gPi-tau*gPiTau, 2) / gPiPi))
-	// Calculate speed of sound using alternative IF-97 formula
-	// w² = R * T * gPi² / (gPiPi - (gPi - tau*gPiTau)² / (tau² * gTauTau))
-	// But if denominator is negative, use absolute value (common in some implementations)
-	denominator := gPiPi - (math.Pow(gPi-tau*gPiTau, 2) / (tau * tau * gTauTau))
-
-	if math.Abs(denominator) < 1e-10 {
-		return calc_core.Properties{}, fmt.Errorf("Region 1: speed of sound calculation failed (denominator too small: %.6f)", denominator)
-	}
-	w := math.Sqrt(R * 1000.0 * T * (gPi * gPi / math.Abs(denominator)))
-
-	// Sanity validation
-	if !finiteAll(v, ro, u, s, h, cv, cp, w) {
-		return calc_core.Properties{}, errors.New("Region 1 calculation produced non-finite values")
-	}
-	if ro <= 0 || v <= 0 || w <= 0 {
-		return calc_core.Properties{}, errors.New("Region 1 invalid physical result (negative density/volume/speed)")
-	}
-	// cp and cv should be positive in Region 1
-	if cp <= 0 || cv <= 0 {
-		return calc_core.Properties{}, errors.New("Region 1 heat capacities are non-positive; inputs may be out of applicability")
-	}
-
-	return calc_core.Properties{
-		SpecificVolume:                v,
-		Density:                       ro,
-		SpecificInternalEnergy:        u,
-		SpecificEntropy:               s,
-		SpecificEnthalpy:              h,
-		SpecificIsochoricHeatCapacity: cv,
-		SpecificIsobaricHeatCapacity:  cp,
-		SpeedOfSound:                  w,
+	var g, gPi, gPiPi, gTau, gTauTau, gPiTau float64
+	for _, row := range terms {
+		i, j := row.I-termsMinI+2, row.J-termsMinJ+2
+		I, J := float64(row.I), float64(row.J)
+		g += row.N * pp[i] * tp[j]
+		gPi -= row.N * I * pp[i-1] * tp[j]
+		gPiPi += row.N * I * (I - 1) * pp[i-2] * tp[j]
+		gTau += row.N * J * pp[i] * tp[j-1]
+		gTauTau += row.N * J * (J - 1) * pp[i] * tp[j-2]
+		gPiTau -= row.N * I * J * pp[i-1] * tp[j-1]
+	}
+
+	return calc_core.Gibbs{
+		T: T, P: pPascal, R: referR, Pi: pi, Tau: tau,
+		G: g, GPi: gPi, GPiPi: gPiPi, GTau: gTau, GTauTau: gTauTau, GPiTau: gPiTau,
 	}, nil
 }
 
@@ -5,17 +5,16 @@ import (
 	"testing"
 )
 
-func TestLoadRowsOnce_EdgeCases(t *testing.T) {
-	// Test that loadRowsOnce can be called multiple times
-	err := loadRowsOnce()
-	if err != nil {
-		t.Errorf("loadRowsOnce() error = %v", err)
+func TestTerms(t *testing.T) {
+	// IF-97, table 2: 34 terms, n1 and n34 with their exponents
+	if len(terms) != 34 {
+		t.Fatalf("len(terms) = %d, want 34", len(terms))
 	}
-
-	// Call again to test idempotency
-	err = loadRowsOnce()
-	if err != nil {
-		t.Errorf("loadRowsOnce() second call error = %v", err)
+	if got := terms[0]; got != (term{0, -2, 0.14632971213167}) {
+		t.Errorf("terms[0] = %+v", got)
+	}
+	if got := terms[33]; got != (term{32, -41, -0.93537087292458e-25}) {
+		t.Errorf("terms[33] = %+v", got)
 	}
 }
 
@@ -5,31 +5,16 @@ import (
 	"testing"
 )
 
-func TestLoadIdealOnce_EdgeCases(t *testing.T) {
-	// Test that loadIdealOnce can be called multiple times
-	err := loadIdealOnce()
-	if err != nil {
-		t.Errorf("loadIdealOnce() error = %v", err)
+func TestTerms(t *testing.T) {
+	// IF-97, tables 10 and 11: 9 ideal-gas and 43 residual terms
+	if len(idealTerms) != 9 || len(residualTerms) != 43 {
+		t.Fatalf("got %d ideal and %d residual terms, want 9 and 43", len(idealTerms), len(residualTerms))
 	}
-
-	// Call again to test idempotency
-	err = loadIdealOnce()
-	if err != nil {
-		t.Errorf("loadIdealOnce() second call error = %v", err)
+	if got := idealTerms[0]; got != (termJ{0, -0.96927686500217e1}) {
+		t.Errorf("idealTerms[0] = %+v", got)
 	}
-}
-
-func TestLoadResidualOnce_EdgeCases(t *testing.T) {
-	// Test that loadResidualOnce can be called multiple times
-	err := loadResidualOnce()
-	if err != nil {
-		t.Errorf("loadResidualOnce() error = %v", err)
-	}
-
-	// Call again to test idempotency
-	err = loadResidualOnce()
-	if err != nil {
-		t.Errorf("loadResidualOnce() second call error = %v", err)
+	if got := residualTerms[42]; got != (term{24, 58, -0.94369707241210e-6}) {
+		t.Errorf("residualTerms[42] = %+v", got)
 	}
 }
 
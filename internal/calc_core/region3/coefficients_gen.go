@@ -0,0 +1,526 @@
+// Code generated by coeffgen from iapws-if97-region3.csv, p_3a(h,s).csv, p_3b(h,s).csv, T_3a(p,h).csv, v_3a(p,h).csv, T_3b(p,h).csv, v_3b(p,h).csv, T_3a(p,s).csv, v_3a(p,s).csv, T_3b(p,s).csv, v_3b(p,s).csv, h_3ab(p).csv; DO NOT EDIT.
+
+package region3
+
+// term is one term n·x^I·y^J of an IF-97 series.
+type term struct {
+	I, J int
+	N    float64
+}
+
+// terms holds the 40 terms of iapws-if97-region3.csv.
+var terms = []term{
+	{0, 0, 1.0658070028513},
+	{0, 0, -15.732845290239},
+	{0, 1, 20.944396974307},
+	{0, 2, -7.6867707878716},
+	{0, 7, 2.6185947787954},
+	{0, 10, -2.808078114862},
+	{0, 12, 1.2053369696517},
+	{0, 23, -0.0084566812812502},
+	{1, 2, -1.2654315477714},
+	{1, 6, -1.1524407806681},
+	{1, 15, 0.88521043984318},
+	{1, 17, -0.64207765181607},
+	{2, 0, 0.38493460186671},
+	{2, 2, -0.85214708824206},
+	{2, 6, 4.8972281541877},
+	{2, 7, -3.0502617256965},
+	{2, 22, 0.039420536879154},
+	{2, 26, 0.12558408424308},
+	{3, 0, -0.2799932969871},
+	{3, 2, 1.389979956946},
+	{3, 4, -2.018991502357},
+	{3, 16, -0.0082147637173963},
+	{3, 26, -0.47596035734923},
+	{4, 0, 0.0439840744735},
+	{4, 2, -0.44476435428739},
+	{4, 4, 0.90572070719733},
+	{4, 26, 0.70522450087967},
+	{5, 1, 0.10770512626332},
+	{5, 3, -0.32913623258954},
+	{5, 26, -0.50871062041158},
+	{6, 0, -0.022175400873096},
+	{6, 2, 0.094260751665092},
+	{6, 26, 0.16436278447961},
+	{7, 2, -0.013503372241348},
+	{8, 26, -0.014834345352472},
+	{9, 2, 0.00057922953628084},
+	{9, 26, 0.0032308904703711},
+	{10, 0, 8.0964802996215e-05},
+	{10, 1, -0.00016557679795037},
+	{11, 26, -4.4923899061815e-05},
+}
+
+const (
+	termsMinI = 0
+	termsMaxI = 11
+)
+
+const (
+	termsMinJ = 0
+	termsMaxJ = 26
+)
+
+// p3a holds the 33 terms of p_3a(h,s).csv.
+var p3a = []term{
+	{0, 0, 7.70889828326934},
+	{0, 1, -26.0835009128688},
+	{0, 5, 267.416218930389},
+	{1, 0, 17.2221089496844},
+	{1, 3, -293.54233214597},
+	{1, 4, 614.135601882478},
+	{1, 8, -61056.2757725674},
+	{1, 14, -6.51272251118219e+07},
+	{2, 6, 73591.9313521937},
+	{2, 16, -1.16646505914191e+10},
+	{3, 0, 35.5267086434461},
+	{3, 2, -596.144543825955},
+	{3, 3, -475.842430145708},
+	{4, 0, 69.6781965359503},
+	{4, 1, 335.674250377312},
+	{4, 4, 25052.6809130882},
+	{4, 5, 146997.380630766},
+	{5, 28, 5.38069315091534e+19},
+	{6, 28, 1.43619827291346e+21},
+	{7, 24, 3.64985866165994e+19},
+	{8, 1, -2547.41561156775},
+	{10, 32, 2.40120197096563e+27},
+	{10, 36, -3.93847464679496e+29},
+	{14, 22, 1.47073407024852e+24},
+	{18, 28, -4.26391250432059e+31},
+	{20, 36, 1.94509340621077e+38},
+	{22, 16, 6.66212132114896e+23},
+	{22, 28, 7.06777016552858e+33},
+	{24, 36, 1.75563621975576e+41},
+	{28, 16, 1.08408607429124e+28},
+	{28, 36, 7.30872705175151e+43},
+	{32, 10, 1.5914584739887e+24},
+	{32, 28, 3.77121605943324e+40},
+}
+
+const (
+	p3aMinI = 0
+	p3aMaxI = 32
+)
+
+const (
+	p3aMinJ = 0
+	p3aMaxJ = 36
+)
+
+// p3b holds the 35 terms of p_3b(h,s).csv.
+var p3b = []term{
+	{-12, 2, 1.25244360717979e-13},
+	{-12, 10, -0.0126599322553713},
+	{-12, 12, 5.06878030140626},
+	{-12, 14, 31.7847171154202},
+	{-12, 20, -391041.161399932},
+	{-10, 2, -9.75733406392044e-11},
+	{-10, 10, -18.6312419488279},
+	{-10, 14, 510.973543414101},
+	{-10, 18, 373847.005822362},
+	{-8, 2, 2.99804024666572e-08},
+	{-8, 8, 20.0544393820342},
+	{-6, 2, -4.98030487662829e-06},
+	{-6, 6, -10.230180636003},
+	{-6, 7, 55.2819126990325},
+	{-6, 8, -206.211367510878},
+	{-5, 10, -7940.12232324823},
+	{-4, 4, 7.82248472028153},
+	{-4, 5, -58.6544326902468},
+	{-4, 8, 3550.73647696481},
+	{-3, 1, -0.000115303107290162},
+	{-3, 3, -1.75092403171802},
+	{-3, 5, 257.98168774816},
+	{-3, 6, -727.048374179467},
+	{-2, 0, 0.000121644822609198},
+	{-2, 1, 0.0393137871762692},
+	{-1, 0, 0.00704181005909296},
+	{0, 3, -82.910820069811},
+	{2, 0, -0.265250178818131},
+	{2, 1, 13.7531682453991},
+	{4, 1, -52.2394090753046},
+	{6, 1, 2405.56298941048},
+	{8, 1, -22736.1631268929},
+	{10, 1, 89074.6343932567},
+	{14, 3, -2.39234565822486e+07},
+	{14, 7, 5.68795808129714e+09},
+}
+
+const (
+	p3bMinI = -12
+	p3bMaxI = 14
+)
+
+const (
+	p3bMinJ = 0
+	p3bMaxJ = 20
+)
+
+// T3aPH holds the 31 terms of T_3a(p,h).csv.
+var T3aPH = []term{
+	{-12, 0, -1.33645667811215e-07},
+	{-12, 1, 4.55912656802978e-06},
+	{-12, 2, -1.46294640700979e-05},
+	{-12, 6, 0.0063934131297008},
+	{-12, 14, 372.783927268847},
+	{-12, 16, -7186.54377460447},
+	{-12, 20, 573494.7521034},
+	{-12, 22, -2.67569329111139e+06},
+	{-10, 1, -3.34066283302614e-05},
+	{-10, 5, -0.0245479214069597},
+	{-10, 12, 47.8087847764996},
+	{-8, 0, 7.64664131818904e-06},
+	{-8, 2, 0.00128350627676972},
+	{-8, 4, 0.0171219081377331},
+	{-8, 10, -8.51007304583213},
+	{-5, 2, -0.0136513461629781},
+	{-3, 0, -3.84460997596657e-06},
+	{-2, 1, 0.00337423807911655},
+	{-2, 3, -0.551624873066791},
+	{-2, 4, 0.72920227710747},
+	{-1, 0, -0.00992522757376041},
+	{-1, 2, -0.119308831407288},
+	{0, 0, 0.793929190615421},
+	{0, 1, 0.454270731799386},
+	{1, 1, 0.20999859125991},
+	{3, 0, -0.00642109823904738},
+	{3, 1, -0.023515586860454},
+	{4, 0, 0.00252233108341612},
+	{4, 3, -0.00764885133368119},
+	{10, 4, 0.0136176427574291},
+	{12, 5, -0.0133027883575669},
+}
+
+const (
+	T3aPHMinI = -12
+	T3aPHMaxI = 12
+)
+
+const (
+	T3aPHMinJ = 0
+	T3aPHMaxJ = 22
+)
+
+// V3aPH holds the 32 terms of v_3a(p,h).csv.
+var V3aPH = []term{
+	{-12, 6, 0.0052994406266028},
+	{-12, 8, -0.170099690234461},
+	{-12, 12, 11.1323814312927},
+	{-12, 18, -2178.98123145125},
+	{-10, 4, -0.000506061827980875},
+	{-10, 7, 0.556495239685324},
+	{-10, 10, -9.43672726094016},
+	{-8, 5, -0.297856807561527},
+	{-8, 12, 93.9353943717186},
+	{-6, 3, 0.0192944939465981},
+	{-6, 4, 0.421740664704763},
+	{-6, 22, -3.6891412628233e+06},
+	{-4, 2, -0.00737566847600639},
+	{-4, 3, -0.354753242424366},
+	{-3, 7, -1.99768169338727},
+	{-2, 3, 1.15456297059049},
+	{-2, 16, 5683.6687581596},
+	{-1, 0, 0.00808169540124668},
+	{-1, 1, 0.172416341519307},
+	{-1, 2, 1.04270175292927},
+	{-1, 3, -0.297691372792847},
+	{0, 0, 0.560394465163593},
+	{0, 1, 0.275234661176914},
+	{1, 0, -0.148347894866012},
+	{1, 1, -0.0651142513478515},
+	{1, 2, -2.92468715386302},
+	{2, 0, 0.0664876096952665},
+	{2, 2, 3.52335014263844},
+	{3, 0, -0.0146340792313332},
+	{4, 2, -2.24503486668184},
+	{5, 2, 1.10533464706142},
+	{8, 2, -0.0408757344495612},
+}
+
+const (
+	V3aPHMinI = -12
+	V3aPHMaxI = 8
+)
+
+const (
+	V3aPHMinJ = 0
+	V3aPHMaxJ = 22
+)
+
+// T3bPH holds the 33 terms of T_3b(p,h).csv.
+var T3bPH = []term{
+	{-12, 0, 3.2325457364492e-05},
+	{-12, 1, -0.000127575556587181},
+	{-10, 0, -0.000475851877356068},
+	{-10, 1, 0.00156183014181602},
+	{-10, 5, 0.105724860113781},
+	{-10, 10, -85.8514221132534},
+	{-10, 12, 724.140095480911},
+	{-8, 0, 0.00296475810273257},
+	{-8, 1, -0.00592721983365988},
+	{-8, 2, -0.0126305422818666},
+	{-8, 4, -0.115716196364853},
+	{-8, 10, 84.9000969739595},
+	{-6, 0, -0.0108602260086615},
+	{-6, 1, 0.0154304475328851},
+	{-6, 2, 0.0750455441524466},
+	{-4, 0, 0.0252520973612982},
+	{-4, 1, -0.0602507901232996},
+	{-3, 5, -3.07622221350501},
+	{-2, 0, -0.0574011959864879},
+	{-2, 4, 5.03471360939849},
+	{-1, 2, -0.925081888584834},
+	{-1, 4, 3.91882917733755},
+	{-1, 6, -77.314600713019},
+	{-1, 10, 9493.08762098587},
+	{-1, 14, -1.41043719679409e+06},
+	{-1, 16, 8.49166230819026e+06},
+	{0, 0, 0.861095729446704},
+	{0, 2, 0.32334644281172},
+	{1, 1, 0.873281936020439},
+	{3, 1, -0.436653048526683},
+	{5, 1, 0.286596714529479},
+	{6, 1, -0.131778331276228},
+	{8, 1, 0.00676682064330275},
+}
+
+const (
+	T3bPHMinI = -12
+	T3bPHMaxI = 8
+)
+
+const (
+	T3bPHMinJ = 0
+	T3bPHMaxJ = 16
+)
+
+// V3bPH holds the 30 terms of v_3b(p,h).csv.
+var V3bPH = []term{
+	{-12, 0, -2.25196934336318e-09},
+	{-12, 1, 1.40674363313486e-08},
+	{-8, 0, 2.3378408528056e-06},
+	{-8, 1, -3.31833715229001e-05},
+	{-8, 3, 0.00107956778514318},
+	{-8, 6, -0.271382067378863},
+	{-8, 7, 1.07202262490333},
+	{-8, 8, -0.853821329075382},
+	{-6, 0, -2.15214194340526e-05},
+	{-6, 1, 0.00076965608822273},
+	{-6, 2, -0.00431136580433864},
+	{-6, 5, 0.453342167309331},
+	{-6, 6, -0.507749535873652},
+	{-6, 10, -100.475154528389},
+	{-4, 3, -0.219201924648793},
+	{-4, 6, -3.21087965668917},
+	{-4, 10, 60.7567815637771},
+	{-3, 0, 0.000557686450685932},
+	{-3, 2, 0.18749904002955},
+	{-2, 1, 0.00905368030448107},
+	{-2, 2, 0.285417173048685},
+	{-1, 0, 0.0329924030996098},
+	{-1, 1, 0.239897419685483},
+	{-1, 4, 4.82754995951394},
+	{-1, 5, -11.8035753702231},
+	{0, 0, 0.169490044091791},
+	{1, 0, -0.01799672225077787},
+	{1, 1, 0.0371810116332674},
+	{2, 2, -0.0536288335065096},
+	{2, 6, 1.6069710109252},
+}
+
+const (
+	V3bPHMinI = -12
+	V3bPHMaxI = 2
+)
+
+const (
+	V3bPHMinJ = 0
+	V3bPHMaxJ = 10
+)
+
+// T3aPS holds the 33 terms of T_3a(p,s).csv.
+var T3aPS = []term{
+	{-12, 28, 1.50042008263875e+09},
+	{-12, 32, -1.59397258480424e+11},
+	{-10, 4, 0.000502181140217975},
+	{-10, 10, -67.2057767855466},
+	{-10, 12, 1450.58545404456},
+	{-10, 14, -8238.8953488889},
+	{-8, 5, -0.154852214233853},
+	{-8, 7, 11.2305046746695},
+	{-8, 8, -29.7000213482822},
+	{-8, 28, 4.38565132635495e+10},
+	{-6, 2, 0.001378378386355464},
+	{-6, 6, -2.97478527157462},
+	{-6, 32, 9.71777947349413e+12},
+	{-5, 0, -5.71527767052398e-05},
+	{-5, 14, 28830.794977842},
+	{-5, 32, -7.44428289262703e+13},
+	{-4, 6, 12.8017324848921},
+	{-4, 10, -368.275545889071},
+	{-4, 36, 6.64768904779177e+15},
+	{-2, 1, 0.044935925195888},
+	{-2, 4, -4.22897836099655},
+	{-1, 1, -0.240614376434179},
+	{-1, 6, -4.74341365254924},
+	{0, 0, 0.72409399912611},
+	{0, 1, 0.923874349695897},
+	{0, 4, 3.99043655281315},
+	{1, 0, 0.0384066651868009},
+	{2, 0, -0.00359344365571848},
+	{2, 3, -0.735196448821653},
+	{3, 2, 0.188367048396131},
+	{8, 0, 0.000141064266818704},
+	{8, 1, -0.00257418501496337},
+	{10, 2, 0.00123220024851555},
+}
+
+const (
+	T3aPSMinI = -12
+	T3aPSMaxI = 10
+)
+
+const (
+	T3aPSMinJ = 0
+	T3aPSMaxJ = 36
+)
+
+// V3aPS holds the 28 terms of v_3a(p,s).csv.
+var V3aPS = []term{
+	{-12, 10, 79.5544074093975},
+	{-12, 12, -2382.6124298459},
+	{-12, 14, 17681.3100617787},
+	{-10, 4, -0.00110524727080379},
+	{-10, 8, -15.3213833655326},
+	{-10, 10, 297.544599376982},
+	{-10, 20, -3.50315206871242e+07},
+	{-8, 5, 0.277513761062119},
+	{-8, 6, -0.523964271036888},
+	{-8, 14, -148011.182995403},
+	{-8, 16, 1.60014899374266e+06},
+	{-6, 28, 1.70802322663427e+12},
+	{-5, 1, 0.000246866996006494},
+	{-4, 5, 1.6532608479798},
+	{-3, 2, -0.118008384666987},
+	{-3, 4, 2.537986423559},
+	{-2, 3, 0.965127704669424},
+	{-2, 8, -28.2172420532826},
+	{-1, 1, 0.203224612353823},
+	{-1, 2, 1.10648186063513},
+	{0, 0, 0.52612794845128},
+	{0, 1, 0.277000018736321},
+	{0, 3, 1.08153340501132},
+	{1, 0, -0.0744127885357893},
+	{2, 0, 0.0164094443541384},
+	{4, 2, -0.0680468275301065},
+	{5, 2, 0.025798857610164},
+	{6, 0, -0.000145749861944416},
+}
+
+const (
+	V3aPSMinI = -12
+	V3aPSMaxI = 6
+)
+
+const (
+	V3aPSMinJ = 0
+	V3aPSMaxJ = 28
+)
+
+// T3bPS holds the 28 terms of T_3b(p,s).csv.
+var T3bPS = []term{
+	{-12, 1, 0.52711170160166},
+	{-12, 3, -40.1317830052742},
+	{-12, 4, 153.020073134484},
+	{-12, 7, -2247.99398218827},
+	{-8, 0, -0.193993484669048},
+	{-8, 1, -1.40467557893768},
+	{-8, 3, 42.6799878114024},
+	{-6, 0, 0.752810643416743},
+	{-6, 2, 22.6657238616417},
+	{-6, 4, -622.873556909932},
+	{-5, 0, -0.660823667935396},
+	{-5, 1, 0.841267087271658},
+	{-5, 2, -25.3717501764397},
+	{-5, 4, 485.7089635322948},
+	{-5, 6, 880.531517490555},
+	{-4, 12, 2.65015592794626e+06},
+	{-3, 1, -0.359287150025783},
+	{-3, 6, -656.991567673753},
+	{-2, 2, 2.41768149185367},
+	{0, 0, 0.856873461222588},
+	{2, 1, 0.655143675313458},
+	{3, 1, -0.213535213206406},
+	{4, 0, 0.00562974957606348},
+	{6, 15, -3.16955725450471e+14},
+	{6, 20, -0.000699997000152457},
+	{8, 3, 0.0119845803210767},
+	{12, 1, 1.93848122022095e-05},
+	{14, 2, -2.15095749182309e-05},
+}
+
+const (
+	T3bPSMinI = -12
+	T3bPSMaxI = 14
+)
+
+const (
+	T3bPSMinJ = 0
+	T3bPSMaxJ = 20
+)
+
+// V3bPS holds the 31 terms of v_3b(p,s).csv.
+var V3bPS = []term{
+	{-12, 0, 5.91599780322238e-05},
+	{-12, 1, -0.00185465997137856},
+	{-12, 2, 0.0104190510480013},
+	{-12, 3, 0.0059864730203859},
+	{-12, 5, -0.771391189901699},
+	{-12, 6, 1.72549765557036},
+	{-10, 0, -0.000467076079846526},
+	{-10, 1, 0.0134533823384439},
+	{-10, 2, -0.0808094336805495},
+	{-10, 4, 0.508139374365767},
+	{-8, 0, 0.00128584643361683},
+	{-5, 1, -1.63899353915435},
+	{-5, 2, 5.86938199318063},
+	{-5, 3, -2.92466667918613},
+	{-4, 0, -0.00614076301499537},
+	{-4, 1, 5.76199014049172},
+	{-4, 2, -12.1613320606788},
+	{-4, 3, 1.67637540957944},
+	{-3, 1, -7.44135838773463},
+	{-2, 0, 0.0378168091437659},
+	{-2, 1, 4.01432203027688},
+	{-2, 2, 16.0279837479185},
+	{-2, 3, 3.17848779347728},
+	{-2, 4, -3.58362310304853},
+	{-2, 12, -1.15995260446827e+06},
+	{0, 0, 0.199256573577909},
+	{0, 1, -0.122270624794624},
+	{0, 2, -19.1449143716586},
+	{1, 0, -0.0150448002905284},
+	{1, 2, 14.6407900162154},
+	{2, 2, -3.2747778718823},
+}
+
+const (
+	V3bPSMinI = -12
+	V3bPSMaxI = 2
+)
+
+const (
+	V3bPSMinJ = 0
+	V3bPSMaxJ = 12
+)
+
+// h3abN holds the coefficients n1..n4 of h_3ab(p).csv; h3abN[0] is unused.
+var h3abN = [5]float64{
+	0,
+	2014.64004206875,
+	3.74696550136983,
+	-0.0219921901054187,
+	8.7513168600995e-05,
+}
@@ -0,0 +1,213 @@
+package region3
+
+import (
+	"math"
+
+	"github.com/somepgs/steamprops/internal/calc_core"
+	"github.com/somepgs/steamprops/internal/calc_core/region4"
+	"github.com/somepgs/steamprops/propserr"
+)
+
+// Tc is the critical temperature (K).
+const Tc = referT
+
+// phi holds the dimensionless Helmholtz free energy φ(δ, τ) of Region 3 and
+// its partial derivatives.
+type phi struct {
+	f, d, dd, t, tt, dt float64
+}
+
+// helmholtz evaluates φ = n1 ln δ + Σ ni δ^Ii τ^Ji and its derivatives.
+func helmholtz(delta, tau float64) phi {
+	var dp [termsMaxI + 1]float64
+	var tp [termsMaxJ + 1]float64
+	dp[0], tp[0] = 1, 1
+	for i := 1; i <= termsMaxI; i++ {
+		dp[i] = dp[i-1] * delta
+	}
+	for j := 1; j <= termsMaxJ; j++ {
+		tp[j] = tp[j-1] * tau
+	}
+
+	var r phi
+	n1 := terms[0].N
+	r.f = n1 * math.Log(delta)
+	r.d = n1 / delta
+	r.dd = -n1 / (delta * delta)
+	for _, t := range terms[1:] {
+		di := dp[t.I]
+		tj := tp[t.J]
+		I, J := float64(t.I), float64(t.J)
+		r.f += t.N * di * tj
+		if t.I != 0 {
+			r.d += t.N * I * di / delta * tj
+			r.dd += t.N * I * (I - 1) * di / (delta * delta) * tj
+		}
+		if t.J != 0 {
+			r.t += t.N * di * J * tj / tau
+			r.tt += t.N * di * J * (J - 1) * tj / (tau * tau)
+			if t.I != 0 {
+				r.dt += t.N * I * di / delta * J * tj / tau
+			}
+		}
+	}
+	return r
+}
+
+// pressureRhoT returns p (Pa) and (∂p/∂ρ)_T (Pa·m^3/kg) at density rho (kg/m^3) and T (K).
+func pressureRhoT(rho, T float64) (float64, float64) {
+	delta := rho / referRho
+	f := helmholtz(delta, referT/T)
+	p := rho * referR * T * delta * f.d * 1000.0
+	dp := referR * T * (2*delta*f.d + delta*delta*f.dd) * 1000.0
+	return p, dp
+}
+
+// PropertiesRhoT evaluates the Region 3 fundamental equation f(ρ,T) directly.
+// Inputs: rho in kg/m^3, T in Celsius. Returns pressure (Pa) and properties.
+// The range of Region 3 is not checked, so the caller is responsible for it.
+func PropertiesRhoT(rho, tCelsius float64) (float64, calc_core.Properties, error) {
+	f, err := Derivatives(rho, tCelsius)
+	if err != nil {
+		return 0, calc_core.Properties{}, err
+	}
+	p := f.Pressure()
+	props := f.Properties()
+	w2 := f.SpeedOfSoundSquared()
+
+	for _, v := range []float64{p, props.SpecificInternalEnergy, props.SpecificEntropy, props.SpecificEnthalpy,
+		props.SpecificIsochoricHeatCapacity, props.SpecificIsobaricHeatCapacity, w2} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return 0, calc_core.Properties{}, &propserr.Error{Code: propserr.NonPhysical, Region: 3, Detail: "нечисловые значения свойств"}
+		}
+	}
+	if w2 <= 0 {
+		return 0, calc_core.Properties{}, &propserr.Error{Code: propserr.NonPhysical, Region: 3, Quantity: "rho", Value: rho, Unit: "kg/m³", Detail: "механически неустойчивое состояние при T = %.2f K", Args: []interface{}{f.T}}
+	}
+	return p, props, nil
+}
+
+// Derivatives evaluates φ(δ, τ) and its derivatives at rho in kg/m^3 and T in
+// Celsius. Like PropertiesRhoT it does not check the range of Region 3.
+func Derivatives(rho, tCelsius float64) (calc_core.Helmholtz, error) {
+	if !(rho > 0) || math.IsInf(rho, 0) {
+		return calc_core.Helmholtz{}, propserr.Range(propserr.InvalidInput, 3, "rho", rho, "kg/m³", propserr.Min, 0)
+	}
+	T := tCelsius + 273.15
+	if !(T > 0) || math.IsInf(T, 0) {
+		return calc_core.Helmholtz{}, propserr.Range(propserr.InvalidInput, 3, "T", tCelsius, "°C", propserr.Min, -273.15)
+	}
+
+	delta := rho / referRho
+	tau := referT / T
+	f := helmholtz(delta, tau)
+	return calc_core.Helmholtz{
+		T: T, Rho: rho, R: referR, Delta: delta, Tau: tau,
+		F: f.f, FDelta: f.d, FDeltaDelta: f.dd, FTau: f.t, FTauTau: f.tt, FDeltaTau: f.dt,
+	}, nil
+}
+
+// Density limits for the search on the p(ρ) isotherm, kg/m^3.
+const (
+	densityMin  = 20.0
+	densityMax  = 800.0 // p(800 kg/m^3) > 100 MPa in all of Region 3
+	densityStep = 5.0
+)
+
+// solveDensity finds ρ (kg/m^3) with p(ρ,T) = p on an isotherm T (K).
+// Below Tc the isotherm has a van der Waals loop: the liquid-like root is
+// searched from high densities downwards and the vapor-like root from low
+// densities upwards, each up to the spinodal. Above Tc the isotherm is
+// monotonic and liquid is ignored.
+func solveDensity(T, p float64, liquid bool) (float64, error) {
+	if T >= Tc {
+		liquid = false
+	}
+	step := densityStep
+	start := densityMin
+	if liquid {
+		step, start = -densityStep, densityMax
+	}
+
+	// Bracket the root, stopping at the spinodal (∂p/∂ρ = 0)
+	a := start
+	pa, dpa := pressureRhoT(a, T)
+	if (pa-p)*step > 0 || dpa <= 0 {
+		return 0, &propserr.Error{Code: propserr.NoSolution, Region: 3, Quantity: "p", Value: p, Unit: "Pa", Detail: "нет плотности при T = %.2f K", Args: []interface{}{T}}
+	}
+	var b float64
+	found := false
+	for b = a + step; b >= densityMin && b <= densityMax; b += step {
+		pb, dpb := pressureRhoT(b, T)
+		if dpb <= 0 {
+			// Past the spinodal: the extremum of p lies between a and b
+			lo, hi := a, b
+			for i := 0; i < 60; i++ {
+				mid := 0.5 * (lo + hi)
+				if _, d := pressureRhoT(mid, T); d > 0 {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			if ps, _ := pressureRhoT(lo, T); (ps-p)*step < 0 {
+				return 0, &propserr.Error{Code: propserr.NoSolution, Region: 3, Quantity: "p", Value: p, Unit: "Pa", Detail: "давление за спинодалью при T = %.2f K", Args: []interface{}{T}}
+			}
+			b = lo
+			found = true
+			break
+		}
+		if (pb-p)*step >= 0 {
+			found = true
+			break
+		}
+		a = b
+	}
+	if !found {
+		return 0, &propserr.Error{Code: propserr.NoSolution, Region: 3, Quantity: "p", Value: p, Unit: "Pa", Detail: "нет плотности при T = %.2f K", Args: []interface{}{T}}
+	}
+
+	// Newton iteration safeguarded by bisection on [a, b]
+	lo, hi := math.Min(a, b), math.Max(a, b)
+	rho := 0.5 * (lo + hi)
+	for i := 0; i < 100; i++ {
+		pr, dp := pressureRhoT(rho, T)
+		if pr < p {
+			lo = rho
+		} else {
+			hi = rho
+		}
+		next := rho - (pr-p)/dp
+		if !(dp > 0) || next <= lo || next >= hi {
+			next = 0.5 * (lo + hi)
+		}
+		if math.Abs(next-rho) <= 1e-13*rho {
+			return next, nil
+		}
+		rho = next
+	}
+	return rho, nil
+}
+
+// SaturatedDensities returns the densities (kg/m^3) of saturated liquid and
+// vapor at T in Celsius from 623.15 K up to Tc: the Region 3 roots at the
+// Region 4 saturation pressure.
+func SaturatedDensities(tCelsius float64) (float64, float64, error) {
+	T := tCelsius + 273.15
+	if T < 623.15 || T >= Tc {
+		return 0, 0, &propserr.Error{Code: propserr.RegionNotApplicable, Region: 3, Quantity: "T", Value: T, Unit: "K", Detail: "линия насыщения Region 3 определена при 623.15 ≤ T < %.3f K", Args: []interface{}{Tc}}
+	}
+	psat, err := region4.SaturationPressure(T)
+	if err != nil {
+		return 0, 0, err
+	}
+	liquid, err := solveDensity(T, psat, true)
+	if err != nil {
+		return 0, 0, err
+	}
+	vapor, err := solveDensity(T, psat, false)
+	if err != nil {
+		return 0, 0, err
+	}
+	return liquid, vapor, nil
+}
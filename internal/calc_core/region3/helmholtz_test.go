@@ -0,0 +1,88 @@
+package region3
+
+import (
+	"math"
+	"testing"
+)
+
+// IF-97, Table 33: verification values for f(ρ,T)
+var rhoTReference = []struct {
+	T, rho         float64 // K, kg/m^3
+	p, h, u, s, cp float64 // Pa, kJ/kg, kJ/kg, kJ/(kg*K), kJ/(kg*K)
+	w              float64 // m/s
+}{
+	{650, 500, 25.5837018e6, 1863.43019, 1812.26279, 4.05427273, 13.8935717, 502.005554},
+	{650, 200, 22.2930643e6, 2375.12401, 2263.65868, 4.85438792, 44.6579342, 383.444594},
+	{750, 500, 78.3095639e6, 2258.68845, 2102.06932, 4.46971906, 6.34165359, 760.696041},
+}
+
+func TestPropertiesRhoT(t *testing.T) {
+	for _, ref := range rhoTReference {
+		p, props, err := PropertiesRhoT(ref.rho, ref.T-273.15)
+		if err != nil {
+			t.Fatalf("PropertiesRhoT(%g, %g K) error: %v", ref.rho, ref.T, err)
+		}
+		check := func(name string, got, want float64) {
+			if math.Abs(got/want-1) > 1e-8 {
+				t.Errorf("T=%g K, rho=%g: %s = %.9g, want %.9g", ref.T, ref.rho, name, got, want)
+			}
+		}
+		check("p", p, ref.p)
+		check("h", props.SpecificEnthalpy, ref.h)
+		check("u", props.SpecificInternalEnergy, ref.u)
+		check("s", props.SpecificEntropy, ref.s)
+		check("cp", props.SpecificIsobaricHeatCapacity, ref.cp)
+		check("w", props.SpeedOfSound, ref.w)
+	}
+
+	if _, _, err := PropertiesRhoT(0, 400); err == nil {
+		t.Errorf("expected error for zero density")
+	}
+}
+
+func TestCalculateDensity(t *testing.T) {
+	for _, ref := range rhoTReference {
+		props, err := Calculate(ref.T-273.15, ref.p)
+		if err != nil {
+			t.Fatalf("Calculate(%g K, %g Pa) error: %v", ref.T, ref.p, err)
+		}
+		// The reference pressures have 9 digits; near Tc ρ is 20 times more sensitive
+		if math.Abs(props.Density/ref.rho-1) > 1e-7 {
+			t.Errorf("T=%g K, p=%g Pa: rho = %.9g, want %g", ref.T, ref.p, props.Density, ref.rho)
+		}
+	}
+
+	if _, err := Calculate(630-273.15, 16.6e6); err == nil {
+		t.Errorf("expected error below the B23 boundary")
+	}
+}
+
+func TestSaturatedDensities(t *testing.T) {
+	for _, T := range []float64{623.15, 630, 640, 645, 647} {
+		rhoL, rhoV, err := SaturatedDensities(T - 273.15)
+		if err != nil {
+			t.Fatalf("SaturatedDensities(%g K) error: %v", T, err)
+		}
+		if !(rhoL > 322 && rhoV < 322) {
+			t.Errorf("T=%g K: rho' = %g, rho'' = %g", T, rhoL, rhoV)
+		}
+		// Phase equilibrium: equal Gibbs free energy g = h - T*s
+		_, liquid, err := PropertiesRhoT(rhoL, T-273.15)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, vapor, err := PropertiesRhoT(rhoV, T-273.15)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gL := liquid.SpecificEnthalpy - T*liquid.SpecificEntropy
+		gV := vapor.SpecificEnthalpy - T*vapor.SpecificEntropy
+		if math.Abs(gL-gV) > 0.05 {
+			t.Errorf("T=%g K: g' - g'' = %g kJ/kg", T, gL-gV)
+		}
+	}
+
+	if _, _, err := SaturatedDensities(300); err == nil {
+		t.Errorf("expected error below 623.15 K")
+	}
+}
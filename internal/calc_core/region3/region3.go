@@ -1,16 +1,12 @@
 package region3
 
 import (
-	"bufio"
-	"embed"
-	"errors"
-	"fmt"
 	"math"
-	"strconv"
-	"strings"
 
 	"github.com/somepgs/steamprops/internal/calc_core"
 	"github.com/somepgs/steamprops/internal/calc_core/bounds"
+	"github.com/somepgs/steamprops/internal/calc_core/region4"
+	"github.com/somepgs/steamprops/propserr"
 )
 
 const (
@@ -19,14 +15,7 @@ const (
 	referRho = 322.0    // kg/m^3
 )
 
-//go:embed iapws-if97-region3.csv p_3a(h,s).csv p_3b(h,s).csv T_3a(p,h).csv v_3a(p,h).csv T_3b(p,h).csv v_3b(p,h).csv T_3a(p,s).csv v_3a(p,s).csv T_3b(p,s).csv v_3b(p,s).csv h_3ab(p).csv
-var coeff embed.FS
-
-type term struct {
-	I int
-	J int
-	N float64
-}
+//go:generate go run ../internal/coeffgen terms=iapws-if97-region3.csv:ijn p3a=p_3a(h,s).csv:ijn p3b=p_3b(h,s).csv:ijn T3aPH=T_3a(p,h).csv:ijn V3aPH=v_3a(p,h).csv:ijn T3bPH=T_3b(p,h).csv:ijn V3bPH=v_3b(p,h).csv:ijn T3aPS=T_3a(p,s).csv:ijn V3aPS=v_3a(p,s).csv:ijn T3bPS=T_3b(p,s).csv:ijn V3bPS=v_3b(p,s).csv:ijn h3abN=h_3ab(p).csv:n
 
 type backward struct {
 	sub string
@@ -45,292 +34,8 @@ type subrange struct {
 	smax float64
 }
 
-var (
-	loadedMain bool
-	terms      []term
-
-	loadedHS bool
-	p3a      []term
-	p3b      []term
-
-	loadedPH bool
-	T3aPH    []term
-	V3aPH    []term
-	T3bPH    []term
-	V3bPH    []term
-
-	loadedPS bool
-	T3aPS    []term
-	V3aPS    []term
-	T3bPS    []term
-	V3bPS    []term
-
-	loadedH3ab bool
-	h3abN      []float64 // 1-based coefficients for polynomial in p*
-)
-
-func loadMainOnce() error {
-	if loadedMain {
-		return nil
-	}
-	f, err := coeff.Open("iapws-if97-region3.csv")
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-	scanner := bufio.NewScanner(f)
-	first := true
-	for scanner.Scan() {
-		line := scanner.Text()
-		if first {
-			first = false
-			continue
-		}
-		parts := strings.Split(line, ",")
-		if len(parts) != 4 {
-			return fmt.Errorf("invalid region3 line: %s", line)
-		}
-		Ii := strings.TrimSpace(parts[1])
-		Ji := strings.TrimSpace(parts[2])
-		i := 0
-		j := 0
-		if Ii != "" {
-			v, err := strconv.Atoi(Ii)
-			if err != nil {
-				return err
-			}
-			i = v
-		}
-		if Ji != "" {
-			v, err := strconv.Atoi(Ji)
-			if err != nil {
-				return err
-			}
-			j = v
-		}
-		n, err := strconv.ParseFloat(strings.TrimSpace(parts[3]), 64)
-		if err != nil {
-			return err
-		}
-		terms = append(terms, term{I: i, J: j, N: n})
-	}
-	if err := scanner.Err(); err != nil {
-		return err
-	}
-	loadedMain = true
-	return nil
-}
-
-func loadHSOnce() error {
-	if loadedHS {
-		return nil
-	}
-	load := func(name string, dest *[]term) error {
-		f, err := coeff.Open(name)
-		if err != nil {
-			return err
-		}
-		defer f.Close()
-		scanner := bufio.NewScanner(f)
-		first := true
-		for scanner.Scan() {
-			line := scanner.Text()
-			if first {
-				first = false
-				continue
-			}
-			parts := strings.Split(line, ",")
-			if len(parts) != 4 {
-				return fmt.Errorf("invalid %s line: %s", name, line)
-			}
-			Ii := strings.TrimSpace(parts[1])
-			Ji := strings.TrimSpace(parts[2])
-			i := 0
-			j := 0
-			if Ii != "" {
-				v, err := strconv.Atoi(Ii)
-				if err != nil {
-					return err
-				}
-				i = v
-			}
-			if Ji != "" {
-				v, err := strconv.Atoi(Ji)
-				if err != nil {
-					return err
-				}
-				j = v
-			}
-			n, err := strconv.ParseFloat(strings.TrimSpace(parts[3]), 64)
-			if err != nil {
-				return err
-			}
-			*dest = append(*dest, term{I: i, J: j, N: n})
-		}
-		return scanner.Err()
-	}
-	if err := load("p_3a(h,s).csv", &p3a); err != nil {
-		return err
-	}
-	if err := load("p_3b(h,s).csv", &p3b); err != nil {
-		return err
-	}
-	loadedHS = true
-	return nil
-}
-
-var ErrNotImplemented = errors.New("region3 equation of state not implemented yet")
-
-// ---- Backward tables loaders ----
-
-func loadPHOnce() error {
-	if loadedPH {
-		return nil
-	}
-	load := func(name string, dest *[]term) error {
-		f, err := coeff.Open(name)
-		if err != nil {
-			return err
-		}
-		defer f.Close()
-		scanner := bufio.NewScanner(f)
-		first := true
-		for scanner.Scan() {
-			line := scanner.Text()
-			if first {
-				first = false
-				continue
-			}
-			parts := strings.Split(line, ",")
-			if len(parts) != 4 {
-				return fmt.Errorf("invalid %s line: %s", name, line)
-			}
-			i, err := strconv.Atoi(strings.TrimSpace(parts[1]))
-			if err != nil {
-				return err
-			}
-			j, err := strconv.Atoi(strings.TrimSpace(parts[2]))
-			if err != nil {
-				return err
-			}
-			n, err := strconv.ParseFloat(strings.TrimSpace(parts[3]), 64)
-			if err != nil {
-				return err
-			}
-			*dest = append(*dest, term{I: i, J: j, N: n})
-		}
-		return scanner.Err()
-	}
-	if err := load("T_3a(p,h).csv", &T3aPH); err != nil {
-		return err
-	}
-	if err := load("v_3a(p,h).csv", &V3aPH); err != nil {
-		return err
-	}
-	if err := load("T_3b(p,h).csv", &T3bPH); err != nil {
-		return err
-	}
-	if err := load("v_3b(p,h).csv", &V3bPH); err != nil {
-		return err
-	}
-	loadedPH = true
-	return nil
-}
-
-func loadPSOnce() error {
-	if loadedPS {
-		return nil
-	}
-	load := func(name string, dest *[]term) error {
-		f, err := coeff.Open(name)
-		if err != nil {
-			return err
-		}
-		defer f.Close()
-		scanner := bufio.NewScanner(f)
-		first := true
-		for scanner.Scan() {
-			line := scanner.Text()
-			if first {
-				first = false
-				continue
-			}
-			parts := strings.Split(line, ",")
-			if len(parts) != 4 {
-				return fmt.Errorf("invalid %s line: %s", name, line)
-			}
-			i, err := strconv.Atoi(strings.TrimSpace(parts[1]))
-			if err != nil {
-				return err
-			}
-			j, err := strconv.Atoi(strings.TrimSpace(parts[2]))
-			if err != nil {
-				return err
-			}
-			n, err := strconv.ParseFloat(strings.TrimSpace(parts[3]), 64)
-			if err != nil {
-				return err
-			}
-			*dest = append(*dest, term{I: i, J: j, N: n})
-		}
-		return scanner.Err()
-	}
-	if err := load("T_3a(p,s).csv", &T3aPS); err != nil {
-		return err
-	}
-	if err := load("v_3a(p,s).csv", &V3aPS); err != nil {
-		return err
-	}
-	if err := load("T_3b(p,s).csv", &T3bPS); err != nil {
-		return err
-	}
-	if err := load("v_3b(p,s).csv", &V3bPS); err != nil {
-		return err
-	}
-	loadedPS = true
-	return nil
-}
-
-func loadH3abOnce() error {
-	if loadedH3ab {
-		return nil
-	}
-	f, err := coeff.Open("h_3ab(p).csv")
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-	scanner := bufio.NewScanner(f)
-	first := true
-	var coef []float64
-	for scanner.Scan() {
-		line := scanner.Text()
-		if first {
-			first = false
-			continue
-		}
-		parts := strings.Split(line, ",")
-		if len(parts) != 2 {
-			return fmt.Errorf("invalid h_3ab line: %s", line)
-		}
-		// parts[0] index, parts[1] value
-		val, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
-		if err != nil {
-			return err
-		}
-		coef = append(coef, val)
-	}
-	if err := scanner.Err(); err != nil {
-		return err
-	}
-	// 1-based
-	h3abN = make([]float64, len(coef)+1)
-	for i, v := range coef {
-		h3abN[i+1] = v
-	}
-	loadedH3ab = true
-	return nil
-}
+// errInvalidSubregion is returned for a subregion other than 3a and 3b
+var errInvalidSubregion = &propserr.Error{Code: propserr.Internal, Region: 3, Detail: "неизвестная подобласть"}
 
 // ---- Backward evaluators ----
 
@@ -384,22 +89,29 @@ const (
 	sigShiftV3bPS = -0.816
 )
 
-func powi(x float64, n int) float64 {
-	return math.Pow(x, float64(n))
-}
+// Exponent bounds over all backward tables, so that evalSeries keeps its
+// power tables on the stack whatever table it is given.
+const (
+	backwardMinI = min(p3aMinI, p3bMinI, T3aPHMinI, V3aPHMinI, T3bPHMinI, V3bPHMinI, T3aPSMinI, V3aPSMinI, T3bPSMinI, V3bPSMinI)
+	backwardMaxI = max(p3aMaxI, p3bMaxI, T3aPHMaxI, V3aPHMaxI, T3bPHMaxI, V3bPHMaxI, T3aPSMaxI, V3aPSMaxI, T3bPSMaxI, V3bPSMaxI)
+	backwardMinJ = min(p3aMinJ, p3bMinJ, T3aPHMinJ, V3aPHMinJ, T3bPHMinJ, V3bPHMinJ, T3aPSMinJ, V3aPSMinJ, T3bPSMinJ, V3bPSMinJ)
+	backwardMaxJ = max(p3aMaxJ, p3bMaxJ, T3aPHMaxJ, V3aPHMaxJ, T3bPHMaxJ, V3bPHMaxJ, T3aPSMaxJ, V3aPSMaxJ, T3bPSMaxJ, V3bPSMaxJ)
+)
 
+// evalSeries returns Σ n x^I y^J over the terms of a backward table.
 func evalSeries(terms []term, x, y float64) float64 {
+	var xp [backwardMaxI - backwardMinI + 1]float64
+	var yp [backwardMaxJ - backwardMinJ + 1]float64
+	calc_core.IntPowers(xp[:], x, backwardMinI)
+	calc_core.IntPowers(yp[:], y, backwardMinJ)
 	var s float64
 	for _, t := range terms {
-		s += t.N * powi(x, t.I) * powi(y, t.J)
+		s += t.N * xp[t.I-backwardMinI] * yp[t.J-backwardMinJ]
 	}
 	return s
 }
 
 func Tph(sub sub3, pPa, h float64) (float64, error) {
-	if err := loadPHOnce(); err != nil {
-		return 0, err
-	}
 	pMPa := pPa / 1e6
 	switch sub {
 	case sub3a:
@@ -411,14 +123,11 @@ func Tph(sub sub3, pPa, h float64) (float64, error) {
 		eta := h/hstar3bPH + etaShift3bPH
 		return Tstar3bPH * evalSeries(T3bPH, pi, eta), nil
 	default:
-		return 0, errors.New("invalid subregion")
+		return 0, errInvalidSubregion
 	}
 }
 
 func Vph(sub sub3, pPa, h float64) (float64, error) {
-	if err := loadPHOnce(); err != nil {
-		return 0, err
-	}
 	pMPa := pPa / 1e6
 	switch sub {
 	case sub3a:
@@ -430,14 +139,11 @@ func Vph(sub sub3, pPa, h float64) (float64, error) {
 		eta := h/hstar3bPH + etaShiftV3bPH
 		return vstar3bPH * evalSeries(V3bPH, pi, eta), nil
 	default:
-		return 0, errors.New("invalid subregion")
+		return 0, errInvalidSubregion
 	}
 }
 
 func Tps(sub sub3, pPa, s float64) (float64, error) {
-	if err := loadPSOnce(); err != nil {
-		return 0, err
-	}
 	pMPa := pPa / 1e6
 	switch sub {
 	case sub3a:
@@ -449,14 +155,11 @@ func Tps(sub sub3, pPa, s float64) (float64, error) {
 		sig := s/sstar3bPS + sigShift3bPS
 		return Tstar3bPS * evalSeries(T3bPS, pi, sig), nil
 	default:
-		return 0, errors.New("invalid subregion")
+		return 0, errInvalidSubregion
 	}
 }
 
 func Vps(sub sub3, pPa, s float64) (float64, error) {
-	if err := loadPSOnce(); err != nil {
-		return 0, err
-	}
 	pMPa := pPa / 1e6
 	switch sub {
 	case sub3a:
@@ -468,14 +171,11 @@ func Vps(sub sub3, pPa, s float64) (float64, error) {
 		sig := s/sstar3bPS + sigShiftV3bPS
 		return vstar3bPS * evalSeries(V3bPS, pi, sig), nil
 	default:
-		return 0, errors.New("invalid subregion")
+		return 0, errInvalidSubregion
 	}
 }
 
 func h3ab(pPa float64) (float64, error) {
-	if err := loadH3abOnce(); err != nil {
-		return 0, err
-	}
 	pMPa := pPa / 1e6 // p* = 1 MPa
 	// polynomial sum n_i * (p/p*)^{i-1}? Data uses i starting at 1; standard uses i from 1..4 with powers 0..3
 	var sum float64
@@ -502,7 +202,7 @@ func bracketAndBisect(f rootFunc, a, b float64, maxIter int, tol float64) (float
 		return 0, err
 	}
 	if math.IsNaN(fa) || math.IsNaN(fb) || math.IsInf(fa, 0) || math.IsInf(fb, 0) {
-		return 0, errors.New("invalid function values")
+		return 0, &propserr.Error{Code: propserr.NonPhysical, Region: 3, Detail: "нечисловые значения в границах интервала"}
 	}
 	if fa == 0 {
 		return a, nil
@@ -532,7 +232,7 @@ func bracketAndBisect(f rootFunc, a, b float64, maxIter int, tol float64) (float
 			prevF = fx
 		}
 		if fa*fb > 0 {
-			return 0, errors.New("no sign change in bracket")
+			return 0, &propserr.Error{Code: propserr.NoSolution, Region: 3, Detail: "нет корня на интервале"}
 		}
 	}
 	for i := 0; i < maxIter; i++ {
@@ -552,315 +252,61 @@ func bracketAndBisect(f rootFunc, a, b float64, maxIter int, tol float64) (float
 			fa = fm
 		}
 	}
-	return 0, errors.New("bisection did not converge")
+	return 0, &propserr.Error{Code: propserr.NoConvergence, Region: 3}
 }
 
 // Calculate computes Region 3 properties for T in Celsius and P in Pascals.
+// The density is found from the fundamental equation f(ρ,T); below Tc the
+// liquid-like root is taken at p >= psat(T) and the vapor-like one otherwise.
 func Calculate(tCelsius, pPascal float64) (calc_core.Properties, error) {
-	if tCelsius < -273.15 {
-		return calc_core.Properties{}, errors.New("temperature below absolute zero")
-	}
-	if pPascal <= 0 {
-		return calc_core.Properties{}, errors.New("pressure must be positive")
-	}
-	if err := loadMainOnce(); err != nil {
-		return calc_core.Properties{}, err
-	}
-	if err := loadPHOnce(); err != nil {
-		return calc_core.Properties{}, err
-	}
-	if err := loadPSOnce(); err != nil {
-		return calc_core.Properties{}, err
-	}
-	if err := loadH3abOnce(); err != nil {
+	rho, err := Density(tCelsius, pPascal)
+	if err != nil {
 		return calc_core.Properties{}, err
 	}
+	_, props, err := PropertiesRhoT(rho, tCelsius)
+	return props, err
+}
 
-	T := tCelsius + 273.15
-	if T < 623.15 || T > 1073.15 {
-		return calc_core.Properties{}, fmt.Errorf("Region 3 not applicable: T=%.2f K out of [623.15, 1073.15] K", T)
+// Density checks the Region 3 range and finds ρ (kg/m^3) at T in Celsius and
+// P in Pascals on the liquid-like or vapor-like branch of the isotherm,
+// depending on the side of the saturation line.
+func Density(tCelsius, pPascal float64) (float64, error) {
+	if tCelsius < -273.15 {
+		return 0, propserr.Range(propserr.InvalidInput, 3, "T", tCelsius, "°C", propserr.Min, -273.15)
 	}
-	if pPascal > 100e6 {
-		return calc_core.Properties{}, fmt.Errorf("Region 3 not applicable: p=%.0f Pa exceeds 100 MPa", pPascal)
+	if pPascal <= 0 {
+		return 0, propserr.Range(propserr.InvalidInput, 3, "p", pPascal, "Pa", propserr.Min, 0)
 	}
 
-	// Check Region 3 boundaries more precisely
-	// Lower boundary: T >= 623.15 K and p >= 16.529 MPa
-	if T >= 623.15 && pPascal >= 16.529e6 {
-		// Check B23 boundary for T > 623.15 K
-		if T > 623.15 {
-			tB23, err := bounds.B23T(pPascal / 1e6)
-			if err == nil && T < tB23 {
-				return calc_core.Properties{}, fmt.Errorf("Region 3 not applicable: T=%.2f K below B23 boundary T=%.2f K", T, tB23)
-			}
-		}
-	} else {
-		return calc_core.Properties{}, fmt.Errorf("Region 3 not applicable: T=%.2f K, p=%.0f Pa below minimum boundaries", T, pPascal)
+	T := tCelsius + 273.15
+	if T < 623.15 {
+		return 0, propserr.Range(propserr.RegionNotApplicable, 3, "T", T, "K", propserr.Min, 623.15)
 	}
-
-	// Determine subregion by attempting solve on 3a then 3b
-	hb, err := h3ab(pPascal)
-	if err != nil {
-		return calc_core.Properties{}, err
+	if T > 1073.15 {
+		return 0, propserr.Range(propserr.RegionNotApplicable, 3, "T", T, "K", propserr.Max, 1073.15)
 	}
-
-	// Solve h from T(p,h)=T
-	const tolT = 1e-6 // K
-	var hsol float64
-	var sub sub3
-	trySolve := func(s sub3, lo, hi float64) (float64, error) {
-		f := func(h float64) (float64, error) {
-			Th, err := Tph(s, pPascal, h)
-			if err != nil {
-				return 0, err
-			}
-			return Th - T, nil
-		}
-		return bracketAndBisect(f, lo, hi, 80, tolT)
-	}
-	if hb > 100 && hb < 4000 {
-		if hs, err := trySolve(sub3a, 1.0, math.Nextafter(hb, 0)); err == nil {
-			hsol = hs
-			sub = sub3a
-		} else if hs, err2 := trySolve(sub3b, hb, 4500.0); err2 == nil {
-			hsol = hs
-			sub = sub3b
-		} else {
-			return calc_core.Properties{}, fmt.Errorf("Region 3: failed to invert T(p,h) in both subregions: %v", err2)
-		}
-	} else {
-		// fallback: try both wide ranges
-		if hs, err := trySolve(sub3a, 1.0, 2500.0); err == nil {
-			hsol = hs
-			sub = sub3a
-		} else if hs, err2 := trySolve(sub3b, 2500.0, 4500.0); err2 == nil {
-			hsol = hs
-			sub = sub3b
-		} else {
-			return calc_core.Properties{}, errors.New("Region 3: failed to invert T(p,h)")
-		}
+	if pPascal > 100e6 {
+		return 0, propserr.Range(propserr.RegionNotApplicable, 3, "p", pPascal, "Pa", propserr.Max, 100e6)
 	}
 
-	// v from v(p,h) in chosen subregion
-	v, err := Vph(sub, pPascal, hsol)
+	// Lower boundary: B23 line p >= p_B23(T)
+	pB23, err := bounds.B23P(T)
 	if err != nil {
-		return calc_core.Properties{}, err
-	}
-	if !(v > 0) || math.IsNaN(v) || math.IsInf(v, 0) {
-		return calc_core.Properties{}, errors.New("Region 3: invalid specific volume")
+		return 0, err
 	}
-	ro := 1.0 / v
-
-	// Solve s from T(p,s)=T in the same subregion
-	sc := scKJperKgK
-	var ssol float64
-	if sub == sub3a {
-		f := func(s float64) (float64, error) {
-			Ts, err := Tps(sub3a, pPascal, s)
-			if err != nil {
-				return 0, err
-			}
-			return Ts - T, nil
-		}
-		ss, err := bracketAndBisect(f, 0.0, math.Nextafter(sc, 0), 80, tolT)
-		if err != nil {
-			return calc_core.Properties{}, fmt.Errorf("Region 3: failed to invert T(p,s) in 3a: %v", err)
-		}
-		ssol = ss
-	} else {
-		f := func(s float64) (float64, error) {
-			Ts, err := Tps(sub3b, pPascal, s)
-			if err != nil {
-				return 0, err
-			}
-			return Ts - T, nil
-		}
-		ss, err := bracketAndBisect(f, math.Nextafter(sc, math.Inf(1)), 10.0, 80, tolT)
-		if err != nil {
-			return calc_core.Properties{}, fmt.Errorf("Region 3: failed to invert T(p,s) in 3b: %v", err)
-		}
-		ssol = ss
-	}
-
-	// Compute properties
-	h := hsol
-	u := h - pPascal*v/1000.0
-	s := ssol
-
-	// Heat capacities and speed of sound via numeric derivatives
-	// cp ≈ (∂h/∂T)_p
-	const dT = 1e-3 // K - более точный шаг для лучшей точности
-	Tp := T + dT
-	Tm := T - dT
-	var hp, hm float64
-	if sub == sub3a {
-		// invert for hp at Tp
-		fwd := func(TK float64) (float64, error) {
-			f := func(hh float64) (float64, error) {
-				Th, err := Tph(sub3a, pPascal, hh)
-				if err != nil {
-					return 0, err
-				}
-				return Th - TK, nil
-			}
-			return bracketAndBisect(f, 1.0, math.Nextafter(hb, 0), 80, tolT)
-		}
-		hp, err = fwd(Tp)
-		if err != nil {
-			return calc_core.Properties{}, err
-		}
-		hm, err = fwd(Tm)
-		if err != nil {
-			return calc_core.Properties{}, err
-		}
-	} else {
-		fwd := func(TK float64) (float64, error) {
-			f := func(hh float64) (float64, error) {
-				Th, err := Tph(sub3b, pPascal, hh)
-				if err != nil {
-					return 0, err
-				}
-				return Th - TK, nil
-			}
-			return bracketAndBisect(f, hb, 4500.0, 80, tolT)
-		}
-		hp, err = fwd(Tp)
-		if err != nil {
-			return calc_core.Properties{}, err
-		}
-		hm, err = fwd(Tm)
-		if err != nil {
-			return calc_core.Properties{}, err
-		}
+	if pPascal < pB23*1e6*(1-1e-9) {
+		return 0, &propserr.Error{Code: propserr.RegionNotApplicable, Region: 3, Quantity: "p", Value: pPascal, Unit: "Pa", Bound: propserr.Min, Limit: pB23 * 1e6, Detail: "давление ниже границы B23"}
 	}
-	cp := (hp - hm) / (2.0 * dT) // kJ/(kg*K)
 
-	// alpha and kappa_T via v(p,h(T,p)) numerical derivatives
-	var vp, vm float64
-	if sub == sub3a {
-		// compute v at Tp and Tm holding p constant
-		if vhp, err := Vph(sub3a, pPascal, hp); err == nil {
-			vp = vhp
-		} else {
-			return calc_core.Properties{}, err
-		}
-		if vhm, err := Vph(sub3a, pPascal, hm); err == nil {
-			vm = vhm
-		} else {
-			return calc_core.Properties{}, err
-		}
-	} else {
-		if vhp, err := Vph(sub3b, pPascal, hp); err == nil {
-			vp = vhp
-		} else {
-			return calc_core.Properties{}, err
-		}
-		if vhm, err := Vph(sub3b, pPascal, hm); err == nil {
-			vm = vhm
-		} else {
-			return calc_core.Properties{}, err
-		}
-	}
-	dv_dT_p := (vp - vm) / (2.0 * dT)
-	alpha := (1.0 / v) * dv_dT_p // 1/K
-
-	const dp = 1e3 // Pa (0.001 MPa) - более точный шаг для лучшей точности
-	// keep T fixed; vary p, recompute h via inversion, then v
-	var vpp, vmm float64
-	if sub == sub3a {
-		fwd := func(p float64) (float64, error) {
-			f := func(hh float64) (float64, error) {
-				Th, err := Tph(sub3a, p, hh)
-				if err != nil {
-					return 0, err
-				}
-				return Th - T, nil
-			}
-			hh, err := bracketAndBisect(f, 1.0, math.Nextafter(hb, 0), 80, tolT)
-			if err != nil {
-				return 0, err
-			}
-			return Vph(sub3a, p, hh)
-		}
-		vpp, err = fwd(pPascal + dp)
+	liquid := false
+	if T < Tc {
+		psat, err := region4.SaturationPressure(T)
 		if err != nil {
-			return calc_core.Properties{}, err
-		}
-		vmm, err = fwd(pPascal - dp)
-		if err != nil {
-			return calc_core.Properties{}, err
-		}
-	} else {
-		fwd := func(p float64) (float64, error) {
-			f := func(hh float64) (float64, error) {
-				Th, err := Tph(sub3b, p, hh)
-				if err != nil {
-					return 0, err
-				}
-				return Th - T, nil
-			}
-			hh, err := bracketAndBisect(f, hb, 4500.0, 80, tolT)
-			if err != nil {
-				return 0, err
-			}
-			return Vph(sub3b, p, hh)
-		}
-		vpp, err = fwd(pPascal + dp)
-		if err != nil {
-			return calc_core.Properties{}, err
-		}
-		vmm, err = fwd(pPascal - dp)
-		if err != nil {
-			return calc_core.Properties{}, err
-		}
-	}
-	dv_dp_T := (vpp - vmm) / (2.0 * dp)           // m^3/(kg*Pa)
-	kappaT := -(1.0 / v) * dv_dp_T                // 1/Pa
-	cv := cp - (T*alpha*alpha)/(ro*kappaT)/1000.0 // kJ/(kg*K)
-
-	// Speed of sound via isentropic compressibility from v(p,s)
-	const dpS = 1e3 // Pa - более точный шаг для лучшей точности
-	var vpsp, vpsm float64
-	if sub == sub3a {
-		if vpsp, err = Vps(sub3a, pPascal+dpS, ssol); err != nil {
-			return calc_core.Properties{}, err
-		}
-		if vpsm, err = Vps(sub3a, pPascal-dpS, ssol); err != nil {
-			return calc_core.Properties{}, err
-		}
-	} else {
-		if vpsp, err = Vps(sub3b, pPascal+dpS, ssol); err != nil {
-			return calc_core.Properties{}, err
-		}
-		if vpsm, err = Vps(sub3b, pPascal-dpS, ssol); err != nil {
-			return calc_core.Properties{}, err
-		}
-	}
-	dv_dp_s := (vpsp - vpsm) / (2.0 * dpS)
-	kappaS := -(1.0 / v) * dv_dp_s // 1/Pa
-	w := math.Sqrt(1.0 / (ro * kappaS))
-
-	// Sanity checks
-	all := []float64{v, ro, u, s, h, cp, cv, w}
-	for _, x := range all {
-		if math.IsNaN(x) || math.IsInf(x, 0) {
-			return calc_core.Properties{}, errors.New("Region 3 produced non-finite values")
+			return 0, err
 		}
+		liquid = pPascal >= psat
 	}
-	if v <= 0 || ro <= 0 || w <= 0 || cp <= 0 || cv <= 0 {
-		return calc_core.Properties{}, errors.New("Region 3 invalid physical results (non-positive)")
-	}
-
-	return calc_core.Properties{
-		SpecificVolume:                v,
-		Density:                       ro,
-		SpecificInternalEnergy:        u,
-		SpecificEntropy:               s,
-		SpecificEnthalpy:              h,
-		SpecificIsochoricHeatCapacity: cv,
-		SpecificIsobaricHeatCapacity:  cp,
-		SpeedOfSound:                  w,
-	}, nil
+	return solveDensity(T, pPascal, liquid)
 }
 
 const (
@@ -869,57 +315,44 @@ const (
 
 func validateHS(h, s float64) error {
 	if !(h > 0 && h < 4000) {
-		return fmt.Errorf("Region 3: enthalpy out of expected range (0..4000 kJ/kg): h=%.3f", h)
+		return &propserr.Error{Code: propserr.RegionNotApplicable, Region: 3, Quantity: "h", Value: h, Unit: "kJ/kg", Detail: "энтальпия вне диапазона 0..4000 кДж/кг"}
 	}
 	if !(s > 0 && s < 10) {
-		return fmt.Errorf("Region 3: entropy out of expected range (0..10 kJ/(kg*K)): s=%.3f", s)
+		return &propserr.Error{Code: propserr.RegionNotApplicable, Region: 3, Quantity: "s", Value: s, Unit: "kJ/(kg·K)", Detail: "энтропия вне диапазона 0..10 кДж/(кг·К)"}
 	}
 	return nil
 }
 
 // PressureHS3a computes pressure (Pa) for subregion 3a from enthalpy h (kJ/kg) and entropy s (kJ/(kg*K)).
 func PressureHS3a(h, s float64) (float64, error) {
-	if err := loadHSOnce(); err != nil {
-		return 0, err
-	}
 	if err := validateHS(h, s); err != nil {
 		return 0, err
 	}
 	eta := h / 2300.0
 	sigma := s / 4.4
-	var sum float64
-	for _, t := range p3a {
-		sum += t.N * math.Pow(eta-1.01, float64(t.I)) * math.Pow(sigma-0.750, float64(t.J))
-	}
-	pMPa := 100.0 * sum
+	pMPa := 100.0 * evalSeries(p3a, eta-1.01, sigma-0.750)
 	p := pMPa * 1e6
 	if math.IsNaN(p) || math.IsInf(p, 0) || p <= 0 {
-		return 0, errors.New("Region 3 (3a) invalid pressure result")
+		return 0, &propserr.Error{Code: propserr.NonPhysical, Region: 3, Detail: "обратное уравнение p(h,s) подобласти 3a дало недопустимое давление"}
 	}
 	return p, nil
 }
 
 // PressureHS3b computes pressure (Pa) for subregion 3b from enthalpy h (kJ/kg) and entropy s (kJ/(kg*K)).
 func PressureHS3b(h, s float64) (float64, error) {
-	if err := loadHSOnce(); err != nil {
-		return 0, err
-	}
 	if err := validateHS(h, s); err != nil {
 		return 0, err
 	}
 	eta := h / 2800.0
 	sigma := s / 5.3
-	var denom float64
-	for _, t := range p3b {
-		denom += t.N * math.Pow(eta-0.681, float64(t.I)) * math.Pow(sigma-0.792, float64(t.J))
-	}
+	denom := evalSeries(p3b, eta-0.681, sigma-0.792)
 	if denom == 0 || math.IsNaN(denom) || math.IsInf(denom, 0) {
-		return 0, errors.New("Region 3 (3b) invalid denominator in backward equation")
+		return 0, &propserr.Error{Code: propserr.NonPhysical, Region: 3, Detail: "обратное уравнение p(h,s) подобласти 3b не определено"}
 	}
 	pMPa := 100.0 / denom
 	p := pMPa * 1e6
 	if math.IsNaN(p) || math.IsInf(p, 0) || p <= 0 {
-		return 0, errors.New("Region 3 (3b) invalid pressure result")
+		return 0, &propserr.Error{Code: propserr.NonPhysical, Region: 3, Detail: "обратное уравнение p(h,s) подобласти 3b дало недопустимое давление"}
 	}
 	return p, nil
 }
@@ -958,7 +391,7 @@ func PropertiesFromHS(h, s float64) (float64, float64, calc_core.Properties, err
 		return 0, 0, calc_core.Properties{}, err
 	}
 	if !(v > 0) || math.IsNaN(v) || math.IsInf(v, 0) {
-		return 0, 0, calc_core.Properties{}, errors.New("Region 3 HS: invalid specific volume from Vps")
+		return 0, 0, calc_core.Properties{}, &propserr.Error{Code: propserr.NonPhysical, Region: 3, Detail: "недопустимый удельный объем v(p,s)"}
 	}
 	ro := 1.0 / v
 	// Internal energy from h and p*v (unit-consistent: p[Pa]*v[m^3/kg]/1000 = kJ/kg)
@@ -968,11 +401,11 @@ func PropertiesFromHS(h, s float64) (float64, float64, calc_core.Properties, err
 	Tp, err1 := Tps(sub, p, s+ds)
 	Tm, err2 := Tps(sub, p, s-ds)
 	if err1 != nil || err2 != nil {
-		return 0, 0, calc_core.Properties{}, errors.New("Region 3 HS: failed to evaluate Tps for cp derivative")
+		return 0, 0, calc_core.Properties{}, &propserr.Error{Code: propserr.NonPhysical, Region: 3, Detail: "не удалось вычислить производную T(p,s) для cp"}
 	}
 	dTds := (Tp - Tm) / (2.0 * ds)
 	if dTds == 0 || math.IsNaN(dTds) || math.IsInf(dTds, 0) {
-		return 0, 0, calc_core.Properties{}, errors.New("Region 3 HS: invalid dT/ds for cp")
+		return 0, 0, calc_core.Properties{}, &propserr.Error{Code: propserr.NonPhysical, Region: 3, Detail: "недопустимая производная dT/ds для cp"}
 	}
 	cp := T / dTds // kJ/(kg*K)
 	// Speed of sound from isentropic compressibility using Vps at constant s
@@ -988,13 +421,13 @@ func PropertiesFromHS(h, s float64) (float64, float64, calc_core.Properties, err
 	dv_dp_s := (vpsp - vpsm) / (2.0 * dpS)
 	kappaS := -(1.0 / v) * dv_dp_s // 1/Pa
 	if kappaS <= 0 || math.IsNaN(kappaS) || math.IsInf(kappaS, 0) {
-		return 0, 0, calc_core.Properties{}, errors.New("Region 3 HS: invalid isentropic compressibility")
+		return 0, 0, calc_core.Properties{}, &propserr.Error{Code: propserr.NonPhysical, Region: 3, Detail: "недопустимая изоэнтропная сжимаемость"}
 	}
 	w := math.Sqrt(1.0 / (ro * kappaS))
 	// Sanity checks for outputs
 	for _, x := range []float64{ro, u, cp, w} {
 		if !(x > 0) || math.IsNaN(x) || math.IsInf(x, 0) {
-			return 0, 0, calc_core.Properties{}, errors.New("Region 3 HS: non-physical result")
+			return 0, 0, calc_core.Properties{}, &propserr.Error{Code: propserr.NonPhysical, Region: 3}
 		}
 	}
 	props := calc_core.Properties{
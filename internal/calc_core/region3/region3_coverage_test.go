@@ -349,3 +349,33 @@ func TestCalculate_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestTermCounts(t *testing.T) {
+	// Numbers of terms in IF-97 (table 30) and in the backward equations of
+	// the supplementary releases for T(p,h), v(p,h), T(p,s), v(p,s) and p(h,s)
+	tables := []struct {
+		name  string
+		terms []term
+		want  int
+	}{
+		{"f(ρ,T)", terms, 40},
+		{"p3a(h,s)", p3a, 33},
+		{"p3b(h,s)", p3b, 35},
+		{"T3a(p,h)", T3aPH, 31},
+		{"v3a(p,h)", V3aPH, 32},
+		{"T3b(p,h)", T3bPH, 33},
+		{"v3b(p,h)", V3bPH, 30},
+		{"T3a(p,s)", T3aPS, 33},
+		{"v3a(p,s)", V3aPS, 28},
+		{"T3b(p,s)", T3bPS, 28},
+		{"v3b(p,s)", V3bPS, 31},
+	}
+	for _, tt := range tables {
+		if len(tt.terms) != tt.want {
+			t.Errorf("%s: %d terms, want %d", tt.name, len(tt.terms), tt.want)
+		}
+	}
+	if terms[0] != (term{0, 0, 0.10658070028513e1}) {
+		t.Errorf("n1 ln δ term = %+v", terms[0])
+	}
+}
@@ -1,70 +1,18 @@
 package region4
 
 import (
-	"bufio"
-	"embed"
-	"errors"
-	"fmt"
 	"math"
-	"strconv"
-	"strings"
-)
-
-//go:embed iapws-if97-region4.csv
-var coeff embed.FS
 
-var (
-	loaded bool
-	n      []float64 // 1-based, n[1..10]
+	"github.com/somepgs/steamprops/propserr"
 )
 
-func loadOnce() error {
-	if loaded {
-		return nil
-	}
-	f, err := coeff.Open("iapws-if97-region4.csv")
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-	scanner := bufio.NewScanner(f)
-	first := true
-	n = make([]float64, 11)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if first {
-			first = false
-			continue
-		}
-		parts := strings.Split(line, ",")
-		if len(parts) != 2 {
-			return fmt.Errorf("invalid region4 line: %s", line)
-		}
-		idx, err := strconv.Atoi(strings.TrimSpace(parts[0]))
-		if err != nil {
-			return err
-		}
-		val, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
-		if err != nil {
-			return err
-		}
-		n[idx] = val
-	}
-	if err := scanner.Err(); err != nil {
-		return err
-	}
-	loaded = true
-	return nil
-}
+//go:generate go run ../internal/coeffgen n=iapws-if97-region4.csv:n
 
 // SaturationPressure returns saturation pressure (Pa) for given temperature (K)
 // Uses IF-97 Region 4 formulation with provided coefficients
 func SaturationPressure(T float64) (float64, error) {
-	if err := loadOnce(); err != nil {
-		return 0, err
-	}
 	if T <= 0 {
-		return 0, errors.New("invalid temperature")
+		return 0, propserr.Range(propserr.InvalidInput, 4, "T", T, "K", propserr.Min, 0)
 	}
 	// Standard IF-97 form:
 	// theta = T + n9/(T - n10)
@@ -78,22 +26,20 @@ func SaturationPressure(T float64) (float64, error) {
 	C := n[6]*theta*theta + n[7]*theta + n[8]
 	disc := B*B - 4*A*C
 	if disc < 0 {
-		return 0, errors.New("region4: negative discriminant")
+		return 0, &propserr.Error{Code: propserr.OutOfRange, Region: 4, Quantity: "T", Value: T, Unit: "K", Detail: "уравнение линии насыщения не определено"}
 	}
 	sqrtDisc := math.Sqrt(disc)
 	x := (2 * C) / (-B + sqrtDisc)
-	p := 1e6 * math.Pow(x, 4)
+	x2 := x * x
+	p := 1e6 * x2 * x2
 	return p, nil
 }
 
 // SaturationTemperature returns saturation temperature (K) for given pressure (Pa)
 // Inverts the same equation per IF-97 recommended inversion
 func SaturationTemperature(p float64) (float64, error) {
-	if err := loadOnce(); err != nil {
-		return 0, err
-	}
 	if p <= 0 {
-		return 0, errors.New("invalid pressure")
+		return 0, propserr.Range(propserr.InvalidInput, 4, "p", p, "Pa", propserr.Min, 0)
 	}
 	// IF-97 inversion:
 	// beta = p^(1/4)
@@ -102,20 +48,20 @@ func SaturationTemperature(p float64) (float64, error) {
 	// G = n2*beta^2 + n5*beta + n8
 	// D = 2*G/(-F - sqrt(F^2 - 4*E*G))
 	// T = 0.5*(n10 + D - sqrt((n10 + D)^2 - 4*(n9 + n10*D)))
-	beta := math.Pow(p/1e6, 0.25)
+	beta := math.Sqrt(math.Sqrt(p / 1e6))
 	E := beta*beta + n[3]*beta + n[6]
 	F := n[1]*beta*beta + n[4]*beta + n[7]
 	G := n[2]*beta*beta + n[5]*beta + n[8]
 	disc := F*F - 4*E*G
 	if disc < 0 {
-		return 0, errors.New("region4 inversion: negative discriminant")
+		return 0, &propserr.Error{Code: propserr.OutOfRange, Region: 4, Quantity: "p", Value: p, Unit: "Pa", Detail: "уравнение линии насыщения не определено"}
 	}
 	sqrtDisc := math.Sqrt(disc)
 	D := (2 * G) / (-F - sqrtDisc)
 	y := n[10] + D
 	inner := y*y - 4*(n[9]+n[10]*D)
 	if inner < 0 {
-		return 0, errors.New("region4 inversion: negative inner discriminant")
+		return 0, &propserr.Error{Code: propserr.OutOfRange, Region: 4, Quantity: "p", Value: p, Unit: "Pa", Detail: "уравнение линии насыщения не определено"}
 	}
 	T := 0.5 * (y - math.Sqrt(inner))
 	return T, nil
@@ -174,17 +174,10 @@ func TestSaturationTemperature_Roundtrip(t *testing.T) {
 	}
 }
 
-func TestLoadOnce_EdgeCases(t *testing.T) {
-	// Test that loadOnce can be called multiple times
-	err := loadOnce()
-	if err != nil {
-		t.Errorf("loadOnce() error = %v", err)
-	}
-
-	// Call again to test idempotency
-	err = loadOnce()
-	if err != nil {
-		t.Errorf("loadOnce() second call error = %v", err)
+func TestCoefficients(t *testing.T) {
+	// IF-97, table 34: n1 and n10
+	if n[1] != 0.11670521452767e4 || n[10] != 0.65017534844798e3 {
+		t.Errorf("n[1] = %v, n[10] = %v", n[1], n[10])
 	}
 }
 
@@ -1,15 +1,10 @@
 package region5
 
 import (
-	"bufio"
-	"embed"
-	"errors"
-	"fmt"
 	"math"
-	"strconv"
-	"strings"
 
 	"github.com/somepgs/steamprops/internal/calc_core"
+	"github.com/somepgs/steamprops/propserr"
 )
 
 const (
@@ -18,132 +13,44 @@ const (
 	referR = 0.461526 // kJ/kg*K
 )
 
-//go:embed iapws-if97-region5_0.csv
-var coeffIdeal embed.FS
+//go:generate go run ../internal/coeffgen idealTerms=iapws-if97-region5_0.csv:jn residualTerms=iapws-if97-region5.csv:ijn
 
-//go:embed iapws-if97-region5.csv
-var coeffResidual embed.FS
-
-type idealRow struct {
-	J float64
-	N float64
-}
-
-type residualRow struct {
-	I float64
-	J float64
-	N float64
-}
-
-var (
-	idealLoaded bool
-	residLoaded bool
-	idealRows   []idealRow
-	residRows   []residualRow
-)
-
-func loadIdealOnce() error {
-	if idealLoaded {
-		return nil
-	}
-	f, err := coeffIdeal.Open("iapws-if97-region5_0.csv")
+// Calculate computes Region 5 properties for T in Celsius and P in Pascals.
+func Calculate(tCelsius, pPascal float64) (calc_core.Properties, error) {
+	g, err := Derivatives(tCelsius, pPascal)
 	if err != nil {
-		return err
-	}
-	defer f.Close()
-	scanner := bufio.NewScanner(f)
-	first := true
-	for scanner.Scan() {
-		line := scanner.Text()
-		if first {
-			first = false
-			continue // skip header
-		}
-		parts := strings.Split(line, ",")
-		if len(parts) != 3 {
-			return fmt.Errorf("invalid ideal line: %s", line)
-		}
-		j, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
-		if err != nil {
-			return err
-		}
-		n, err := strconv.ParseFloat(strings.TrimSpace(parts[2]), 64)
-		if err != nil {
-			return err
-		}
-		idealRows = append(idealRows, idealRow{J: j, N: n})
-	}
-	if err := scanner.Err(); err != nil {
-		return err
+		return calc_core.Properties{}, err
 	}
-	idealLoaded = true
-	return nil
-}
+	p := g.Properties()
 
-func loadResidualOnce() error {
-	if residLoaded {
-		return nil
+	// Sanity validation; SpeedOfSound is NaN where its denominator vanishes
+	if !finiteAll(p.SpecificVolume, p.Density, p.SpecificInternalEnergy, p.SpecificEntropy,
+		p.SpecificEnthalpy, p.SpecificIsochoricHeatCapacity, p.SpecificIsobaricHeatCapacity, p.SpeedOfSound) {
+		return calc_core.Properties{}, &propserr.Error{Code: propserr.NonPhysical, Region: 5, Detail: "нечисловые значения свойств"}
 	}
-	f, err := coeffResidual.Open("iapws-if97-region5.csv")
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-	scanner := bufio.NewScanner(f)
-	first := true
-	for scanner.Scan() {
-		line := scanner.Text()
-		if first {
-			first = false
-			continue // header
-		}
-		parts := strings.Split(line, ",")
-		if len(parts) != 4 {
-			return fmt.Errorf("invalid residual line: %s", line)
-		}
-		i, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
-		if err != nil {
-			return err
-		}
-		j, err := strconv.ParseFloat(strings.TrimSpace(parts[2]), 64)
-		if err != nil {
-			return err
-		}
-		n, err := strconv.ParseFloat(strings.TrimSpace(parts[3]), 64)
-		if err != nil {
-			return err
-		}
-		residRows = append(residRows, residualRow{I: i, J: j, N: n})
-	}
-	if err := scanner.Err(); err != nil {
-		return err
-	}
-	residLoaded = true
-	return nil
+	return p, nil
 }
 
-// Calculate computes Region 5 properties for T in Celsius and P in Pascals.
-func Calculate(tCelsius, pPascal float64) (calc_core.Properties, error) {
+// Derivatives checks the Region 5 range and evaluates γ = γ° + γr and its
+// derivatives for T in Celsius and P in Pascals.
+func Derivatives(tCelsius, pPascal float64) (calc_core.Gibbs, error) {
 	if tCelsius < -273.15 {
-		return calc_core.Properties{}, errors.New("temperature below absolute zero")
+		return calc_core.Gibbs{}, propserr.Range(propserr.InvalidInput, 5, "T", tCelsius, "°C", propserr.Min, -273.15)
 	}
 	if pPascal <= 0 {
-		return calc_core.Properties{}, errors.New("pressure must be positive")
-	}
-	if err := loadIdealOnce(); err != nil {
-		return calc_core.Properties{}, err
-	}
-	if err := loadResidualOnce(); err != nil {
-		return calc_core.Properties{}, err
+		return calc_core.Gibbs{}, propserr.Range(propserr.InvalidInput, 5, "p", pPascal, "Pa", propserr.Min, 0)
 	}
 
 	T := tCelsius + 273.15
 	// IF-97 Region 5 applicability: 1073.15 K <= T <= 2273.15 K, p <= 50 MPa
-	if T < 1073.15 || T > 2273.15 {
-		return calc_core.Properties{}, fmt.Errorf("Region 5 not applicable: T=%.2f K out of [1073.15, 2273.15] K", T)
+	if T < 1073.15 {
+		return calc_core.Gibbs{}, propserr.Range(propserr.RegionNotApplicable, 5, "T", T, "K", propserr.Min, 1073.15)
+	}
+	if T > 2273.15 {
+		return calc_core.Gibbs{}, propserr.Range(propserr.RegionNotApplicable, 5, "T", T, "K", propserr.Max, 2273.15)
 	}
 	if pPascal > 50e6 {
-		return calc_core.Properties{}, fmt.Errorf("Region 5 not applicable: p=%.0f Pa exceeds 50 MPa", pPascal)
+		return calc_core.Gibbs{}, propserr.Range(propserr.RegionNotApplicable, 5, "p", pPascal, "Pa", propserr.Max, 50e6)
 	}
 
 	PMPa := pPascal / 1_000_000.0
@@ -153,23 +60,34 @@ func Calculate(tCelsius, pPascal float64) (calc_core.Properties, error) {
 	// Ideal part g0
 	var g0, g0Tau, g0TauTau float64
 	g0 = math.Log(pi)
-	for _, r := range idealRows {
-		g0 += r.N * math.Pow(tau, r.J)
-		g0Tau += r.N * r.J * math.Pow(tau, r.J-1)
-		g0TauTau += r.N * r.J * (r.J - 1) * math.Pow(tau, r.J-2)
+	var tp0 [idealTermsMaxJ - idealTermsMinJ + 3]float64
+	calc_core.IntPowers(tp0[:], tau, idealTermsMinJ-2)
+	for _, r := range idealTerms {
+		j := r.J - idealTermsMinJ + 2
+		J := float64(r.J)
+		g0 += r.N * tp0[j]
+		g0Tau += r.N * J * tp0[j-1]
+		g0TauTau += r.N * J * (J - 1) * tp0[j-2]
 	}
 	g0Pi := 1.0 / pi
 	g0PiPi := -1.0 / (pi * pi)
 
 	// Residual part gr
+	var pp [residualTermsMaxI - residualTermsMinI + 3]float64
+	var tp [residualTermsMaxJ - residualTermsMinJ + 3]float64
+	calc_core.IntPowers(pp[:], pi, residualTermsMinI-2)
+	calc_core.IntPowers(tp[:], tau-1.0, residualTermsMinJ-2)
+
 	var gr, grPi, grPiPi, grTau, grTauTau, grPiTau float64
-	for _, r := range residRows {
-		gr += r.N * math.Pow(pi, r.I) * math.Pow(tau-1.0, r.J)
-		grPi += r.N * r.I * math.Pow(pi, r.I-1) * math.Pow(tau-1.0, r.J)
-		grPiPi += r.N * r.I * (r.I - 1) * math.Pow(pi, r.I-2) * math.Pow(tau-1.0, r.J)
-		grTau += r.N * r.J * math.Pow(pi, r.I) * math.Pow(tau-1.0, r.J-1)
-		grTauTau += r.N * r.J * (r.J - 1) * math.Pow(pi, r.I) * math.Pow(tau-1.0, r.J-2)
-		grPiTau += r.N * r.I * r.J * math.Pow(pi, r.I-1) * math.Pow(tau-1.0, r.J-1)
+	for _, r := range residualTerms {
+		i, j := r.I-residualTermsMinI+2, r.J-residualTermsMinJ+2
+		I, J := float64(r.I), float64(r.J)
+		gr += r.N * pp[i] * tp[j]
+		grPi += r.N * I * pp[i-1] * tp[j]
+		grPiPi += r.N * I * (I - 1) * pp[i-2] * tp[j]
+		grTau += r.N * J * pp[i] * tp[j-1]
+		grTauTau += r.N * J * (J - 1) * pp[i] * tp[j-2]
+		grPiTau += r.N * I * J * pp[i-1] * tp[j-1]
 	}
 
 	gPi := g0Pi + grPi
@@ -179,34 +97,17 @@ func Calculate(tCelsius, pPascal float64) (calc_core.Properties, error) {
 	gPiTau := grPiTau // g0PiTau is zero
 	g := g0 + gr
 
-	R := referR
-	pKPa := pPascal / 1000.0
-
-	v := pi * gPi * (R * T / pKPa)
-	ro := pKPa / (R * T * (pi * gPi))
-	u := R * T * (tau*gTau - pi*gPi)
-	s := R * (tau*gTau - g)
-	h := R * T * tau * gTau
-	cv := R * (-(tau*tau)*gTauTau + (math.Pow(gPi-tau*gPiTau, 2) / gPiPi))
-	cp := R * (-(tau*tau)*gTauTau + (math.Pow(gPi-tau*gPiTau, 2) / gPiPi))
-	// Calculate speed of sound using alternative IF-97 formula
-	// w² = R * T * gPi² / (gPiPi - (gPi - tau*gPiTau)² / (tau² * gTauTau))
-	// But if denominator is negative, use absolute value (common in some implementations)
-	denominator := gPiPi - (math.Pow(gPi-tau*gPiTau, 2) / (tau * tau * gTauTau))
+	return calc_core.Gibbs{
+		T: T, P: pPascal, R: referR, Pi: pi, Tau: tau,
+		G: g, GPi: gPi, GPiPi: gPiPi, GTau: gTau, GTauTau: gTauTau, GPiTau: gPiTau,
+	}, nil
+}
 
-	if math.Abs(denominator) < 1e-10 {
-		return calc_core.Properties{}, fmt.Errorf("Region 5: speed of sound calculation failed (denominator too small: %.6f)", denominator)
+func finiteAll(vals ...float64) bool {
+	for _, x := range vals {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return false
+		}
 	}
-	w := math.Sqrt(R * 1000.0 * T * (gPi * gPi / math.Abs(denominator)))
-
-	return calc_core.Properties{
-		SpecificVolume:                v,
-		Density:                       ro,
-		SpecificInternalEnergy:        u,
-		SpecificEntropy:               s,
-		SpecificEnthalpy:              h,
-		SpecificIsochoricHeatCapacity: cv,
-		SpecificIsobaricHeatCapacity:  cp,
-		SpeedOfSound:                  w,
-	}, nil
+	return true
 }
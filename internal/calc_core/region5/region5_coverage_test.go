@@ -4,31 +4,16 @@ import (
 	"testing"
 )
 
-func TestLoadIdealOnce_EdgeCases(t *testing.T) {
-	// Test that loadIdealOnce can be called multiple times
-	err := loadIdealOnce()
-	if err != nil {
-		t.Errorf("loadIdealOnce() error = %v", err)
+func TestTerms(t *testing.T) {
+	// IF-97, tables 37 and 38: 6 ideal-gas and 6 residual terms
+	if len(idealTerms) != 6 || len(residualTerms) != 6 {
+		t.Fatalf("got %d ideal and %d residual terms, want 6 and 6", len(idealTerms), len(residualTerms))
 	}
-
-	// Call again to test idempotency
-	err = loadIdealOnce()
-	if err != nil {
-		t.Errorf("loadIdealOnce() second call error = %v", err)
+	if got := idealTerms[2]; got != (termJ{-3, -0.24805148933466e-1}) {
+		t.Errorf("idealTerms[2] = %+v", got)
 	}
-}
-
-func TestLoadResidualOnce_EdgeCases(t *testing.T) {
-	// Test that loadResidualOnce can be called multiple times
-	err := loadResidualOnce()
-	if err != nil {
-		t.Errorf("loadResidualOnce() error = %v", err)
-	}
-
-	// Call again to test idempotency
-	err = loadResidualOnce()
-	if err != nil {
-		t.Errorf("loadResidualOnce() second call error = %v", err)
+	if got := residualTerms[5]; got != (term{3, 7, 0.37919454822955e-7}) {
+		t.Errorf("residualTerms[5] = %+v", got)
 	}
 }
 
@@ -0,0 +1,450 @@
+// Package sbtl implements a spline-based table look-up (SBTL) for fast
+// evaluation of water and steam properties, in the spirit of the IAPWS
+// guideline on the SBTL method.
+//
+// The tables are generated once from the IF-97 equations supplied through
+// Source and then evaluated with bicubic splines instead of the iterative
+// inversion of the equations:
+//
+//   - (p, h): three tables over (ln p, ξ), where ξ in [0, 1] is the enthalpy
+//     scaled between the boundaries of the table at the given pressure
+//     (0 °C, saturated liquid, saturated vapor, 800 °C). Below
+//     Source.SaturationPressureMax the liquid and vapor tables are separated
+//     by the saturation line and two-phase states are computed from 1-D
+//     saturation splines; above it a single table spans 0..800 °C.
+//   - (v, u): Newton iteration on the (p, h) splines, started from a coarse
+//     index over (ln v, u).
+//
+// States the source cannot evaluate are left out of the tables, and look-ups
+// there return ErrOutOfRange. Since the spline stencil spans two cells on
+// each side, this also applies to a narrow band next to such a hole. A
+// source that crosses the saturation line above SaturationPressureMax (IF-97
+// up to the critical pressure) must leave a band around it undefined this way,
+// so that two-phase states are not interpolated between the phases.
+//
+// Single-phase states reproduce the source equations within Tolerance, or
+// BoundaryTolerance next to the IF-97 region boundaries and the critical
+// point; two-phase states follow the saturation splines, which are much finer
+// and reproduce the saturation states to about 1e-7.
+package sbtl
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/somepgs/steamprops/internal/calc_core"
+	"github.com/somepgs/steamprops/propserr"
+)
+
+// Range covered by the tables.
+const (
+	MinTemperature = 0.0     // °C
+	MaxTemperature = 800.0   // °C
+	MinPressure    = 611.657 // Pa, triple point
+	MaxPressure    = 100e6   // Pa
+)
+
+// Tolerance is the maximum deviation of the tables from the source
+// equations in single-phase states at T ≥ 1 °C, Region 3 included, outside
+// the zones of BoundaryTolerance. The largest deviations are found in the
+// vapor close to the saturation line at 16 MPa; at low pressure they are one
+// to two orders of magnitude smaller.
+var Tolerance = struct {
+	Temperature  float64 // K, absolute
+	Volume       float64 // relative
+	Entropy      float64 // kJ/(kg·K), absolute
+	HeatCapacity float64 // relative, cp and cv
+	SpeedOfSound float64 // relative
+}{
+	Temperature:  5e-3,
+	Volume:       5e-5,
+	Entropy:      5e-5,
+	HeatCapacity: 5e-4,
+	SpeedOfSound: 1e-4,
+}
+
+// BoundaryTolerance is the maximum deviation from IF-97 within 5 K of the
+// boundaries of Region 3 (350 °C and B23), where the region equations are
+// only consistent to the IF-97 tolerances and the splines smooth over the
+// step, and in the supercritical states at p_c ≤ p ≤ 30 MPa, T_c ≤ T ≤ 420 °C,
+// where cp peaks sharply near the pseudo-critical line.
+var BoundaryTolerance = struct {
+	Temperature  float64 // K, absolute
+	Volume       float64 // relative
+	Entropy      float64 // kJ/(kg·K), absolute
+	HeatCapacity float64 // relative, cp and cv
+	SpeedOfSound float64 // relative
+}{
+	Temperature:  3e-2,
+	Volume:       1e-4,
+	Entropy:      2e-5,
+	HeatCapacity: 5e-2,
+	SpeedOfSound: 5e-3,
+}
+
+// Grid sizes of the tables.
+const (
+	saturationNodes   = 2000 // along ln p in the saturation spline
+	pressureNodes     = 240  // along ln p in the liquid and vapor tables
+	liquidNodes       = 120  // along ξ in the liquid table
+	vaporNodes        = 200  // along ξ in the vapor table
+	highPressureNodes = 120  // along ln p above SaturationPressureMax
+	highNodes         = 640  // along ξ above SaturationPressureMax
+)
+
+// ErrOutOfRange is returned for states outside the tables.
+var ErrOutOfRange = &propserr.Error{Code: propserr.OutOfRange, Detail: "состояние вне таблиц SBTL"}
+
+// Source supplies the IF-97 equations the tables are generated from.
+type Source struct {
+	// Properties returns single-phase properties at temperature t (°C) and pressure p (Pa).
+	Properties func(t, p float64) (calc_core.Properties, error)
+	// Saturation returns the saturation temperature (°C) and the properties of
+	// saturated liquid and vapor at pressure p (Pa).
+	Saturation func(p float64) (t float64, liquid, vapor calc_core.Properties, err error)
+	// SaturationPressureMax is the upper pressure limit (Pa) of the two-phase tables.
+	SaturationPressureMax float64
+}
+
+// Phase identifies the table a state was evaluated from.
+type Phase int
+
+const (
+	Liquid       Phase = iota // below the saturated liquid line
+	Vapor                     // above the saturated vapor line
+	TwoPhase                  // between the saturation lines
+	HighPressure              // above Source.SaturationPressureMax
+)
+
+// State is a state evaluated from the tables.
+type State struct {
+	Temperature float64 // °C
+	Pressure    float64 // Pa
+	Quality     float64 // 0..1 for two-phase states, -1 otherwise
+	Phase       Phase
+	Properties  calc_core.Properties
+}
+
+// Values stored in the single-phase tables.
+const (
+	valT = iota
+	valLnV
+	valS
+	valCp
+	valCv
+	valW
+	singleValues
+)
+
+// Values stored in the saturation spline.
+const (
+	satT = iota
+	satHf
+	satHg
+	satSf
+	satSg
+	satLnVf
+	satLnVg
+	satHmin // h at MinTemperature
+	satHmax // h at MaxTemperature
+	satValues
+)
+
+// Values stored in the boundary spline above SaturationPressureMax.
+const (
+	highHmin = iota
+	highHmax
+	highValues
+)
+
+// Tables holds the generated spline tables.
+type Tables struct {
+	pSplit float64
+	sat    *table1 // over ln p, MinPressure..pSplit
+	bounds *table1 // over ln p, pSplit..MaxPressure
+	liquid *table2
+	vapor  *table2
+	high   *table2
+	index  *index
+
+	satNodes []satNode // saturation states at the nodes of sat, for the (v, u) inversion
+}
+
+// Generate builds the tables from src. The saturation line must be available
+// up to SaturationPressureMax; other nodes where the source equations fail
+// are left undefined and evaluation near them returns ErrOutOfRange.
+func Generate(src Source) (*Tables, error) {
+	if src.Properties == nil || src.Saturation == nil {
+		return nil, errors.New("sbtl: source equations are not set")
+	}
+	if !(src.SaturationPressureMax > MinPressure && src.SaturationPressureMax < MaxPressure) {
+		return nil, fmt.Errorf("sbtl: invalid saturation pressure limit %g Pa", src.SaturationPressureMax)
+	}
+
+	t := &Tables{pSplit: src.SaturationPressureMax}
+	lnMin, lnSplit, lnMax := math.Log(MinPressure), math.Log(src.SaturationPressureMax), math.Log(MaxPressure)
+	pAxis := newAxis(lnMin, lnSplit, pressureNodes)
+	highAxis := newAxis(lnSplit, lnMax, highPressureNodes)
+
+	satAxis := newAxis(lnMin, lnSplit, saturationNodes)
+	t.sat = newTable1(satAxis, satValues)
+	for i := 0; i < satAxis.n; i++ {
+		p := math.Exp(satAxis.at(i))
+		if i == satAxis.n-1 {
+			p = src.SaturationPressureMax
+		}
+		tsat, liquid, vapor, cold, hot, err := boundaries(src, p)
+		if err != nil {
+			return nil, err
+		}
+		t.sat.set(i, []float64{
+			tsat,
+			liquid.SpecificEnthalpy, vapor.SpecificEnthalpy,
+			liquid.SpecificEntropy, vapor.SpecificEntropy,
+			math.Log(liquid.SpecificVolume), math.Log(vapor.SpecificVolume),
+			cold.SpecificEnthalpy, hot.SpecificEnthalpy,
+		})
+	}
+	t.sat.finish()
+
+	t.liquid = newTable2(pAxis, newAxis(0, 1, liquidNodes), singleValues)
+	t.vapor = newTable2(pAxis, newAxis(0, 1, vaporNodes), singleValues)
+	for i := 0; i < pAxis.n; i++ {
+		p := math.Exp(pAxis.at(i))
+		if i == pAxis.n-1 {
+			p = src.SaturationPressureMax
+		}
+		tsat, liquid, vapor, cold, hot, err := boundaries(src, p)
+		if err != nil {
+			return nil, err
+		}
+
+		t.satNodes = append(t.satNodes, satNode{
+			lnP: math.Log(p),
+			vf:  liquid.SpecificVolume,
+			vg:  vapor.SpecificVolume,
+			uf:  liquid.SpecificEnthalpy - p*liquid.SpecificVolume/1000,
+			ug:  vapor.SpecificEnthalpy - p*vapor.SpecificVolume/1000,
+		})
+
+		fillRow(src, t.liquid, i, p, bound{MinTemperature, cold}, bound{tsat, liquid})
+		fillRow(src, t.vapor, i, p, bound{tsat, vapor}, bound{MaxTemperature, hot})
+	}
+	t.liquid.finish()
+	t.vapor.finish()
+
+	t.bounds = newTable1(highAxis, highValues)
+	t.high = newTable2(highAxis, newAxis(0, 1, highNodes), singleValues)
+	for i := 0; i < highAxis.n; i++ {
+		p := math.Exp(highAxis.at(i))
+		switch i {
+		case 0:
+			p = src.SaturationPressureMax
+		case highAxis.n - 1:
+			p = MaxPressure
+		}
+		cold, errCold := src.Properties(MinTemperature, p)
+		hot, errHot := src.Properties(MaxTemperature, p)
+		if errCold != nil || errHot != nil {
+			t.bounds.set(i, []float64{math.NaN(), math.NaN()})
+			for j := 0; j < t.high.y.n; j++ {
+				t.high.set(i, j, undefinedNode[:])
+			}
+			continue
+		}
+		t.bounds.set(i, []float64{cold.SpecificEnthalpy, hot.SpecificEnthalpy})
+		fillRow(src, t.high, i, p, bound{MinTemperature, cold}, bound{MaxTemperature, hot})
+	}
+	t.bounds.finish()
+	t.high.finish()
+
+	t.index = newIndex(t)
+	return t, nil
+}
+
+// boundaries returns the saturation states and the states at MinTemperature
+// and MaxTemperature at pressure p below SaturationPressureMax.
+func boundaries(src Source, p float64) (tsat float64, liquid, vapor, cold, hot calc_core.Properties, err error) {
+	tsat, liquid, vapor, err = src.Saturation(p)
+	if err != nil {
+		return 0, liquid, vapor, cold, hot, fmt.Errorf("sbtl: saturation at p=%g Pa: %w", p, err)
+	}
+	cold, err = src.Properties(MinTemperature, p)
+	if err != nil {
+		return 0, liquid, vapor, cold, hot, fmt.Errorf("sbtl: properties at %g °C, p=%g Pa: %w", MinTemperature, p, err)
+	}
+	hot, err = src.Properties(MaxTemperature, p)
+	if err != nil {
+		return 0, liquid, vapor, cold, hot, fmt.Errorf("sbtl: properties at %g °C, p=%g Pa: %w", MaxTemperature, p, err)
+	}
+	return tsat, liquid, vapor, cold, hot, nil
+}
+
+// bound is a state at the boundary of a table row.
+type bound struct {
+	t     float64 // °C
+	props calc_core.Properties
+}
+
+// boundaryOffset keeps the inner nodes of a row off the saturation line,
+// so that the source equations do not pick the other phase by round-off.
+const boundaryOffset = 1e-7 // K
+
+// fillRow computes the nodes of row i at pressure p between the states lo and hi.
+func fillRow(src Source, tab *table2, i int, p float64, lo, hi bound) {
+	n := tab.y.n
+	hLo, hHi := lo.props.SpecificEnthalpy, hi.props.SpecificEnthalpy
+	// The previous node of the row gives the slope dh/dT and the initial guess
+	prevT, prevH := lo.t, hLo
+	slope := (hHi - hLo) / (hi.t - lo.t)
+	for j := 0; j < n; j++ {
+		var t float64
+		var props calc_core.Properties
+		var err error
+		switch j {
+		case 0:
+			t, props = lo.t, lo.props
+		case n - 1:
+			t, props = hi.t, hi.props
+		default:
+			h := hLo + tab.y.at(j)*(hHi-hLo)
+			t, props, err = solveTemperature(src, p, h, lo.t+boundaryOffset, hi.t-boundaryOffset, prevT+(h-prevH)/slope, slope)
+		}
+		if err != nil {
+			tab.set(i, j, undefinedNode[:])
+			continue
+		}
+		if j > 0 && t > prevT {
+			slope = (props.SpecificEnthalpy - prevH) / (t - prevT)
+		}
+		prevT, prevH = t, props.SpecificEnthalpy
+		tab.set(i, j, []float64{
+			t,
+			math.Log(props.SpecificVolume),
+			props.SpecificEntropy,
+			props.SpecificIsobaricHeatCapacity,
+			props.SpecificIsochoricHeatCapacity,
+			props.SpeedOfSound,
+		})
+	}
+}
+
+var undefinedNode = [singleValues]float64{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()}
+
+// solveTemperature finds t in [lo, hi] with h(t, p) = h by the secant
+// method, safeguarded by bisection. The derivative of the source equations
+// is not used, so the tables stay consistent with the enthalpy alone.
+func solveTemperature(src Source, p, h, lo, hi, guess, slope float64) (float64, calc_core.Properties, error) {
+	const maxIter = 100
+	tol := 1e-11 * math.Max(1, math.Abs(h))
+
+	t := math.Min(math.Max(guess, lo), hi)
+	props, err := src.Properties(t, p)
+	if err != nil {
+		return 0, calc_core.Properties{}, err
+	}
+	r := props.SpecificEnthalpy - h
+	for iter := 0; iter < maxIter; iter++ {
+		if math.Abs(r) <= tol || hi-lo < 1e-12 {
+			return t, props, nil
+		}
+		if r > 0 {
+			hi = t
+		} else {
+			lo = t
+		}
+		next := t - r/slope
+		if !(next > lo && next < hi) {
+			next = 0.5 * (lo + hi)
+		}
+		nextProps, err := src.Properties(next, p)
+		if err != nil {
+			return 0, calc_core.Properties{}, err
+		}
+		nextR := nextProps.SpecificEnthalpy - h
+		if d := (nextR - r) / (next - t); d > 0 && !math.IsInf(d, 0) {
+			slope = d
+		}
+		t, props, r = next, nextProps, nextR
+	}
+	return 0, calc_core.Properties{}, &propserr.Error{Code: propserr.NoConvergence, Quantity: "h", Value: h, Unit: "kJ/kg", Detail: "SBTL: нет сходимости при p = %g Pa", Args: []interface{}{p}}
+}
+
+// PH evaluates the state at pressure p (Pa) and specific enthalpy h (kJ/kg).
+func (t *Tables) PH(p, h float64) (State, error) {
+	var st State
+	if err := t.ph(p, h, &st); err != nil {
+		return State{}, err
+	}
+	return st, nil
+}
+
+func (t *Tables) ph(p, h float64, st *State) error {
+	if !(p >= MinPressure*(1-1e-12) && p <= MaxPressure*(1+1e-12)) || math.IsNaN(h) || math.IsInf(h, 0) {
+		return fmt.Errorf("%w: p=%g Pa, h=%g kJ/kg", ErrOutOfRange, p, h)
+	}
+	lnp := math.Log(p)
+	var vals [maxValues]float64
+
+	var tab *table2
+	var xi float64
+	st.Pressure = p
+	st.Quality = -1
+	if p <= t.pSplit {
+		var sat [satValues]float64
+		if !t.sat.eval(lnp, sat[:]) {
+			return fmt.Errorf("%w: p=%g Pa", ErrOutOfRange, p)
+		}
+		hf, hg := sat[satHf], sat[satHg]
+		switch {
+		case h < sat[satHmin] || h > sat[satHmax]:
+			return fmt.Errorf("%w: h=%g kJ/kg at p=%g Pa", ErrOutOfRange, h, p)
+		case h <= hf:
+			tab, xi, st.Phase = t.liquid, (h-sat[satHmin])/(hf-sat[satHmin]), Liquid
+		case h >= hg:
+			tab, xi, st.Phase = t.vapor, (h-hg)/(sat[satHmax]-hg), Vapor
+		default:
+			x := (h - hf) / (hg - hf)
+			vf, vg := math.Exp(sat[satLnVf]), math.Exp(sat[satLnVg])
+			v := vf + x*(vg-vf)
+			st.Temperature = sat[satT]
+			st.Quality = x
+			st.Phase = TwoPhase
+			st.Properties = calc_core.Properties{
+				SpecificVolume:         v,
+				Density:                1 / v,
+				SpecificInternalEnergy: h - p*v/1000,
+				SpecificEntropy:        sat[satSf] + x*(sat[satSg]-sat[satSf]),
+				SpecificEnthalpy:       h,
+			}
+			return nil
+		}
+	} else {
+		var b [highValues]float64
+		if !t.bounds.eval(lnp, b[:]) || math.IsNaN(b[highHmin]+b[highHmax]) {
+			return fmt.Errorf("%w: p=%g Pa", ErrOutOfRange, p)
+		}
+		if h < b[highHmin] || h > b[highHmax] {
+			return fmt.Errorf("%w: h=%g kJ/kg at p=%g Pa", ErrOutOfRange, h, p)
+		}
+		tab, xi, st.Phase = t.high, (h-b[highHmin])/(b[highHmax]-b[highHmin]), HighPressure
+	}
+
+	if !tab.eval(lnp, xi, vals[:]) {
+		return fmt.Errorf("%w: p=%g Pa, h=%g kJ/kg", ErrOutOfRange, p, h)
+	}
+	v := math.Exp(vals[valLnV])
+	st.Temperature = vals[valT]
+	st.Properties = calc_core.Properties{
+		SpecificVolume:                v,
+		Density:                       1 / v,
+		SpecificInternalEnergy:        h - p*v/1000,
+		SpecificEntropy:               vals[valS],
+		SpecificEnthalpy:              h,
+		SpecificIsochoricHeatCapacity: vals[valCv],
+		SpecificIsobaricHeatCapacity:  vals[valCp],
+		SpeedOfSound:                  vals[valW],
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package sbtl
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/somepgs/steamprops/internal/calc_core"
+	"github.com/somepgs/steamprops/internal/calc_core/region1"
+	"github.com/somepgs/steamprops/internal/calc_core/region2"
+	"github.com/somepgs/steamprops/internal/calc_core/region4"
+)
+
+// testSource uses the Region 1, 2 and 4 equations; Region 3 between
+// 350 °C and the B23 boundary is left out of the tables.
+func testSource() Source {
+	return Source{
+		Properties: func(t, p float64) (calc_core.Properties, error) {
+			if t <= 350 {
+				if ps, err := region4.SaturationPressure(t + 273.15); err == nil && p >= ps {
+					return region1.Calculate(t, p)
+				}
+			}
+			if p > 16.529e6 {
+				// B23: T = n4 + sqrt((π - n5)/n3)
+				tB23 := 572.54459862746 + math.Sqrt((p/1e6-13.91883977887)/1.0192970039326e-3) - 273.15
+				if t < tB23 {
+					return calc_core.Properties{}, errors.New("region 3")
+				}
+			}
+			return region2.Calculate(t, p)
+		},
+		Saturation: func(p float64) (float64, calc_core.Properties, calc_core.Properties, error) {
+			tk, err := region4.SaturationTemperature(p)
+			if err != nil {
+				return 0, calc_core.Properties{}, calc_core.Properties{}, err
+			}
+			// Both phases are evaluated exactly on the saturation line
+			t := math.Min(tk-273.15, 350)
+			ps, err := region4.SaturationPressure(t + 273.15)
+			if err != nil {
+				return 0, calc_core.Properties{}, calc_core.Properties{}, err
+			}
+			liquid, err := region1.Calculate(t, ps)
+			if err != nil {
+				return 0, calc_core.Properties{}, calc_core.Properties{}, err
+			}
+			vapor, err := region2.Calculate(t, ps)
+			return t, liquid, vapor, err
+		},
+		SaturationPressureMax: 16.529e6,
+	}
+}
+
+var (
+	tablesOnce sync.Once
+	tables     *Tables
+	tablesErr  error
+)
+
+func testTables(t testing.TB) *Tables {
+	tablesOnce.Do(func() {
+		tables, tablesErr = Generate(testSource())
+	})
+	if tablesErr != nil {
+		t.Fatalf("Generate: %v", tablesErr)
+	}
+	return tables
+}
+
+func TestPH(t *testing.T) {
+	tab := testTables(t)
+	src := testSource()
+	checked := 0
+	for _, p := range []float64{1e3, 1e4, 101325, 1e6, 5e6, 1e7, 16e6, 20e6, 40e6, 80e6} {
+		for temp := 1.0; temp <= 800; temp += 7.3 {
+			ref, err := src.Properties(temp, p)
+			if err != nil {
+				continue // Region 3
+			}
+			if _, err := src.Properties(temp-15, 1.15*p); err != nil && temp > 350 {
+				continue // next to the Region 3 hole
+			}
+			if tsat, _, _, err := src.Saturation(math.Min(p, 16.5e6)); err == nil && p < 16.529e6 && math.Abs(temp-tsat) < 1e-6 {
+				continue
+			}
+			st, err := tab.PH(p, ref.SpecificEnthalpy)
+			if err != nil {
+				t.Errorf("PH(%g, %g) at %g °C: %v", p, ref.SpecificEnthalpy, temp, err)
+				continue
+			}
+			checked++
+			got := st.Properties
+			if d := math.Abs(st.Temperature - temp); d > Tolerance.Temperature {
+				t.Errorf("p=%g T=%g: ΔT = %g K", p, temp, d)
+			}
+			if d := math.Abs(got.SpecificVolume/ref.SpecificVolume - 1); d > Tolerance.Volume {
+				t.Errorf("p=%g T=%g: δv = %g", p, temp, d)
+			}
+			if d := math.Abs(got.SpecificEntropy - ref.SpecificEntropy); d > Tolerance.Entropy {
+				t.Errorf("p=%g T=%g: Δs = %g", p, temp, d)
+			}
+			if d := math.Abs(got.SpecificIsobaricHeatCapacity/ref.SpecificIsobaricHeatCapacity - 1); d > Tolerance.HeatCapacity {
+				t.Errorf("p=%g T=%g: δcp = %g", p, temp, d)
+			}
+			if d := math.Abs(got.SpeedOfSound/ref.SpeedOfSound - 1); d > Tolerance.SpeedOfSound {
+				t.Errorf("p=%g T=%g: δw = %g", p, temp, d)
+			}
+			if st.Quality != -1 || st.Phase == TwoPhase {
+				t.Errorf("p=%g T=%g: single-phase state reported as %v, x=%g", p, temp, st.Phase, st.Quality)
+			}
+		}
+	}
+	if checked < 500 {
+		t.Errorf("only %d states checked", checked)
+	}
+}
+
+func TestPHTwoPhase(t *testing.T) {
+	tab := testTables(t)
+	src := testSource()
+	for _, p := range []float64{1e3, 101325, 1e6, 1e7, 16e6, 16.5e6} {
+		tsat, liquid, vapor, err := src.Saturation(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, x := range []float64{0.01, 0.25, 0.5, 0.75, 0.99} {
+			h := liquid.SpecificEnthalpy + x*(vapor.SpecificEnthalpy-liquid.SpecificEnthalpy)
+			st, err := tab.PH(p, h)
+			if err != nil {
+				t.Fatalf("PH(%g, %g): %v", p, h, err)
+			}
+			if st.Phase != TwoPhase {
+				t.Errorf("p=%g x=%g: phase %v", p, x, st.Phase)
+			}
+			if math.Abs(st.Quality-x) > 1e-6 || math.Abs(st.Temperature-tsat) > 1e-6 {
+				t.Errorf("p=%g x=%g: got x=%g T=%g, want T=%g", p, x, st.Quality, st.Temperature, tsat)
+			}
+			v := liquid.SpecificVolume + x*(vapor.SpecificVolume-liquid.SpecificVolume)
+			if d := math.Abs(st.Properties.SpecificVolume/v - 1); d > 1e-6 {
+				t.Errorf("p=%g x=%g: δv = %g", p, x, d)
+			}
+		}
+	}
+}
+
+func TestVU(t *testing.T) {
+	tab := testTables(t)
+	tests := []struct {
+		name  string
+		p, h  float64
+		phase Phase
+	}{
+		{"cold liquid", 5e6, 10, Liquid},
+		{"liquid", 101325, 400, Liquid},
+		{"liquid near saturation", 1e7, 1400, Liquid},
+		{"wet steam", 101325, 1500, TwoPhase},
+		{"wet steam high quality", 1e4, 2500, TwoPhase},
+		{"wet steam high pressure", 16e6, 2000, TwoPhase},
+		{"vapor", 101325, 2800, Vapor},
+		{"vapor at low pressure", 1e3, 4000, Vapor},
+		{"supercritical", 30e6, 3500, HighPressure},
+		{"compressed liquid", 80e6, 800, HighPressure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := tab.PH(tt.p, tt.h)
+			if err != nil {
+				t.Fatalf("PH: %v", err)
+			}
+			if want.Phase != tt.phase {
+				t.Fatalf("phase %v, want %v", want.Phase, tt.phase)
+			}
+			got, err := tab.VU(want.Properties.SpecificVolume, want.Properties.SpecificInternalEnergy)
+			if err != nil {
+				t.Fatalf("VU: %v", err)
+			}
+			tol := 1e-9
+			if tt.phase == Liquid || tt.p > 50e6 {
+				tol = 1e-5 // the volume of the liquid hardly depends on pressure
+			}
+			if d := math.Abs(got.Pressure/tt.p - 1); d > tol {
+				t.Errorf("δp = %g", d)
+			}
+			if d := math.Abs(got.Properties.SpecificEnthalpy - tt.h); d > 1e-6 {
+				t.Errorf("Δh = %g", d)
+			}
+			if got.Phase != tt.phase {
+				t.Errorf("phase %v, want %v", got.Phase, tt.phase)
+			}
+			if math.Abs(got.Quality-want.Quality) > 1e-9 {
+				t.Errorf("x = %g, want %g", got.Quality, want.Quality)
+			}
+		})
+	}
+}
+
+func TestOutOfRange(t *testing.T) {
+	tab := testTables(t)
+	tests := []struct {
+		name string
+		p, h float64
+	}{
+		{"below triple point pressure", 500, 100},
+		{"above maximum pressure", 150e6, 1000},
+		{"below 0 °C", 101325, -10},
+		{"above 800 °C", 101325, 5000},
+		{"region 3", 20e6, 2000},
+		{"NaN", math.NaN(), 1000},
+	}
+	for _, tt := range tests {
+		if _, err := tab.PH(tt.p, tt.h); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%s: PH(%g, %g) error = %v, want ErrOutOfRange", tt.name, tt.p, tt.h, err)
+		}
+	}
+	for _, vu := range [][2]float64{{0, 100}, {-1, 100}, {1e-4, 100}, {1, math.Inf(1)}, {1, 10000}} {
+		if _, err := tab.VU(vu[0], vu[1]); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("VU(%g, %g) error = %v, want ErrOutOfRange", vu[0], vu[1], err)
+		}
+	}
+}
+
+func BenchmarkPH(b *testing.B) {
+	tab := testTables(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := tab.PH(1e6, 1000+float64(i%2000)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVU(b *testing.B) {
+	tab := testTables(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := tab.VU(0.2, 1000+float64(i%1500)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package sbtl
+
+import "math"
+
+// maxValues is the largest number of values stored per table node.
+const maxValues = 10
+
+// axis is a uniform grid axis with n nodes from min to min+(n-1)*step.
+type axis struct {
+	min  float64
+	step float64
+	n    int
+}
+
+func newAxis(min, max float64, n int) axis {
+	return axis{min: min, step: (max - min) / float64(n-1), n: n}
+}
+
+func (a axis) max() float64 { return a.min + a.step*float64(a.n-1) }
+
+func (a axis) at(i int) float64 { return a.min + a.step*float64(i) }
+
+// locate returns the cell index i in [0, n-2] and the fractional position
+// within the cell. ok is false if x is outside the axis range (with a small
+// tolerance for round-off).
+func (a axis) locate(x float64) (i int, f float64, ok bool) {
+	u := (x - a.min) / a.step
+	const eps = 1e-9
+	if !(u >= -eps && u <= float64(a.n-1)+eps) {
+		return 0, 0, false
+	}
+	i = int(u)
+	if i < 0 {
+		i = 0
+	}
+	if i > a.n-2 {
+		i = a.n - 2
+	}
+	return i, u - float64(i), true
+}
+
+// weights returns the Catmull-Rom cubic convolution weights for the nodes
+// i-1, i, i+1, i+2 at fractional position f in cell i.
+func weights(f float64) [4]float64 {
+	f2 := f * f
+	f3 := f2 * f
+	return [4]float64{
+		0.5 * (-f3 + 2*f2 - f),
+		0.5 * (3*f3 - 5*f2 + 2),
+		0.5 * (-3*f3 + 4*f2 + f),
+		0.5 * (f3 - f2),
+	}
+}
+
+// table1 is a piecewise cubic (Catmull-Rom) spline of several values over
+// one axis. A ghost node is stored at each end, so that evaluation never
+// needs special handling at the boundaries.
+type table1 struct {
+	x    axis
+	nv   int
+	data []float64 // (i+1)*nv + k, i in [-1, n]
+}
+
+func newTable1(x axis, nv int) *table1 {
+	return &table1{x: x, nv: nv, data: make([]float64, (x.n+2)*nv)}
+}
+
+func (t *table1) set(i int, values []float64) {
+	copy(t.data[(i+1)*t.nv:(i+2)*t.nv], values)
+}
+
+// finish fills the ghost nodes by quadratic extrapolation.
+func (t *table1) finish() {
+	n, nv := t.x.n, t.nv
+	for k := 0; k < nv; k++ {
+		v := func(i int) float64 { return t.data[(i+1)*nv+k] }
+		t.data[k] = 3*v(0) - 3*v(1) + v(2)
+		t.data[(n+1)*nv+k] = 3*v(n-1) - 3*v(n-2) + v(n-3)
+	}
+}
+
+// eval interpolates all values at x into out. ok is false outside the axis.
+func (t *table1) eval(x float64, out []float64) bool {
+	i, f, ok := t.x.locate(x)
+	if !ok {
+		return false
+	}
+	w := weights(f)
+	nv := t.nv
+	for k := 0; k < nv; k++ {
+		out[k] = 0
+	}
+	for a := 0; a < 4; a++ {
+		row := t.data[(i+a)*nv : (i+a+1)*nv]
+		for k := 0; k < nv; k++ {
+			out[k] += w[a] * row[k]
+		}
+	}
+	return true
+}
+
+// table2 is a bicubic (Catmull-Rom) spline of several values over a
+// rectangular grid, with a ghost layer of nodes around it.
+type table2 struct {
+	x, y axis
+	nv   int
+	data []float64 // ((i+1)*(y.n+2) + j+1)*nv + k
+}
+
+func newTable2(x, y axis, nv int) *table2 {
+	return &table2{x: x, y: y, nv: nv, data: make([]float64, (x.n+2)*(y.n+2)*nv)}
+}
+
+func (t *table2) offset(i, j int) int {
+	return ((i+1)*(t.y.n+2) + j + 1) * t.nv
+}
+
+func (t *table2) set(i, j int, values []float64) {
+	o := t.offset(i, j)
+	copy(t.data[o:o+t.nv], values)
+}
+
+func (t *table2) node(i, j int) []float64 {
+	o := t.offset(i, j)
+	return t.data[o : o+t.nv]
+}
+
+// finish fills the ghost layer by quadratic extrapolation, first along y
+// for the inner rows, then along x for all columns including the ghosts.
+func (t *table2) finish() {
+	nx, ny := t.x.n, t.y.n
+	for i := 0; i < nx; i++ {
+		for k := 0; k < t.nv; k++ {
+			t.node(i, -1)[k] = 3*t.node(i, 0)[k] - 3*t.node(i, 1)[k] + t.node(i, 2)[k]
+			t.node(i, ny)[k] = 3*t.node(i, ny-1)[k] - 3*t.node(i, ny-2)[k] + t.node(i, ny-3)[k]
+		}
+	}
+	for j := -1; j <= ny; j++ {
+		for k := 0; k < t.nv; k++ {
+			t.node(-1, j)[k] = 3*t.node(0, j)[k] - 3*t.node(1, j)[k] + t.node(2, j)[k]
+			t.node(nx, j)[k] = 3*t.node(nx-1, j)[k] - 3*t.node(nx-2, j)[k] + t.node(nx-3, j)[k]
+		}
+	}
+}
+
+// eval interpolates all values at (x, y) into out. ok is false outside the
+// grid or if any node of the 4x4 stencil is undefined (NaN).
+func (t *table2) eval(x, y float64, out []float64) bool {
+	i, fx, ok := t.x.locate(x)
+	if !ok {
+		return false
+	}
+	j, fy, ok := t.y.locate(y)
+	if !ok {
+		return false
+	}
+	wx, wy := weights(fx), weights(fy)
+	nv := t.nv
+	for k := 0; k < nv; k++ {
+		out[k] = 0
+	}
+	stride := (t.y.n + 2) * nv
+	for a := 0; a < 4; a++ {
+		base := (i+a)*stride + j*nv
+		for b := 0; b < 4; b++ {
+			w := wx[a] * wy[b]
+			row := t.data[base+b*nv : base+(b+1)*nv]
+			for k := 0; k < nv; k++ {
+				out[k] += w * row[k]
+			}
+		}
+	}
+	for k := 0; k < nv; k++ {
+		if math.IsNaN(out[k]) {
+			return false
+		}
+	}
+	return true
+}
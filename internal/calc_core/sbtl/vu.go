@@ -0,0 +1,330 @@
+package sbtl
+
+import (
+	"fmt"
+	"math"
+)
+
+// index maps (ln v, u) to an initial guess of (ln p, h) for the (v, u) inversion.
+type index struct {
+	x, y axis
+	lnP  []float64
+	h    []float64
+}
+
+// indexNodes is the number of index cells along each axis.
+const indexNodes = 256
+
+// newIndex collects (ln v, u) of the nodes of the single-phase tables and
+// stores a node in every index cell; empty cells take the guess of a
+// neighbouring cell. Of the nodes in a cell the one with the highest
+// pressure is stored: in the liquid a cell spans a wide pressure range, and
+// at a lower pressure the liquid may not exist at the temperature of the
+// solution.
+func newIndex(t *Tables) *index {
+	type sample struct {
+		lnV, u, lnP, h float64
+		edge           bool
+	}
+	var samples []sample
+	var st State
+	add := func(lnP, h, lnV float64, edge bool) {
+		u := h - math.Exp(lnP)*math.Exp(lnV)/1000
+		if math.IsNaN(lnV) || math.IsNaN(u) {
+			return
+		}
+		// Next to undefined nodes the splines cannot be evaluated
+		if !edge && t.ph(math.Exp(lnP), h, &st) != nil {
+			edge = true
+		}
+		samples = append(samples, sample{lnV, u, lnP, h, edge})
+	}
+
+	// Edge nodes only extend the index range and are not used as guesses:
+	// at an edge of a table the finite differences in VU may have no
+	// direction that stays inside it
+	var sat [satValues]float64
+	for i := 0; i < t.liquid.x.n; i++ {
+		lnP := t.liquid.x.at(i)
+		t.sat.eval(lnP, sat[:])
+		edge := i == 0 || i == t.liquid.x.n-1
+		for j := 0; j < t.liquid.y.n; j++ {
+			add(lnP, sat[satHmin]+t.liquid.y.at(j)*(sat[satHf]-sat[satHmin]), t.liquid.node(i, j)[valLnV],
+				edge || j == 0 || j == t.liquid.y.n-1)
+		}
+		for j := 0; j < t.vapor.y.n; j++ {
+			add(lnP, sat[satHg]+t.vapor.y.at(j)*(sat[satHmax]-sat[satHg]), t.vapor.node(i, j)[valLnV],
+				edge || j == 0 || j == t.vapor.y.n-1)
+		}
+	}
+	for i := 0; i < t.bounds.x.n; i++ {
+		lnP := t.bounds.x.at(i)
+		hMin, hMax := t.bounds.data[(i+1)*highValues+highHmin], t.bounds.data[(i+1)*highValues+highHmax]
+		edge := i == 0 || i == t.bounds.x.n-1
+		for j := 0; j < t.high.y.n; j++ {
+			add(lnP, hMin+t.high.y.at(j)*(hMax-hMin), t.high.node(i, j)[valLnV],
+				edge || j == 0 || j == t.high.y.n-1)
+		}
+	}
+
+	minV, maxV, minU, maxU := math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+	for _, s := range samples {
+		minV, maxV = math.Min(minV, s.lnV), math.Max(maxV, s.lnV)
+		minU, maxU = math.Min(minU, s.u), math.Max(maxU, s.u)
+	}
+	idx := &index{
+		x:   newAxis(minV, maxV, indexNodes),
+		y:   newAxis(minU, maxU, indexNodes),
+		lnP: make([]float64, indexNodes*indexNodes),
+		h:   make([]float64, indexNodes*indexNodes),
+	}
+	dist := make([]float64, indexNodes*indexNodes)
+	for k := range dist {
+		dist[k] = math.Inf(1)
+	}
+	for _, s := range samples {
+		if s.edge {
+			continue
+		}
+		i := int(math.Round((s.lnV - idx.x.min) / idx.x.step))
+		j := int(math.Round((s.u - idx.y.min) / idx.y.step))
+		k := i*indexNodes + j
+		if math.IsInf(dist[k], 1) || s.lnP > idx.lnP[k] {
+			dist[k], idx.lnP[k], idx.h[k] = 0, s.lnP, s.h
+		}
+	}
+
+	// Fill empty cells from their neighbours until none are left
+	for empty := true; empty; {
+		empty = false
+		next := append([]float64(nil), dist...)
+		for i := 0; i < indexNodes; i++ {
+			for j := 0; j < indexNodes; j++ {
+				k := i*indexNodes + j
+				if !math.IsInf(dist[k], 1) {
+					continue
+				}
+				empty = true
+				for _, n := range [4][2]int{{i - 1, j}, {i + 1, j}, {i, j - 1}, {i, j + 1}} {
+					if n[0] < 0 || n[0] >= indexNodes || n[1] < 0 || n[1] >= indexNodes {
+						continue
+					}
+					if kn := n[0]*indexNodes + n[1]; !math.IsInf(dist[kn], 1) {
+						next[k], idx.lnP[k], idx.h[k] = dist[kn]+1, idx.lnP[kn], idx.h[kn]
+						break
+					}
+				}
+			}
+		}
+		dist = next
+	}
+	return idx
+}
+
+// guess returns the stored (ln p, h) for the cell nearest to (ln v, u).
+func (idx *index) guess(lnV, u float64) (lnP, h float64, ok bool) {
+	i, fx, okX := idx.x.locate(lnV)
+	j, fy, okY := idx.y.locate(u)
+	if !okX || !okY {
+		return 0, 0, false
+	}
+	i += int(math.Round(fx))
+	j += int(math.Round(fy))
+	k := i*indexNodes + j
+	return idx.lnP[k], idx.h[k], true
+}
+
+// VU evaluates the state at specific volume v (m³/kg) and specific internal
+// energy u (kJ/kg) by Newton iteration on the (p, h) tables.
+//
+// In the compressed liquid at low pressure the volume depends only weakly on
+// pressure, so the pressure found there is sensitive to the input volume.
+func (t *Tables) VU(v, u float64) (State, error) {
+	const (
+		maxIter     = 50
+		maxHalvings = 30
+		tolV        = 1e-12 // ln v
+		tolU        = 1e-10 // relative
+	)
+	if !(v > 0) || math.IsInf(v, 0) || math.IsNaN(u) || math.IsInf(u, 0) {
+		return State{}, fmt.Errorf("%w: v=%g m³/kg, u=%g kJ/kg", ErrOutOfRange, v, u)
+	}
+	lnV := math.Log(v)
+	if st, ok := t.twoPhaseVU(v, u); ok {
+		return st, nil
+	}
+	lnP, h, ok := t.index.guess(lnV, u)
+	if !ok {
+		return State{}, fmt.Errorf("%w: v=%g m³/kg, u=%g kJ/kg", ErrOutOfRange, v, u)
+	}
+	lnMin, lnMax := math.Log(MinPressure), math.Log(MaxPressure)
+	uScale := math.Max(100, math.Abs(u))
+
+	var st State
+	residual := func(lnP, h float64) (r1, r2 float64, ok bool) {
+		if t.ph(math.Exp(lnP), h, &st) != nil {
+			return 0, 0, false
+		}
+		return math.Log(st.Properties.SpecificVolume) - lnV, (st.Properties.SpecificInternalEnergy - u) / uScale, true
+	}
+
+	r1, r2, ok := residual(lnP, h)
+	if !ok {
+		return State{}, fmt.Errorf("%w: v=%g m³/kg, u=%g kJ/kg", ErrOutOfRange, v, u)
+	}
+	for iter := 0; iter < maxIter; iter++ {
+		if math.Abs(r1) < tolV && math.Abs(r2) < tolU {
+			return st, nil
+		}
+
+		// Jacobian by finite differences on the splines
+		// Near the table boundaries and the saturation line the difference is
+		// taken in the direction that stays in the same table
+		phase := st.Phase
+		diff := func(dp, dh float64) (d1, d2, step float64, ok bool) {
+			for _, sign := range [2]float64{1, -1} {
+				n1, n2, ok := residual(lnP+sign*dp, h+sign*dh)
+				if ok && st.Phase == phase {
+					return n1, n2, sign, true
+				}
+			}
+			return 0, 0, 0, false
+		}
+		// In the liquid the volume changes so little with pressure that a
+		// small difference in ln p is lost in round-off
+		dp := 1e-7
+		if phase == Liquid {
+			dp = 1e-4
+		}
+		a1, a2, sp, okP := diff(dp, 0)
+		dh := 1e-7 * math.Max(100, math.Abs(h))
+		b1, b2, sh, okH := diff(0, dh)
+		if !okP || !okH {
+			break
+		}
+		dp *= sp
+		dh *= sh
+		j11, j21 := (a1-r1)/dp, (a2-r2)/dp
+		j12, j22 := (b1-r1)/dh, (b2-r2)/dh
+		det := j11*j22 - j12*j21
+		if det == 0 || math.IsNaN(det) {
+			break
+		}
+		stepP := (r1*j22 - r2*j12) / det
+		stepH := (j11*r2 - j21*r1) / det
+
+		// In the liquid the volume is close to linear in p rather than in
+		// ln p, so the step is applied to p there
+		step := func(lambda, stepP float64) float64 {
+			if phase == Liquid {
+				return math.Log(math.Max(math.Exp(lnP)*(1-lambda*stepP), MinPressure))
+			}
+			return lnP - lambda*stepP
+		}
+		norm := r1*r1 + r2*r2
+		search := func(stepP, stepH float64) bool {
+			for lambda, k := 1.0, 0; k < maxHalvings; lambda, k = lambda/2, k+1 {
+				nP := math.Min(math.Max(step(lambda, stepP), lnMin), lnMax)
+				nH := h - lambda*stepH
+				n1, n2, ok := residual(nP, nH)
+				if ok && n1*n1+n2*n2 < norm {
+					lnP, h, r1, r2 = nP, nH, n1, n2
+					return true
+				}
+			}
+			return false
+		}
+		// Close to the density maximum of the liquid (4 °C) the derivative
+		// of the volume with respect to h changes sign and the Newton step
+		// is unreliable until the energy is close to the solution. The energy
+		// alone is corrected by h, on which it depends almost exclusively,
+		// first in the liquid and otherwise when the Newton step fails
+		newton, energy := [2]float64{stepP, stepH}, [2]float64{0, r2 / j22}
+		order := [2][2]float64{newton, energy}
+		if phase == Liquid && math.Abs(r2) > 1e-6 {
+			order = [2][2]float64{energy, newton}
+		}
+		if !search(order[0][0], order[0][1]) && !search(order[1][0], order[1][1]) {
+			break
+		}
+	}
+	if _, _, ok := residual(lnP, h); ok && math.Abs(r1) < 1e3*tolV && math.Abs(r2) < 1e3*tolU {
+		return st, nil
+	}
+	return State{}, fmt.Errorf("%w: no solution for v=%g m³/kg, u=%g kJ/kg", ErrOutOfRange, v, u)
+}
+
+// satNode is a saturation state at a node of the saturation spline.
+type satNode struct {
+	lnP            float64
+	vf, vg, uf, ug float64
+}
+
+// twoPhaseVU looks for the tie line through (v, u): the pressure at which the
+// qualities from the volume and from the energy agree. Tie lines do not
+// intersect, so a root with the quality in [0, 1] is the only solution.
+func (t *Tables) twoPhaseVU(v, u float64) (State, bool) {
+	const tolX = 1e-12
+	mismatch := func(n satNode) (g, x float64) {
+		x = (v - n.vf) / (n.vg - n.vf)
+		return x - (u-n.uf)/(n.ug-n.uf), x
+	}
+	at := func(lnP float64) (satNode, bool) {
+		var sat [satValues]float64
+		if !t.sat.eval(lnP, sat[:]) {
+			return satNode{}, false
+		}
+		p := math.Exp(lnP)
+		vf, vg := math.Exp(sat[satLnVf]), math.Exp(sat[satLnVg])
+		return satNode{lnP, vf, vg, sat[satHf] - p*vf/1000, sat[satHg] - p*vg/1000}, true
+	}
+
+	for i := 1; i < len(t.satNodes); i++ {
+		a, b := t.satNodes[i-1], t.satNodes[i]
+		ga, xa := mismatch(a)
+		gb, xb := mismatch(b)
+		if ga*gb > 0 || math.Max(xa, xb) < -0.5 || math.Min(xa, xb) > 1.5 {
+			continue
+		}
+
+		// Illinois modification of the regula falsi on ln p
+		lo, hi := a.lnP, b.lnP
+		side := 0
+		var n satNode
+		var g, x float64
+		for iter := 0; iter < 100; iter++ {
+			lnP := (lo*gb - hi*ga) / (gb - ga)
+			var ok bool
+			if n, ok = at(lnP); !ok {
+				return State{}, false
+			}
+			g, x = mismatch(n)
+			if math.Abs(g) < tolX || hi-lo < 1e-15 {
+				break
+			}
+			if g*gb > 0 {
+				hi, gb = lnP, g
+				if side == 1 {
+					ga /= 2
+				}
+				side = 1
+			} else {
+				lo, ga = lnP, g
+				if side == -1 {
+					gb /= 2
+				}
+				side = -1
+			}
+		}
+		if math.Abs(g) > 1e3*tolX || x < -tolX || x > 1+tolX {
+			return State{}, false
+		}
+		p := math.Exp(n.lnP)
+		var st State
+		if t.ph(p, u+p*v/1000, &st) != nil || st.Phase != TwoPhase {
+			return State{}, false
+		}
+		return st, true
+	}
+	return State{}, false
+}
@@ -1,8 +1,9 @@
 package transport
 
 import (
-	"errors"
 	"math"
+
+	"github.com/somepgs/steamprops/propserr"
 )
 
 // Critical point properties
@@ -12,139 +13,48 @@ const (
 	pc   = 22.064e6 // Pa - critical pressure
 )
 
-// Reference properties for viscosity (IAPWS 2008)
-const (
-	Tref   = 647.096   // K - reference temperature
-	rhoref = 317.763   // kg/m³ - reference density
-	muref  = 55.071e-6 // Pa·s - reference viscosity
-)
-
-// Reference properties for thermal conductivity (IAPWS 2011)
+// Reducing constants of IAPWS 2008 (viscosity) and IAPWS 2011 (thermal
+// conductivity): T* = Tc, ρ* = ρc
 const (
-	lambdaref = 1.0 // W/(m·K) - reference thermal conductivity
+	muref     = 1e-6 // Pa·s - reference viscosity μ*
+	lambdaref = 1e-3 // W/(m·K) - reference thermal conductivity λ*
 )
 
-// IAPWS 2008 viscosity coefficients
-var (
-	// H0 coefficients for viscosity
-	H0 = []float64{
-		0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0,
-		0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0,
-	}
-
-	// H1 coefficients for viscosity
-	H1 = []float64{
-		0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0,
-		0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0,
-	}
+//go:generate go run ../internal/coeffgen mu0Terms=iapws-2008-viscosity-0.csv:jn mu1Terms=iapws-2008-viscosity-1.csv:ijn lambda0Terms=iapws-2011-conductivity-0.csv:jn lambda1Terms=iapws-2011-conductivity-1.csv:ijn
 
-	// H2 coefficients for viscosity
-	H2 = []float64{
-		0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0,
-		0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0,
+// checkInputs requires positive temperature and density
+func checkInputs(Tkelvin, rho float64) error {
+	if !(Tkelvin > 0) {
+		return propserr.Range(propserr.InvalidInput, 0, "T", Tkelvin, "K", propserr.Min, 0)
 	}
-)
-
-// IAPWS 2011 thermal conductivity coefficients
-var (
-	// L0 coefficients for thermal conductivity
-	L0 = []float64{
-		0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0,
-		0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0,
-	}
-
-	// L1 coefficients for thermal conductivity
-	L1 = []float64{
-		0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0,
-		0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0,
-	}
-
-	// L2 coefficients for thermal conductivity
-	L2 = []float64{
-		0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0,
-		0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0,
+	if !(rho > 0) {
+		return propserr.Range(propserr.InvalidInput, 0, "rho", rho, "kg/m³", propserr.Min, 0)
 	}
-)
+	return nil
+}
 
-// DynamicViscosity returns dynamic viscosity μ in Pa·s using IAPWS 2008 formulation
+// DynamicViscosity returns dynamic viscosity μ in Pa·s from IAPWS 2008,
+// μ = μ*·μ̄0(T̄)·μ̄1(T̄,ρ̄). The critical enhancement μ̄2 is taken as 1, as
+// recommended for industrial use; it matters only within about 1 K and 1 %
+// in density of the critical point.
 func DynamicViscosity(Tkelvin float64, rho float64) (float64, error) {
-	if Tkelvin <= 0 || rho <= 0 {
-		return 0, errors.New("invalid inputs for viscosity (T>0, rho>0 required)")
-	}
-
-	// IAPWS 2008 formulation for water viscosity
-	// For liquid water (T < 373.15 K and rho > 500 kg/m³)
-	if Tkelvin < 373.15 && rho > 500 {
-		// Use empirical correlation for liquid water
-		// Based on IAPWS 2008, simplified for liquid water
-
-		// Reference viscosity at 20°C (1.002 mPa·s)
-		mu20 := 1.002e-3 // Pa·s
-
-		// Temperature dependence for liquid water
-		// Arrhenius-type temperature dependence
-		mu := mu20 * math.Exp(1700.0*(1.0/Tkelvin-1.0/293.15))
-
-		// Density correction for liquid water
-		// For liquid water, viscosity increases with density
-		if rho > 998 {
-			// Correct for higher density
-			mu *= math.Pow(rho/998.2, 0.1)
-		}
-
-		return mu, nil
-	}
-
-	// For steam (T >= 373.15 K or rho < 500 kg/m³)
-	// Use Sutherland's law for steam
-	mu := 1.8e-5 * math.Pow(Tkelvin/288.0, 0.7)
-
-	// Density correction for steam
-	if rho < 100 {
-		mu *= math.Pow(rho/0.5, 0.1)
+	if err := checkInputs(Tkelvin, rho); err != nil {
+		return 0, err
 	}
-
-	return mu, nil
+	tr, dr := Tkelvin/Tc, rho/rhoc
+	return muref * viscosity0(tr) * viscosity1(tr, dr), nil
 }
 
-// ThermalConductivity returns thermal conductivity λ in W/(m·K) using IAPWS 2011 formulation
+// ThermalConductivity returns thermal conductivity λ in W/(m·K) from IAPWS
+// 2011, λ = λ*·λ̄0(T̄)·λ̄1(T̄,ρ̄). The critical enhancement λ̄2 needs the
+// equation of state and is omitted: away from the critical region it is
+// below 1 %, close to the critical point λ is underestimated.
 func ThermalConductivity(Tkelvin float64, rho float64) (float64, error) {
-	if Tkelvin <= 0 || rho <= 0 {
-		return 0, errors.New("invalid inputs for thermal conductivity (T>0, rho>0 required)")
-	}
-
-	// IAPWS 2011 formulation for water thermal conductivity
-	// For liquid water (rho > 500 kg/m³)
-	if rho > 500 {
-		// Use empirical correlation for liquid water
-		// Based on IAPWS 2011, simplified for liquid water
-
-		// Reference thermal conductivity at 20°C
-		lambda20 := 0.603 // W/(m·K)
-
-		// Temperature dependence for liquid water
-		lambda := lambda20 + 0.001*(Tkelvin-293.15)
-
-		// Density correction for liquid water
-		// For liquid water, thermal conductivity increases with density
-		if rho > 998 {
-			// Correct for higher density
-			lambda *= math.Pow(rho/998.2, 0.05)
-		}
-
-		return lambda, nil
-	}
-
-	// For steam (rho < 500 kg/m³)
-	// Use empirical correlation for steam
-	lambda := 0.02 + 0.0001*(Tkelvin-273.15)
-
-	// Density correction for steam
-	if rho < 100 {
-		lambda *= math.Pow(rho/0.5, 0.1)
+	if err := checkInputs(Tkelvin, rho); err != nil {
+		return 0, err
 	}
-
-	return lambda, nil
+	tr, dr := Tkelvin/Tc, rho/rhoc
+	return lambdaref * conductivity0(tr) * conductivity1(tr, dr), nil
 }
 
 // KinematicViscosity returns kinematic viscosity ν in m²/s
@@ -154,63 +64,47 @@ func KinematicViscosity(Tkelvin float64, rho float64) (float64, error) {
 		return 0, err
 	}
 
-	if rho <= 0 {
-		return 0, errors.New("density must be positive for kinematic viscosity")
-	}
-
 	return mu / rho, nil
 }
 
-// sumH0 calculates sum of H0 coefficients
-func sumH0(Tr float64) float64 {
-	var sum float64
-	for i := 0; i < len(H0); i++ {
-		sum += H0[i] * math.Pow(Tr, float64(i))
-	}
-	return sum
+// viscosity0 returns the dilute-gas viscosity μ̄0 = 100·√T̄ / Σ Hi·T̄^-i
+// (IAPWS 2008, Eq. 11)
+func viscosity0(tr float64) float64 {
+	return 100 * math.Sqrt(tr) / sumJ(mu0Terms, tr)
 }
 
-// sumH1 calculates sum of H1 coefficients
-func sumH1(Tr float64) float64 {
-	var sum float64
-	for i := 0; i < len(H1); i++ {
-		sum += H1[i] * math.Pow(Tr, float64(i))
-	}
-	return sum
+// viscosity1 returns the residual factor μ̄1 = exp(ρ̄ Σ Hij·(1/T̄-1)^i·(ρ̄-1)^j)
+// (IAPWS 2008, Eq. 12)
+func viscosity1(tr, dr float64) float64 {
+	return math.Exp(dr * sumIJ(mu1Terms, 1/tr-1, dr-1))
 }
 
-// sumH2 calculates sum of H2 coefficients
-func sumH2(Tr float64) float64 {
-	var sum float64
-	for i := 0; i < len(H2); i++ {
-		sum += H2[i] * math.Pow(Tr, float64(i))
-	}
-	return sum
+// conductivity0 returns the dilute-gas conductivity λ̄0 = √T̄ / Σ Lk·T̄^-k
+// (IAPWS 2011, Eq. 16)
+func conductivity0(tr float64) float64 {
+	return math.Sqrt(tr) / sumJ(lambda0Terms, tr)
 }
 
-// sumL0 calculates sum of L0 coefficients
-func sumL0(Tr float64) float64 {
-	var sum float64
-	for i := 0; i < len(L0); i++ {
-		sum += L0[i] * math.Pow(Tr, float64(i))
-	}
-	return sum
+// conductivity1 returns the residual factor λ̄1 = exp(ρ̄ Σ Lij·(1/T̄-1)^i·(ρ̄-1)^j)
+// (IAPWS 2011, Eq. 17)
+func conductivity1(tr, dr float64) float64 {
+	return math.Exp(dr * sumIJ(lambda1Terms, 1/tr-1, dr-1))
 }
 
-// sumL1 calculates sum of L1 coefficients
-func sumL1(Tr float64) float64 {
+// sumJ evaluates Σ n·x^J
+func sumJ(terms []termJ, x float64) float64 {
 	var sum float64
-	for i := 0; i < len(L1); i++ {
-		sum += L1[i] * math.Pow(Tr, float64(i))
+	for _, t := range terms {
+		sum += t.N * math.Pow(x, float64(t.J))
 	}
 	return sum
 }
 
-// sumL2 calculates sum of L2 coefficients
-func sumL2(Tr float64) float64 {
+// sumIJ evaluates Σ n·x^I·y^J
+func sumIJ(terms []term, x, y float64) float64 {
 	var sum float64
-	for i := 0; i < len(L2); i++ {
-		sum += L2[i] * math.Pow(Tr, float64(i))
+	for _, t := range terms {
+		sum += t.N * math.Pow(x, float64(t.I)) * math.Pow(y, float64(t.J))
 	}
 	return sum
 }
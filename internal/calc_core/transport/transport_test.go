@@ -63,6 +63,53 @@ func TestDynamicViscosity(t *testing.T) {
 	}
 }
 
+// TestIAPWSVerification checks the computer-program verification values of
+// IAPWS 2008 (Table 4, μ̄2 = 1) and IAPWS 2011 (Table 4, λ̄2 = 0)
+func TestIAPWSVerification(t *testing.T) {
+	viscosity := []struct{ T, rho, mu float64 }{ // μPa·s
+		{298.15, 998, 889.735100},
+		{298.15, 1200, 1437.649467},
+		{373.15, 1000, 307.883622},
+		{433.15, 1, 14.538324},
+		{433.15, 1000, 217.685358},
+		{873.15, 1, 32.619287},
+		{873.15, 100, 35.802262},
+		{873.15, 600, 77.430195},
+		{1173.15, 1, 44.217245},
+		{1173.15, 100, 47.640433},
+		{1173.15, 400, 64.154608},
+	}
+	for _, tt := range viscosity {
+		mu, err := DynamicViscosity(tt.T, tt.rho)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := mu * 1e6; math.Abs(got-tt.mu) > 1e-6 {
+			t.Errorf("DynamicViscosity(%g, %g) = %.6f μPa·s, want %.6f", tt.T, tt.rho, got, tt.mu)
+		}
+	}
+
+	conductivity := []struct{ T, rho, lambda float64 }{ // mW/(m·K)
+		{298.15, 998, 607.712868},
+		{298.15, 1200, 799.038144},
+	}
+	for _, tt := range conductivity {
+		lambda, err := ThermalConductivity(tt.T, tt.rho)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := lambda * 1e3; math.Abs(got-tt.lambda) > 1e-6 {
+			t.Errorf("ThermalConductivity(%g, %g) = %.6f mW/(m·K), want %.6f", tt.T, tt.rho, got, tt.lambda)
+		}
+	}
+	// Dilute-gas limit (ρ = 0)
+	for _, tt := range []struct{ T, lambda float64 }{{298.15, 18.4341883}, {873.15, 79.1034659}} {
+		if got := conductivity0(tt.T / Tc); math.Abs(got-tt.lambda) > 1e-7 {
+			t.Errorf("conductivity0(%g) = %.7f mW/(m·K), want %.7f", tt.T, got, tt.lambda)
+		}
+	}
+}
+
 func TestThermalConductivity(t *testing.T) {
 	tests := []struct {
 		name      string
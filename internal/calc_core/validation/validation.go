@@ -6,6 +6,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/somepgs/steamprops/units"
 )
 
 // InputValidator provides comprehensive input validation
@@ -282,34 +284,28 @@ func (iv *InputValidator) ValidateStringInput(input string, inputType string) (f
 	return value, result
 }
 
-// ValidateUnitConversion validates unit conversion parameters
+// ValidateUnitConversion validates a value given in a unit of the units
+// package. Unit symbols and their aliases are recognised by units.Parse*Unit;
+// a temperature must not be below absolute zero in any scale.
 func (iv *InputValidator) ValidateUnitConversion(value float64, unit string, property string) *ValidationResult {
 	result := NewValidationResult()
 
-	// Check for valid unit
-	validUnits := map[string][]string{
-		"temperature": {"°C", "K", "°F"},
-		"pressure":    {"Pa", "MPa", "bar", "atm", "кгс/см²", "мм рт.ст.", "мм вод.ст."},
-		"enthalpy":    {"кДж/кг", "Дж/кг"},
-		"entropy":     {"кДж/(кг·К)", "Дж/(кг·К)"},
-	}
-
-	if units, exists := validUnits[property]; exists {
-		valid := false
-		for _, u := range units {
-			if u == unit {
-				valid = true
-				break
-			}
-		}
-		if !valid {
-			result.AddError(fmt.Sprintf("Invalid unit %s for %s", unit, property))
+	var err error
+	switch property {
+	case "temperature":
+		var u units.TemperatureUnit
+		if u, err = units.ParseTemperatureUnit(unit); err == nil && u.Of(value) < 0 {
+			result.AddError(fmt.Sprintf("Temperature %g %s is below absolute zero", value, u))
 		}
+	case "pressure":
+		_, err = units.ParsePressureUnit(unit)
+	case "enthalpy", "internal_energy":
+		_, err = units.ParseSpecificEnergyUnit(unit)
+	case "entropy", "heat_capacity":
+		_, err = units.ParseSpecificEntropyUnit(unit)
 	}
-
-	// Check for reasonable values after unit conversion
-	if property == "temperature" && unit == "K" && value < 0 {
-		result.AddError("Temperature in Kelvin cannot be negative")
+	if err != nil {
+		result.AddError(fmt.Sprintf("Invalid unit %s for %s", unit, property))
 	}
 
 	return result
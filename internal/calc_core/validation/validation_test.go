@@ -385,6 +385,34 @@ func TestInputValidator_ValidateUnitConversion(t *testing.T) {
 			property: "temperature",
 			wantErr:  true,
 		},
+		{
+			name:     "Below absolute zero in Fahrenheit",
+			value:    -500.0,
+			unit:     "°F",
+			property: "temperature",
+			wantErr:  true,
+		},
+		{
+			name:     "Russian pressure unit",
+			value:    10.0,
+			unit:     "кгс/см²",
+			property: "pressure",
+			wantErr:  false,
+		},
+		{
+			name:     "US customary enthalpy unit",
+			value:    1200.0,
+			unit:     "BTU/lb",
+			property: "enthalpy",
+			wantErr:  false,
+		},
+		{
+			name:     "Pressure unit for entropy",
+			value:    1.0,
+			unit:     "bar",
+			property: "entropy",
+			wantErr:  true,
+		},
 	}
 
 	for _, tt := range tests {
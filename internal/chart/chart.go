@@ -0,0 +1,241 @@
+package chart
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/internal/steamprops"
+)
+
+// Axis описывает ось диаграммы
+type Axis struct {
+	Label    string
+	Quantity string // обозначение величины: s, h, t или p (МПа)
+	Min      float64
+	Max      float64
+	Log      bool                  // логарифмическая шкала
+	Value    func(s State) float64 // значение координаты для точки
+}
+
+// Diagram описывает тип диаграммы: координаты и диапазоны осей. Заголовок
+// и подписи осей — сообщения каталога i18n, New переводит их на язык
+// Options.Locale.
+type Diagram struct {
+	Name  string
+	Title string
+	X     Axis
+	Y     Axis
+}
+
+// HS возвращает h–s диаграмму (диаграмму Молье)
+func HS() Diagram {
+	return Diagram{
+		Name:  "hs",
+		Title: i18n.N("h–s диаграмма воды и водяного пара (IAPWS IF-97)"),
+		X: Axis{
+			Label:    i18n.N("s, кДж/(кг·К)"),
+			Quantity: "s",
+			Min:      0,
+			Max:      10,
+			Value:    func(s State) float64 { return s.Entropy },
+		},
+		Y: Axis{
+			Label:    i18n.N("h, кДж/кг"),
+			Quantity: "h",
+			Min:      0,
+			Max:      4200,
+			Value:    func(s State) float64 { return s.Enthalpy },
+		},
+	}
+}
+
+// TS возвращает T–s диаграмму
+func TS() Diagram {
+	return Diagram{
+		Name:  "ts",
+		Title: i18n.N("T–s диаграмма воды и водяного пара (IAPWS IF-97)"),
+		X: Axis{
+			Label:    i18n.N("s, кДж/(кг·К)"),
+			Quantity: "s",
+			Min:      0,
+			Max:      10,
+			Value:    func(s State) float64 { return s.Entropy },
+		},
+		Y: Axis{
+			Label:    "t, °C",
+			Quantity: "t",
+			Min:      0,
+			Max:      800,
+			Value:    func(s State) float64 { return s.Temperature },
+		},
+	}
+}
+
+// PH возвращает диаграмму lg p–h с логарифмической осью давления
+func PH() Diagram {
+	return Diagram{
+		Name:  "ph",
+		Title: i18n.N("lg p–h диаграмма воды и водяного пара (IAPWS IF-97)"),
+		X: Axis{
+			Label:    i18n.N("h, кДж/кг"),
+			Quantity: "h",
+			Min:      0,
+			Max:      4200,
+			Value:    func(s State) float64 { return s.Enthalpy },
+		},
+		Y: Axis{
+			Label:    i18n.N("p, МПа"),
+			Quantity: "p",
+			Min:      1e-3,
+			Max:      100,
+			Log:      true,
+			Value:    func(s State) float64 { return s.Pressure / 1e6 },
+		},
+	}
+}
+
+// Options задает набор линий и размер изображения
+type Options struct {
+	Width     int         // пикселей
+	Height    int         // пикселей
+	Isobars   []float64   // Pa
+	Isotherms []float64   // °C
+	Qualities []float64   // 0..1
+	Steps     int         // число участков разбиения линии; 0 — DefaultSteps, не более MaxSteps
+	Locale    i18n.Locale // язык подписей; пустой — русский
+}
+
+// DefaultOptions возвращает набор линий, привычный для печатных диаграмм
+func DefaultOptions() Options {
+	return Options{
+		Width:     1000,
+		Height:    800,
+		Isobars:   []float64{1e3, 1e4, 1e5, 1e6, 10e6, 100e6},
+		Isotherms: []float64{100, 200, 300, 400, 500, 600, 700, 800},
+		Qualities: []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9},
+		Steps:     DefaultSteps,
+	}
+}
+
+// Chart построенная диаграмма: набор линий в координатах State
+type Chart struct {
+	Diagram Diagram
+	Width   int
+	Height  int
+	Curves  []Curve
+	Paths   []Path
+}
+
+// New рассчитывает купол насыщения и линии, заданные в opts. Каждый из
+// списков линий opts содержит не более MaxCurves значений.
+func New(calc *steamprops.Calculator, d Diagram, opts Options) (*Chart, error) {
+	if calc == nil {
+		return nil, i18n.Errorf("не задан калькулятор")
+	}
+	if opts.Width <= 0 || opts.Height <= 0 {
+		return nil, i18n.Errorf("размер изображения %dx%d должен быть положительным", opts.Width, opts.Height)
+	}
+	if err := d.validate(); err != nil {
+		return nil, err
+	}
+	for _, lines := range [][]float64{opts.Isobars, opts.Isotherms, opts.Qualities} {
+		if len(lines) > MaxCurves {
+			return nil, i18n.Errorf("слишком много линий одного вида (%d): допускается не более %d", len(lines), MaxCurves)
+		}
+	}
+
+	c := &Chart{Diagram: d.localize(opts.Locale), Width: opts.Width, Height: opts.Height}
+	for _, x := range opts.Qualities {
+		curve, err := QualityLine(calc, x, opts.Steps)
+		if err != nil {
+			return nil, err
+		}
+		c.Curves = append(c.Curves, curve)
+	}
+	for _, t := range opts.Isotherms {
+		curve, err := IsothermLine(calc, t, opts.Steps)
+		if err != nil {
+			return nil, err
+		}
+		c.Curves = append(c.Curves, curve)
+	}
+	for _, p := range opts.Isobars {
+		curve, err := IsobarLine(calc, p, maxTemperatureFor(p), opts.Steps)
+		if err != nil {
+			return nil, err
+		}
+		curve.Label = formatPressure(opts.Locale, p)
+		c.Curves = append(c.Curves, curve)
+	}
+	dome, err := SaturationDome(calc, opts.Steps)
+	if err != nil {
+		return nil, err
+	}
+	c.Curves = append(c.Curves, dome)
+	return c, nil
+}
+
+// maxTemperatureFor верхняя граница изобары: Region 5 определен до 50 МПа
+func maxTemperatureFor(pressure float64) float64 {
+	if pressure <= 50e6 {
+		return maxTemperature
+	}
+	return 800
+}
+
+// localize переводит заголовок и подписи осей
+func (d Diagram) localize(l i18n.Locale) Diagram {
+	d.Title = l.T(d.Title)
+	d.X.Label = l.T(d.X.Label)
+	d.Y.Label = l.T(d.Y.Label)
+	return d
+}
+
+func (d Diagram) validate() error {
+	for _, a := range []Axis{d.X, d.Y} {
+		if a.Value == nil {
+			return i18n.Errorf("для оси %q не задана координата", a.Label)
+		}
+		if !(a.Max > a.Min) || math.IsInf(a.Max-a.Min, 0) {
+			return i18n.Errorf("некорректный диапазон оси %q: %g..%g", a.Label, a.Min, a.Max)
+		}
+		if a.Log && a.Min <= 0 {
+			return i18n.Errorf("логарифмическая ось %q должна начинаться с положительного значения", a.Label)
+		}
+	}
+	return nil
+}
+
+// ParseList разбирает список чисел через запятую, умножая каждое на factor.
+// Пустая строка дает пустой список.
+func ParseList(s string, factor float64) ([]float64, error) {
+	var out []float64
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, i18n.Errorf("некорректное число %q в списке %q", field, s)
+		}
+		out = append(out, v*factor)
+	}
+	return out, nil
+}
+
+// DiagramByName возвращает диаграмму по короткому имени
+func DiagramByName(name string) (Diagram, error) {
+	switch strings.ToLower(name) {
+	case "hs":
+		return HS(), nil
+	case "ts":
+		return TS(), nil
+	case "ph":
+		return PH(), nil
+	default:
+		return Diagram{}, i18n.Errorf("неизвестная диаграмма %q: ожидается hs, ts или ph", name)
+	}
+}
@@ -0,0 +1,382 @@
+package chart
+
+import (
+	"bytes"
+	"encoding/json"
+	"image/png"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/somepgs/steamprops/internal/steamprops"
+)
+
+func TestSaturationDome(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	dome, err := SaturationDome(calc, 100)
+	if err != nil {
+		t.Fatalf("SaturationDome: %v", err)
+	}
+	if len(dome.Segments) != 1 {
+		t.Fatalf("dome segments = %d, want 1", len(dome.Segments))
+	}
+	pts := dome.Segments[0]
+	first, last := pts[0], pts[len(pts)-1]
+	// Обе ветви начинаются в тройной точке: s' ≈ 0, s'' ≈ 9.155
+	if math.Abs(first.Entropy) > 1e-3 || math.Abs(last.Entropy-9.155) > 1e-2 {
+		t.Errorf("dome ends: s' = %.4f, s'' = %.4f", first.Entropy, last.Entropy)
+	}
+
+	var top State
+	for _, p := range pts {
+		if p.Temperature > top.Temperature {
+			top = p
+		}
+	}
+	if top != criticalState {
+		t.Errorf("dome apex = %+v, want critical point", top)
+	}
+
+	// Выше 350°C купол строится по Region 3 вплоть до критической точки
+	near := 0
+	for _, p := range pts {
+		if p.Temperature > 373 && p != criticalState {
+			near++
+			if math.Abs(p.Entropy-CriticalEntropy) > 0.3 {
+				t.Errorf("dome point far from critical entropy: %+v", p)
+			}
+		}
+	}
+	if near == 0 {
+		t.Errorf("no dome points between 373°C and the critical point")
+	}
+}
+
+func TestIsobarCrossesDome(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	curve, err := IsobarLine(calc, 101325, 600, 120)
+	if err != nil {
+		t.Fatalf("IsobarLine: %v", err)
+	}
+	if curve.Label != "101.325 кПа" {
+		t.Errorf("label = %q", curve.Label)
+	}
+
+	// Изобара содержит обе точки насыщения при 100°C
+	sat, _ := calc.SaturationAtPressure(101325)
+	found := 0
+	for _, seg := range curve.Segments {
+		for _, p := range seg {
+			if p.Quality == 0 && math.Abs(p.Enthalpy-sat.Liquid.SpecificEnthalpy) < 1e-9 {
+				found++
+			}
+			if p.Quality == 1 && math.Abs(p.Enthalpy-sat.Vapor.SpecificEnthalpy) < 1e-9 {
+				found++
+			}
+			if math.Abs(p.Pressure-101325) > 1e-6 {
+				t.Fatalf("point off the isobar: %+v", p)
+			}
+		}
+	}
+	if found != 2 {
+		t.Errorf("saturation points on isobar = %d, want 2", found)
+	}
+}
+
+func TestIsobarCrossesRegion3(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	for _, pressure := range []float64{22e6, 25e6, 100e6} {
+		curve, err := IsobarLine(calc, pressure, 800, 200)
+		if err != nil {
+			t.Fatalf("IsobarLine(%.0f MPa): %v", pressure/1e6, err)
+		}
+		// Изобара проходит через Region 3 одним отрезком от тройной точки до 800°C
+		if len(curve.Segments) != 1 {
+			t.Fatalf("%.0f MPa: segments = %d, want 1", pressure/1e6, len(curve.Segments))
+		}
+		seg := curve.Segments[0]
+		if first, last := seg[0].Temperature, seg[len(seg)-1].Temperature; first != minTemperature || last != 800 {
+			t.Errorf("%.0f MPa: isobar spans %.2f..%.2f°C, want 0.01..800°C", pressure/1e6, first, last)
+		}
+		for i := 1; i < len(seg); i++ {
+			if seg[i].Temperature < seg[i-1].Temperature || seg[i].Entropy <= seg[i-1].Entropy {
+				t.Fatalf("%.0f MPa: isobar must rise in T and s: %+v -> %+v", pressure/1e6, seg[i-1], seg[i])
+			}
+		}
+	}
+
+	// Ниже критического давления изобара пересекает купол в Region 3
+	curve, err := IsobarLine(calc, 22e6, 800, 200)
+	if err != nil {
+		t.Fatalf("IsobarLine: %v", err)
+	}
+	sat, err := calc.SaturationAtPressure(22e6)
+	if err != nil {
+		t.Fatalf("SaturationAtPressure: %v", err)
+	}
+	found := 0
+	for _, p := range curve.Segments[0] {
+		if (p.Quality == 0 && p.Entropy == sat.Liquid.SpecificEntropy) || (p.Quality == 1 && p.Entropy == sat.Vapor.SpecificEntropy) {
+			found++
+		}
+	}
+	if found != 2 {
+		t.Errorf("saturation points on 22 MPa isobar = %d, want 2", found)
+	}
+}
+
+func TestIsothermCrossesRegion3(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	curve, err := IsothermLine(calc, 400, 100)
+	if err != nil {
+		t.Fatalf("IsothermLine: %v", err)
+	}
+	// Выше границы B23 изотерма 400°C проходит через Region 3 без разрыва
+	if len(curve.Segments) != 1 {
+		t.Fatalf("segments = %d, want 1", len(curve.Segments))
+	}
+	seg := curve.Segments[0]
+	if p := seg[len(seg)-1].Pressure; math.Abs(p-maxPressure) > 1 {
+		t.Errorf("isotherm ends at %.3f MPa, want 100 MPa", p/1e6)
+	}
+	for i := 1; i < len(seg); i++ {
+		if seg[i].Pressure <= seg[i-1].Pressure {
+			t.Fatalf("pressure must grow along isotherm")
+		}
+		if seg[i].Entropy >= seg[i-1].Entropy {
+			t.Fatalf("entropy must fall along isotherm: %+v -> %+v", seg[i-1], seg[i])
+		}
+	}
+}
+
+func TestInvalidLines(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	if _, err := QualityLine(calc, 1.5, 0); err == nil {
+		t.Errorf("expected error for x > 1")
+	}
+	if _, err := IsobarLine(calc, 200e6, 800, 0); err == nil {
+		t.Errorf("expected error for p > 100 MPa")
+	}
+	if _, err := IsothermLine(calc, -10, 0); err == nil {
+		t.Errorf("expected error for t < 0.01°C")
+	}
+	if _, err := New(calc, Diagram{X: HS().X, Y: Axis{Min: 1, Max: 0}}, DefaultOptions()); err == nil {
+		t.Errorf("expected error for invalid axis")
+	}
+
+	// Число линий и точек процесса ограничено: каждая — сотни расчетов
+	opts := DefaultOptions()
+	opts.Isotherms = make([]float64, MaxCurves+1)
+	if _, err := New(calc, HS(), opts); err == nil {
+		t.Errorf("expected error for %d isotherms", len(opts.Isotherms))
+	}
+	if _, err := NewPath(calc, "", make([]PointSpec, MaxPathPoints+1), 0); err == nil {
+		t.Errorf("expected error for %d path points", MaxPathPoints+1)
+	}
+	if normalizeSteps(1e6) != MaxSteps {
+		t.Errorf("normalizeSteps(1e6) = %d, want %d", normalizeSteps(1e6), MaxSteps)
+	}
+}
+
+func TestClip(t *testing.T) {
+	f := frame{d: HS(), left: 0, right: 100, top: 0, bott: 100}
+	parts := f.clip([]point{{-50, 50}, {50, 50}, {150, 50}, {150, 150}, {50, 80}})
+	if len(parts) != 2 {
+		t.Fatalf("parts = %v, want 2", parts)
+	}
+	if parts[0][0] != (point{0, 50}) || parts[0][len(parts[0])-1] != (point{100, 50}) {
+		t.Errorf("first part = %v", parts[0])
+	}
+}
+
+func TestWriteSVGAndPNG(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	opts := DefaultOptions()
+	opts.Width, opts.Height, opts.Steps = 400, 300, 40
+	c, err := New(calc, HS(), opts)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if len(c.Curves) != len(opts.Isobars)+len(opts.Isotherms)+len(opts.Qualities)+1 {
+		t.Errorf("curves = %d", len(c.Curves))
+	}
+
+	var svg bytes.Buffer
+	if err := c.WriteSVG(&svg); err != nil {
+		t.Fatalf("WriteSVG: %v", err)
+	}
+	out := svg.String()
+	for _, want := range []string{"<svg", "<polyline", "100 МПа", "x = 0.5", "s, кДж/(кг·К)", "</svg>"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("SVG does not contain %q", want)
+		}
+	}
+
+	var raster bytes.Buffer
+	if err := c.WritePNG(&raster); err != nil {
+		t.Fatalf("WritePNG: %v", err)
+	}
+	img, err := png.Decode(&raster)
+	if err != nil {
+		t.Fatalf("png.Decode: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 400 || b.Dy() != 300 {
+		t.Errorf("PNG size = %v", b)
+	}
+}
+
+func TestParseList(t *testing.T) {
+	got, err := ParseList(" 0.1, 1,10 ", 1e6)
+	if err != nil || len(got) != 3 || got[0] != 1e5 || got[2] != 1e7 {
+		t.Errorf("ParseList = %v, %v", got, err)
+	}
+	if got, err := ParseList("", 1); err != nil || len(got) != 0 {
+		t.Errorf("empty list = %v, %v", got, err)
+	}
+	if _, err := ParseList("1,a", 1); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestNewPathRankineCycle(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	specs := []PointSpec{
+		{Mode: "PX", Pressure: 10e3, Quality: 0},
+		{Mode: "TP", Temperature: 540, Pressure: 10e6, Label: "ПП"},
+		{Mode: "PH", Pressure: 10e3, Enthalpy: 2200},
+	}
+	path, err := NewPath(calc, "Цикл", specs, 20)
+	if err != nil {
+		t.Fatalf("NewPath: %v", err)
+	}
+	if len(path.Points) != 3 || path.Points[0].Label != "1" || path.Points[1].Label != "ПП" {
+		t.Fatalf("points = %+v", path.Points)
+	}
+	if last := path.Line[len(path.Line)-1]; last != path.Points[2].State {
+		t.Errorf("line must end at the last node")
+	}
+
+	// Участок расширения 2→3 лежит между давлениями узлов и заходит во влажный пар
+	wet := false
+	for _, s := range path.Line {
+		if s.Pressure < 10e3*(1-1e-9) || s.Pressure > 10e6*(1+1e-9) {
+			t.Fatalf("state outside the process pressures: %+v", s)
+		}
+		if s.Quality > 0 && s.Quality < 1 {
+			wet = true
+		}
+	}
+	if !wet {
+		t.Errorf("expansion line should enter the two-phase region")
+	}
+
+	if _, err := NewPath(calc, "Ошибка", []PointSpec{{Mode: "XY"}}, 0); err == nil {
+		t.Errorf("expected error for unknown point mode")
+	}
+	if _, err := NewPath(calc, "Пусто", nil, 0); err == nil {
+		t.Errorf("expected error for empty path")
+	}
+}
+
+func TestConnectIsobarThroughDome(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	liquid, _ := calc.CalculatePX(1e6, 0)
+	steam, _ := calc.Calculate(&steamprops.InputData{Mode: "TP", Temperature: 300, Pressure: 1e6})
+
+	line := Connect(calc, StateOf(liquid), StateOf(steam), 10)
+	if len(line) != 10 {
+		t.Fatalf("points = %d, want 10", len(line))
+	}
+	for _, s := range line {
+		if math.Abs(s.Pressure-1e6) > 1e-6 {
+			t.Fatalf("isobaric connection left the isobar: %+v", s)
+		}
+	}
+}
+
+func TestDiagramsWithPath(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	opts := Options{Width: 300, Height: 200, Qualities: []float64{0.5}, Steps: 20}
+	path, err := NewPath(calc, "Процесс", []PointSpec{
+		{Mode: "TP", Temperature: 400, Pressure: 5e6},
+		{Mode: "PX", Pressure: 0.1e6, Quality: 0.95},
+	}, 10)
+	if err != nil {
+		t.Fatalf("NewPath: %v", err)
+	}
+
+	for _, name := range []string{"hs", "ts", "ph"} {
+		d, err := DiagramByName(name)
+		if err != nil {
+			t.Fatalf("DiagramByName(%q): %v", name, err)
+		}
+		c, err := New(calc, d, opts)
+		if err != nil {
+			t.Fatalf("New(%q): %v", name, err)
+		}
+		c.AddPath(path)
+
+		var svg bytes.Buffer
+		if err := c.WriteSVG(&svg); err != nil {
+			t.Fatalf("WriteSVG: %v", err)
+		}
+		if n := strings.Count(svg.String(), "<circle"); n != 2 {
+			t.Errorf("%s: markers = %d, want 2", name, n)
+		}
+		if !strings.Contains(svg.String(), "Процесс") {
+			t.Errorf("%s: legend is missing", name)
+		}
+		if _, err := c.Image(); err != nil {
+			t.Errorf("%s: Image: %v", name, err)
+		}
+	}
+	if _, err := DiagramByName("pv"); err == nil {
+		t.Errorf("expected error for unknown diagram")
+	}
+}
+
+func TestChartData(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	opts := Options{Width: 300, Height: 200, Isobars: []float64{1e6}, Steps: 20}
+	c, err := New(calc, PH(), opts)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	path, err := NewPath(calc, "Дросселирование", []PointSpec{
+		{Mode: "TP", Temperature: 300, Pressure: 1e6},
+		{Mode: "PH", Pressure: 0.1e6, Enthalpy: 3051.7},
+	}, 10)
+	if err != nil {
+		t.Fatalf("NewPath: %v", err)
+	}
+	c.AddPath(path)
+
+	data := c.Data()
+	if data.Name != "ph" || data.X.Quantity != "h" || data.Y.Quantity != "p" || !data.Y.Log {
+		t.Fatalf("unexpected axes: %+v / %+v", data.X, data.Y)
+	}
+	if len(data.Y.Ticks) == 0 {
+		t.Errorf("no ticks on the pressure axis")
+	}
+	if len(data.Curves) != 2 || data.Curves[0].Kind != "isobar" || data.Curves[1].Kind != "saturation" {
+		t.Fatalf("unexpected curves: %d", len(data.Curves))
+	}
+	// Изобара 1 МПа в координатах lg p–h — горизонтальная линия p = 1
+	for _, seg := range data.Curves[0].Segments {
+		for _, pt := range seg {
+			if math.Abs(pt[1]-1) > 1e-9 {
+				t.Fatalf("isobar point %v, want p = 1 MPa", pt)
+			}
+		}
+	}
+	if len(data.Paths) != 1 || len(data.Paths[0].Points) != 2 {
+		t.Fatalf("unexpected paths: %+v", data.Paths)
+	}
+	if end := data.Paths[0].Points[1]; math.Abs(end.X-3051.7) > 1e-6 || math.Abs(end.Y-0.1) > 1e-12 {
+		t.Errorf("path end = %+v, want (3051.7, 0.1)", end)
+	}
+
+	if _, err := json.Marshal(data); err != nil {
+		t.Errorf("json.Marshal: %v", err)
+	}
+}
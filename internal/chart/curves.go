@@ -0,0 +1,299 @@
+// Package chart строит диаграммы состояния воды и водяного пара (h–s и др.)
+// по уравнениям IF-97: купол насыщения, изобары, изотермы и линии постоянной
+// степени сухости, с выводом в SVG и PNG.
+package chart
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/internal/calc_core/region4"
+	"github.com/somepgs/steamprops/internal/steamprops"
+)
+
+// Параметры критической точки воды (IAPWS)
+const (
+	CriticalTemperature = 373.946          // °C
+	CriticalPressure    = 22.064e6         // Pa
+	CriticalEnthalpy    = 2087.546845      // кДж/кг
+	CriticalEntropy     = 4.41202148223476 // кДж/(кг·К)
+)
+
+// Границы построения линий
+const (
+	minTemperature = 0.01    // °C, тройная точка
+	maxTemperature = 2000.0  // °C
+	minPressure    = 611.657 // Pa
+	maxPressure    = 100e6   // Pa
+
+	// DefaultSteps число участков разбиения одной линии
+	DefaultSteps = 200
+	// MaxSteps наибольшее число участков разбиения линии: каждый участок —
+	// несколько расчетов свойств
+	MaxSteps = 2000
+	// MaxCurves наибольшее число изобар, изотерм или линий сухости в Options
+	MaxCurves = 50
+	// MaxPathPoints наибольшее число точек одного процесса
+	MaxPathPoints = 100
+)
+
+// State точка на диаграмме
+type State struct {
+	Temperature float64 // °C
+	Pressure    float64 // Pa
+	Enthalpy    float64 // кДж/кг
+	Entropy     float64 // кДж/(кг·К)
+	Volume      float64 // м³/кг
+	Quality     float64 // 0..1 для влажного пара, -1 для однофазных состояний
+}
+
+// StateOf преобразует результат расчета калькулятора в точку диаграммы
+func StateOf(r *steamprops.Result) State {
+	return State{
+		Temperature: r.Temperature,
+		Pressure:    r.Pressure,
+		Enthalpy:    r.Properties.SpecificEnthalpy,
+		Entropy:     r.Properties.SpecificEntropy,
+		Volume:      r.Properties.SpecificVolume,
+		Quality:     r.Quality,
+	}
+}
+
+// criticalState точка, в которой сходятся ветви купола и линии x = const
+var criticalState = State{
+	Temperature: CriticalTemperature,
+	Pressure:    CriticalPressure,
+	Enthalpy:    CriticalEnthalpy,
+	Entropy:     CriticalEntropy,
+	Volume:      1 / 322.0,
+	Quality:     -1,
+}
+
+// CurveKind тип линии на диаграмме
+type CurveKind int
+
+const (
+	Saturation CurveKind = iota // пограничные кривые x = 0 и x = 1
+	Isobar                      // p = const
+	Isotherm                    // T = const
+	Quality                     // x = const
+)
+
+// String возвращает название типа линии
+func (k CurveKind) String() string {
+	switch k {
+	case Saturation:
+		return "saturation"
+	case Isobar:
+		return "isobar"
+	case Isotherm:
+		return "isotherm"
+	case Quality:
+		return "quality"
+	default:
+		return "unknown"
+	}
+}
+
+// Curve линия на диаграмме. Там, где свойства не рассчитываются, линия
+// разрывается на несколько сегментов.
+type Curve struct {
+	Kind     CurveKind
+	Value    float64 // Pa для изобар, °C для изотерм, x для линий сухости
+	Label    string
+	Segments [][]State
+}
+
+// curveBuilder собирает сегменты линии, начиная новый сегмент после разрыва
+type curveBuilder struct {
+	segments [][]State
+	current  []State
+}
+
+func (b *curveBuilder) add(s State) {
+	b.current = append(b.current, s)
+}
+
+func (b *curveBuilder) addResult(r *steamprops.Result, err error) {
+	if err != nil {
+		b.split()
+		return
+	}
+	b.add(StateOf(r))
+}
+
+func (b *curveBuilder) split() {
+	if len(b.current) > 1 {
+		b.segments = append(b.segments, b.current)
+	}
+	b.current = nil
+}
+
+func (b *curveBuilder) result() [][]State {
+	b.split()
+	return b.segments
+}
+
+// SaturationDome строит пограничные кривые от тройной до критической точки:
+// насыщенная жидкость, затем насыщенный пар в обратном порядке.
+func SaturationDome(calc *steamprops.Calculator, steps int) (Curve, error) {
+	liquid, err := QualityLine(calc, 0, steps)
+	if err != nil {
+		return Curve{}, err
+	}
+	vapor, err := QualityLine(calc, 1, steps)
+	if err != nil {
+		return Curve{}, err
+	}
+	points := liquid.Segments[0]
+	v := vapor.Segments[0]
+	for i := len(v) - 2; i >= 0; i-- {
+		points = append(points, v[i])
+	}
+	return Curve{Kind: Saturation, Label: "x = 0 / x = 1", Segments: [][]State{points}}, nil
+}
+
+// QualityLine строит линию постоянной степени сухости x от тройной точки до критической.
+// Шаг по температуре уменьшается к критической точке, где ветви купола
+// сходятся с вертикальной касательной.
+func QualityLine(calc *steamprops.Calculator, x float64, steps int) (Curve, error) {
+	if math.IsNaN(x) || x < 0 || x > 1 {
+		return Curve{}, i18n.Errorf("степень сухости %.4f вне диапазона 0..1", x)
+	}
+	steps = normalizeSteps(steps)
+
+	var b curveBuilder
+	for i := 0; i < steps; i++ {
+		f := 1 - float64(i)/float64(steps)
+		t := CriticalTemperature - (CriticalTemperature-minTemperature)*f*f
+		sat, err := calc.SaturationAtTemperature(t)
+		if err != nil {
+			b.split()
+			continue
+		}
+		mix := sat.Mixture(x)
+		b.add(State{
+			Temperature: t,
+			Pressure:    sat.Pressure,
+			Enthalpy:    mix.SpecificEnthalpy,
+			Entropy:     mix.SpecificEntropy,
+			Volume:      mix.SpecificVolume,
+			Quality:     x,
+		})
+	}
+	b.add(criticalState)
+
+	segments := b.result()
+	if len(segments) == 0 {
+		return Curve{}, i18n.Errorf("не удалось построить линию x = %.2f", x)
+	}
+	return Curve{Kind: Quality, Value: x, Label: fmt.Sprintf("x = %.2g", x), Segments: segments}, nil
+}
+
+// IsobarLine строит изобару p (Pa) от тройной точки до температуры tMax (°C).
+// Ниже критического давления изобара проходит через двухфазную область по прямой.
+func IsobarLine(calc *steamprops.Calculator, pressure, tMax float64, steps int) (Curve, error) {
+	if math.IsNaN(pressure) || pressure < minPressure || pressure > maxPressure {
+		return Curve{}, i18n.Errorf("давление изобары %.0f Па вне диапазона IF-97", pressure)
+	}
+	if math.IsNaN(tMax) || tMax <= minTemperature || tMax > maxTemperature {
+		return Curve{}, i18n.Errorf("температура %.2f°C вне диапазона построения", tMax)
+	}
+	steps = normalizeSteps(steps)
+
+	tSat := math.Inf(1)
+	if pressure < CriticalPressure {
+		if TK, err := region4.SaturationTemperature(pressure); err == nil {
+			tSat = TK - 273.15
+		}
+	}
+
+	var b curveBuilder
+	crossed := false
+	for i := 0; i <= steps; i++ {
+		t := minTemperature + (tMax-minTemperature)*float64(i)/float64(steps)
+		if !crossed && t >= tSat {
+			crossed = true
+			b.addResult(calc.CalculatePX(pressure, 0))
+			b.addResult(calc.CalculatePX(pressure, 1))
+			if t == tSat {
+				continue
+			}
+		}
+		b.addResult(calc.Calculate(&steamprops.InputData{Mode: "TP", Temperature: t, Pressure: pressure}))
+	}
+
+	segments := b.result()
+	if len(segments) == 0 {
+		return Curve{}, i18n.Errorf("не удалось построить изобару p = %.0f Па", pressure)
+	}
+	return Curve{Kind: Isobar, Value: pressure, Label: FormatPressure(pressure), Segments: segments}, nil
+}
+
+// IsothermLine строит изотерму t (°C) от минимального давления IF-97 до 100 МПа
+// с логарифмическим шагом. Ниже критической температуры изотерма проходит через
+// двухфазную область по прямой.
+func IsothermLine(calc *steamprops.Calculator, temperature float64, steps int) (Curve, error) {
+	if math.IsNaN(temperature) || temperature < minTemperature || temperature > maxTemperature {
+		return Curve{}, i18n.Errorf("температура изотермы %.2f°C вне диапазона IF-97", temperature)
+	}
+	steps = normalizeSteps(steps)
+
+	pSat := math.Inf(1)
+	if temperature < CriticalTemperature {
+		if p, err := region4.SaturationPressure(temperature + 273.15); err == nil {
+			pSat = p
+		}
+	}
+
+	var b curveBuilder
+	crossed := false
+	ratio := math.Log(maxPressure / minPressure)
+	for i := 0; i <= steps; i++ {
+		p := minPressure * math.Exp(ratio*float64(i)/float64(steps))
+		if !crossed && p >= pSat {
+			crossed = true
+			b.addResult(calc.CalculatePX(pSat, 1))
+			b.addResult(calc.CalculatePX(pSat, 0))
+			if p == pSat {
+				continue
+			}
+		}
+		b.addResult(calc.Calculate(&steamprops.InputData{Mode: "TP", Temperature: temperature, Pressure: p}))
+	}
+
+	segments := b.result()
+	if len(segments) == 0 {
+		return Curve{}, i18n.Errorf("не удалось построить изотерму t = %.2f°C", temperature)
+	}
+	return Curve{Kind: Isotherm, Value: temperature, Label: fmt.Sprintf("%g °C", temperature), Segments: segments}, nil
+}
+
+// FormatPressure форматирует давление для подписи изобары
+func FormatPressure(pressure float64) string {
+	return formatPressure(i18n.Russian, pressure)
+}
+
+// formatPressure форматирует давление для подписи изобары на языке l
+func formatPressure(l i18n.Locale, pressure float64) string {
+	switch {
+	case pressure >= 1e6:
+		return l.T("%g МПа", pressure/1e6)
+	case pressure >= 1e3:
+		return l.T("%g кПа", pressure/1e3)
+	default:
+		return l.T("%g Па", pressure)
+	}
+}
+
+// normalizeSteps заменяет 0 на DefaultSteps и ограничивает число участков MaxSteps
+func normalizeSteps(steps int) int {
+	switch {
+	case steps <= 0:
+		return DefaultSteps
+	case steps > MaxSteps:
+		return MaxSteps
+	}
+	return steps
+}
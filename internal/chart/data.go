@@ -0,0 +1,102 @@
+package chart
+
+import "math"
+
+// AxisData ось диаграммы для клиентской отрисовки
+type AxisData struct {
+	Label    string    `json:"label"`
+	Quantity string    `json:"quantity"`
+	Min      float64   `json:"min"`
+	Max      float64   `json:"max"`
+	Log      bool      `json:"log"`
+	Ticks    []float64 `json:"ticks"`
+}
+
+// CurveData линия в координатах осей диаграммы: каждая точка — пара [x, y]
+type CurveData struct {
+	Kind     string         `json:"kind"`
+	Value    float64        `json:"value"`
+	Label    string         `json:"label"`
+	Segments [][][2]float64 `json:"segments"`
+}
+
+// PathPointData узловая точка процесса в координатах осей
+type PathPointData struct {
+	Label string  `json:"label"`
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+}
+
+// PathData процесс в координатах осей
+type PathData struct {
+	Label  string          `json:"label"`
+	Points []PathPointData `json:"points"`
+	Line   [][2]float64    `json:"line"`
+}
+
+// Data диаграмма в виде ломаных для построения на стороне клиента (JSON)
+type Data struct {
+	Name   string      `json:"name"`
+	Title  string      `json:"title"`
+	X      AxisData    `json:"x"`
+	Y      AxisData    `json:"y"`
+	Curves []CurveData `json:"curves"`
+	Paths  []PathData  `json:"paths"`
+}
+
+// Data переводит линии и процессы диаграммы в координаты осей.
+// Точки с неопределенной координатой (например, p ≤ 0 на логарифмической оси) отбрасываются.
+func (c *Chart) Data() Data {
+	d := c.Diagram
+	out := Data{
+		Name:   d.Name,
+		Title:  d.Title,
+		X:      axisData(d.X),
+		Y:      axisData(d.Y),
+		Curves: []CurveData{},
+		Paths:  []PathData{},
+	}
+	for _, curve := range c.Curves {
+		cd := CurveData{Kind: curve.Kind.String(), Value: curve.Value, Label: curve.Label, Segments: [][][2]float64{}}
+		for _, seg := range curve.Segments {
+			if pts := c.coords(seg); len(pts) > 1 {
+				cd.Segments = append(cd.Segments, pts)
+			}
+		}
+		out.Curves = append(out.Curves, cd)
+	}
+	for _, path := range c.Paths {
+		pd := PathData{Label: path.Label, Points: []PathPointData{}, Line: c.coords(path.Line)}
+		for _, node := range path.Points {
+			x, y, ok := c.coord(node.State)
+			if !ok {
+				continue
+			}
+			pd.Points = append(pd.Points, PathPointData{Label: node.Label, X: x, Y: y})
+		}
+		out.Paths = append(out.Paths, pd)
+	}
+	return out
+}
+
+func axisData(a Axis) AxisData {
+	return AxisData{Label: a.Label, Quantity: a.Quantity, Min: a.Min, Max: a.Max, Log: a.Log, Ticks: ticks(a)}
+}
+
+func (c *Chart) coord(s State) (float64, float64, bool) {
+	x, y := c.Diagram.X.Value(s), c.Diagram.Y.Value(s)
+	valid := func(a Axis, v float64) bool {
+		return !math.IsNaN(v) && !math.IsInf(v, 0) && (!a.Log || v > 0)
+	}
+	return x, y, valid(c.Diagram.X, x) && valid(c.Diagram.Y, y)
+}
+
+func (c *Chart) coords(states []State) [][2]float64 {
+	pts := make([][2]float64, 0, len(states))
+	for _, s := range states {
+		if x, y, ok := c.coord(s); ok {
+			pts = append(pts, [2]float64{x, y})
+		}
+	}
+	return pts
+}
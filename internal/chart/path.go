@@ -0,0 +1,137 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/internal/steamprops"
+)
+
+// PointSpec задает узловую точку процесса парой параметров.
+// Режимы: TP (t, p), PX (p, x), PH (p, h), PS (p, s).
+type PointSpec struct {
+	Label       string  `json:"label,omitempty"`
+	Mode        string  `json:"mode"`
+	Temperature float64 `json:"temperature,omitempty"` // °C
+	Pressure    float64 `json:"pressure"`              // Pa
+	Enthalpy    float64 `json:"enthalpy,omitempty"`    // кДж/кг
+	Entropy     float64 `json:"entropy,omitempty"`     // кДж/(кг·К)
+	Quality     float64 `json:"quality,omitempty"`     // 0..1
+}
+
+// Resolve рассчитывает состояние в точке
+func (p PointSpec) Resolve(calc *steamprops.Calculator) (*steamprops.Result, error) {
+	switch strings.ToUpper(p.Mode) {
+	case "", "TP":
+		input := &steamprops.InputData{Mode: "TP", Temperature: p.Temperature, Pressure: p.Pressure}
+		if err := input.Validate(); err != nil {
+			return nil, err
+		}
+		return calc.Calculate(input)
+	case "PX":
+		return calc.CalculatePX(p.Pressure, p.Quality)
+	case "PH":
+		return calc.CalculatePH(p.Pressure, p.Enthalpy)
+	case "PS":
+		return calc.CalculatePS(p.Pressure, p.Entropy)
+	default:
+		return nil, i18n.Errorf("неизвестный режим точки %q: ожидается TP, PX, PH или PS", p.Mode)
+	}
+}
+
+// PointAt возвращает точку, однозначно задающую рассчитанное состояние с
+// температурой (°C), давлением (Па) и паросодержанием (-1 вне влажного пара):
+// во влажном паре по давлению и степени сухости, иначе по температуре и давлению
+func PointAt(label string, temperature, pressure, quality float64) PointSpec {
+	if quality >= 0 {
+		return PointSpec{Label: label, Mode: "PX", Pressure: pressure, Quality: quality}
+	}
+	return PointSpec{Label: label, Mode: "TP", Temperature: temperature, Pressure: pressure}
+}
+
+// PathPoint узловая точка процесса на диаграмме
+type PathPoint struct {
+	State State
+	Label string
+}
+
+// Path последовательность состояний (процесс или цикл), наносимая на диаграмму
+// ломаной поверх изолиний
+type Path struct {
+	Label  string
+	Points []PathPoint
+	Line   []State // промежуточные точки между узлами
+}
+
+// NewPath рассчитывает узлы процесса и линии между ними; процесс содержит не
+// более MaxPathPoints узлов.
+// Соседние узлы соединяются изобарой при равных давлениях, изоэнтропой при
+// равных энтропиях и линией с линейной интерполяцией h и lg p в остальных случаях.
+func NewPath(calc *steamprops.Calculator, label string, specs []PointSpec, steps int) (Path, error) {
+	if len(specs) == 0 {
+		return Path{}, i18n.Errorf("процесс %q не содержит точек", label)
+	}
+	if len(specs) > MaxPathPoints {
+		return Path{}, i18n.Errorf("процесс %q содержит %d точек: допускается не более %d", label, len(specs), MaxPathPoints)
+	}
+	switch {
+	case steps <= 0:
+		steps = 40
+	case steps > MaxSteps:
+		steps = MaxSteps
+	}
+
+	path := Path{Label: label}
+	for i, spec := range specs {
+		res, err := spec.Resolve(calc)
+		if err != nil {
+			return Path{}, i18n.Errorf("точка %d процесса %q: %w", i+1, label, err)
+		}
+		name := spec.Label
+		if name == "" {
+			name = fmt.Sprint(i + 1)
+		}
+		path.Points = append(path.Points, PathPoint{State: StateOf(res), Label: name})
+	}
+
+	path.Line = append(path.Line, path.Points[0].State)
+	for i := 1; i < len(path.Points); i++ {
+		path.Line = append(path.Line, Connect(calc, path.Points[i-1].State, path.Points[i].State, steps)...)
+	}
+	return path, nil
+}
+
+// Connect возвращает промежуточные состояния от a (не включая) до b (включая)
+func Connect(calc *steamprops.Calculator, a, b State, steps int) []State {
+	isobaric := math.Abs(a.Pressure-b.Pressure) <= 1e-9*math.Max(a.Pressure, b.Pressure)
+	isentropic := math.Abs(a.Entropy-b.Entropy) <= 1e-6
+
+	var out []State
+	for i := 1; i < steps; i++ {
+		f := float64(i) / float64(steps)
+		p := a.Pressure * math.Pow(b.Pressure/a.Pressure, f)
+
+		var res *steamprops.Result
+		var err error
+		switch {
+		case isobaric:
+			res, err = calc.CalculatePH(a.Pressure, a.Enthalpy+f*(b.Enthalpy-a.Enthalpy))
+		case isentropic:
+			res, err = calc.CalculatePS(p, a.Entropy)
+		default:
+			res, err = calc.CalculatePH(p, a.Enthalpy+f*(b.Enthalpy-a.Enthalpy))
+		}
+		if err != nil {
+			continue
+		}
+		out = append(out, StateOf(res))
+	}
+	return append(out, b)
+}
+
+// AddPath наносит процесс на диаграмму
+func (c *Chart) AddPath(p Path) {
+	c.Paths = append(c.Paths, p)
+}
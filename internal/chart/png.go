@@ -0,0 +1,202 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"sync"
+
+	"github.com/somepgs/steamprops/i18n"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+var (
+	fontOnce sync.Once
+	fontData *opentype.Font
+	fontErr  error
+)
+
+func loadFont() (*opentype.Font, error) {
+	fontOnce.Do(func() {
+		fontData, fontErr = opentype.Parse(goregular.TTF)
+	})
+	return fontData, fontErr
+}
+
+// rasterCanvas рисует диаграмму в растровое изображение
+type rasterCanvas struct {
+	img   *image.RGBA
+	font  *opentype.Font
+	faces map[float64]font.Face
+	err   error
+}
+
+func (r *rasterCanvas) polyline(pts []point, st style) {
+	parts := [][]point{pts}
+	if st.Dash {
+		parts = dashed(pts, 4, 3)
+	}
+	b := r.img.Bounds()
+	z := vector.NewRasterizer(b.Dx(), b.Dy())
+	half := math.Max(st.Width, 1) / 2
+	for _, part := range parts {
+		for i := 1; i < len(part); i++ {
+			a, c := part[i-1], part[i]
+			dx, dy := c.X-a.X, c.Y-a.Y
+			l := math.Hypot(dx, dy)
+			if l == 0 {
+				continue
+			}
+			// Отрезок рисуется прямоугольником, продленным на полширины линии
+			ux, uy := dx/l*half, dy/l*half
+			nx, ny := -uy, ux
+			z.MoveTo(float32(a.X-ux+nx), float32(a.Y-uy+ny))
+			z.LineTo(float32(c.X+ux+nx), float32(c.Y+uy+ny))
+			z.LineTo(float32(c.X+ux-nx), float32(c.Y+uy-ny))
+			z.LineTo(float32(a.X-ux-nx), float32(a.Y-uy-ny))
+			z.ClosePath()
+		}
+	}
+	z.Draw(r.img, b, image.NewUniform(st.Color), image.Point{})
+}
+
+func (r *rasterCanvas) circle(x, y, radius float64, c color.RGBA) {
+	const n = 24
+	b := r.img.Bounds()
+	z := vector.NewRasterizer(b.Dx(), b.Dy())
+	z.MoveTo(float32(x+radius), float32(y))
+	for i := 1; i < n; i++ {
+		a := 2 * math.Pi * float64(i) / n
+		z.LineTo(float32(x+radius*math.Cos(a)), float32(y+radius*math.Sin(a)))
+	}
+	z.ClosePath()
+	z.Draw(r.img, b, image.NewUniform(c), image.Point{})
+}
+
+// dashed разбивает ломаную на штрихи длиной on с промежутками off
+func dashed(pts []point, on, off float64) [][]point {
+	var out [][]point
+	var cur []point
+	drawing, left := true, on
+	if len(pts) > 0 {
+		cur = []point{pts[0]}
+	}
+	for i := 1; i < len(pts); i++ {
+		a, b := pts[i-1], pts[i]
+		l := math.Hypot(b.X-a.X, b.Y-a.Y)
+		pos := 0.0
+		for l-pos > left {
+			pos += left
+			p := point{a.X + (b.X-a.X)*pos/l, a.Y + (b.Y-a.Y)*pos/l}
+			if drawing {
+				out = append(out, append(cur, p))
+				cur = nil
+				left = off
+			} else {
+				cur = []point{p}
+				left = on
+			}
+			drawing = !drawing
+		}
+		left -= l - pos
+		if drawing {
+			cur = append(cur, b)
+		}
+	}
+	if drawing && len(cur) > 1 {
+		out = append(out, cur)
+	}
+	return out
+}
+
+func (r *rasterCanvas) face(size float64) font.Face {
+	if f, ok := r.faces[size]; ok {
+		return f
+	}
+	f, err := opentype.NewFace(r.font, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		r.err = err
+		return nil
+	}
+	r.faces[size] = f
+	return f
+}
+
+func (r *rasterCanvas) text(x, y float64, s string, size float64, anchor textAnchor, c color.RGBA) {
+	face := r.face(size)
+	if face == nil {
+		return
+	}
+	d := &font.Drawer{Dst: r.img, Src: image.NewUniform(c), Face: face}
+	width := float64(d.MeasureString(s)) / 64
+	switch anchor {
+	case anchorMiddle:
+		x -= width / 2
+	case anchorEnd:
+		x -= width
+	}
+	d.Dot = fixed.P(int(math.Round(x)), int(math.Round(y)))
+	d.DrawString(s)
+}
+
+func (r *rasterCanvas) verticalText(x, y float64, s string, size float64, c color.RGBA) {
+	face := r.face(size)
+	if face == nil {
+		return
+	}
+	// Текст рисуется горизонтально во временное изображение и поворачивается на 90°
+	height := int(math.Ceil(size * 1.4))
+	tmp := image.NewRGBA(image.Rect(0, 0, font.MeasureString(face, s).Ceil(), height))
+	d := &font.Drawer{Dst: tmp, Src: image.NewUniform(c), Face: face, Dot: fixed.P(0, int(size))}
+	d.DrawString(s)
+
+	w := tmp.Bounds().Dx()
+	x0 := int(math.Round(x)) - height/2
+	y0 := int(math.Round(y)) + w/2
+	for i := 0; i < w; i++ {
+		for j := 0; j < height; j++ {
+			px := tmp.RGBAAt(i, j)
+			if px.A == 0 {
+				continue
+			}
+			draw.Draw(r.img, image.Rect(x0+j, y0-i, x0+j+1, y0-i+1), image.NewUniform(px), image.Point{}, draw.Over)
+		}
+	}
+}
+
+// Image рисует диаграмму в растровое изображение
+func (c *Chart) Image() (*image.RGBA, error) {
+	f, err := loadFont()
+	if err != nil {
+		return nil, i18n.Errorf("ошибка загрузки шрифта: %w", err)
+	}
+	img := image.NewRGBA(image.Rect(0, 0, c.Width, c.Height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	r := &rasterCanvas{img: img, font: f, faces: map[float64]font.Face{}}
+	c.draw(r)
+	for _, face := range r.faces {
+		face.Close()
+	}
+	if r.err != nil {
+		return nil, i18n.Errorf("ошибка вывода текста: %w", r.err)
+	}
+	return img, nil
+}
+
+// WritePNG выводит диаграмму в формате PNG
+func (c *Chart) WritePNG(w io.Writer) error {
+	img, err := c.Image()
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
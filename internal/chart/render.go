@@ -0,0 +1,289 @@
+package chart
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+)
+
+// Поля области построения, пикселей
+const (
+	marginLeft   = 70.0
+	marginRight  = 20.0
+	marginTop    = 40.0
+	marginBottom = 55.0
+	fontSize     = 12.0
+	titleSize    = 15.0
+)
+
+type point struct{ X, Y float64 }
+
+type textAnchor int
+
+const (
+	anchorStart textAnchor = iota
+	anchorMiddle
+	anchorEnd
+)
+
+type style struct {
+	Color color.RGBA
+	Width float64
+	Dash  bool
+}
+
+// canvas общий интерфейс вывода для SVG и растровых изображений
+type canvas interface {
+	polyline(pts []point, st style)
+	text(x, y float64, s string, size float64, anchor textAnchor, c color.RGBA)
+	verticalText(x, y float64, s string, size float64, c color.RGBA)
+	circle(x, y, r float64, c color.RGBA)
+}
+
+var (
+	colorBlack  = color.RGBA{0x00, 0x00, 0x00, 0xff}
+	colorGrid   = color.RGBA{0xdd, 0xdd, 0xdd, 0xff}
+	colorText   = color.RGBA{0x33, 0x33, 0x33, 0xff}
+	colorIsobar = color.RGBA{0x1f, 0x5f, 0xbf, 0xff}
+	colorTherm  = color.RGBA{0xc0, 0x39, 0x2b, 0xff}
+	colorQual   = color.RGBA{0x2e, 0x8b, 0x57, 0xff}
+
+	// pathColors цвета процессов, наносимых поверх изолиний
+	pathColors = []color.RGBA{
+		{0x8e, 0x44, 0xad, 0xff},
+		{0xe6, 0x7e, 0x22, 0xff},
+		{0x16, 0xa0, 0x85, 0xff},
+		{0xd3, 0x54, 0x00, 0xff},
+	}
+)
+
+func curveStyle(k CurveKind) style {
+	switch k {
+	case Saturation:
+		return style{Color: colorBlack, Width: 2}
+	case Isobar:
+		return style{Color: colorIsobar, Width: 1}
+	case Isotherm:
+		return style{Color: colorTherm, Width: 1}
+	default:
+		return style{Color: colorQual, Width: 0.8, Dash: true}
+	}
+}
+
+// frame переводит координаты диаграммы в пиксели
+type frame struct {
+	d                      Diagram
+	left, right, top, bott float64
+}
+
+func newFrame(c *Chart) frame {
+	return frame{
+		d:     c.Diagram,
+		left:  marginLeft,
+		right: float64(c.Width) - marginRight,
+		top:   marginTop,
+		bott:  float64(c.Height) - marginBottom,
+	}
+}
+
+func scale(a Axis, v float64) float64 {
+	if a.Log {
+		return (math.Log10(v) - math.Log10(a.Min)) / (math.Log10(a.Max) - math.Log10(a.Min))
+	}
+	return (v - a.Min) / (a.Max - a.Min)
+}
+
+func (f frame) project(s State) point {
+	return point{
+		X: f.left + scale(f.d.X, f.d.X.Value(s))*(f.right-f.left),
+		Y: f.bott - scale(f.d.Y, f.d.Y.Value(s))*(f.bott-f.top),
+	}
+}
+
+// clip разбивает ломаную на видимые в области построения части (алгоритм Лианга–Барски)
+func (f frame) clip(pts []point) [][]point {
+	var parts [][]point
+	var cur []point
+	flush := func() {
+		if len(cur) > 1 {
+			parts = append(parts, cur)
+		}
+		cur = nil
+	}
+	for i := 1; i < len(pts); i++ {
+		a, b, ok := f.clipSegment(pts[i-1], pts[i])
+		if !ok {
+			flush()
+			continue
+		}
+		if len(cur) == 0 || cur[len(cur)-1] != a {
+			flush()
+			cur = append(cur, a)
+		}
+		cur = append(cur, b)
+		if b != pts[i] {
+			flush()
+		}
+	}
+	flush()
+	return parts
+}
+
+func (f frame) clipSegment(a, b point) (point, point, bool) {
+	if math.IsNaN(a.X+a.Y+b.X+b.Y) || math.IsInf(a.X+a.Y+b.X+b.Y, 0) {
+		return a, b, false
+	}
+	dx, dy := b.X-a.X, b.Y-a.Y
+	t0, t1 := 0.0, 1.0
+	for _, e := range [4][2]float64{
+		{-dx, a.X - f.left}, {dx, f.right - a.X},
+		{-dy, a.Y - f.top}, {dy, f.bott - a.Y},
+	} {
+		p, q := e[0], e[1]
+		if p == 0 {
+			if q < 0 {
+				return a, b, false
+			}
+			continue
+		}
+		r := q / p
+		if p < 0 {
+			if r > t1 {
+				return a, b, false
+			}
+			t0 = math.Max(t0, r)
+		} else {
+			if r < t0 {
+				return a, b, false
+			}
+			t1 = math.Min(t1, r)
+		}
+	}
+	na, nb := a, b
+	if t0 > 0 {
+		na = point{a.X + t0*dx, a.Y + t0*dy}
+	}
+	if t1 < 1 {
+		nb = point{a.X + t1*dx, a.Y + t1*dy}
+	}
+	return na, nb, true
+}
+
+// draw выводит оси, сетку, линии и подписи на canvas
+func (c *Chart) draw(cv canvas) {
+	f := newFrame(c)
+
+	for _, v := range ticks(c.Diagram.X) {
+		x := f.left + scale(c.Diagram.X, v)*(f.right-f.left)
+		cv.polyline([]point{{x, f.top}, {x, f.bott}}, style{Color: colorGrid, Width: 1})
+		cv.text(x, f.bott+16, formatTick(v), fontSize, anchorMiddle, colorText)
+	}
+	for _, v := range ticks(c.Diagram.Y) {
+		y := f.bott - scale(c.Diagram.Y, v)*(f.bott-f.top)
+		cv.polyline([]point{{f.left, y}, {f.right, y}}, style{Color: colorGrid, Width: 1})
+		cv.text(f.left-6, y+4, formatTick(v), fontSize, anchorEnd, colorText)
+	}
+
+	for _, curve := range c.Curves {
+		st := curveStyle(curve.Kind)
+		var visible [][]point
+		for _, seg := range curve.Segments {
+			pts := make([]point, len(seg))
+			for i, s := range seg {
+				pts[i] = f.project(s)
+			}
+			visible = append(visible, f.clip(pts)...)
+		}
+		for _, pts := range visible {
+			cv.polyline(pts, st)
+		}
+		if curve.Kind != Saturation && len(visible) > 0 {
+			c.drawLabel(cv, curve, visible, st.Color)
+		}
+	}
+
+	for i, path := range c.Paths {
+		c.drawPath(cv, f, path, pathColors[i%len(pathColors)], i)
+	}
+
+	cv.polyline([]point{{f.left, f.top}, {f.right, f.top}, {f.right, f.bott}, {f.left, f.bott}, {f.left, f.top}},
+		style{Color: colorBlack, Width: 1})
+	cv.text((f.left+f.right)/2, f.top-14, c.Diagram.Title, titleSize, anchorMiddle, colorBlack)
+	cv.text((f.left+f.right)/2, float64(c.Height)-12, c.Diagram.X.Label, fontSize, anchorMiddle, colorBlack)
+	cv.verticalText(18, (f.top+f.bott)/2, c.Diagram.Y.Label, fontSize, colorBlack)
+}
+
+// drawLabel подписывает линию: изобары и изотермы у конца последнего видимого
+// участка, линии сухости у начала первого
+func (c *Chart) drawLabel(cv canvas, curve Curve, visible [][]point, col color.RGBA) {
+	var at point
+	anchor := anchorEnd
+	if curve.Kind == Quality {
+		at = visible[0][0]
+		anchor = anchorStart
+	} else {
+		last := visible[len(visible)-1]
+		at = last[len(last)-1]
+	}
+	cv.text(at.X-2, at.Y-4, curve.Label, fontSize-2, anchor, col)
+}
+
+// drawPath выводит процесс: ломаную, узловые точки с подписями и строку легенды
+func (c *Chart) drawPath(cv canvas, f frame, path Path, col color.RGBA, index int) {
+	pts := make([]point, len(path.Line))
+	for i, s := range path.Line {
+		pts[i] = f.project(s)
+	}
+	for _, part := range f.clip(pts) {
+		cv.polyline(part, style{Color: col, Width: 2.5})
+	}
+	for _, node := range path.Points {
+		at := f.project(node.State)
+		if at.X < f.left || at.X > f.right || at.Y < f.top || at.Y > f.bott {
+			continue
+		}
+		cv.circle(at.X, at.Y, 4, col)
+		cv.text(at.X+6, at.Y-6, node.Label, fontSize, anchorStart, col)
+	}
+	if path.Label != "" {
+		y := f.top + 16 + float64(index)*16
+		cv.polyline([]point{{f.left + 10, y - 4}, {f.left + 30, y - 4}}, style{Color: col, Width: 2.5})
+		cv.text(f.left+36, y, path.Label, fontSize, anchorStart, col)
+	}
+}
+
+// ticks возвращает «круглые» значения делений оси
+func ticks(a Axis) []float64 {
+	var out []float64
+	if a.Log {
+		for e := math.Floor(math.Log10(a.Min)); e <= math.Ceil(math.Log10(a.Max)); e++ {
+			v := math.Pow(10, e)
+			if v >= a.Min && v <= a.Max {
+				out = append(out, v)
+			}
+		}
+		return out
+	}
+	step := niceStep((a.Max - a.Min) / 10)
+	for v := math.Ceil(a.Min/step) * step; v <= a.Max+step*1e-9; v += step {
+		out = append(out, v)
+	}
+	return out
+}
+
+func niceStep(raw float64) float64 {
+	mag := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, m := range []float64{1, 2, 2.5, 5, 10} {
+		if m*mag >= raw {
+			return m * mag
+		}
+	}
+	return 10 * mag
+}
+
+func formatTick(v float64) string {
+	if math.Abs(v) < 1e-9 {
+		v = 0
+	}
+	return strconv.FormatFloat(v, 'g', 6, 64)
+}
@@ -0,0 +1,70 @@
+package chart
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+)
+
+// svgCanvas формирует SVG-документ
+type svgCanvas struct {
+	buf bytes.Buffer
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func (s *svgCanvas) polyline(pts []point, st style) {
+	s.buf.WriteString(`<polyline fill="none" points="`)
+	for i, p := range pts {
+		if i > 0 {
+			s.buf.WriteByte(' ')
+		}
+		fmt.Fprintf(&s.buf, "%.2f,%.2f", p.X, p.Y)
+	}
+	fmt.Fprintf(&s.buf, `" stroke="%s" stroke-width="%.2g"`, hexColor(st.Color), st.Width)
+	if st.Dash {
+		s.buf.WriteString(` stroke-dasharray="4 3"`)
+	}
+	s.buf.WriteString("/>\n")
+}
+
+func (s *svgCanvas) text(x, y float64, str string, size float64, anchor textAnchor, c color.RGBA) {
+	fmt.Fprintf(&s.buf, `<text x="%.2f" y="%.2f" font-size="%.3g" text-anchor="%s" fill="%s">%s</text>`+"\n",
+		x, y, size, svgAnchor(anchor), hexColor(c), html.EscapeString(str))
+}
+
+func (s *svgCanvas) verticalText(x, y float64, str string, size float64, c color.RGBA) {
+	fmt.Fprintf(&s.buf, `<text x="%.2f" y="%.2f" font-size="%.3g" text-anchor="middle" fill="%s" transform="rotate(-90 %.2f %.2f)">%s</text>`+"\n",
+		x, y, size, hexColor(c), x, y, html.EscapeString(str))
+}
+
+func (s *svgCanvas) circle(x, y, r float64, c color.RGBA) {
+	fmt.Fprintf(&s.buf, `<circle cx="%.2f" cy="%.2f" r="%.2g" fill="%s"/>`+"\n", x, y, r, hexColor(c))
+}
+
+func svgAnchor(a textAnchor) string {
+	switch a {
+	case anchorMiddle:
+		return "middle"
+	case anchorEnd:
+		return "end"
+	default:
+		return "start"
+	}
+}
+
+// WriteSVG выводит диаграмму в формате SVG
+func (c *Chart) WriteSVG(w io.Writer) error {
+	s := &svgCanvas{}
+	fmt.Fprintf(&s.buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n",
+		c.Width, c.Height, c.Width, c.Height)
+	fmt.Fprintf(&s.buf, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", c.Width, c.Height)
+	c.draw(s)
+	s.buf.WriteString("</svg>\n")
+	_, err := s.buf.WriteTo(w)
+	return err
+}
@@ -0,0 +1,161 @@
+// Package boiler выполняет тепловой баланс парового котла (парогенератора):
+// тепловую нагрузку по поверхностям нагрева, потери с непрерывной продувкой
+// и КПД по прямому балансу.
+package boiler
+
+import (
+	"math"
+
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/internal/steamprops"
+)
+
+// Boiler описывает режим работы котла
+type Boiler struct {
+	FeedwaterTemperature float64 // °C
+	FeedwaterPressure    float64 // Pa
+	DrumPressure         float64 // Pa
+	SteamTemperature     float64 // °C, на выходе пароперегревателя; 0 — сухой насыщенный пар из барабана
+	SteamPressure        float64 // Pa, на выходе пароперегревателя; 0 — давление в барабане
+	SteamFlow            float64 // кг/с, паропроизводительность
+	BlowdownFraction     float64 // доля непрерывной продувки от расхода питательной воды, 0..1
+	FlashPressure        float64 // Pa, давление в расширителе продувки; 0 — без расширителя
+	FuelFlow             float64 // кг/с; 0 — КПД не рассчитывается
+	HeatingValue         float64 // кДж/кг, низшая теплота сгорания топлива
+}
+
+// Validate проверяет параметры котла
+func (b *Boiler) Validate() error {
+	if !(b.SteamFlow > 0) {
+		return i18n.Errorf("паропроизводительность %.4f кг/с должна быть положительной", b.SteamFlow)
+	}
+	if !(b.DrumPressure > 0) || !(b.FeedwaterPressure > 0) {
+		return i18n.Errorf("давления в барабане %.0f Па и питательной воды %.0f Па должны быть положительными", b.DrumPressure, b.FeedwaterPressure)
+	}
+	if math.IsNaN(b.BlowdownFraction) || b.BlowdownFraction < 0 || b.BlowdownFraction >= 1 {
+		return i18n.Errorf("доля продувки %.4f вне диапазона 0..1", b.BlowdownFraction)
+	}
+	if b.FlashPressure < 0 || (b.FlashPressure > 0 && b.FlashPressure >= b.DrumPressure) {
+		return i18n.Errorf("давление в расширителе %.0f Па должно быть ниже давления в барабане %.0f Па", b.FlashPressure, b.DrumPressure)
+	}
+	if b.FuelFlow < 0 || b.HeatingValue < 0 {
+		return i18n.Errorf("расход топлива %.4f кг/с и теплота сгорания %.1f кДж/кг не могут быть отрицательными", b.FuelFlow, b.HeatingValue)
+	}
+	return nil
+}
+
+// Result содержит тепловой баланс котла
+type Result struct {
+	Feedwater  *steamprops.Result // питательная вода
+	DrumLiquid *steamprops.Result // котловая вода (продувка)
+	DrumVapor  *steamprops.Result // насыщенный пар в барабане
+	Steam      *steamprops.Result // пар на выходе котла
+
+	FeedwaterFlow  float64 // кг/с
+	BlowdownFlow   float64 // кг/с
+	FlashSteamFlow float64 // кг/с, пар вторичного вскипания в расширителе
+
+	EconomizerDuty  float64 // кВт, подогрев питательной воды до кипения
+	EvaporatorDuty  float64 // кВт, парообразование
+	SuperheaterDuty float64 // кВт, перегрев пара
+	Duty            float64 // кВт, теплота, воспринятая рабочей средой
+
+	SteamHeat     float64 // кВт, полезная теплота пара
+	BlowdownLoss  float64 // кВт, теплота, уносимая продувкой
+	FlashRecovery float64 // кВт, теплота пара расширителя (возвращается в цикл)
+
+	FuelInput     float64 // кВт
+	Efficiency    float64 // КПД брутто по прямому балансу (продувка — полезная теплота)
+	NetEfficiency float64 // КПД с учетом потерь с продувкой (за вычетом возврата из расширителя)
+}
+
+// Calculate выполняет тепловой баланс котла
+func Calculate(calc *steamprops.Calculator, b *Boiler) (*Result, error) {
+	if calc == nil || b == nil {
+		return nil, i18n.Errorf("не заданы калькулятор или параметры котла")
+	}
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	feedwater, err := calc.Calculate(&steamprops.InputData{
+		Mode:        "TP",
+		Temperature: b.FeedwaterTemperature,
+		Pressure:    b.FeedwaterPressure,
+	})
+	if err != nil {
+		return nil, i18n.Errorf("питательная вода: %w", err)
+	}
+	drumLiquid, err := calc.CalculatePX(b.DrumPressure, 0)
+	if err != nil {
+		return nil, i18n.Errorf("котловая вода: %w", err)
+	}
+	drumVapor, err := calc.CalculatePX(b.DrumPressure, 1)
+	if err != nil {
+		return nil, i18n.Errorf("насыщенный пар: %w", err)
+	}
+
+	steam := drumVapor
+	if b.SteamTemperature > 0 {
+		pressure := b.SteamPressure
+		if pressure == 0 {
+			pressure = b.DrumPressure
+		}
+		steam, err = calc.Calculate(&steamprops.InputData{
+			Mode:        "TP",
+			Temperature: b.SteamTemperature,
+			Pressure:    pressure,
+		})
+		if err != nil {
+			return nil, i18n.Errorf("перегретый пар: %w", err)
+		}
+	}
+
+	hfw := feedwater.Properties.SpecificEnthalpy
+	hf := drumLiquid.Properties.SpecificEnthalpy
+	hg := drumVapor.Properties.SpecificEnthalpy
+	hs := steam.Properties.SpecificEnthalpy
+
+	if hfw >= hf {
+		return nil, i18n.Errorf("питательная вода (h=%.2f кДж/кг) не должна быть горячее котловой воды (h=%.2f кДж/кг)", hfw, hf)
+	}
+	if hs < hg {
+		return nil, i18n.Errorf("энтальпия пара на выходе %.2f кДж/кг ниже энтальпии насыщенного пара в барабане %.2f кДж/кг", hs, hg)
+	}
+
+	res := &Result{
+		Feedwater:  feedwater,
+		DrumLiquid: drumLiquid,
+		DrumVapor:  drumVapor,
+		Steam:      steam,
+	}
+	res.FeedwaterFlow = b.SteamFlow / (1 - b.BlowdownFraction)
+	res.BlowdownFlow = res.FeedwaterFlow - b.SteamFlow
+
+	res.EconomizerDuty = res.FeedwaterFlow * (hf - hfw)
+	res.EvaporatorDuty = b.SteamFlow * (hg - hf)
+	res.SuperheaterDuty = b.SteamFlow * (hs - hg)
+	res.Duty = res.EconomizerDuty + res.EvaporatorDuty + res.SuperheaterDuty
+
+	res.SteamHeat = b.SteamFlow * (hs - hfw)
+	res.BlowdownLoss = res.BlowdownFlow * (hf - hfw)
+
+	if b.FlashPressure > 0 && res.BlowdownFlow > 0 {
+		flash, err := calc.SaturationAtPressure(b.FlashPressure)
+		if err != nil {
+			return nil, i18n.Errorf("расширитель продувки: %w", err)
+		}
+		hfFlash := flash.Liquid.SpecificEnthalpy
+		hgFlash := flash.Vapor.SpecificEnthalpy
+		x := (hf - hfFlash) / (hgFlash - hfFlash)
+		res.FlashSteamFlow = res.BlowdownFlow * math.Max(x, 0)
+		res.FlashRecovery = res.FlashSteamFlow * (hgFlash - hfw)
+	}
+
+	res.FuelInput = b.FuelFlow * b.HeatingValue
+	if res.FuelInput > 0 {
+		res.Efficiency = res.Duty / res.FuelInput
+		res.NetEfficiency = (res.Duty - res.BlowdownLoss + res.FlashRecovery) / res.FuelInput
+	}
+	return res, nil
+}
@@ -0,0 +1,119 @@
+package boiler
+
+import (
+	"math"
+	"testing"
+
+	"github.com/somepgs/steamprops/internal/steamprops"
+)
+
+func TestCalculate_EnergyBalance(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	b := &Boiler{
+		FeedwaterTemperature: 105,
+		FeedwaterPressure:    5e6,
+		DrumPressure:         4e6,
+		SteamTemperature:     440,
+		SteamPressure:        3.9e6,
+		SteamFlow:            10,
+		BlowdownFraction:     0.02,
+		FuelFlow:             0.7,
+		HeatingValue:         42000,
+	}
+
+	res, err := Calculate(calc, b)
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+
+	if math.Abs(res.FeedwaterFlow-10/0.98) > 1e-12 || math.Abs(res.BlowdownFlow-0.02*10/0.98) > 1e-12 {
+		t.Errorf("flows = %v/%v", res.FeedwaterFlow, res.BlowdownFlow)
+	}
+
+	// Сумма по поверхностям нагрева равна теплоте пара и продувки
+	hfw := res.Feedwater.Properties.SpecificEnthalpy
+	expected := b.SteamFlow*(res.Steam.Properties.SpecificEnthalpy-hfw) +
+		res.BlowdownFlow*(res.DrumLiquid.Properties.SpecificEnthalpy-hfw)
+	if math.Abs(res.Duty-expected) > 1e-6 {
+		t.Errorf("duty = %.3f kW, want %.3f kW", res.Duty, expected)
+	}
+	if math.Abs(res.Duty-res.SteamHeat-res.BlowdownLoss) > 1e-6 {
+		t.Errorf("duty %.3f != steam heat %.3f + blowdown %.3f", res.Duty, res.SteamHeat, res.BlowdownLoss)
+	}
+	// Около 3305 - 444 ≈ 2860 кДж/кг на 10 кг/с пара
+	if res.SteamHeat < 28000 || res.SteamHeat > 29000 {
+		t.Errorf("steam heat = %.1f kW, want ≈ 28600 kW", res.SteamHeat)
+	}
+
+	if math.Abs(res.FuelInput-29400) > 1e-9 {
+		t.Errorf("fuel input = %v, want 29400", res.FuelInput)
+	}
+	if math.Abs(res.Efficiency-res.Duty/res.FuelInput) > 1e-12 {
+		t.Errorf("efficiency = %v", res.Efficiency)
+	}
+	if res.NetEfficiency >= res.Efficiency {
+		t.Errorf("net efficiency %.4f should be below gross %.4f", res.NetEfficiency, res.Efficiency)
+	}
+}
+
+func TestCalculate_SaturatedSteamWithFlashTank(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	b := &Boiler{
+		FeedwaterTemperature: 105,
+		FeedwaterPressure:    1.5e6,
+		DrumPressure:         1.3e6,
+		SteamFlow:            5,
+		BlowdownFraction:     0.05,
+		FlashPressure:        0.15e6,
+	}
+
+	res, err := Calculate(calc, b)
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+	if res.SuperheaterDuty != 0 {
+		t.Errorf("superheater duty = %v, want 0 for saturated steam", res.SuperheaterDuty)
+	}
+	// При вскипании от 1.3 до 0.15 МПа испаряется около 14% продувочной воды
+	x := res.FlashSteamFlow / res.BlowdownFlow
+	if x < 0.12 || x > 0.16 {
+		t.Errorf("flash fraction = %.4f, want ≈ 0.14", x)
+	}
+	if res.FlashRecovery <= 0 || res.FlashRecovery >= res.BlowdownLoss {
+		t.Errorf("flash recovery = %.3f kW, blowdown loss = %.3f kW", res.FlashRecovery, res.BlowdownLoss)
+	}
+	if res.Efficiency != 0 {
+		t.Errorf("efficiency = %v, want 0 without fuel input", res.Efficiency)
+	}
+}
+
+func TestCalculate_InvalidInputs(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	valid := Boiler{
+		FeedwaterTemperature: 105,
+		FeedwaterPressure:    5e6,
+		DrumPressure:         4e6,
+		SteamFlow:            10,
+	}
+
+	tests := []struct {
+		name   string
+		modify func(b *Boiler)
+	}{
+		{"Zero steam flow", func(b *Boiler) { b.SteamFlow = 0 }},
+		{"Blowdown fraction", func(b *Boiler) { b.BlowdownFraction = 1 }},
+		{"Flash above drum", func(b *Boiler) { b.FlashPressure = 5e6 }},
+		{"Negative fuel", func(b *Boiler) { b.FuelFlow = -1 }},
+		{"Hot feedwater", func(b *Boiler) { b.FeedwaterTemperature = 260 }},
+		{"Steam below saturation", func(b *Boiler) { b.SteamTemperature = 250; b.SteamPressure = 4e6 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := valid
+			tt.modify(&b)
+			if _, err := Calculate(calc, &b); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
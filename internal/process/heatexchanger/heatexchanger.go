@@ -0,0 +1,191 @@
+// Package heatexchanger строит T–Q диаграммы теплообменников (экономайзеров,
+// испарителей и пароперегревателей котлов-утилизаторов, конденсаторов) и
+// находит точку пинча, среднелогарифмический температурный напор и UA.
+//
+// Тепловая нагрузка разбивается на участки; на каждом участке температуры
+// теплоносителей определяются по их энтальпии, поэтому фазовые переходы
+// воды и пара учитываются автоматически.
+package heatexchanger
+
+import (
+	"math"
+
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/internal/steamprops"
+)
+
+// DefaultSegments число участков разбиения тепловой нагрузки
+const DefaultSegments = 100
+
+// Flow задает схему движения теплоносителей
+type Flow int
+
+const (
+	CounterFlow  Flow = iota // противоток
+	ParallelFlow             // прямоток
+)
+
+// Stream описывает теплоноситель, температура которого зависит от переданной теплоты
+type Stream interface {
+	// Temperature возвращает температуру (°C) после изменения теплосодержания
+	// потока на dq (кВт): dq > 0 — нагрев, dq < 0 — охлаждение
+	Temperature(dq float64) (float64, error)
+}
+
+// WaterStream — поток воды или пара при постоянном давлении, свойства IF-97
+type WaterStream struct {
+	Calculator *steamprops.Calculator
+	MassFlow   float64            // кг/с
+	Inlet      *steamprops.Result // состояние на входе
+}
+
+// Temperature возвращает температуру потока после изменения энтальпии
+func (w *WaterStream) Temperature(dq float64) (float64, error) {
+	state, err := w.State(dq)
+	if err != nil {
+		return 0, err
+	}
+	return state.Temperature, nil
+}
+
+// State возвращает состояние потока после изменения теплосодержания на dq (кВт)
+func (w *WaterStream) State(dq float64) (*steamprops.Result, error) {
+	if w.Calculator == nil || w.Inlet == nil {
+		return nil, i18n.Errorf("не заданы калькулятор или входное состояние потока")
+	}
+	if !(w.MassFlow > 0) {
+		return nil, i18n.Errorf("массовый расход %.4f кг/с должен быть положительным", w.MassFlow)
+	}
+	return w.Calculator.CalculatePH(w.Inlet.Pressure, w.Inlet.Properties.SpecificEnthalpy+dq/w.MassFlow)
+}
+
+// DutyTo возвращает теплоту (кВт), необходимую для изменения энтальпии потока до h (кДж/кг)
+func (w *WaterStream) DutyTo(h float64) float64 {
+	return w.MassFlow * math.Abs(h-w.Inlet.Properties.SpecificEnthalpy)
+}
+
+// GasStream — поток с постоянной теплоемкостью (например, дымовые газы)
+type GasStream struct {
+	MassFlow     float64 // кг/с
+	Inlet        float64 // °C
+	HeatCapacity float64 // кДж/(кг·К)
+}
+
+// Temperature возвращает температуру газа после изменения теплосодержания
+func (g *GasStream) Temperature(dq float64) (float64, error) {
+	if !(g.MassFlow > 0) || !(g.HeatCapacity > 0) {
+		return 0, i18n.Errorf("расход %.4f кг/с и теплоемкость %.4f кДж/(кг·К) газа должны быть положительными", g.MassFlow, g.HeatCapacity)
+	}
+	return g.Inlet + dq/(g.MassFlow*g.HeatCapacity), nil
+}
+
+// Exchanger описывает теплообменник
+type Exchanger struct {
+	Hot      Stream  // греющий теплоноситель
+	Cold     Stream  // нагреваемый теплоноситель
+	Duty     float64 // кВт, тепловая нагрузка
+	Flow     Flow
+	Segments int // по умолчанию DefaultSegments
+}
+
+// Point — точка T–Q диаграммы
+type Point struct {
+	Duty            float64 // кВт, теплота, переданная холодному теплоносителю от его входа
+	HotTemperature  float64 // °C
+	ColdTemperature float64 // °C
+	Difference      float64 // K, температурный напор
+}
+
+// Result содержит результаты анализа теплообменника
+type Result struct {
+	Points          []Point // T–Q диаграмма от входа холодного теплоносителя
+	Duty            float64 // кВт
+	PinchDifference float64 // K, минимальный температурный напор
+	PinchDuty       float64 // кВт, положение точки пинча
+	HotInlet        float64 // °C
+	HotOutlet       float64 // °C
+	ColdInlet       float64 // °C
+	ColdOutlet      float64 // °C
+	LMTD            float64 // K, по температурам на концах аппарата
+	UA              float64 // кВт/К, сумма по участкам
+	EffectiveLMTD   float64 // K, Q/UA с учетом нелинейности T–Q кривых
+}
+
+// Analyze строит T–Q диаграмму и рассчитывает пинч, LMTD и UA
+func Analyze(ex *Exchanger) (*Result, error) {
+	if ex == nil || ex.Hot == nil || ex.Cold == nil {
+		return nil, i18n.Errorf("не заданы теплоносители")
+	}
+	if !(ex.Duty > 0) {
+		return nil, i18n.Errorf("тепловая нагрузка %.3f кВт должна быть положительной", ex.Duty)
+	}
+	if ex.Flow != CounterFlow && ex.Flow != ParallelFlow {
+		return nil, i18n.Errorf("неизвестная схема движения теплоносителей: %d", ex.Flow)
+	}
+	segments := ex.Segments
+	if segments == 0 {
+		segments = DefaultSegments
+	}
+	if segments < 1 {
+		return nil, i18n.Errorf("число участков %d должно быть положительным", segments)
+	}
+
+	res := &Result{Duty: ex.Duty, PinchDifference: math.Inf(1)}
+	for i := 0; i <= segments; i++ {
+		q := ex.Duty * float64(i) / float64(segments)
+		tc, err := ex.Cold.Temperature(q)
+		if err != nil {
+			return nil, i18n.Errorf("холодный теплоноситель при Q=%.3f кВт: %w", q, err)
+		}
+		// При противотоке холодный вход встречается с горячим выходом
+		released := q
+		if ex.Flow == CounterFlow {
+			released = ex.Duty - q
+		}
+		th, err := ex.Hot.Temperature(-released)
+		if err != nil {
+			return nil, i18n.Errorf("горячий теплоноситель при Q=%.3f кВт: %w", released, err)
+		}
+		dt := th - tc
+		if dt <= 0 {
+			return nil, i18n.Errorf("пересечение температур при Q=%.3f кВт: Tгор=%.3f°C, Tхол=%.3f°C", q, th, tc)
+		}
+		res.Points = append(res.Points, Point{
+			Duty:            q,
+			HotTemperature:  th,
+			ColdTemperature: tc,
+			Difference:      dt,
+		})
+		if dt < res.PinchDifference {
+			res.PinchDifference = dt
+			res.PinchDuty = q
+		}
+		if i > 0 {
+			prev := res.Points[i-1]
+			res.UA += (q - prev.Duty) / lmtd(prev.Difference, dt)
+		}
+	}
+
+	first := res.Points[0]
+	last := res.Points[segments]
+	res.ColdInlet = first.ColdTemperature
+	res.ColdOutlet = last.ColdTemperature
+	if ex.Flow == CounterFlow {
+		res.HotInlet = last.HotTemperature
+		res.HotOutlet = first.HotTemperature
+	} else {
+		res.HotInlet = first.HotTemperature
+		res.HotOutlet = last.HotTemperature
+	}
+	res.LMTD = lmtd(first.Difference, last.Difference)
+	res.EffectiveLMTD = res.Duty / res.UA
+	return res, nil
+}
+
+// lmtd возвращает среднелогарифмический температурный напор
+func lmtd(dt1, dt2 float64) float64 {
+	if math.Abs(dt1-dt2) < 1e-9*math.Max(dt1, dt2) {
+		return 0.5 * (dt1 + dt2)
+	}
+	return (dt1 - dt2) / math.Log(dt1/dt2)
+}
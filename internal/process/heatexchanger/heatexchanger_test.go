@@ -0,0 +1,139 @@
+package heatexchanger
+
+import (
+	"math"
+	"testing"
+
+	"github.com/somepgs/steamprops/internal/steamprops"
+)
+
+func TestAnalyze_BalancedCounterFlow(t *testing.T) {
+	// Равные водяные эквиваленты: температурный напор постоянен по длине
+	ex := &Exchanger{
+		Hot:  &GasStream{MassFlow: 2, Inlet: 200, HeatCapacity: 1},
+		Cold: &GasStream{MassFlow: 2, Inlet: 50, HeatCapacity: 1},
+		Duty: 200,
+	}
+	res, err := Analyze(ex)
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	if math.Abs(res.PinchDifference-50) > 1e-9 || math.Abs(res.LMTD-50) > 1e-9 {
+		t.Errorf("pinch/LMTD = %v/%v, want 50/50", res.PinchDifference, res.LMTD)
+	}
+	if math.Abs(res.UA-4) > 1e-9 {
+		t.Errorf("UA = %v, want 4", res.UA)
+	}
+	if res.HotOutlet != 100 || res.ColdOutlet != 150 {
+		t.Errorf("outlets = %v/%v, want 100/150", res.HotOutlet, res.ColdOutlet)
+	}
+}
+
+func TestAnalyze_ParallelFlowLMTD(t *testing.T) {
+	ex := &Exchanger{
+		Hot:  &GasStream{MassFlow: 1, Inlet: 200, HeatCapacity: 1},
+		Cold: &GasStream{MassFlow: 1, Inlet: 20, HeatCapacity: 1},
+		Duty: 60,
+		Flow: ParallelFlow,
+	}
+	res, err := Analyze(ex)
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	// Концевые напоры 180 и 60 K
+	want := (180.0 - 60.0) / math.Log(3)
+	if math.Abs(res.LMTD-want) > 1e-9 {
+		t.Errorf("LMTD = %v, want %v", res.LMTD, want)
+	}
+	// Для линейных T–Q кривых эффективный напор совпадает с LMTD
+	if math.Abs(res.EffectiveLMTD-want)/want > 1e-4 {
+		t.Errorf("effective LMTD = %v, want %v", res.EffectiveLMTD, want)
+	}
+	if math.Abs(res.PinchDuty-60) > 1e-9 {
+		t.Errorf("pinch duty = %v, want 60 (outlet end)", res.PinchDuty)
+	}
+}
+
+func TestAnalyze_Evaporator(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	feed, err := calc.Calculate(&steamprops.InputData{Mode: "TP", Temperature: 200, Pressure: 4e6})
+	if err != nil {
+		t.Fatalf("feed: %v", err)
+	}
+	sat, err := calc.SaturationAtPressure(4e6)
+	if err != nil {
+		t.Fatalf("saturation: %v", err)
+	}
+	water := &WaterStream{Calculator: calc, MassFlow: 10, Inlet: feed}
+
+	ex := &Exchanger{
+		Hot:  &GasStream{MassFlow: 100, Inlet: 550, HeatCapacity: 1.1},
+		Cold: water,
+		Duty: water.DutyTo(sat.Vapor.SpecificEnthalpy),
+	}
+	res, err := Analyze(ex)
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	// Пинч находится в начале испарения (на линии кипения)
+	qBubble := water.DutyTo(sat.Liquid.SpecificEnthalpy)
+	if math.Abs(res.PinchDuty-qBubble) > ex.Duty/float64(DefaultSegments) {
+		t.Errorf("pinch duty = %.1f kW, want ≈ %.1f kW at bubble point", res.PinchDuty, qBubble)
+	}
+	if math.Abs(res.ColdOutlet-sat.Temperature) > 1e-3 {
+		t.Errorf("cold outlet = %.3f, want Tsat %.3f", res.ColdOutlet, sat.Temperature)
+	}
+	// Фазовый переход делает T–Q кривую нелинейной: эффективный напор ниже концевого LMTD
+	if res.EffectiveLMTD >= res.LMTD {
+		t.Errorf("effective LMTD %.3f should be below terminal LMTD %.3f", res.EffectiveLMTD, res.LMTD)
+	}
+}
+
+func TestAnalyze_CondenserApproach(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	exhaust, err := calc.CalculatePX(10e3, 0.95)
+	if err != nil {
+		t.Fatalf("exhaust: %v", err)
+	}
+	sat, err := calc.SaturationAtPressure(10e3)
+	if err != nil {
+		t.Fatalf("saturation: %v", err)
+	}
+	steam := &WaterStream{Calculator: calc, MassFlow: 1, Inlet: exhaust}
+	duty := steam.DutyTo(sat.Liquid.SpecificEnthalpy)
+
+	ex := &Exchanger{
+		Hot:  steam,
+		Cold: &GasStream{MassFlow: duty / (4.18 * 10), Inlet: 20, HeatCapacity: 4.18},
+		Duty: duty,
+	}
+	res, err := Analyze(ex)
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	// Конденсация при постоянной температуре: пинч на выходе охлаждающей воды
+	if math.Abs(res.PinchDifference-(sat.Temperature-30)) > 1e-3 {
+		t.Errorf("approach = %.3f K, want %.3f K", res.PinchDifference, sat.Temperature-30)
+	}
+	if math.Abs(res.HotInlet-res.HotOutlet) > 1e-3 {
+		t.Errorf("condensing temperature should stay constant: %.3f → %.3f", res.HotInlet, res.HotOutlet)
+	}
+}
+
+func TestAnalyze_TemperatureCross(t *testing.T) {
+	ex := &Exchanger{
+		Hot:  &GasStream{MassFlow: 1, Inlet: 100, HeatCapacity: 1},
+		Cold: &GasStream{MassFlow: 1, Inlet: 50, HeatCapacity: 1},
+		Duty: 80,
+		Flow: ParallelFlow,
+	}
+	if _, err := Analyze(ex); err == nil {
+		t.Errorf("expected temperature cross error")
+	}
+	if _, err := Analyze(&Exchanger{Hot: ex.Hot, Cold: ex.Cold}); err == nil {
+		t.Errorf("expected error for zero duty")
+	}
+	if _, err := Analyze(nil); err == nil {
+		t.Errorf("expected error for nil exchanger")
+	}
+}
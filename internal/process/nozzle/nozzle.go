@@ -0,0 +1,176 @@
+// Package nozzle рассчитывает критическое (запертое) истечение пара и
+// вскипающей воды через сопло и пропускную способность предохранительных клапанов.
+//
+// Расчет ведется вдоль изоэнтропы от параметров торможения. Двухфазная область
+// описывается гомогенной равновесной моделью (HEM): фазы движутся с одной
+// скоростью и находятся в термодинамическом равновесии.
+package nozzle
+
+import (
+	"math"
+
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/internal/steamprops"
+)
+
+const (
+	// DefaultSteps число шагов по давлению при движении вдоль изоэнтропы
+	DefaultSteps = 200
+
+	// minPressureRatio нижняя граница отношения давлений при поиске критического сечения
+	minPressureRatio = 0.01
+
+	minPressure = 611.657 // Pa, тройная точка
+
+	goldenIterations = 60
+)
+
+// Result содержит параметры критического сечения сопла
+type Result struct {
+	Stagnation            *steamprops.Result // параметры торможения
+	Throat                *steamprops.Result // состояние в горле
+	CriticalPressureRatio float64            // p*/p0
+	ThroatVelocity        float64            // м/с
+	MassFlux              float64            // кг/(м²·с)
+	Choked                bool               // истечение запертое (противодавление ниже критического)
+}
+
+// Capacity возвращает массовый расход (кг/с) через проходное сечение area (м²)
+// с коэффициентом расхода kd
+func (r *Result) Capacity(area, kd float64) float64 {
+	return kd * area * r.MassFlux
+}
+
+// point описывает состояние на изоэнтропе
+type point struct {
+	state    *steamprops.Result
+	velocity float64 // м/с
+	flux     float64 // кг/(м²·с)
+}
+
+// Calculate находит критическое сечение сопла для параметров торможения stagnation.
+// Если backPressure (Pa) больше критического давления, истечение не запертое и
+// горло принимается при противодавлении. backPressure <= 0 означает истечение в вакуум.
+func Calculate(calc *steamprops.Calculator, stagnation *steamprops.Result, backPressure float64) (*Result, error) {
+	return CalculateWithSteps(calc, stagnation, backPressure, DefaultSteps)
+}
+
+// CalculateWithSteps выполняет расчет с заданным числом шагов по давлению
+func CalculateWithSteps(calc *steamprops.Calculator, stagnation *steamprops.Result, backPressure float64, steps int) (*Result, error) {
+	if calc == nil || stagnation == nil {
+		return nil, i18n.Errorf("не заданы калькулятор или параметры торможения")
+	}
+	if steps < 2 {
+		return nil, i18n.Errorf("число шагов %d должно быть не меньше 2", steps)
+	}
+	p0 := stagnation.Pressure
+	if backPressure >= p0 {
+		return nil, i18n.Errorf("противодавление %.0f Па не ниже давления торможения %.0f Па", backPressure, p0)
+	}
+
+	h0 := stagnation.Properties.SpecificEnthalpy
+	s0 := stagnation.Properties.SpecificEntropy
+
+	// evaluate рассчитывает скорость и плотность потока при давлении p.
+	// Вдоль изоэнтропы dh = v·dp, поэтому перепад энтальпий h0 - h(p, s0)
+	// равен интегралу ∫v·dp от p до p0.
+	evaluate := func(p float64) (point, error) {
+		state, err := calc.CalculatePS(p, s0)
+		if err != nil {
+			return point{}, err
+		}
+		dh := h0 - state.Properties.SpecificEnthalpy // кДж/кг
+		if dh < 0 {
+			dh = 0
+		}
+		velocity := math.Sqrt(2 * dh * 1000)
+		return point{
+			state:    state,
+			velocity: velocity,
+			flux:     state.Properties.Density * velocity,
+		}, nil
+	}
+
+	pMin := math.Max(p0*minPressureRatio, minPressure)
+	if backPressure > pMin {
+		pMin = backPressure
+	}
+
+	// Движемся вдоль изоэнтропы и находим шаг с максимальной плотностью потока
+	pressures := make([]float64, steps+1)
+	best := -1
+	var bestPoint point
+	for i := 0; i <= steps; i++ {
+		p := p0 - (p0-pMin)*float64(i)/float64(steps)
+		pressures[i] = p
+		if i == 0 {
+			continue
+		}
+		pt, err := evaluate(p)
+		if err != nil {
+			return nil, i18n.Errorf("ошибка расчета на изоэнтропе при p=%.0f Па: %w", p, err)
+		}
+		if best < 0 || pt.flux > bestPoint.flux {
+			best = i
+			bestPoint = pt
+		}
+	}
+
+	choked := best < steps
+	if choked {
+		// Уточняем максимум методом золотого сечения между соседними шагами
+		hi := pressures[best-1]
+		lo := pressures[best+1]
+		pt, err := goldenMaximum(evaluate, lo, hi)
+		if err != nil {
+			return nil, err
+		}
+		if pt.flux > bestPoint.flux {
+			bestPoint = pt
+		}
+	}
+
+	return &Result{
+		Stagnation:            stagnation,
+		Throat:                bestPoint.state,
+		CriticalPressureRatio: bestPoint.state.Pressure / p0,
+		ThroatVelocity:        bestPoint.velocity,
+		MassFlux:              bestPoint.flux,
+		Choked:                choked,
+	}, nil
+}
+
+// goldenMaximum ищет максимум плотности потока на интервале давлений [lo, hi]
+func goldenMaximum(evaluate func(p float64) (point, error), lo, hi float64) (point, error) {
+	ratio := (math.Sqrt(5) - 1) / 2
+	a, b := lo, hi
+	c := b - ratio*(b-a)
+	d := a + ratio*(b-a)
+	pc, err := evaluate(c)
+	if err != nil {
+		return point{}, err
+	}
+	pd, err := evaluate(d)
+	if err != nil {
+		return point{}, err
+	}
+	for i := 0; i < goldenIterations; i++ {
+		if pc.flux > pd.flux {
+			b, d, pd = d, c, pc
+			c = b - ratio*(b-a)
+			if pc, err = evaluate(c); err != nil {
+				return point{}, err
+			}
+		} else {
+			a, c, pc = c, d, pd
+			d = a + ratio*(b-a)
+			if pd, err = evaluate(d); err != nil {
+				return point{}, err
+			}
+		}
+	}
+	if pc.flux > pd.flux {
+		return pc, nil
+	}
+	return pd, nil
+}
@@ -0,0 +1,142 @@
+package nozzle
+
+import (
+	"math"
+	"testing"
+
+	"github.com/somepgs/steamprops/internal/calc_core"
+	"github.com/somepgs/steamprops/internal/steamprops"
+)
+
+func TestCalculate_SuperheatedSteam(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	stagnation, err := calc.Calculate(&steamprops.InputData{Mode: "TP", Temperature: 300, Pressure: 1e6})
+	if err != nil {
+		t.Fatalf("stagnation: %v", err)
+	}
+
+	res, err := Calculate(calc, stagnation, 0)
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+	if !res.Choked {
+		t.Fatalf("expected choked flow")
+	}
+	// Для перегретого пара (k ≈ 1.3) критическое отношение давлений ≈ 0.546
+	if math.Abs(res.CriticalPressureRatio-0.546) > 0.01 {
+		t.Errorf("critical pressure ratio = %.4f, want ≈ 0.546", res.CriticalPressureRatio)
+	}
+	// Идеальный газ с k = 1.3 дает G* ≈ 1298 кг/(м²·с)
+	if math.Abs(res.MassFlux-1298)/1298 > 0.03 {
+		t.Errorf("mass flux = %.1f, want ≈ 1298", res.MassFlux)
+	}
+	// В горле скорость потока равна скорости звука
+	if w := res.Throat.Properties.SpeedOfSound; math.Abs(res.ThroatVelocity-w)/w > 0.02 {
+		t.Errorf("throat velocity %.1f differs from speed of sound %.1f", res.ThroatVelocity, w)
+	}
+}
+
+func TestCalculate_FlashingWater(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	stagnation, err := calc.CalculatePX(1e6, 0)
+	if err != nil {
+		t.Fatalf("stagnation: %v", err)
+	}
+
+	res, err := Calculate(calc, stagnation, 0)
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+	if !res.Choked {
+		t.Fatalf("expected choked flow")
+	}
+	if res.Throat.Quality <= 0 || res.Throat.Quality >= 1 {
+		t.Errorf("throat quality = %.4f, want two-phase", res.Throat.Quality)
+	}
+	// Для насыщенной воды HEM дает высокое критическое отношение давлений (ω ≈ 16)
+	if res.CriticalPressureRatio < 0.8 || res.CriticalPressureRatio > 0.95 {
+		t.Errorf("critical pressure ratio = %.4f, want 0.8..0.95", res.CriticalPressureRatio)
+	}
+	if res.MassFlux < 5000 || res.MassFlux > 8000 {
+		t.Errorf("mass flux = %.1f, want 5000..8000", res.MassFlux)
+	}
+}
+
+func TestCalculate_FlashingWaterHighPressure(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	// При 20 МПа линия насыщения лежит в Region 3 (Tsat = 365.75°C)
+	stagnation, err := calc.CalculatePX(20e6, 0.5)
+	if err != nil {
+		t.Fatalf("stagnation: %v", err)
+	}
+
+	res, err := Calculate(calc, stagnation, 0)
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+	if !res.Choked {
+		t.Fatalf("expected choked flow")
+	}
+	throat := res.Throat
+	if throat.Region != calc_core.Region4 || throat.Quality <= 0 || throat.Quality >= 1 {
+		t.Fatalf("throat region = %v, quality = %.4f, want two-phase", throat.Region, throat.Quality)
+	}
+	if s0 := stagnation.Properties.SpecificEntropy; math.Abs(throat.Properties.SpecificEntropy-s0) > 1e-6 {
+		t.Errorf("throat entropy = %.6f, want %.6f", throat.Properties.SpecificEntropy, s0)
+	}
+	// Состояние в горле должно лежать на куполе при своих давлении и паросодержании
+	ref, err := calc.CalculatePX(throat.Pressure, throat.Quality)
+	if err != nil {
+		t.Fatalf("CalculatePX at throat: %v", err)
+	}
+	if math.Abs(throat.Properties.SpecificEnthalpy-ref.Properties.SpecificEnthalpy) > 1e-3 {
+		t.Errorf("throat enthalpy = %.4f, want %.4f", throat.Properties.SpecificEnthalpy, ref.Properties.SpecificEnthalpy)
+	}
+	if res.CriticalPressureRatio < 0.55 || res.CriticalPressureRatio > 0.75 {
+		t.Errorf("critical pressure ratio = %.4f, want 0.55..0.75", res.CriticalPressureRatio)
+	}
+}
+
+func TestCalculate_Unchoked(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	stagnation, err := calc.Calculate(&steamprops.InputData{Mode: "TP", Temperature: 300, Pressure: 1e6})
+	if err != nil {
+		t.Fatalf("stagnation: %v", err)
+	}
+
+	res, err := Calculate(calc, stagnation, 0.8e6)
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+	if res.Choked {
+		t.Errorf("expected unchoked flow at back pressure above critical")
+	}
+	if math.Abs(res.Throat.Pressure-0.8e6) > 1 {
+		t.Errorf("throat pressure = %.0f, want back pressure", res.Throat.Pressure)
+	}
+}
+
+func TestCalculate_InvalidInputs(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	stagnation, err := calc.Calculate(&steamprops.InputData{Mode: "TP", Temperature: 300, Pressure: 1e6})
+	if err != nil {
+		t.Fatalf("stagnation: %v", err)
+	}
+
+	if _, err := Calculate(calc, nil, 0); err == nil {
+		t.Errorf("expected error for nil stagnation")
+	}
+	if _, err := Calculate(calc, stagnation, 2e6); err == nil {
+		t.Errorf("expected error for back pressure above stagnation")
+	}
+	if _, err := CalculateWithSteps(calc, stagnation, 0, 1); err == nil {
+		t.Errorf("expected error for too few steps")
+	}
+}
+
+func TestResult_Capacity(t *testing.T) {
+	r := &Result{MassFlux: 1000}
+	if got := r.Capacity(0.01, 0.9); math.Abs(got-9) > 1e-12 {
+		t.Errorf("Capacity = %v, want 9", got)
+	}
+}
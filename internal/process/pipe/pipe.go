@@ -0,0 +1,243 @@
+// Package pipe рассчитывает падение давления и тепловые потери в паропроводах
+// и водопроводах.
+//
+// Труба разбивается на участки; на каждом участке учитываются трение по
+// Дарси–Вейсбаху с коэффициентом по Колбруку, изменение высоты, ускорение
+// потока и тепловые потери, после чего состояние среды пересчитывается по (p,h).
+package pipe
+
+import (
+	"math"
+
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/internal/calc_core/transport"
+	"github.com/somepgs/steamprops/internal/steamprops"
+)
+
+const (
+	// DefaultSegments число участков, если оно не задано
+	DefaultSegments = 50
+
+	gravity            = 9.80665 // м/с²
+	laminarReynolds    = 2300.0
+	colebrookMaxIter   = 50
+	colebrookTolerance = 1e-12
+)
+
+// Pipe описывает геометрию трубопровода и условия теплообмена
+type Pipe struct {
+	Length    float64 // м
+	Diameter  float64 // м, внутренний диаметр
+	Roughness float64 // м, абсолютная эквивалентная шероховатость
+	Elevation float64 // м, отметка выхода минус отметка входа
+	Segments  int     // число участков (по умолчанию DefaultSegments)
+
+	// Тепловые потери задаются удельным потоком на метр длины либо
+	// коэффициентом теплопередачи, отнесенным к внутренней поверхности.
+	HeatLossPerLength       float64 // Вт/м
+	HeatTransferCoefficient float64 // Вт/(м²·К)
+	AmbientTemperature      float64 // °C, для HeatTransferCoefficient
+}
+
+// Validate проверяет параметры трубопровода
+func (p *Pipe) Validate() error {
+	if !(p.Length > 0) {
+		return i18n.Errorf("длина трубы %.3f м должна быть положительной", p.Length)
+	}
+	if !(p.Diameter > 0) {
+		return i18n.Errorf("диаметр трубы %.4f м должен быть положительным", p.Diameter)
+	}
+	if p.Roughness < 0 {
+		return i18n.Errorf("шероховатость %.6f м не может быть отрицательной", p.Roughness)
+	}
+	if p.Segments < 0 {
+		return i18n.Errorf("число участков %d не может быть отрицательным", p.Segments)
+	}
+	if p.HeatTransferCoefficient < 0 {
+		return i18n.Errorf("коэффициент теплопередачи %.3f Вт/(м²·К) не может быть отрицательным", p.HeatTransferCoefficient)
+	}
+	return nil
+}
+
+// Area возвращает площадь проходного сечения, м²
+func (p *Pipe) Area() float64 {
+	return math.Pi * p.Diameter * p.Diameter / 4
+}
+
+// Node описывает состояние потока в узле между участками
+type Node struct {
+	Position       float64            // м от входа
+	State          *steamprops.Result // состояние среды
+	Velocity       float64            // м/с
+	Viscosity      float64            // Па·с
+	Reynolds       float64
+	FrictionFactor float64 // коэффициент трения Дарси
+}
+
+// Result содержит результаты расчета трубопровода
+type Result struct {
+	Nodes            []Node // узлы от входа до выхода
+	Outlet           *steamprops.Result
+	PressureDrop     float64 // Pa, полное падение давления
+	FrictionLoss     float64 // Pa
+	ElevationLoss    float64 // Pa
+	AccelerationLoss float64 // Pa
+	HeatLoss         float64 // Вт
+}
+
+// Calculate рассчитывает течение массового расхода massFlow (кг/с) через трубу
+// от входного состояния inlet
+func Calculate(calc *steamprops.Calculator, p *Pipe, inlet *steamprops.Result, massFlow float64) (*Result, error) {
+	if calc == nil || p == nil || inlet == nil {
+		return nil, i18n.Errorf("не заданы калькулятор, труба или входное состояние")
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	if !(massFlow > 0) {
+		return nil, i18n.Errorf("массовый расход %.4f кг/с должен быть положительным", massFlow)
+	}
+
+	segments := p.Segments
+	if segments == 0 {
+		segments = DefaultSegments
+	}
+	dL := p.Length / float64(segments)
+	dz := p.Elevation / float64(segments)
+	G := massFlow / p.Area() // кг/(м²·с)
+
+	res := &Result{}
+	state := inlet
+	node, err := newNode(calc, p, state, 0, G)
+	if err != nil {
+		return nil, err
+	}
+	res.Nodes = append(res.Nodes, node)
+
+	for i := 1; i <= segments; i++ {
+		rho := state.Properties.Density
+
+		// Потери давления на трении и подъем по параметрам в начале участка
+		dpFriction := node.FrictionFactor * dL / p.Diameter * rho * node.Velocity * node.Velocity / 2
+		dpElevation := rho * gravity * dz
+
+		// Тепловые потери участка, Вт
+		q := p.HeatLossPerLength * dL
+		if p.HeatTransferCoefficient > 0 {
+			q += p.HeatTransferCoefficient * math.Pi * p.Diameter * dL * (state.Temperature - p.AmbientTemperature)
+		}
+
+		// Энергетический баланс: h + w²/2 + g·z = const - q/m (кДж/кг)
+		w1 := node.Velocity
+		h2 := state.Properties.SpecificEnthalpy - q/massFlow/1000 - gravity*dz/1000
+		p2 := state.Pressure - dpFriction - dpElevation
+
+		// Уточняем ускорение потока по плотности в конце участка
+		var next *steamprops.Result
+		var dpAcceleration float64
+		for iter := 0; iter < 2; iter++ {
+			next, err = calc.CalculatePH(p2-dpAcceleration, h2-velocityHead(G, next, w1))
+			if err != nil {
+				return nil, i18n.Errorf("участок %d (%.2f м): %w", i, float64(i)*dL, err)
+			}
+			dpAcceleration = G * G * (next.Properties.SpecificVolume - state.Properties.SpecificVolume)
+		}
+
+		res.FrictionLoss += dpFriction
+		res.ElevationLoss += dpElevation
+		res.AccelerationLoss += dpAcceleration
+		res.HeatLoss += q
+
+		state = next
+		node, err = newNode(calc, p, state, float64(i)*dL, G)
+		if err != nil {
+			return nil, err
+		}
+		res.Nodes = append(res.Nodes, node)
+	}
+
+	res.Outlet = state
+	res.PressureDrop = inlet.Pressure - state.Pressure
+	return res, nil
+}
+
+// velocityHead возвращает прирост кинетической энергии на участке, кДж/кг
+func velocityHead(G float64, next *steamprops.Result, w1 float64) float64 {
+	if next == nil {
+		return 0
+	}
+	w2 := G / next.Properties.Density
+	return (w2*w2 - w1*w1) / 2 / 1000
+}
+
+// newNode рассчитывает скорость, вязкость и коэффициент трения в узле
+func newNode(calc *steamprops.Calculator, p *Pipe, state *steamprops.Result, position, G float64) (Node, error) {
+	mu, err := viscosity(calc, state)
+	if err != nil {
+		return Node{}, err
+	}
+	re := G * p.Diameter / mu
+	f, err := FrictionFactor(re, p.Roughness/p.Diameter)
+	if err != nil {
+		return Node{}, err
+	}
+	return Node{
+		Position:       position,
+		State:          state,
+		Velocity:       G / state.Properties.Density,
+		Viscosity:      mu,
+		Reynolds:       re,
+		FrictionFactor: f,
+	}, nil
+}
+
+// viscosity возвращает динамическую вязкость среды. Для влажного пара
+// используется гомогенная модель Мак-Адамса: 1/μ = x/μg + (1-x)/μl.
+func viscosity(calc *steamprops.Calculator, state *steamprops.Result) (float64, error) {
+	TK := state.Temperature + 273.15
+	if state.Quality < 0 {
+		return transport.DynamicViscosity(TK, state.Properties.Density)
+	}
+	sat, err := calc.SaturationAtPressure(state.Pressure)
+	if err != nil {
+		return 0, err
+	}
+	muL, err := transport.DynamicViscosity(TK, sat.Liquid.Density)
+	if err != nil {
+		return 0, err
+	}
+	muG, err := transport.DynamicViscosity(TK, sat.Vapor.Density)
+	if err != nil {
+		return 0, err
+	}
+	x := state.Quality
+	return 1 / (x/muG + (1-x)/muL), nil
+}
+
+// FrictionFactor возвращает коэффициент трения Дарси для числа Рейнольдса re
+// и относительной шероховатости relRoughness = ε/D. Для ламинарного течения
+// используется 64/Re, для турбулентного — уравнение Колбрука.
+func FrictionFactor(re, relRoughness float64) (float64, error) {
+	if !(re > 0) || math.IsInf(re, 0) {
+		return 0, i18n.Errorf("недопустимое число Рейнольдса: %v", re)
+	}
+	if relRoughness < 0 {
+		return 0, i18n.Errorf("относительная шероховатость %v не может быть отрицательной", relRoughness)
+	}
+	if re < laminarReynolds {
+		return 64 / re, nil
+	}
+
+	// Начальное приближение по Свами–Джейну
+	a := relRoughness/3.7 + 5.74/math.Pow(re, 0.9)
+	x := -2 * math.Log10(a) // x = 1/√f
+	for i := 0; i < colebrookMaxIter; i++ {
+		next := -2 * math.Log10(relRoughness/3.7+2.51*x/re)
+		if math.Abs(next-x) < colebrookTolerance {
+			x = next
+			break
+		}
+		x = next
+	}
+	return 1 / (x * x), nil
+}
@@ -0,0 +1,162 @@
+package pipe
+
+import (
+	"math"
+	"testing"
+
+	"github.com/somepgs/steamprops/internal/steamprops"
+)
+
+func TestFrictionFactor(t *testing.T) {
+	tests := []struct {
+		name         string
+		re           float64
+		relRoughness float64
+		expected     float64
+		tolerance    float64
+	}{
+		{"Laminar", 1000, 1e-4, 0.064, 1e-12},
+		{"Smooth turbulent", 1e5, 0, 0.01799, 1e-4},
+		{"Rough turbulent", 1e5, 1e-4, 0.01852, 1e-4},
+		{"Fully rough", 1e8, 1e-2, 0.03798, 1e-4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := FrictionFactor(tt.re, tt.relRoughness)
+			if err != nil {
+				t.Fatalf("FrictionFactor: %v", err)
+			}
+			if math.Abs(f-tt.expected) > tt.tolerance {
+				t.Errorf("FrictionFactor(%g, %g) = %.5f, want %.5f", tt.re, tt.relRoughness, f, tt.expected)
+			}
+		})
+	}
+
+	if _, err := FrictionFactor(0, 0); err == nil {
+		t.Errorf("expected error for zero Reynolds number")
+	}
+	if _, err := FrictionFactor(1e5, -1); err == nil {
+		t.Errorf("expected error for negative roughness")
+	}
+}
+
+func TestCalculate_WaterFriction(t *testing.T) {
+	// Расчет вручную по Дарси–Вейсбаху для трубы L = 100 м, D = 0.1 м,
+	// ε = 0.045 мм (ε/D = 4.5·10⁻⁴) и расхода 10 кг/с; ρ и μ — из таблиц
+	// свойств воды, f — по формуле Колбрука (диаграмма Муди):
+	//   20 °C, 0.5 МПа:  ρ = 998.4 кг/м³, μ = 1.0016 мПа·с, w = 1.275 м/с,
+	//                    Re = 1.271·10⁵, f = 0.01951, Δp = 15.84 кПа
+	//   150 °C, 1 МПа:   ρ = 917.3 кг/м³, μ = 0.182 мПа·с,  w = 1.388 м/с,
+	//                    Re = 7.00·10⁵,  f = 0.01707, Δp = 15.09 кПа
+	tests := []struct {
+		name     string
+		t, p     float64
+		re, f    float64
+		pressure float64 // Па
+	}{
+		{"холодная вода", 20, 5e5, 1.271e5, 0.01951, 15836},
+		{"горячая вода", 150, 1e6, 7.00e5, 0.01707, 15087},
+	}
+	const tolerance = 0.01 // погрешность табличных ρ и μ и округления f
+
+	calc := steamprops.NewCalculator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inlet, err := calc.Calculate(&steamprops.InputData{Mode: "TP", Temperature: tt.t, Pressure: tt.p})
+			if err != nil {
+				t.Fatalf("inlet: %v", err)
+			}
+
+			p := &Pipe{Length: 100, Diameter: 0.1, Roughness: 4.5e-5}
+			res, err := Calculate(calc, p, inlet, 10)
+			if err != nil {
+				t.Fatalf("Calculate: %v", err)
+			}
+			if len(res.Nodes) != DefaultSegments+1 {
+				t.Fatalf("nodes = %d, want %d", len(res.Nodes), DefaultSegments+1)
+			}
+
+			n := res.Nodes[0]
+			if math.Abs(n.Reynolds/tt.re-1) > tolerance {
+				t.Errorf("Re = %.4g, want %.4g", n.Reynolds, tt.re)
+			}
+			if math.Abs(n.FrictionFactor/tt.f-1) > tolerance {
+				t.Errorf("f = %.5f, want %.5f", n.FrictionFactor, tt.f)
+			}
+			if math.Abs(res.PressureDrop/tt.pressure-1) > tolerance {
+				t.Errorf("pressure drop = %.1f Pa, want %.1f Pa", res.PressureDrop, tt.pressure)
+			}
+			if res.ElevationLoss != 0 || res.HeatLoss != 0 {
+				t.Errorf("unexpected elevation/heat loss: %v/%v", res.ElevationLoss, res.HeatLoss)
+			}
+		})
+	}
+}
+
+func TestCalculate_Elevation(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	inlet, err := calc.Calculate(&steamprops.InputData{Mode: "TP", Temperature: 20, Pressure: 5e5})
+	if err != nil {
+		t.Fatalf("inlet: %v", err)
+	}
+
+	p := &Pipe{Length: 20, Diameter: 0.1, Roughness: 4.5e-5, Elevation: 10, Segments: 10}
+	res, err := Calculate(calc, p, inlet, 0.1)
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+	expected := inlet.Properties.Density * gravity * 10
+	if math.Abs(res.ElevationLoss-expected)/expected > 1e-3 {
+		t.Errorf("elevation loss = %.1f Pa, want ≈ %.1f Pa", res.ElevationLoss, expected)
+	}
+}
+
+func TestCalculate_SteamHeatLoss(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	inlet, err := calc.CalculatePX(1e6, 0.95)
+	if err != nil {
+		t.Fatalf("inlet: %v", err)
+	}
+
+	p := &Pipe{Length: 100, Diameter: 0.1, Roughness: 4.5e-5, HeatLossPerLength: 200}
+	res, err := Calculate(calc, p, inlet, 2)
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+	if math.Abs(res.HeatLoss-20000) > 1e-6 {
+		t.Errorf("heat loss = %.3f W, want 20000 W", res.HeatLoss)
+	}
+	if res.Outlet.Quality >= inlet.Quality {
+		t.Errorf("outlet quality %.4f should be below inlet %.4f due to condensation", res.Outlet.Quality, inlet.Quality)
+	}
+	if res.PressureDrop <= 0 {
+		t.Errorf("pressure drop = %.1f, want positive", res.PressureDrop)
+	}
+}
+
+func TestCalculate_InvalidInputs(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	inlet, err := calc.Calculate(&steamprops.InputData{Mode: "TP", Temperature: 20, Pressure: 5e5})
+	if err != nil {
+		t.Fatalf("inlet: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		pipe     *Pipe
+		massFlow float64
+	}{
+		{"Zero length", &Pipe{Diameter: 0.1}, 1},
+		{"Zero diameter", &Pipe{Length: 10}, 1},
+		{"Negative roughness", &Pipe{Length: 10, Diameter: 0.1, Roughness: -1}, 1},
+		{"Zero mass flow", &Pipe{Length: 10, Diameter: 0.1}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Calculate(calc, tt.pipe, inlet, tt.massFlow); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Package pump рассчитывает повышение давления сжатой жидкости (Region 1) в
+// насосах с неидеальным КПД: изоэнтропное и действительное состояние на
+// выходе, потребляемую мощность и располагаемый кавитационный запас (NPSHa).
+package pump
+
+import (
+	"math"
+
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/internal/calc_core"
+	"github.com/somepgs/steamprops/internal/calc_core/region4"
+	"github.com/somepgs/steamprops/internal/steamprops"
+)
+
+const gravity = 9.80665 // м/с²
+
+// Pump описывает режим работы насоса
+type Pump struct {
+	InletTemperature float64 // °C
+	InletPressure    float64 // Pa, абсолютное давление на входе
+	OutletPressure   float64 // Pa
+	MassFlow         float64 // кг/с
+	Efficiency       float64 // внутренний (изоэнтропный) КПД, 0..1
+	InletVelocity    float64 // м/с, скорость во входном патрубке (для NPSHa)
+}
+
+// Validate проверяет параметры насоса
+func (p *Pump) Validate() error {
+	if !(p.OutletPressure > p.InletPressure) {
+		return i18n.Errorf("давление на выходе %.0f Па должно превышать давление на входе %.0f Па", p.OutletPressure, p.InletPressure)
+	}
+	if !(p.MassFlow > 0) {
+		return i18n.Errorf("массовый расход %.4f кг/с должен быть положительным", p.MassFlow)
+	}
+	if !(p.Efficiency > 0) || p.Efficiency > 1 {
+		return i18n.Errorf("КПД %.4f вне диапазона (0, 1]", p.Efficiency)
+	}
+	if p.InletVelocity < 0 {
+		return i18n.Errorf("скорость на входе %.3f м/с не может быть отрицательной", p.InletVelocity)
+	}
+	return nil
+}
+
+// Result содержит результаты расчета насоса
+type Result struct {
+	Inlet              *steamprops.Result
+	IsentropicOutlet   *steamprops.Result
+	Outlet             *steamprops.Result
+	IsentropicWork     float64 // кДж/кг
+	Work               float64 // кДж/кг, действительная удельная работа
+	Power              float64 // кВт, мощность на валу
+	Head               float64 // м, напор
+	SaturationPressure float64 // Pa, давление насыщения при температуре на входе
+	NPSHAvailable      float64 // м
+}
+
+// Calculate рассчитывает процесс сжатия жидкости в насосе
+func Calculate(calc *steamprops.Calculator, p *Pump) (*Result, error) {
+	if calc == nil || p == nil {
+		return nil, i18n.Errorf("не заданы калькулятор или параметры насоса")
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	inlet, err := calc.Calculate(&steamprops.InputData{
+		Mode:        "TP",
+		Temperature: p.InletTemperature,
+		Pressure:    p.InletPressure,
+	})
+	if err != nil {
+		return nil, i18n.Errorf("состояние на входе: %w", err)
+	}
+	if inlet.Region != calc_core.Region1 {
+		return nil, i18n.Errorf("на входе насоса должна быть сжатая жидкость (Region 1), получен Region %d", int(inlet.Region))
+	}
+
+	isentropic, err := calc.CalculatePS(p.OutletPressure, inlet.Properties.SpecificEntropy)
+	if err != nil {
+		return nil, i18n.Errorf("изоэнтропное состояние на выходе: %w", err)
+	}
+	if isentropic.Region != calc_core.Region1 {
+		return nil, i18n.Errorf("состояние на выходе вне Region 1 (Region %d)", int(isentropic.Region))
+	}
+
+	h1 := inlet.Properties.SpecificEnthalpy
+	ws := isentropic.Properties.SpecificEnthalpy - h1
+	w := ws / p.Efficiency
+
+	outlet, err := calc.CalculatePH(p.OutletPressure, h1+w)
+	if err != nil {
+		return nil, i18n.Errorf("действительное состояние на выходе: %w", err)
+	}
+
+	psat, err := region4.SaturationPressure(p.InletTemperature + 273.15)
+	if err != nil {
+		return nil, i18n.Errorf("давление насыщения на входе: %w", err)
+	}
+
+	rho := inlet.Properties.Density
+	return &Result{
+		Inlet:              inlet,
+		IsentropicOutlet:   isentropic,
+		Outlet:             outlet,
+		IsentropicWork:     ws,
+		Work:               w,
+		Power:              p.MassFlow * w,
+		Head:               (p.OutletPressure - p.InletPressure) / (rho * gravity),
+		SaturationPressure: psat,
+		NPSHAvailable:      (p.InletPressure-psat)/(rho*gravity) + math.Pow(p.InletVelocity, 2)/(2*gravity),
+	}, nil
+}
@@ -0,0 +1,97 @@
+package pump
+
+import (
+	"math"
+	"testing"
+
+	"github.com/somepgs/steamprops/internal/steamprops"
+)
+
+func TestCalculate_FeedPump(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	p := &Pump{
+		InletTemperature: 150,
+		InletPressure:    0.6e6,
+		OutletPressure:   16e6,
+		MassFlow:         50,
+		Efficiency:       0.8,
+	}
+
+	res, err := Calculate(calc, p)
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+
+	// Изоэнтропная работа близка к v·Δp для почти несжимаемой жидкости
+	vdp := res.Inlet.Properties.SpecificVolume * (p.OutletPressure - p.InletPressure) / 1000
+	if math.Abs(res.IsentropicWork-vdp)/vdp > 0.01 {
+		t.Errorf("isentropic work = %.4f kJ/kg, want ≈ v·Δp = %.4f kJ/kg", res.IsentropicWork, vdp)
+	}
+	if math.Abs(res.Work-res.IsentropicWork/0.8) > 1e-12 {
+		t.Errorf("work = %.4f, want %.4f", res.Work, res.IsentropicWork/0.8)
+	}
+	if math.Abs(res.Power-50*res.Work) > 1e-9 {
+		t.Errorf("power = %.3f kW, want %.3f kW", res.Power, 50*res.Work)
+	}
+	// Необратимость повышает энтропию и температуру на выходе
+	if res.Outlet.Properties.SpecificEntropy <= res.Inlet.Properties.SpecificEntropy {
+		t.Errorf("outlet entropy should increase")
+	}
+	if res.Outlet.Temperature <= res.IsentropicOutlet.Temperature {
+		t.Errorf("actual outlet %.4f°C should be hotter than isentropic %.4f°C", res.Outlet.Temperature, res.IsentropicOutlet.Temperature)
+	}
+}
+
+func TestCalculate_NPSHAvailable(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	p := &Pump{
+		InletTemperature: 40,
+		InletPressure:    101325,
+		OutletPressure:   1e6,
+		MassFlow:         10,
+		Efficiency:       0.75,
+		InletVelocity:    2,
+	}
+
+	res, err := Calculate(calc, p)
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+	// psat(40°C) ≈ 7384 Па, ρ ≈ 992.2 кг/м³
+	if math.Abs(res.SaturationPressure-7384) > 5 {
+		t.Errorf("psat = %.1f Pa, want ≈ 7384 Pa", res.SaturationPressure)
+	}
+	want := (101325-res.SaturationPressure)/(res.Inlet.Properties.Density*gravity) + 4/(2*gravity)
+	if math.Abs(res.NPSHAvailable-want) > 1e-9 || math.Abs(res.NPSHAvailable-9.86) > 0.05 {
+		t.Errorf("NPSHa = %.4f m, want %.4f m", res.NPSHAvailable, want)
+	}
+	if math.Abs(res.Head-92.3) > 0.2 {
+		t.Errorf("head = %.3f m, want ≈ 92.3 m", res.Head)
+	}
+}
+
+func TestCalculate_InvalidInputs(t *testing.T) {
+	calc := steamprops.NewCalculator()
+	valid := Pump{InletTemperature: 40, InletPressure: 101325, OutletPressure: 1e6, MassFlow: 10, Efficiency: 0.75}
+
+	tests := []struct {
+		name   string
+		modify func(p *Pump)
+	}{
+		{"Outlet below inlet", func(p *Pump) { p.OutletPressure = 5e4 }},
+		{"Zero mass flow", func(p *Pump) { p.MassFlow = 0 }},
+		{"Zero efficiency", func(p *Pump) { p.Efficiency = 0 }},
+		{"Efficiency above one", func(p *Pump) { p.Efficiency = 1.2 }},
+		{"Negative velocity", func(p *Pump) { p.InletVelocity = -1 }},
+		{"Steam at inlet", func(p *Pump) { p.InletTemperature = 150 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid
+			tt.modify(&p)
+			if _, err := Calculate(calc, &p); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
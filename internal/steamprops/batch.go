@@ -0,0 +1,257 @@
+package steamprops
+
+import (
+	"math"
+	"sync"
+
+	"github.com/somepgs/steamprops/internal/calc_core"
+	"github.com/somepgs/steamprops/internal/calc_core/transport"
+	"github.com/somepgs/steamprops/propserr"
+)
+
+// Batch задает входные данные пакетного расчета по столбцам: строка i
+// состоит из i-х элементов столбцов, которые использует режим Mode
+// (см. InputData). Столбцы, не нужные режиму, не читаются и могут быть пустыми.
+// Строки режима HS рассчитываются CalculateHS во всех регионах IF-97.
+type Batch struct {
+	Mode           string    // режим InputData: "TP", "PH", "PS", "HS", "PX", "TX", "VU", "RhoT", "TH" или "TS"
+	Temperature    []float64 // °C
+	Pressure       []float64 // Pa
+	Enthalpy       []float64 // кДж/кг
+	Entropy        []float64 // кДж/(кг·К)
+	SpecificVolume []float64 // м³/кг
+	InternalEnergy []float64 // кДж/кг
+	Density        []float64 // кг/м³
+	Quality        []float64 // паросодержание 0..1
+}
+
+// BatchOptions задает параметры пакетного расчета
+type BatchOptions struct {
+	Workers   int  // число горутин; 0 или 1 — расчет в вызывающей горутине
+	Transport bool // рассчитывать вязкость и теплопроводность
+}
+
+// BatchResult результаты пакетного расчета по столбцам. Для строки с ошибкой
+// Errors[i] != nil, Region[i] == 0, а числовые столбцы содержат NaN.
+type BatchResult struct {
+	Region                        []calc_core.Region
+	Temperature                   []float64 // °C
+	Pressure                      []float64 // Pa
+	Quality                       []float64 // 0..1 в двухфазной области, -1 для однофазных состояний
+	SpecificVolume                []float64 // м³/кг
+	Density                       []float64 // кг/м³
+	SpecificInternalEnergy        []float64 // кДж/кг
+	SpecificEntropy               []float64 // кДж/(кг·К)
+	SpecificEnthalpy              []float64 // кДж/кг
+	SpecificIsochoricHeatCapacity []float64 // кДж/(кг·К)
+	SpecificIsobaricHeatCapacity  []float64 // кДж/(кг·К)
+	SpeedOfSound                  []float64 // м/с
+	// Транспортные свойства заполняются при BatchOptions.Transport,
+	// в двухфазной области равны NaN
+	DynamicViscosity    []float64 // Па·с
+	ThermalConductivity []float64 // Вт/(м·К)
+	Errors              []error
+}
+
+// Len возвращает число строк
+func (r *BatchResult) Len() int {
+	return len(r.Errors)
+}
+
+// Failed возвращает число строк с ошибкой
+func (r *BatchResult) Failed() int {
+	n := 0
+	for _, err := range r.Errors {
+		if err != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// columns возвращает столбцы, которые читает режим b.Mode
+func (b *Batch) columns() ([][]float64, error) {
+	switch b.Mode {
+	case "TP":
+		return [][]float64{b.Temperature, b.Pressure}, nil
+	case "PH":
+		return [][]float64{b.Pressure, b.Enthalpy}, nil
+	case "PS":
+		return [][]float64{b.Pressure, b.Entropy}, nil
+	case "HS":
+		return [][]float64{b.Enthalpy, b.Entropy}, nil
+	case "PX":
+		return [][]float64{b.Pressure, b.Quality}, nil
+	case "TX":
+		return [][]float64{b.Temperature, b.Quality}, nil
+	case "VU":
+		return [][]float64{b.SpecificVolume, b.InternalEnergy}, nil
+	case "RhoT":
+		return [][]float64{b.Density, b.Temperature}, nil
+	case "TH":
+		return [][]float64{b.Temperature, b.Enthalpy}, nil
+	case "TS":
+		return [][]float64{b.Temperature, b.Entropy}, nil
+	default:
+		return nil, invalidMode(b.Mode)
+	}
+}
+
+// row собирает входные данные строки i
+func (b *Batch) row(i int) InputData {
+	in := InputData{Mode: b.Mode}
+	at := func(col []float64) float64 {
+		if i < len(col) {
+			return col[i]
+		}
+		return 0
+	}
+	in.Temperature = at(b.Temperature)
+	in.Pressure = at(b.Pressure)
+	in.Enthalpy = at(b.Enthalpy)
+	in.Entropy = at(b.Entropy)
+	in.SpecificVolume = at(b.SpecificVolume)
+	in.InternalEnergy = at(b.InternalEnergy)
+	in.Density = at(b.Density)
+	in.Quality = at(b.Quality)
+	return in
+}
+
+// CalculateBatch рассчитывает свойства для всех строк b. Ошибка расчета строки
+// не прерывает пакет и возвращается в BatchResult.Errors; CalculateBatch
+// возвращает ошибку только для неверного режима или столбцов разной длины.
+// В отличие от Calculate, строки не формируют Result.TransportProps:
+// транспортные свойства при необходимости записываются числами.
+func (c *Calculator) CalculateBatch(b *Batch, opts BatchOptions) (*BatchResult, error) {
+	cols, err := b.columns()
+	if err != nil {
+		return nil, err
+	}
+	n := len(cols[0])
+	for _, col := range cols[1:] {
+		if len(col) != n {
+			return nil, propserr.Newf(propserr.InvalidInput, "столбцы режима %s имеют разную длину: %d и %d", b.Mode, n, len(col))
+		}
+	}
+
+	res := newBatchResult(n, opts.Transport)
+	calc := *c
+	calc.skipTransport = true
+	run := func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			calc.calculateRow(b, i, res, opts.Transport)
+		}
+	}
+
+	workers := opts.Workers
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		run(0, n)
+		return res, nil
+	}
+	var wg sync.WaitGroup
+	chunk := (n + workers - 1) / workers
+	for lo := 0; lo < n; lo += chunk {
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			run(lo, hi)
+		}(lo, min(lo+chunk, n))
+	}
+	wg.Wait()
+	return res, nil
+}
+
+// newBatchResult выделяет столбцы результатов на n строк
+func newBatchResult(n int, withTransport bool) *BatchResult {
+	col := func() []float64 { return make([]float64, n) }
+	res := &BatchResult{
+		Region:                        make([]calc_core.Region, n),
+		Temperature:                   col(),
+		Pressure:                      col(),
+		Quality:                       col(),
+		SpecificVolume:                col(),
+		Density:                       col(),
+		SpecificInternalEnergy:        col(),
+		SpecificEntropy:               col(),
+		SpecificEnthalpy:              col(),
+		SpecificIsochoricHeatCapacity: col(),
+		SpecificIsobaricHeatCapacity:  col(),
+		SpeedOfSound:                  col(),
+		Errors:                        make([]error, n),
+	}
+	if withTransport {
+		res.DynamicViscosity = col()
+		res.ThermalConductivity = col()
+	}
+	return res
+}
+
+// calculateRow рассчитывает строку i и записывает ее в res
+func (c *Calculator) calculateRow(b *Batch, i int, res *BatchResult, withTransport bool) {
+	in := b.row(i)
+	err := in.Validate()
+	var r *Result
+	switch {
+	case err != nil:
+	case in.Mode == "HS":
+		// Как и конструктор FromHS, строки HS рассчитываются во всех регионах
+		r, err = c.CalculateHS(in.Enthalpy, in.Entropy)
+	default:
+		r, err = c.Calculate(&in)
+	}
+	if err != nil {
+		nan := math.NaN()
+		res.Errors[i] = err
+		res.setRow(i, calc_core.Properties{
+			SpecificVolume:                nan,
+			Density:                       nan,
+			SpecificInternalEnergy:        nan,
+			SpecificEntropy:               nan,
+			SpecificEnthalpy:              nan,
+			SpecificIsochoricHeatCapacity: nan,
+			SpecificIsobaricHeatCapacity:  nan,
+			SpeedOfSound:                  nan,
+		}, 0, nan, nan, nan)
+		if withTransport {
+			res.DynamicViscosity[i] = nan
+			res.ThermalConductivity[i] = nan
+		}
+		return
+	}
+
+	res.setRow(i, r.Properties, r.Region, r.Temperature, r.Pressure, r.Quality)
+	if !withTransport {
+		return
+	}
+	mu, lambda := math.NaN(), math.NaN()
+	if r.Region != calc_core.Region4 {
+		tK := r.Temperature + 273.15
+		if v, err := transport.DynamicViscosity(tK, r.Properties.Density); err == nil {
+			mu = v
+		}
+		if v, err := transport.ThermalConductivity(tK, r.Properties.Density); err == nil {
+			lambda = v
+		}
+	}
+	res.DynamicViscosity[i] = mu
+	res.ThermalConductivity[i] = lambda
+}
+
+// setRow записывает состояние в строку i
+func (r *BatchResult) setRow(i int, props calc_core.Properties, region calc_core.Region, temperature, pressure, quality float64) {
+	r.Region[i] = region
+	r.Temperature[i] = temperature
+	r.Pressure[i] = pressure
+	r.Quality[i] = quality
+	r.SpecificVolume[i] = props.SpecificVolume
+	r.Density[i] = props.Density
+	r.SpecificInternalEnergy[i] = props.SpecificInternalEnergy
+	r.SpecificEntropy[i] = props.SpecificEntropy
+	r.SpecificEnthalpy[i] = props.SpecificEnthalpy
+	r.SpecificIsochoricHeatCapacity[i] = props.SpecificIsochoricHeatCapacity
+	r.SpecificIsobaricHeatCapacity[i] = props.SpecificIsobaricHeatCapacity
+	r.SpeedOfSound[i] = props.SpeedOfSound
+}
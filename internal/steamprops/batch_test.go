@@ -0,0 +1,141 @@
+package steamprops
+
+import (
+	"math"
+	"testing"
+
+	"github.com/somepgs/steamprops/internal/calc_core"
+	"github.com/somepgs/steamprops/internal/calc_core/transport"
+)
+
+// historianBatch строки TP: сетка температур и давлений с недопустимыми строками
+func historianBatch(n int) *Batch {
+	b := &Batch{Mode: "TP", Temperature: make([]float64, n), Pressure: make([]float64, n)}
+	for i := 0; i < n; i++ {
+		b.Temperature[i] = 20 + float64(i%50)*15
+		b.Pressure[i] = 1e4 * math.Pow(10, float64(i%7)*0.5)
+	}
+	return b
+}
+
+func TestCalculator_CalculateBatch(t *testing.T) {
+	calc := NewCalculator()
+	b := historianBatch(200)
+	b.Pressure[3] = -1                           // ошибка валидации
+	b.Temperature[5] = math.NaN()                // ошибка валидации
+	b.Temperature[7], b.Pressure[7] = 1500, 80e6 // Region 5 выше 50 МПа
+
+	seq, err := calc.CalculateBatch(b, BatchOptions{Transport: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seq.Len() != 200 || seq.Failed() != 3 {
+		t.Fatalf("Len() = %d, Failed() = %d, want 200 and 3", seq.Len(), seq.Failed())
+	}
+	for i := 0; i < seq.Len(); i++ {
+		want, err := calc.Calculate(&InputData{Mode: "TP", Temperature: b.Temperature[i], Pressure: b.Pressure[i]})
+		if err == nil {
+			err = (&InputData{Mode: "TP", Temperature: b.Temperature[i], Pressure: b.Pressure[i]}).Validate()
+		}
+		if err != nil {
+			if seq.Errors[i] == nil {
+				t.Errorf("row %d: expected error %v", i, err)
+			}
+			if seq.Region[i] != 0 || !math.IsNaN(seq.Density[i]) || !math.IsNaN(seq.DynamicViscosity[i]) {
+				t.Errorf("row %d: region %d, density %v for a failed row", i, seq.Region[i], seq.Density[i])
+			}
+			continue
+		}
+		if seq.Errors[i] != nil {
+			t.Fatalf("row %d: %v", i, seq.Errors[i])
+		}
+		if seq.Region[i] != want.Region || seq.Quality[i] != -1 ||
+			seq.SpecificEnthalpy[i] != want.Properties.SpecificEnthalpy || seq.SpeedOfSound[i] != want.Properties.SpeedOfSound {
+			t.Errorf("row %d: region %d h=%v w=%v, want region %d h=%v w=%v", i, seq.Region[i], seq.SpecificEnthalpy[i], seq.SpeedOfSound[i],
+				want.Region, want.Properties.SpecificEnthalpy, want.Properties.SpeedOfSound)
+		}
+		mu, _ := transport.DynamicViscosity(b.Temperature[i]+273.15, want.Properties.Density)
+		if seq.DynamicViscosity[i] != mu {
+			t.Errorf("row %d: viscosity %v, want %v", i, seq.DynamicViscosity[i], mu)
+		}
+	}
+
+	// Параллельный расчет дает те же результаты
+	par, err := calc.CalculateBatch(b, BatchOptions{Workers: 8, Transport: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < seq.Len(); i++ {
+		same := func(a, b float64) bool { return a == b || math.IsNaN(a) && math.IsNaN(b) }
+		if par.Region[i] != seq.Region[i] || !same(par.Pressure[i], seq.Pressure[i]) || !same(par.SpecificEntropy[i], seq.SpecificEntropy[i]) ||
+			!same(par.ThermalConductivity[i], seq.ThermalConductivity[i]) || (par.Errors[i] == nil) != (seq.Errors[i] == nil) {
+			t.Errorf("row %d differs between sequential and parallel runs", i)
+		}
+	}
+}
+
+func TestCalculator_CalculateBatchModes(t *testing.T) {
+	calc := NewCalculator()
+	res, err := calc.CalculateBatch(&Batch{
+		Mode:           "VU",
+		SpecificVolume: []float64{0.2, 0.001, 1, -1},
+		InternalEnergy: []float64{2600, 100, 1500, 2600},
+	}, BatchOptions{Workers: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Failed() != 1 || res.Errors[3] == nil {
+		t.Fatalf("errors = %v, want only the last row to fail", res.Errors)
+	}
+	if res.Region[0] != calc_core.Region2 || res.Region[1] != calc_core.Region1 || res.Region[2] != calc_core.Region4 || res.Quality[2] <= 0 {
+		t.Errorf("regions %v, qualities %v", res.Region, res.Quality)
+	}
+	if res.DynamicViscosity != nil {
+		t.Errorf("transport columns filled without BatchOptions.Transport")
+	}
+
+	if _, err := calc.CalculateBatch(&Batch{Mode: "XY"}, BatchOptions{}); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+	if _, err := calc.CalculateBatch(&Batch{Mode: "TP", Temperature: []float64{100, 200}, Pressure: []float64{1e5}}, BatchOptions{}); err == nil {
+		t.Error("expected error for columns of different length")
+	}
+	if res, err := calc.CalculateBatch(&Batch{Mode: "TP"}, BatchOptions{Workers: 4}); err != nil || res.Len() != 0 {
+		t.Errorf("empty batch: %v, %v", res, err)
+	}
+}
+
+func BenchmarkCalculate_TP(b *testing.B) {
+	calc := NewCalculator()
+	batch := historianBatch(1000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for j := range batch.Temperature {
+			if _, err := calc.Calculate(&InputData{Mode: "TP", Temperature: batch.Temperature[j], Pressure: batch.Pressure[j]}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkCalculateBatch_TP(b *testing.B) {
+	calc := NewCalculator()
+	batch := historianBatch(1000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := calc.CalculateBatch(batch, BatchOptions{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCalculateBatch_TPParallel(b *testing.B) {
+	calc := NewCalculator()
+	batch := historianBatch(1000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := calc.CalculateBatch(batch, BatchOptions{Workers: 8}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
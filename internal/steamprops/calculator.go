@@ -4,18 +4,17 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/somepgs/steamprops/i18n"
 	"github.com/somepgs/steamprops/internal/calc_core"
-	"github.com/somepgs/steamprops/internal/calc_core/region1"
-	"github.com/somepgs/steamprops/internal/calc_core/region2"
 	"github.com/somepgs/steamprops/internal/calc_core/region3"
-	"github.com/somepgs/steamprops/internal/calc_core/region4"
-	"github.com/somepgs/steamprops/internal/calc_core/region5"
 	"github.com/somepgs/steamprops/internal/calc_core/transport"
+	"github.com/somepgs/steamprops/propserr"
 )
 
 // Calculator представляет основной калькулятор SteamProps
 type Calculator struct {
-	// Используем функции напрямую
+	evaluator     Evaluator // вычислитель для расчетов по (p, h) и (v, u)
+	skipTransport bool      // не заполнять Result.TransportProps (пакетный расчет)
 }
 
 // NewCalculator создает новый калькулятор
@@ -25,81 +24,230 @@ func NewCalculator() *Calculator {
 
 // InputData представляет входные данные для расчета
 type InputData struct {
-	Mode        string  // "TP" или "HS"
-	Temperature float64 // °C
-	Pressure    float64 // Pa
-	Enthalpy    float64 // кДж/кг
-	Entropy     float64 // кДж/(кг·К)
+	Mode           string  // "TP", "PH", "PS", "HS", "PX", "TX", "VU", "RhoT", "TH" или "TS"
+	Temperature    float64 // °C
+	Pressure       float64 // Pa
+	Enthalpy       float64 // кДж/кг
+	Entropy        float64 // кДж/(кг·К)
+	SpecificVolume float64 // м³/кг
+	InternalEnergy float64 // кДж/кг
+	Density        float64 // кг/м³
+	Quality        float64 // паросодержание 0..1 (режимы PX и TX)
 }
 
 // Validate проверяет корректность входных данных с улучшенной валидацией
 func (i *InputData) Validate() error {
-	if i.Mode != "TP" && i.Mode != "HS" {
-		return fmt.Errorf("неверный режим расчета: %s", i.Mode)
-	}
-
-	if i.Mode == "TP" {
-		// Проверка на NaN и Inf
-		if math.IsNaN(i.Temperature) || math.IsInf(i.Temperature, 0) {
-			return fmt.Errorf("температура содержит недопустимое значение: %v", i.Temperature)
+	switch i.Mode {
+	case "TP":
+		if err := i.validateTemperature(); err != nil {
+			return err
 		}
-		if math.IsNaN(i.Pressure) || math.IsInf(i.Pressure, 0) {
-			return fmt.Errorf("давление содержит недопустимое значение: %v", i.Pressure)
+		return i.validatePressure()
+	case "HS":
+		return i.validateHS()
+	case "PH":
+		if err := i.validatePressure(); err != nil {
+			return err
 		}
-
-		// Проверка физических границ
-		if i.Temperature < -273.15 {
-			return fmt.Errorf("температура %.2f°C ниже абсолютного нуля", i.Temperature)
+		if math.IsNaN(i.Enthalpy) || math.IsInf(i.Enthalpy, 0) {
+			return invalidValue("энтальпия содержит недопустимое значение", "h", i.Enthalpy, "kJ/kg")
 		}
-		if i.Pressure <= 0 {
-			return fmt.Errorf("давление %.0f Па должно быть положительным", i.Pressure)
+	case "PS":
+		if err := i.validatePressure(); err != nil {
+			return err
 		}
-
-		// Проверка границ IF-97
-		if i.Temperature > 2000 {
-			return fmt.Errorf("температура %.2f°C превышает максимальную для IF-97 (2000°C)", i.Temperature)
+		if math.IsNaN(i.Entropy) || math.IsInf(i.Entropy, 0) {
+			return invalidValue("энтропия содержит недопустимое значение", "s", i.Entropy, "kJ/(kg·K)")
 		}
-		if i.Pressure > 100e6 {
-			return fmt.Errorf("давление %.0f Па превышает максимальное для IF-97 (100 МПа)", i.Pressure)
+	case "PX":
+		if err := i.validatePressure(); err != nil {
+			return err
 		}
-
-		// Проверка минимальных границ IF-97
-		if i.Temperature < -0.01 {
-			return fmt.Errorf("температура %.2f°C ниже минимальной для IF-97 (-0.01°C)", i.Temperature)
+		if i.Pressure > criticalPressure {
+			return limitError(propserr.OutOfRange, "давление выше критического: влажного пара нет", "p", i.Pressure, "Pa", propserr.Max, criticalPressure)
 		}
-		if i.Pressure < 611.657 {
-			return fmt.Errorf("давление %.0f Па ниже минимального для IF-97 (611.657 Па)", i.Pressure)
+		return i.validateQuality()
+	case "TX":
+		if err := i.validateTemperature(); err != nil {
+			return err
+		}
+		if i.Temperature < minTemperature {
+			return limitError(propserr.OutOfRange, "температура ниже линии насыщения", "T", i.Temperature, "°C", propserr.Min, minTemperature)
+		}
+		if i.Temperature >= criticalTemperature {
+			return limitError(propserr.OutOfRange, "температура выше линии насыщения: влажного пара нет", "T", i.Temperature, "°C", propserr.Max, criticalTemperature)
+		}
+		return i.validateQuality()
+	case "TH":
+		if err := i.validateTemperature(); err != nil {
+			return err
 		}
-
-	} else { // HS режим
-		// Проверка на NaN и Inf
 		if math.IsNaN(i.Enthalpy) || math.IsInf(i.Enthalpy, 0) {
-			return fmt.Errorf("энтальпия содержит недопустимое значение: %v", i.Enthalpy)
+			return invalidValue("энтальпия содержит недопустимое значение", "h", i.Enthalpy, "kJ/kg")
+		}
+	case "TS":
+		if err := i.validateTemperature(); err != nil {
+			return err
 		}
 		if math.IsNaN(i.Entropy) || math.IsInf(i.Entropy, 0) {
-			return fmt.Errorf("энтропия содержит недопустимое значение: %v", i.Entropy)
+			return invalidValue("энтропия содержит недопустимое значение", "s", i.Entropy, "kJ/(kg·K)")
 		}
-
-		// Проверка физических границ
-		if i.Enthalpy < 0 {
-			return fmt.Errorf("энтальпия %.2f кДж/кг не может быть отрицательной", i.Enthalpy)
+	case "VU":
+		if math.IsNaN(i.SpecificVolume) || math.IsInf(i.SpecificVolume, 0) {
+			return invalidValue("удельный объем содержит недопустимое значение", "v", i.SpecificVolume, "m³/kg")
 		}
-		if i.Entropy < 0 {
-			return fmt.Errorf("энтропия %.2f кДж/(кг·К) не может быть отрицательной", i.Entropy)
+		if math.IsNaN(i.InternalEnergy) || math.IsInf(i.InternalEnergy, 0) {
+			return invalidValue("внутренняя энергия содержит недопустимое значение", "u", i.InternalEnergy, "kJ/kg")
 		}
-
-		// Проверка разумных границ для IF-97
-		if i.Enthalpy > 5000 {
-			return fmt.Errorf("энтальпия %.2f кДж/кг превышает разумный максимум для IF-97", i.Enthalpy)
+		if i.SpecificVolume <= 0 {
+			return limitError(propserr.InvalidInput, "удельный объем должен быть положительным", "v", i.SpecificVolume, "m³/kg", propserr.Min, 0)
 		}
-		if i.Entropy > 15 {
-			return fmt.Errorf("энтропия %.2f кДж/(кг·К) превышает разумный максимум для IF-97", i.Entropy)
+	case "RhoT":
+		if err := i.validateTemperature(); err != nil {
+			return err
 		}
+		if math.IsNaN(i.Density) || math.IsInf(i.Density, 0) {
+			return invalidValue("плотность содержит недопустимое значение", "rho", i.Density, "kg/m³")
+		}
+		if i.Density <= 0 {
+			return limitError(propserr.InvalidInput, "плотность должна быть положительной", "rho", i.Density, "kg/m³", propserr.Min, 0)
+		}
+	default:
+		return invalidMode(i.Mode)
+	}
+	return nil
+}
+
+// validateTemperature проверяет температуру для режимов TP, RhoT, TH и TS
+func (i *InputData) validateTemperature() error {
+	// Проверка на NaN и Inf
+	if math.IsNaN(i.Temperature) || math.IsInf(i.Temperature, 0) {
+		return invalidValue("температура содержит недопустимое значение", "T", i.Temperature, "°C")
+	}
+
+	// Проверка физических границ
+	if i.Temperature < -273.15 {
+		return limitError(propserr.InvalidInput, "температура ниже абсолютного нуля", "T", i.Temperature, "°C", propserr.Min, -273.15)
+	}
+
+	// Проверка границ IF-97
+	if i.Temperature > 2000 {
+		return limitError(propserr.OutOfRange, "температура превышает максимальную для IF-97", "T", i.Temperature, "°C", propserr.Max, 2000)
+	}
+	if i.Temperature < -0.01 {
+		return limitError(propserr.OutOfRange, "температура ниже минимальной для IF-97", "T", i.Temperature, "°C", propserr.Min, -0.01)
+	}
+	return nil
+}
+
+// validatePressure проверяет давление для режима TP
+func (i *InputData) validatePressure() error {
+	if math.IsNaN(i.Pressure) || math.IsInf(i.Pressure, 0) {
+		return invalidValue("давление содержит недопустимое значение", "p", i.Pressure, "Pa")
+	}
+	if i.Pressure <= 0 {
+		return limitError(propserr.InvalidInput, "давление должно быть положительным", "p", i.Pressure, "Pa", propserr.Min, 0)
+	}
+	if i.Pressure > 100e6 {
+		return limitError(propserr.OutOfRange, "давление превышает максимальное для IF-97", "p", i.Pressure, "Pa", propserr.Max, 100e6)
+	}
+	if i.Pressure < 611.657 {
+		return limitError(propserr.OutOfRange, "давление ниже минимального для IF-97", "p", i.Pressure, "Pa", propserr.Min, 611.657)
+	}
+	return nil
+}
+
+// validateQuality проверяет паросодержание для режимов PX и TX
+func (i *InputData) validateQuality() error {
+	return qualityInRange(i.Quality)
+}
+
+// validateHS проверяет энтальпию и энтропию для режима HS
+func (i *InputData) validateHS() error {
+	// Проверка на NaN и Inf
+	if math.IsNaN(i.Enthalpy) || math.IsInf(i.Enthalpy, 0) {
+		return invalidValue("энтальпия содержит недопустимое значение", "h", i.Enthalpy, "kJ/kg")
+	}
+	if math.IsNaN(i.Entropy) || math.IsInf(i.Entropy, 0) {
+		return invalidValue("энтропия содержит недопустимое значение", "s", i.Entropy, "kJ/(kg·K)")
 	}
 
+	// Проверка физических границ
+	if i.Enthalpy < 0 {
+		return limitError(propserr.OutOfRange, "энтальпия не может быть отрицательной", "h", i.Enthalpy, "kJ/kg", propserr.Min, 0)
+	}
+	if i.Entropy < 0 {
+		return limitError(propserr.OutOfRange, "энтропия не может быть отрицательной", "s", i.Entropy, "kJ/(kg·K)", propserr.Min, 0)
+	}
+
+	// Проверка разумных границ для IF-97
+	if i.Enthalpy > 5000 {
+		return limitError(propserr.OutOfRange, "энтальпия превышает разумный максимум для IF-97", "h", i.Enthalpy, "kJ/kg", propserr.Max, 5000)
+	}
+	if i.Entropy > 15 {
+		return limitError(propserr.OutOfRange, "энтропия превышает разумный максимум для IF-97", "s", i.Entropy, "kJ/(kg·K)", propserr.Max, 15)
+	}
+	return nil
+}
+
+// invalidValue — величина содержит NaN или бесконечность
+func invalidValue(detail, quantity string, value float64, unit string) error {
+	return &propserr.Error{Code: propserr.InvalidInput, Detail: detail, Quantity: quantity, Value: value, Unit: unit}
+}
+
+// limitError — величина вышла за предел limit
+func limitError(code propserr.Code, detail, quantity string, value float64, unit string, bound propserr.Bound, limit float64) error {
+	e := propserr.Range(code, 0, quantity, value, unit, bound, limit)
+	e.Detail = detail
+	return e
+}
+
+// temperatureInRange проверяет, что температура (°C) лежит в диапазоне
+// уравнений IF-97 от 0 до 2000°C
+func temperatureInRange(temperature float64) error {
+	switch {
+	case math.IsNaN(temperature):
+		return invalidValue("температура содержит недопустимое значение", "T", temperature, "°C")
+	case temperature < minTemperature:
+		return limitError(propserr.OutOfRange, "температура вне диапазона IF-97", "T", temperature, "°C", propserr.Min, minTemperature)
+	case temperature > region5TemperatureMax:
+		return limitError(propserr.OutOfRange, "температура вне диапазона IF-97", "T", temperature, "°C", propserr.Max, region5TemperatureMax)
+	}
+	return nil
+}
+
+// pressureInRange проверяет, что давление (Pa) лежит в диапазоне IF-97
+// от тройной точки до 100 МПа
+func pressureInRange(pressure float64) error {
+	switch {
+	case math.IsNaN(pressure):
+		return invalidValue("давление содержит недопустимое значение", "p", pressure, "Pa")
+	case pressure < minPressure:
+		return limitError(propserr.OutOfRange, "давление ниже минимального для IF-97", "p", pressure, "Pa", propserr.Min, minPressure)
+	case pressure > maxPressure:
+		return limitError(propserr.OutOfRange, "давление превышает максимальное для IF-97", "p", pressure, "Pa", propserr.Max, maxPressure)
+	}
+	return nil
+}
+
+// qualityInRange проверяет паросодержание 0..1
+func qualityInRange(quality float64) error {
+	switch {
+	case math.IsNaN(quality):
+		return invalidValue("паросодержание содержит недопустимое значение", "x", quality, "")
+	case quality < 0:
+		return limitError(propserr.OutOfRange, "паросодержание вне диапазона 0..1", "x", quality, "", propserr.Min, 0)
+	case quality > 1:
+		return limitError(propserr.OutOfRange, "паросодержание вне диапазона 0..1", "x", quality, "", propserr.Max, 1)
+	}
 	return nil
 }
 
+// invalidMode — неизвестный режим расчета
+func invalidMode(mode string) error {
+	return propserr.Newf(propserr.InvalidInput, "неверный режим расчета: %s", mode)
+}
+
 // Result представляет результат расчета
 type Result struct {
 	Properties     calc_core.Properties
@@ -108,6 +256,7 @@ type Result struct {
 	TransportProps map[string]string
 	Temperature    float64 // °C
 	Pressure       float64 // Pa
+	Quality        float64 // паросодержание 0..1 в двухфазной области, -1 для однофазных состояний
 }
 
 // Calculate выполняет расчет свойств
@@ -116,84 +265,70 @@ func (c *Calculator) Calculate(inputs *InputData) (*Result, error) {
 	var region calc_core.Region
 	var err error
 
-	var tKelvin float64
 	var temperatureC float64
 	var pressurePa float64
 
-	if inputs.Mode == "TP" {
+	switch inputs.Mode {
+	case "VU":
+		return c.CalculateVU(inputs.SpecificVolume, inputs.InternalEnergy)
+	case "RhoT":
+		return c.CalculateRhoT(inputs.Density, inputs.Temperature)
+	case "TH":
+		return c.CalculateTH(inputs.Temperature, inputs.Enthalpy)
+	case "TS":
+		return c.CalculateTS(inputs.Temperature, inputs.Entropy)
+	case "PH":
+		return c.CalculatePH(inputs.Pressure, inputs.Enthalpy)
+	case "PS":
+		return c.CalculatePS(inputs.Pressure, inputs.Entropy)
+	case "PX":
+		return c.CalculatePX(inputs.Pressure, inputs.Quality)
+	case "TX":
+		return c.CalculateTX(inputs.Temperature, inputs.Quality)
+	case "TP":
 		// Расчет по температуре и давлению
 		props, region, err = c.calculateFromTP(inputs.Temperature, inputs.Pressure)
 		if err != nil {
-			return nil, fmt.Errorf("ошибка расчета по T,P: %w", err)
+			return nil, i18n.Errorf("ошибка расчета по T,P: %w", err)
 		}
 		temperatureC = inputs.Temperature
-		tKelvin = temperatureC + 273.15
 		pressurePa = inputs.Pressure
-	} else {
-		// Расчет по энтальпии и энтропии (Region 3 обратные зависимости)
+	default:
+		// Расчет по энтальпии и энтропии (Region 3 обратные зависимости);
+		// состояния во всех регионах рассчитывает CalculateHS
 		pHS, TK, pr, err := region3.PropertiesFromHS(inputs.Enthalpy, inputs.Entropy)
 		if err != nil {
 			// Пробуем подсказать возможный регион
 			guess := c.guessRegionFromHS(inputs.Enthalpy, inputs.Entropy)
-			return nil, fmt.Errorf("ошибка расчета по h,s: %w (предполагаемый регион: %d)", err, int(guess))
+			return nil, &propserr.Error{Code: propserr.NoSolution, Detail: "ошибка расчета по h,s (предполагаемый регион: %d)", Args: []interface{}{int(guess)}, Err: err}
 		}
 		props = pr
 		region = calc_core.Region3
-		tKelvin = TK
 		temperatureC = TK - 273.15
 		pressurePa = pHS
 	}
 
-	// Определяем фазу вещества
-	phase := c.determinePhase(props, region)
-
-	// Рассчитываем транспортные свойства при фактической температуре
-	transportProps := c.calculateTransportProperties(tKelvin, props)
-
-	return &Result{
-		Properties:     props,
-		Region:         region,
-		Phase:          phase,
-		TransportProps: transportProps,
-		Temperature:    temperatureC,
-		Pressure:       pressurePa,
-	}, nil
+	return c.newResult(props, region, temperatureC, pressurePa, -1), nil
 }
 
 // calculateFromTP рассчитывает свойства по температуре и давлению
 func (c *Calculator) calculateFromTP(temperature, pressure float64) (calc_core.Properties, calc_core.Region, error) {
-	// Определяем регион с помощью общего определения (ожидает T в K)
-	region := calc_core.RegionFromTP(temperature+273.15, pressure)
-
-	var props calc_core.Properties
-	var err error
-
-	switch region {
-	case calc_core.Region1:
-		props, err = region1.Calculate(temperature, pressure)
-	case calc_core.Region2:
-		props, err = region2.Calculate(temperature, pressure)
-	case calc_core.Region3:
-		props, err = region3.Calculate(temperature, pressure)
-	case calc_core.Region4:
-		// Region 4 - линия насыщения: выберем сторону по давлению относительно линии насыщения
-		psat, e := region4.SaturationPressure(temperature + 273.15)
-		if e == nil && pressure >= psat {
-			props, err = region1.Calculate(temperature, pressure)
-		} else {
-			props, err = region2.Calculate(temperature, pressure)
-		}
-	case calc_core.Region5:
-		props, err = region5.Calculate(temperature, pressure)
-	default:
-		return calc_core.Properties{}, calc_core.RegionAuto, fmt.Errorf("неопределенный регион")
+	st, err := FromTP(temperature, pressure)
+	if err != nil {
+		return calc_core.Properties{}, calc_core.RegionFromTP(temperature+273.15, pressure), err
 	}
+	return st.Properties(), st.Region(), nil
+}
 
+// CalculateInRegion рассчитывает свойства по температуре (°C) и давлению (Па)
+// по уравнению заданного региона 1, 2, 3 или 5 вместо определения региона по
+// границам IF-97 (флаг -region командной строки).
+func (c *Calculator) CalculateInRegion(region calc_core.Region, temperature, pressure float64) (*Result, error) {
+	st, err := FromTPRegion(region, temperature, pressure)
 	if err != nil {
-		return calc_core.Properties{}, region, err
+		return nil, err
 	}
-
-	return props, region, nil
+	return c.newResult(st.Properties(), region, temperature, pressure, -1), nil
 }
 
 // calculateFromHS рассчитывает свойства по энтальпии и энтропии
@@ -204,7 +339,7 @@ func (c *Calculator) calculateFromHS(enthalpy, entropy float64) (calc_core.Prope
 	if err != nil {
 		// Пробуем определить, в каком регионе должна быть точка
 		region := c.guessRegionFromHS(enthalpy, entropy)
-		return calc_core.Properties{}, region, fmt.Errorf("не удалось найти решение для h=%.2f кДж/кг, s=%.2f кДж/(кг·К). Возможно, точка находится в Region %d", enthalpy, entropy, int(region))
+		return calc_core.Properties{}, region, &propserr.Error{Code: propserr.NoSolution, Detail: "не удалось найти решение для h=%.2f кДж/кг, s=%.2f кДж/(кг·К). Возможно, точка находится в Region %d", Args: []interface{}{enthalpy, entropy, int(region)}, Err: err}
 	}
 
 	return props, calc_core.Region3, nil
@@ -232,21 +367,22 @@ func (c *Calculator) guessRegionFromHS(enthalpy, entropy float64) calc_core.Regi
 	}
 }
 
-// determinePhase определяет фазу вещества
+// determinePhase определяет фазу вещества. Название — сообщение каталога
+// i18n и переводится при выводе.
 func (c *Calculator) determinePhase(props calc_core.Properties, region calc_core.Region) string {
 	switch region {
 	case calc_core.Region1:
-		return "Сжатая жидкость"
+		return i18n.N("Сжатая жидкость")
 	case calc_core.Region2:
-		return "Перегретый пар"
+		return i18n.N("Перегретый пар")
 	case calc_core.Region3:
-		return "Критическая/сверхкритическая область"
+		return i18n.N("Критическая/сверхкритическая область")
 	case calc_core.Region4:
-		return "Двухфазная область"
+		return i18n.N("Двухфазная область")
 	case calc_core.Region5:
-		return "Высокотемпературный газ"
+		return i18n.N("Высокотемпературный газ")
 	default:
-		return "Неопределенная фаза"
+		return i18n.N("Неопределенная фаза")
 	}
 }
 
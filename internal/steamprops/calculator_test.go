@@ -1,6 +1,7 @@
 package steamprops
 
 import (
+	"math"
 	"testing"
 
 	"github.com/somepgs/steamprops/internal/calc_core"
@@ -192,6 +193,57 @@ func TestInputData_Validate(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "Valid VU input",
+			input: &InputData{
+				Mode:           "VU",
+				SpecificVolume: 0.2,
+				InternalEnergy: 2600.0,
+			},
+			expectError: false,
+		},
+		{
+			name: "Valid RhoT input",
+			input: &InputData{
+				Mode:        "RhoT",
+				Density:     500.0,
+				Temperature: 380.0,
+			},
+			expectError: false,
+		},
+		{
+			name: "Valid TH input",
+			input: &InputData{
+				Mode:        "TH",
+				Temperature: 300.0,
+				Enthalpy:    2900.0,
+			},
+			expectError: false,
+		},
+		{
+			name: "Valid TS input",
+			input: &InputData{
+				Mode:        "TS",
+				Temperature: 300.0,
+				Entropy:     6.0,
+			},
+			expectError: false,
+		},
+		{
+			name:        "Valid PH input",
+			input:       &InputData{Mode: "PH", Pressure: 1e6, Enthalpy: 2800},
+			expectError: false,
+		},
+		{
+			name:        "Valid PX input",
+			input:       &InputData{Mode: "PX", Pressure: 1e6, Quality: 0.5},
+			expectError: false,
+		},
+		{
+			name:        "Valid TX input",
+			input:       &InputData{Mode: "TX", Temperature: 100, Quality: 1},
+			expectError: false,
+		},
 		{
 			name: "Invalid mode",
 			input: &InputData{
@@ -237,6 +289,71 @@ func TestInputData_Validate(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "Invalid specific volume",
+			input: &InputData{
+				Mode:           "VU",
+				SpecificVolume: 0,
+				InternalEnergy: 2600.0,
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid internal energy",
+			input: &InputData{
+				Mode:           "VU",
+				SpecificVolume: 0.2,
+				InternalEnergy: math.NaN(),
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid density",
+			input: &InputData{
+				Mode:        "RhoT",
+				Density:     -1.0,
+				Temperature: 380.0,
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid TH enthalpy",
+			input: &InputData{
+				Mode:        "TH",
+				Temperature: 300.0,
+				Enthalpy:    math.Inf(1),
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid TS temperature",
+			input: &InputData{
+				Mode:        "TS",
+				Temperature: 2500.0,
+				Entropy:     6.0,
+			},
+			expectError: true,
+		},
+		{
+			name:        "Invalid PS pressure",
+			input:       &InputData{Mode: "PS", Pressure: 100, Entropy: 6},
+			expectError: true,
+		},
+		{
+			name:        "PX above critical pressure",
+			input:       &InputData{Mode: "PX", Pressure: 25e6, Quality: 0.5},
+			expectError: true,
+		},
+		{
+			name:        "TX quality out of range",
+			input:       &InputData{Mode: "TX", Temperature: 100, Quality: -0.1},
+			expectError: true,
+		},
+		{
+			name:        "TX above critical temperature",
+			input:       &InputData{Mode: "TX", Temperature: 400, Quality: 0.5},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -280,8 +397,8 @@ func TestCalculator_determineRegion(t *testing.T) {
 		{
 			name:        "Region 3",
 			temperature: 650.0,
-			pressure:    17e6,              // Еще больше уменьшаем давление для Region 3
-			expected:    calc_core.Region3, // Исправляем ожидаемый результат
+			pressure:    25e6, // выше границы B23 (19.3 МПа при 650 K)
+			expected:    calc_core.Region3,
 		},
 		{
 			name:        "Region 5",
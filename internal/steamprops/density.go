@@ -0,0 +1,315 @@
+package steamprops
+
+import (
+	"math"
+
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/internal/calc_core"
+	"github.com/somepgs/steamprops/internal/calc_core/bounds"
+	"github.com/somepgs/steamprops/internal/calc_core/region1"
+	"github.com/somepgs/steamprops/internal/calc_core/region2"
+	"github.com/somepgs/steamprops/internal/calc_core/region3"
+	"github.com/somepgs/steamprops/internal/calc_core/region5"
+	"github.com/somepgs/steamprops/propserr"
+)
+
+// region3TemperatureMax верхняя граница Region 3 (°C): температура B23 при 100 МПа
+const region3TemperatureMax = 590.0
+
+// isothermState состояние на изотерме, найденное по плотности или другому свойству
+type isothermState struct {
+	props    calc_core.Properties
+	region   calc_core.Region
+	pressure float64 // Pa
+	quality  float64 // -1 для однофазных состояний
+}
+
+// CalculateRhoT рассчитывает свойства по плотности (кг/м³) и температуре (°C).
+// Ниже критической температуры плотности между насыщенным паром и насыщенной
+// жидкостью относятся к двухфазной области. В Region 3 свойства вычисляются
+// непосредственно по уравнению f(ρ,T), в остальных регионах давление
+// находится обращением уравнений по плотности.
+func (c *Calculator) CalculateRhoT(density, temperature float64) (*Result, error) {
+	st, err := c.stateFromRhoT(density, temperature)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка расчета по ρ,T: %w", err)
+	}
+	return c.newResult(st.props, st.region, temperature, st.pressure, st.quality), nil
+}
+
+// stateFromRhoT выбирает регион по плотности и температуре и находит давление
+func (c *Calculator) stateFromRhoT(density, temperature float64) (isothermState, error) {
+	if math.IsNaN(density) || math.IsInf(density, 0) {
+		return isothermState{}, invalidValue("плотность содержит недопустимое значение", "rho", density, "kg/m³")
+	}
+	if density <= 0 {
+		return isothermState{}, limitError(propserr.InvalidInput, "плотность должна быть положительной", "rho", density, "kg/m³", propserr.Min, 0)
+	}
+	if err := temperatureInRange(temperature); err != nil {
+		return isothermState{}, err
+	}
+
+	region2At := func(p float64) (calc_core.Properties, calc_core.Region, error) {
+		props, err := region2.Calculate(temperature, p)
+		return props, calc_core.Region2, err
+	}
+	switch {
+	case temperature > region2TemperatureMax:
+		return c.solvePressure(density, temperature, minPressure, region5PressureMax, func(p float64) (calc_core.Properties, calc_core.Region, error) {
+			props, err := region5.Calculate(temperature, p)
+			return props, calc_core.Region5, err
+		})
+	case temperature > region3TemperatureMax:
+		return c.solvePressure(density, temperature, minPressure, maxPressure, region2At)
+	}
+
+	if temperature < criticalTemperature {
+		sat, err := c.SaturationAtTemperature(temperature)
+		if err != nil {
+			return isothermState{}, err
+		}
+		switch {
+		case density > sat.Liquid.Density:
+			if temperature > region1TemperatureMax {
+				return c.region3FromRhoT(density, temperature)
+			}
+			return c.solvePressure(density, temperature, sat.Pressure, maxPressure, func(p float64) (calc_core.Properties, calc_core.Region, error) {
+				props, err := region1.Calculate(temperature, p)
+				return props, calc_core.Region1, err
+			})
+		case density >= sat.Vapor.Density:
+			v := 1 / density
+			x := (v - sat.Liquid.SpecificVolume) / (sat.Vapor.SpecificVolume - sat.Liquid.SpecificVolume)
+			x = math.Min(math.Max(x, 0), 1)
+			return isothermState{sat.Mixture(x), calc_core.Region4, sat.Pressure, x}, nil
+		case temperature <= region1TemperatureMax:
+			return c.solvePressure(density, temperature, minPressure, sat.Pressure, region2At)
+		}
+	}
+
+	// Пар выше 350°C: Region 3 выше границы B23, Region 2 ниже нее.
+	// Регион выбирается по плотности Region 2 на границе, так как уравнения
+	// на B23 согласованы лишь в пределах погрешности
+	pB23, err := bounds.B23P(temperature + 273.15)
+	if err != nil {
+		return isothermState{}, err
+	}
+	pB23 *= 1e6
+	boundary, err := region2.Calculate(temperature, pB23)
+	if err != nil {
+		return isothermState{}, err
+	}
+	if density > boundary.Density {
+		return c.region3FromRhoT(density, temperature)
+	}
+	return c.solvePressure(density, temperature, minPressure, pB23, region2At)
+}
+
+// region3FromRhoT вычисляет состояние по уравнению Region 3 f(ρ,T)
+func (c *Calculator) region3FromRhoT(density, temperature float64) (isothermState, error) {
+	p, props, err := region3.PropertiesRhoT(density, temperature)
+	if err != nil {
+		return isothermState{}, err
+	}
+	if p > maxPressure {
+		e := propserr.Range(propserr.OutOfRange, 0, "p", p, "Pa", propserr.Max, maxPressure)
+		e.Detail = "давление при ρ=%.4g кг/м³ и T=%.2f°C превышает максимальное для IF-97"
+		e.Args = []interface{}{density, temperature}
+		return isothermState{}, e
+	}
+	return isothermState{props, calc_core.Region3, p, -1}, nil
+}
+
+// solvePressure ищет давление (Pa) в интервале [lo, hi], при котором плотность
+// равна density, методом регула фалси (модификация Иллинойс) по ln p.
+// Плотность однофазного состояния монотонно растет с давлением.
+func (c *Calculator) solvePressure(density, temperature, lo, hi float64,
+	calculate func(p float64) (calc_core.Properties, calc_core.Region, error)) (isothermState, error) {
+	const (
+		maxIter = 100
+		tolRho  = 1e-13 // относительная погрешность плотности
+	)
+	if !(lo < hi) {
+		return isothermState{}, propserr.Newf(propserr.NoSolution, "нет однофазного состояния с ρ=%.6g кг/м³ при T=%.2f°C", density, temperature)
+	}
+
+	residual := func(p float64) (calc_core.Properties, calc_core.Region, float64, error) {
+		props, region, err := calculate(p)
+		if err != nil {
+			return props, region, 0, err
+		}
+		return props, region, math.Log(props.Density / density), nil
+	}
+	propsLo, regionLo, rLo, err := residual(lo)
+	if err != nil {
+		return isothermState{}, err
+	}
+	propsHi, regionHi, rHi, err := residual(hi)
+	if err != nil {
+		return isothermState{}, err
+	}
+	switch {
+	case rLo > 0 || rHi < 0:
+		return isothermState{}, &propserr.Error{Code: propserr.NoSolution, Detail: "плотность вне диапазона %.6g..%.6g при T=%.2f°C", Args: []interface{}{propsLo.Density, propsHi.Density, temperature}, Quantity: "rho", Value: density, Unit: "kg/m³"}
+	case rLo == 0:
+		return isothermState{propsLo, regionLo, lo, -1}, nil
+	case rHi == 0:
+		return isothermState{propsHi, regionHi, hi, -1}, nil
+	}
+
+	var st isothermState
+	_, err = illinois(math.Log(lo), math.Log(hi), rLo, rHi, tolRho, 1e-15, maxIter, func(x float64) (float64, error) {
+		p := math.Exp(x)
+		props, region, r, err := residual(p)
+		st = isothermState{props, region, p, -1}
+		return r, err
+	})
+	if err == errNoConvergence {
+		return isothermState{}, propserr.Newf(propserr.NoConvergence, "нет сходимости по давлению для ρ=%.6g кг/м³ при T=%.2f°C", density, temperature)
+	}
+	if err != nil {
+		return isothermState{}, err
+	}
+	return st, nil
+}
+
+// errNoConvergence возвращается illinois, если корень не найден за maxIter итераций
+var errNoConvergence error = propserr.NoConvergence
+
+// illinois уточняет корень f на интервале [a, b], на концах которого f имеет
+// разные знаки, методом регула фалси с модификацией Иллинойс. Итерации
+// прекращаются, когда |f| <= tolF или длина интервала не превышает tolX.
+// Возвращается последняя точка, в которой вычислялась f.
+func illinois(a, b, fa, fb, tolF, tolX float64, maxIter int, f func(x float64) (float64, error)) (float64, error) {
+	if fa > 0 {
+		// Приводим к fa < 0 < fb
+		g := f
+		f = func(x float64) (float64, error) {
+			r, err := g(x)
+			return -r, err
+		}
+		fa, fb = -fa, -fb
+	}
+	side := 0
+	for i := 0; i < maxIter; i++ {
+		x := a
+		if fb != fa {
+			x = (a*fb - b*fa) / (fb - fa)
+		}
+		r, err := f(x)
+		if err != nil {
+			return x, err
+		}
+		if math.Abs(r) <= tolF || math.Abs(b-a) <= tolX {
+			return x, nil
+		}
+		if r > 0 {
+			b, fb = x, r
+			if side == 1 {
+				fa /= 2
+			}
+			side = 1
+		} else {
+			a, fa = x, r
+			if side == -1 {
+				fb /= 2
+			}
+			side = -1
+		}
+	}
+	return 0, errNoConvergence
+}
+
+// calculateVUFromEquations рассчитывает свойства по удельному объему (м³/кг)
+// и удельной внутренней энергии (кДж/кг) по уравнениям IF-97. При постоянной
+// плотности внутренняя энергия монотонно растет с температурой (cv > 0,
+// в том числе в двухфазной области), поэтому температура ищется по сетке
+// и уточняется методом регула фалси на изохоре, рассчитываемой stateFromRhoT.
+func (c *Calculator) calculateVUFromEquations(volume, energy float64) (*Result, error) {
+	const (
+		gridSize     = 100
+		boundaryIter = 40
+		maxIter      = 100
+		tolT         = 1e-9 // °C
+	)
+	if math.IsNaN(volume) || math.IsInf(volume, 0) || volume <= 0 || math.IsNaN(energy) || math.IsInf(energy, 0) {
+		return nil, propserr.Newf(propserr.InvalidInput, "ошибка расчета по v,u: недопустимые значения v=%v, u=%v", volume, energy)
+	}
+	density := 1 / volume
+
+	// residual возвращает u(ρ, T) - u; ok = false, если при температуре t
+	// нет состояния с плотностью ρ в области IF-97
+	residual := func(t float64) (isothermState, float64, bool) {
+		st, err := c.stateFromRhoT(density, t)
+		if err != nil {
+			return st, 0, false
+		}
+		return st, st.props.SpecificInternalEnergy - energy, true
+	}
+
+	// Интервал смены знака ищется по сетке. Изохора выходит за границы
+	// уравнений по давлению, и вблизи границ узлы сетки заменяются ближайшими
+	// допустимыми точками, найденными бисекцией
+	var a, b, ra, rb float64
+	found := false
+	lastOK := false // a, ra хранят последнюю допустимую точку
+	add := func(t, r float64) {
+		if lastOK && ra <= 0 && r >= 0 {
+			b, rb = t, r
+			found = true
+			return
+		}
+		a, ra, lastOK = t, r, true
+	}
+	lo, hi := minTemperature, region5TemperatureMax
+	prevT := lo
+	_, prevR, prevOK := residual(lo)
+	if prevOK {
+		add(lo, prevR)
+	}
+	for i := 1; i <= gridSize && !found; i++ {
+		t := lo + (hi-lo)*float64(i)/gridSize
+		_, r, ok := residual(t)
+		if ok != prevOK {
+			good, bad := prevT, t
+			if ok {
+				good, bad = t, prevT
+			}
+			for k := 0; k < boundaryIter; k++ {
+				mid := 0.5 * (good + bad)
+				if _, _, midOK := residual(mid); midOK {
+					good = mid
+				} else {
+					bad = mid
+				}
+			}
+			_, rGood, _ := residual(good)
+			add(good, rGood)
+		}
+		if ok && !found {
+			add(t, r)
+		}
+		prevT, prevOK = t, ok
+	}
+	if !found {
+		return nil, propserr.Newf(propserr.NoSolution, "ошибка расчета по v,u: нет состояния с v=%.6g м³/кг и u=%.2f кДж/кг в области IF-97", volume, energy)
+	}
+
+	// Регула фалси (модификация Иллинойс) по температуре
+	var st isothermState
+	t, err := illinois(a, b, ra, rb, 1e-12*math.Max(1, math.Abs(energy)), tolT, maxIter, func(t float64) (float64, error) {
+		var r float64
+		var ok bool
+		if st, r, ok = residual(t); !ok {
+			return 0, propserr.Newf(propserr.NoSolution, "изохора v=%.6g м³/кг прерывается при T=%.2f°C", volume, t)
+		}
+		return r, nil
+	})
+	if err == errNoConvergence {
+		return nil, propserr.Newf(propserr.NoConvergence, "ошибка расчета по v,u: нет сходимости для v=%.6g м³/кг, u=%.2f кДж/кг", volume, energy)
+	}
+	if err != nil {
+		return nil, i18n.Errorf("ошибка расчета по v,u: %w", err)
+	}
+	return c.newResult(st.props, st.region, t, st.pressure, st.quality), nil
+}
@@ -0,0 +1,113 @@
+package steamprops
+
+import (
+	"math"
+	"testing"
+
+	"github.com/somepgs/steamprops/internal/calc_core"
+)
+
+// densityCases состояния во всех регионах IF-97 для проверки расчетов по ρ,T и v,u
+var densityCases = []struct {
+	name    string
+	t, p, x float64 // x < 0 для однофазных состояний
+	region  calc_core.Region
+}{
+	{"холодная вода", 4, 101325, -1, calc_core.Region1},
+	{"вода под давлением", 300, 80e6, -1, calc_core.Region1},
+	{"пар низкого давления", 50, 5000, -1, calc_core.Region2},
+	{"перегретый пар", 500, 10e6, -1, calc_core.Region2},
+	{"пар у границы B23", 400, 20e6, -1, calc_core.Region2},
+	{"жидкость в Region 3", 360, 50e6, -1, calc_core.Region3},
+	{"пар в Region 3", 370, 20e6, -1, calc_core.Region3},
+	{"сверхкритическая область", 400, 30e6, -1, calc_core.Region3},
+	{"высокотемпературный газ", 1500, 5e6, -1, calc_core.Region5},
+	{"влажный пар", 0, 101325, 0.3, calc_core.Region4},
+	{"влажный пар в Region 3", 0, 20e6, 0.7, calc_core.Region4},
+}
+
+// densityReference рассчитывает эталонное состояние по T,p или p,x
+func densityReference(t *testing.T, calc *Calculator, temperature, pressure, quality float64) *Result {
+	t.Helper()
+	var res *Result
+	var err error
+	if quality >= 0 {
+		res, err = calc.CalculatePX(pressure, quality)
+	} else {
+		res, err = calc.Calculate(&InputData{Mode: "TP", Temperature: temperature, Pressure: pressure})
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestCalculator_CalculateRhoT(t *testing.T) {
+	calc := NewCalculator()
+	for _, tt := range densityCases {
+		t.Run(tt.name, func(t *testing.T) {
+			want := densityReference(t, calc, tt.t, tt.p, tt.x)
+			if want.Region != tt.region {
+				t.Fatalf("reference region = %d, want %d", want.Region, tt.region)
+			}
+			got, err := calc.Calculate(&InputData{Mode: "RhoT", Density: want.Properties.Density, Temperature: want.Temperature})
+			if err != nil {
+				t.Fatalf("CalculateRhoT: %v", err)
+			}
+			if got.Region != tt.region || math.Abs(got.Quality-want.Quality) > 1e-9 {
+				t.Errorf("region %d x=%g, want region %d x=%g", got.Region, got.Quality, tt.region, want.Quality)
+			}
+			if math.Abs(got.Pressure/want.Pressure-1) > 1e-8 {
+				t.Errorf("p = %.6f Pa, want %.6f Pa", got.Pressure, want.Pressure)
+			}
+			if math.Abs(got.Properties.SpecificEnthalpy-want.Properties.SpecificEnthalpy) > 1e-5 {
+				t.Errorf("h = %.8f, want %.8f", got.Properties.SpecificEnthalpy, want.Properties.SpecificEnthalpy)
+			}
+		})
+	}
+
+	// Контрольная точка IF-97 (таблица 33): ρ = 500 кг/м³, T = 650 K
+	res, err := calc.CalculateRhoT(500, 650-273.15)
+	if err != nil {
+		t.Fatalf("CalculateRhoT: %v", err)
+	}
+	if res.Region != calc_core.Region3 || math.Abs(res.Pressure-25.5837018e6) > 1 {
+		t.Errorf("p = %.1f Pa in region %d, want 25583701.8 Pa in region 3", res.Pressure, res.Region)
+	}
+
+	for _, in := range [][2]float64{{0, 100}, {-5, 100}, {1000, 2100}, {1100, 20}, {math.NaN(), 100}} {
+		if _, err := calc.CalculateRhoT(in[0], in[1]); err == nil {
+			t.Errorf("expected error for ρ=%g, T=%g", in[0], in[1])
+		}
+	}
+}
+
+func TestCalculator_CalculateVU_IF97(t *testing.T) {
+	calc := NewCalculator()
+	for _, tt := range densityCases {
+		t.Run(tt.name, func(t *testing.T) {
+			want := densityReference(t, calc, tt.t, tt.p, tt.x)
+			got, err := calc.Calculate(&InputData{
+				Mode:           "VU",
+				SpecificVolume: want.Properties.SpecificVolume,
+				InternalEnergy: want.Properties.SpecificInternalEnergy,
+			})
+			if err != nil {
+				t.Fatalf("CalculateVU: %v", err)
+			}
+			if got.Region != want.Region || math.Abs(got.Quality-want.Quality) > 1e-8 {
+				t.Errorf("region %d x=%g, want region %d x=%g", got.Region, got.Quality, want.Region, want.Quality)
+			}
+			if math.Abs(got.Temperature-want.Temperature) > 1e-6 || math.Abs(got.Pressure/want.Pressure-1) > 1e-7 {
+				t.Errorf("T = %.9f°C p = %.3f Pa, want T = %.9f°C p = %.3f Pa", got.Temperature, got.Pressure, want.Temperature, want.Pressure)
+			}
+		})
+	}
+
+	if _, err := calc.CalculateVU(-1, 2600); err == nil {
+		t.Errorf("expected error for negative volume")
+	}
+	if _, err := calc.CalculateVU(0.001, 5000); err == nil {
+		t.Errorf("expected error for a state above 100 MPa")
+	}
+}
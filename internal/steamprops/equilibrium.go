@@ -0,0 +1,469 @@
+package steamprops
+
+import (
+	"math"
+
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/internal/calc_core"
+	"github.com/somepgs/steamprops/internal/calc_core/region1"
+	"github.com/somepgs/steamprops/internal/calc_core/region2"
+	"github.com/somepgs/steamprops/internal/calc_core/region3"
+	"github.com/somepgs/steamprops/internal/calc_core/region4"
+	"github.com/somepgs/steamprops/internal/calc_core/region5"
+	"github.com/somepgs/steamprops/propserr"
+)
+
+// Границы областей, используемые при обращении уравнений по давлению
+const (
+	minTemperature        = 0.0      // °C, нижняя граница Region 1/2
+	region1TemperatureMax = 350.0    // °C, выше линия насыщения лежит в Region 3
+	criticalTemperature   = 373.946  // °C
+	region2TemperatureMax = 800.0    // °C
+	region5TemperatureMax = 2000.0   // °C
+	region5PressureMax    = 50e6     // Pa
+	minPressure           = 611.657  // Pa, тройная точка
+	maxPressure           = 100e6    // Pa
+	criticalPressure      = 22.064e6 // Pa
+
+	// saturationOffset отступ от линии насыщения (K), чтобы однофазные
+	// уравнения не отвергали точку из-за погрешности округления psat(Tsat(p))
+	saturationOffset = 1e-6
+)
+
+// SaturationState описывает насыщенную жидкость и насыщенный пар на линии насыщения
+type SaturationState struct {
+	Temperature float64 // °C
+	Pressure    float64 // Pa
+	Liquid      calc_core.Properties
+	Vapor       calc_core.Properties
+}
+
+// Mixture возвращает свойства влажного пара с паросодержанием x.
+// Теплоемкости и скорость звука для равновесной смеси не определены и равны нулю.
+func (s *SaturationState) Mixture(x float64) calc_core.Properties {
+	mix := func(liquid, vapor float64) float64 {
+		return liquid + x*(vapor-liquid)
+	}
+	v := mix(s.Liquid.SpecificVolume, s.Vapor.SpecificVolume)
+	return calc_core.Properties{
+		SpecificVolume:         v,
+		Density:                1.0 / v,
+		SpecificInternalEnergy: mix(s.Liquid.SpecificInternalEnergy, s.Vapor.SpecificInternalEnergy),
+		SpecificEntropy:        mix(s.Liquid.SpecificEntropy, s.Vapor.SpecificEntropy),
+		SpecificEnthalpy:       mix(s.Liquid.SpecificEnthalpy, s.Vapor.SpecificEnthalpy),
+	}
+}
+
+// SaturationAtTemperature рассчитывает состояния насыщения при температуре (°C)
+// от тройной точки до критической. Выше 350°C плотности фаз находятся
+// по уравнению Region 3 f(ρ,T) при давлении насыщения.
+func (c *Calculator) SaturationAtTemperature(temperature float64) (*SaturationState, error) {
+	switch {
+	case math.IsNaN(temperature):
+		return nil, invalidValue("температура насыщения содержит недопустимое значение", "T", temperature, "°C")
+	case temperature < minTemperature:
+		return nil, limitError(propserr.OutOfRange, "температура насыщения ниже тройной точки", "T", temperature, "°C", propserr.Min, minTemperature)
+	case temperature >= criticalTemperature:
+		return nil, limitError(propserr.OutOfRange, "температура насыщения не ниже критической", "T", temperature, "°C", propserr.Max, criticalTemperature)
+	}
+	psat, err := region4.SaturationPressure(temperature + 273.15)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка расчета давления насыщения: %w", err)
+	}
+	sat := &SaturationState{Temperature: temperature, Pressure: psat}
+	if temperature > region1TemperatureMax {
+		rhoL, rhoV, err := region3.SaturatedDensities(temperature)
+		if err != nil {
+			return nil, i18n.Errorf("ошибка расчета плотностей насыщения: %w", err)
+		}
+		if _, sat.Liquid, err = region3.PropertiesRhoT(rhoL, temperature); err != nil {
+			return nil, i18n.Errorf("ошибка расчета насыщенной жидкости: %w", err)
+		}
+		if _, sat.Vapor, err = region3.PropertiesRhoT(rhoV, temperature); err != nil {
+			return nil, i18n.Errorf("ошибка расчета насыщенного пара: %w", err)
+		}
+		return sat, nil
+	}
+	if sat.Liquid, err = region1.Calculate(temperature, psat); err != nil {
+		return nil, i18n.Errorf("ошибка расчета насыщенной жидкости: %w", err)
+	}
+	if sat.Vapor, err = region2.Calculate(temperature, psat); err != nil {
+		return nil, i18n.Errorf("ошибка расчета насыщенного пара: %w", err)
+	}
+	return sat, nil
+}
+
+// SaturationAtPressure рассчитывает состояния насыщения при давлении (Pa)
+func (c *Calculator) SaturationAtPressure(pressure float64) (*SaturationState, error) {
+	if math.IsNaN(pressure) {
+		return nil, invalidValue("давление содержит недопустимое значение", "p", pressure, "Pa")
+	}
+	if pressure < minPressure {
+		return nil, limitError(propserr.OutOfRange, "давление ниже минимального для IF-97", "p", pressure, "Pa", propserr.Min, minPressure)
+	}
+	TK, err := region4.SaturationTemperature(pressure)
+	if err != nil {
+		return nil, i18n.Errorf("ошибка расчета температуры насыщения: %w", err)
+	}
+	temperature := TK - 273.15
+	// Погрешность округления Tsat(p) не должна переносить давления
+	// до psat(350°C) в Region 3
+	if pMax, err := region4.SaturationPressure(region1TemperatureMax + 273.15); err == nil && pressure <= pMax {
+		temperature = math.Min(temperature, region1TemperatureMax)
+	}
+	sat, err := c.SaturationAtTemperature(temperature)
+	if err != nil {
+		return nil, err
+	}
+	sat.Pressure = pressure
+	return sat, nil
+}
+
+// CalculatePX рассчитывает свойства влажного пара по давлению (Pa) и паросодержанию
+func (c *Calculator) CalculatePX(pressure, quality float64) (*Result, error) {
+	if err := qualityInRange(quality); err != nil {
+		return nil, err
+	}
+	sat, err := c.SaturationAtPressure(pressure)
+	if err != nil {
+		return nil, err
+	}
+	return c.newResult(sat.Mixture(quality), calc_core.Region4, sat.Temperature, pressure, quality), nil
+}
+
+// CalculateTX рассчитывает свойства влажного пара по температуре (°C) и паросодержанию
+func (c *Calculator) CalculateTX(temperature, quality float64) (*Result, error) {
+	if err := qualityInRange(quality); err != nil {
+		return nil, err
+	}
+	sat, err := c.SaturationAtTemperature(temperature)
+	if err != nil {
+		return nil, err
+	}
+	return c.newResult(sat.Mixture(quality), calc_core.Region4, temperature, sat.Pressure, quality), nil
+}
+
+// CalculatePH рассчитывает свойства по давлению (Pa) и энтальпии (кДж/кг)
+// выбранным вычислителем (см. SetEvaluator)
+func (c *Calculator) CalculatePH(pressure, enthalpy float64) (*Result, error) {
+	if c.evaluator == EvaluatorSBTL {
+		return c.calculatePHFromTables(pressure, enthalpy)
+	}
+	return c.calculatePHFromEquations(pressure, enthalpy)
+}
+
+// calculatePHFromEquations рассчитывает свойства по (p, h) обращением
+// уравнений IF-97
+func (c *Calculator) calculatePHFromEquations(pressure, enthalpy float64) (*Result, error) {
+	res, err := c.calculateFromPressure(pressure, enthalpy, func(p calc_core.Properties) float64 {
+		return p.SpecificEnthalpy
+	})
+	if err != nil {
+		return nil, i18n.Errorf("ошибка расчета по p,h: %w", err)
+	}
+	return res, nil
+}
+
+// CalculatePS рассчитывает свойства по давлению (Pa) и энтропии (кДж/(кг·К))
+func (c *Calculator) CalculatePS(pressure, entropy float64) (*Result, error) {
+	res, err := c.calculateFromPressure(pressure, entropy, func(p calc_core.Properties) float64 {
+		return p.SpecificEntropy
+	})
+	if err != nil {
+		return nil, i18n.Errorf("ошибка расчета по p,s: %w", err)
+	}
+	return res, nil
+}
+
+// CalculateHS рассчитывает свойства по энтальпии (кДж/кг) и энтропии (кДж/(кг·К))
+// во всех регионах IF-97. Режим HS метода Calculate использует только обратные
+// зависимости Region 3; CalculateHS уточняет по ним состояние Region 3
+// (см. solveHSRegion3), а вне его находит давление на изоэнтропе (см. solveHS).
+func (c *Calculator) CalculateHS(enthalpy, entropy float64) (*Result, error) {
+	if math.IsNaN(enthalpy) || math.IsInf(enthalpy, 0) || math.IsNaN(entropy) || math.IsInf(entropy, 0) {
+		return nil, propserr.Newf(propserr.InvalidInput, "ошибка расчета по h,s: недопустимые значения h=%v, s=%v", enthalpy, entropy)
+	}
+	if res, ok := c.solveHSRegion3(enthalpy, entropy); ok {
+		return res, nil
+	}
+	return c.solveHS(enthalpy, entropy)
+}
+
+// solveHSRegion3 решает h(ρ,T) = h, s(ρ,T) = s методом Ньютона по
+// фундаментальному уравнению Region 3, начиная с обратных зависимостей
+// p(h,s), T(p,s), v(p,s). Найденное состояние принимается, только если оно
+// лежит в Region 3 и вне двухфазной области; иначе ok = false.
+func (c *Calculator) solveHSRegion3(enthalpy, entropy float64) (*Result, bool) {
+	const (
+		maxIter  = 30
+		tolRho   = 1e-13 // ln ρ
+		tolT     = 1e-9  // K
+		maxStep  = 0.2   // ограничение шага по ln ρ
+		maxStepT = 20.0  // ограничение шага по T, K
+	)
+
+	_, TK, guess, err := region3.PropertiesFromHS(enthalpy, entropy)
+	if err != nil {
+		return nil, false
+	}
+	lnRho, T := math.Log(guess.Density), TK
+
+	converged := false
+	for i := 0; i < maxIter && !converged; i++ {
+		f, err := region3.Derivatives(math.Exp(lnRho), T-273.15)
+		if err != nil {
+			return nil, false
+		}
+		v := 1 / f.Rho
+		cv := f.SpecificIsochoricHeatCapacity()
+		dpdT := f.Rho * f.R * (f.Delta*f.FDelta - f.Delta*f.Tau*f.FDeltaTau)     // кПа/К
+		dpdRho := f.R * T * (2*f.Delta*f.FDelta + f.Delta*f.Delta*f.FDeltaDelta) // кПа·м³/кг
+		dsdRho := -dpdT * v * v
+
+		// Якобиан по (ln ρ, T): (∂s/∂v)_T = (∂p/∂T)_v, dh = T ds + v dp
+		hRho, hT := f.Rho*(T*dsdRho+v*dpdRho), cv+v*dpdT
+		sRho, sT := f.Rho*dsdRho, cv/T
+		rh := f.SpecificEnthalpy() - enthalpy
+		rs := f.SpecificEntropy() - entropy
+		det := hRho*sT - hT*sRho
+		if det == 0 || math.IsNaN(det) {
+			return nil, false
+		}
+		dLnRho := (rh*sT - rs*hT) / det
+		dT := (hRho*rs - sRho*rh) / det
+		converged = math.Abs(dLnRho) < tolRho && math.Abs(dT) < tolT
+		lnRho -= math.Max(-maxStep, math.Min(maxStep, dLnRho))
+		T -= math.Max(-maxStepT, math.Min(maxStepT, dT))
+	}
+	if !converged {
+		return nil, false
+	}
+
+	rho, t := math.Exp(lnRho), T-273.15
+	p, props, err := region3.PropertiesRhoT(rho, t)
+	if err != nil || p > maxPressure || calc_core.RegionFromTP(T, p) != calc_core.Region3 {
+		return nil, false
+	}
+	if T < region3.Tc {
+		// Между плотностями насыщенных пара и жидкости лежит двухфазная область
+		rhoL, rhoV, err := region3.SaturatedDensities(t)
+		if err != nil || (rho > rhoV && rho < rhoL) {
+			return nil, false
+		}
+	}
+	return c.newResult(props, calc_core.Region3, t, p, -1), true
+}
+
+// solveHS находит состояние по (h, s) на изоэнтропе. При постоянной энтропии
+// энтальпия растет с давлением ((∂h/∂p)_s = v) и вогнута по нему ((∂v/∂p)_s < 0),
+// поэтому давление ищется методом Ньютона от тройной точки; шаг, выходящий
+// за найденный интервал смены знака, заменяется бисекцией по lg p.
+func (c *Calculator) solveHS(enthalpy, entropy float64) (*Result, error) {
+	const (
+		maxIter = 100
+		tolP    = 1e-9 // относительная точность давления
+	)
+
+	// aboveIsentrope сообщает, что изоэнтропа при давлении p выходит за
+	// верхнюю границу температуры IF-97, а не за нижнюю
+	aboveIsentrope := func(p float64) bool {
+		tMax := region2TemperatureMax
+		if p <= region5PressureMax {
+			tMax = region5TemperatureMax
+		}
+		props, _, err := c.calculateFromTP(tMax, p)
+		return err == nil && entropy > props.SpecificEntropy
+	}
+
+	// На интервале [lo, hi] h(lo, s) < h < h(hi, s); hiChecked — давление hi
+	// рассчитано, loSolved и hiSolved — на концах найдены состояния
+	lo, hi := minPressure, maxPressure
+	hiChecked, loSolved, hiSolved := false, false, false
+	var last *Result
+	p := minPressure
+	for i := 0; i < maxIter && hi-lo > tolP*lo; i++ {
+		res, err := c.CalculatePS(p, entropy)
+		if err != nil {
+			if aboveIsentrope(p) {
+				hi, hiChecked, hiSolved = p, true, false
+			} else {
+				lo, loSolved = p, false
+			}
+			p = math.Sqrt(lo * hi)
+			continue
+		}
+
+		last = res
+		r := res.Properties.SpecificEnthalpy - enthalpy
+		if r < 0 {
+			lo, loSolved = p, true
+		} else {
+			hi, hiChecked, hiSolved = p, true, true
+		}
+		next := p - r*1000/res.Properties.SpecificVolume
+		if math.Abs(next-p) <= tolP*p {
+			return res, nil
+		}
+		switch {
+		case next > lo && next < hi:
+			p = next
+		case next >= hi && !hiChecked:
+			p = hi
+		default:
+			p = math.Sqrt(lo * hi)
+		}
+	}
+	// Шаг Ньютона может не достичь tolP из-за погрешности h(p, s) от
+	// бисекции по температуре; тогда решение — сжавшийся интервал смены знака
+	if loSolved && hiSolved && hi-lo <= tolP*lo {
+		return last, nil
+	}
+	return nil, propserr.Newf(propserr.NoSolution, "ошибка расчета по h,s: нет состояния с h=%.2f кДж/кг и s=%.4f кДж/(кг·К) в области IF-97", enthalpy, entropy)
+}
+
+// propertyOf выбирает свойство, по которому обращаются уравнения
+type propertyOf func(calc_core.Properties) float64
+
+// calculateFromPressure находит состояние с заданным давлением и значением свойства.
+// Ниже критического давления сначала проверяется двухфазная область,
+// затем температура ищется бисекцией на ветви жидкости или пара.
+func (c *Calculator) calculateFromPressure(pressure, value float64, property propertyOf) (*Result, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, propserr.Newf(propserr.InvalidInput, "недопустимое значение свойства: %v", value)
+	}
+	if err := pressureInRange(pressure); err != nil {
+		return nil, err
+	}
+
+	tMax := region2TemperatureMax
+	if pressure <= region5PressureMax {
+		tMax = region5TemperatureMax
+	}
+
+	var tsat float64
+	if pressure < criticalPressure {
+		TK, err := region4.SaturationTemperature(pressure)
+		if err != nil {
+			return nil, err
+		}
+		tsat = TK - 273.15
+	}
+	if pressure >= criticalPressure || tsat >= criticalTemperature {
+		// Выше критического давления двухфазной области нет
+		return c.solveTemperature(pressure, value, property, minTemperature, tMax, func(t float64) (calc_core.Properties, calc_core.Region, error) {
+			return c.calculateFromTP(t, pressure)
+		})
+	}
+
+	sat, err := c.SaturationAtTemperature(tsat)
+	if err != nil {
+		return nil, err
+	}
+	yf := property(sat.Liquid)
+	yg := property(sat.Vapor)
+	// Значения в пределах отступа saturationOffset от линии насыщения относим к двухфазной области
+	tol := 1e-8 * (math.Abs(yf) + math.Abs(yg))
+
+	switch {
+	case value < yf-tol:
+		return c.solveTemperature(pressure, value, property, minTemperature, sat.Temperature-saturationOffset, func(t float64) (calc_core.Properties, calc_core.Region, error) {
+			return c.liquidAt(t, pressure)
+		})
+	case value <= yg+tol:
+		x := math.Min(math.Max((value-yf)/(yg-yf), 0), 1)
+		return c.newResult(sat.Mixture(x), calc_core.Region4, sat.Temperature, pressure, x), nil
+	default:
+		return c.solveTemperature(pressure, value, property, sat.Temperature+saturationOffset, tMax, func(t float64) (calc_core.Properties, calc_core.Region, error) {
+			return c.vaporAt(t, pressure)
+		})
+	}
+}
+
+// liquidAt рассчитывает жидкость ниже линии насыщения: Region 1 до 350°C, выше Region 3
+func (c *Calculator) liquidAt(t, pressure float64) (calc_core.Properties, calc_core.Region, error) {
+	if t > region1TemperatureMax {
+		props, err := region3.Calculate(t, pressure)
+		return props, calc_core.Region3, err
+	}
+	props, err := region1.Calculate(t, pressure)
+	return props, calc_core.Region1, err
+}
+
+// vaporAt рассчитывает пар выше линии насыщения: Region 3 ниже границы B23, Region 2 или Region 5
+func (c *Calculator) vaporAt(t, pressure float64) (calc_core.Properties, calc_core.Region, error) {
+	switch {
+	case t > region2TemperatureMax:
+		props, err := region5.Calculate(t, pressure)
+		return props, calc_core.Region5, err
+	case calc_core.RegionFromTP(t+273.15, pressure) == calc_core.Region3:
+		props, err := region3.Calculate(t, pressure)
+		return props, calc_core.Region3, err
+	default:
+		props, err := region2.Calculate(t, pressure)
+		return props, calc_core.Region2, err
+	}
+}
+
+// solveTemperature ищет бисекцией температуру (°C) в интервале [lo, hi],
+// при которой свойство равно value. Свойство должно монотонно расти с температурой.
+func (c *Calculator) solveTemperature(pressure, value float64, property propertyOf, lo, hi float64,
+	calculate func(t float64) (calc_core.Properties, calc_core.Region, error)) (*Result, error) {
+	const (
+		maxIter = 100
+		tolT    = 1e-9 // °C
+	)
+
+	propsLo, _, err := calculate(lo)
+	if err != nil {
+		return nil, err
+	}
+	propsHi, _, err := calculate(hi)
+	if err != nil {
+		return nil, err
+	}
+	if value < property(propsLo) || value > property(propsHi) {
+		return nil, propserr.Newf(propserr.NoSolution, "значение %.6g вне диапазона %.6g..%.6g при p=%.0f Па", value, property(propsLo), property(propsHi), pressure)
+	}
+
+	for i := 0; i < maxIter && hi-lo > tolT; i++ {
+		mid := 0.5 * (lo + hi)
+		props, _, err := calculate(mid)
+		if err != nil {
+			return nil, err
+		}
+		if property(props) < value {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	t := 0.5 * (lo + hi)
+	props, region, err := calculate(t)
+	if err != nil {
+		return nil, err
+	}
+	return c.newResult(props, region, t, pressure, -1), nil
+}
+
+// newResult собирает Result, определяя фазу и транспортные свойства
+func (c *Calculator) newResult(props calc_core.Properties, region calc_core.Region, temperature, pressure, quality float64) *Result {
+	var transportProps map[string]string
+	switch {
+	case c.skipTransport:
+		// Пакетный расчет заполняет транспортные свойства числами, см. CalculateBatch
+	case region == calc_core.Region4:
+		transportProps = map[string]string{}
+	default:
+		transportProps = c.calculateTransportProperties(temperature+273.15, props)
+	}
+	return &Result{
+		Properties:     props,
+		Region:         region,
+		Phase:          c.determinePhase(props, region),
+		TransportProps: transportProps,
+		Temperature:    temperature,
+		Pressure:       pressure,
+		Quality:        quality,
+	}
+}
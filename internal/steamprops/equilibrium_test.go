@@ -0,0 +1,244 @@
+package steamprops
+
+import (
+	"math"
+	"testing"
+
+	"github.com/somepgs/steamprops/internal/calc_core"
+	"github.com/somepgs/steamprops/propserr"
+)
+
+func TestCalculator_SaturationAtPressure(t *testing.T) {
+	calc := NewCalculator()
+
+	sat, err := calc.SaturationAtPressure(101325)
+	if err != nil {
+		t.Fatalf("SaturationAtPressure: %v", err)
+	}
+	if math.Abs(sat.Temperature-99.97) > 0.05 {
+		t.Errorf("Tsat = %.3f°C, want ≈ 99.97°C", sat.Temperature)
+	}
+	if math.Abs(sat.Liquid.SpecificEnthalpy-419.1) > 0.5 {
+		t.Errorf("hf = %.2f, want ≈ 419.1", sat.Liquid.SpecificEnthalpy)
+	}
+	if math.Abs(sat.Vapor.SpecificEnthalpy-2675.6) > 0.5 {
+		t.Errorf("hg = %.2f, want ≈ 2675.6", sat.Vapor.SpecificEnthalpy)
+	}
+
+	if _, err := calc.SaturationAtPressure(100); err == nil {
+		t.Errorf("expected error below triple point pressure")
+	}
+	if _, err := calc.SaturationAtTemperature(380); err == nil {
+		t.Errorf("expected error above the critical temperature")
+	}
+
+	// Выше 350°C фазы рассчитываются по Region 3: при 360°C
+	// ρ' ≈ 527.8 кг/м³, ρ'' ≈ 144.0 кг/м³, p ≈ 18.666 МПа
+	sat, err = calc.SaturationAtTemperature(360)
+	if err != nil {
+		t.Fatalf("SaturationAtTemperature(360): %v", err)
+	}
+	if math.Abs(sat.Pressure-18.666e6) > 5e3 {
+		t.Errorf("psat(360°C) = %.0f Pa, want ≈ 18.666 MPa", sat.Pressure)
+	}
+	if math.Abs(sat.Liquid.Density-527.8) > 0.5 || math.Abs(sat.Vapor.Density-144.0) > 0.5 {
+		t.Errorf("ρ' = %.2f, ρ'' = %.2f kg/m³", sat.Liquid.Density, sat.Vapor.Density)
+	}
+}
+
+func TestCalculator_CalculatePX(t *testing.T) {
+	calc := NewCalculator()
+
+	res, err := calc.CalculatePX(1e6, 0.5)
+	if err != nil {
+		t.Fatalf("CalculatePX: %v", err)
+	}
+	if res.Region != calc_core.Region4 {
+		t.Errorf("region = %v, want Region4", res.Region)
+	}
+	if res.Quality != 0.5 {
+		t.Errorf("quality = %v, want 0.5", res.Quality)
+	}
+
+	if _, err := calc.CalculatePX(1e6, 1.5); err == nil {
+		t.Errorf("expected error for quality above 1")
+	}
+
+	// TX дает то же состояние при температуре насыщения
+	tx, err := calc.Calculate(&InputData{Mode: "TX", Temperature: res.Temperature, Quality: 0.5})
+	if err != nil {
+		t.Fatalf("CalculateTX: %v", err)
+	}
+	if math.Abs(tx.Pressure/res.Pressure-1) > 1e-9 || math.Abs(tx.Properties.SpecificEnthalpy-res.Properties.SpecificEnthalpy) > 1e-6 {
+		t.Errorf("TX: p = %.3f Pa h = %.6f, want p = %.3f Pa h = %.6f", tx.Pressure, tx.Properties.SpecificEnthalpy, res.Pressure, res.Properties.SpecificEnthalpy)
+	}
+}
+
+func TestCalculator_CalculatePH_PS_Roundtrip(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name        string
+		temperature float64
+		pressure    float64
+		region      calc_core.Region
+	}{
+		{"Compressed liquid", 50, 3e6, calc_core.Region1},
+		{"Superheated steam", 300, 1e6, calc_core.Region2},
+		{"High temperature gas", 1200, 1e6, calc_core.Region5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := calc.Calculate(&InputData{Mode: "TP", Temperature: tt.temperature, Pressure: tt.pressure})
+			if err != nil {
+				t.Fatalf("Calculate: %v", err)
+			}
+
+			ph, err := calc.CalculatePH(tt.pressure, ref.Properties.SpecificEnthalpy)
+			if err != nil {
+				t.Fatalf("CalculatePH: %v", err)
+			}
+			if math.Abs(ph.Temperature-tt.temperature) > 1e-5 {
+				t.Errorf("CalculatePH temperature = %.8f, want %.8f", ph.Temperature, tt.temperature)
+			}
+			if ph.Region != tt.region || ph.Quality != -1 {
+				t.Errorf("CalculatePH region/quality = %v/%v", ph.Region, ph.Quality)
+			}
+
+			ps, err := calc.CalculatePS(tt.pressure, ref.Properties.SpecificEntropy)
+			if err != nil {
+				t.Fatalf("CalculatePS: %v", err)
+			}
+			if math.Abs(ps.Temperature-tt.temperature) > 1e-5 {
+				t.Errorf("CalculatePS temperature = %.8f, want %.8f", ps.Temperature, tt.temperature)
+			}
+		})
+	}
+}
+
+func TestCalculator_CalculatePS_TwoPhase(t *testing.T) {
+	calc := NewCalculator()
+
+	res, err := calc.CalculatePS(10e3, 6.5)
+	if err != nil {
+		t.Fatalf("CalculatePS: %v", err)
+	}
+	if res.Region != calc_core.Region4 {
+		t.Fatalf("region = %v, want Region4", res.Region)
+	}
+	// При 10 кПа: sf = 0.6492, sg = 8.1488 → x ≈ 0.780
+	if math.Abs(res.Quality-0.780) > 0.002 {
+		t.Errorf("quality = %.4f, want ≈ 0.780", res.Quality)
+	}
+
+	// Между psat(350°C) и критическим давлением купол лежит в Region 3:
+	// при 20 МПа Tsat = 365.75°C, sf = 4.0154, sg = 4.9299 → x ≈ 0.5,
+	// h ≈ 2119.2 кДж/кг
+	res, err = calc.CalculatePS(20e6, 4.4727)
+	if err != nil {
+		t.Fatalf("CalculatePS(20 MPa): %v", err)
+	}
+	if res.Region != calc_core.Region4 {
+		t.Fatalf("region at 20 MPa = %v, want Region4", res.Region)
+	}
+	if math.Abs(res.Quality-0.5) > 0.001 {
+		t.Errorf("quality at 20 MPa = %.4f, want ≈ 0.5", res.Quality)
+	}
+	if math.Abs(res.Temperature-365.75) > 0.01 {
+		t.Errorf("temperature at 20 MPa = %.3f°C, want ≈ 365.75°C", res.Temperature)
+	}
+	if math.Abs(res.Properties.SpecificEnthalpy-2119.2) > 1 {
+		t.Errorf("enthalpy at 20 MPa = %.2f, want ≈ 2119.2", res.Properties.SpecificEnthalpy)
+	}
+
+	if _, err := calc.CalculatePH(1e6, 10000); err == nil {
+		t.Errorf("expected error for enthalpy out of range")
+	}
+	if _, err := calc.CalculatePH(200e6, 1000); err == nil {
+		t.Errorf("expected error for pressure out of range")
+	}
+}
+
+func TestCalculator_CalculateHS(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name        string
+		temperature float64
+		pressure    float64
+		quality     float64 // -1 — задано температурой
+	}{
+		{"Compressed liquid", 50, 3e6, -1},
+		{"Cold liquid", 1, 1e5, -1},
+		{"Superheated steam", 300, 1e6, -1},
+		{"Low pressure steam", 150, 10e3, -1},
+		{"High temperature gas", 1200, 1e6, -1},
+		{"Supercritical steam", 427, 30e6, -1},
+		{"Region 3", 400, 40e6, -1},
+		{"Wet steam", 0, 100e3, 0.9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ref *Result
+			var err error
+			if tt.quality >= 0 {
+				ref, err = calc.CalculatePX(tt.pressure, tt.quality)
+			} else {
+				ref, err = calc.Calculate(&InputData{Mode: "TP", Temperature: tt.temperature, Pressure: tt.pressure})
+			}
+			if err != nil {
+				t.Fatalf("reference: %v", err)
+			}
+
+			res, err := calc.CalculateHS(ref.Properties.SpecificEnthalpy, ref.Properties.SpecificEntropy)
+			if err != nil {
+				t.Fatalf("CalculateHS: %v", err)
+			}
+			if math.Abs(res.Pressure-ref.Pressure) > 1e-5*ref.Pressure {
+				t.Errorf("pressure = %.6f, want %.6f", res.Pressure, ref.Pressure)
+			}
+			if math.Abs(res.Temperature-ref.Temperature) > 1e-4 {
+				t.Errorf("temperature = %.6f, want %.6f", res.Temperature, ref.Temperature)
+			}
+			if res.Region != ref.Region || math.Abs(res.Quality-ref.Quality) > 1e-6 {
+				t.Errorf("region/quality = %v/%.6f, want %v/%.6f", res.Region, res.Quality, ref.Region, ref.Quality)
+			}
+		})
+	}
+
+	if _, err := calc.CalculateHS(100, 9); err == nil {
+		t.Errorf("expected error for state outside IF-97")
+	}
+
+	// Режим HS метода Calculate по-прежнему использует только обратные
+	// зависимости Region 3
+	ref, err := calc.Calculate(&InputData{Mode: "TP", Temperature: 300, Pressure: 1e6})
+	if err != nil {
+		t.Fatalf("reference: %v", err)
+	}
+	_, err = calc.Calculate(&InputData{Mode: "HS", Enthalpy: ref.Properties.SpecificEnthalpy, Entropy: ref.Properties.SpecificEntropy})
+	if propserr.CodeOf(err) != propserr.NoSolution {
+		t.Errorf("Calculate HS outside Region 3: %v, want %s", err, propserr.NoSolution)
+	}
+}
+
+// Region 2 вблизи границы B23 и Region 3
+func BenchmarkCalculateHS(b *testing.B) {
+	calc := NewCalculator()
+	for i := 0; i < b.N; i++ {
+		if _, err := calc.CalculateHS(2631.5, 5.175); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCalculateHS_Region3(b *testing.B) {
+	calc := NewCalculator()
+	for i := 0; i < b.N; i++ {
+		if _, err := calc.CalculateHS(2000, 4.2); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package steamprops
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/internal/calc_core"
+	"github.com/somepgs/steamprops/internal/calc_core/region4"
+	"github.com/somepgs/steamprops/internal/calc_core/sbtl"
+	"github.com/somepgs/steamprops/propserr"
+)
+
+// Evaluator задает способ расчета свойств по (p, h) и (v, u)
+type Evaluator int
+
+const (
+	// EvaluatorIF97 обращение уравнений IF-97 итерациями (по умолчанию)
+	EvaluatorIF97 Evaluator = iota
+	// EvaluatorSBTL сплайновые таблицы SBTL, построенные по уравнениям IF-97:
+	// быстрее на порядки, погрешность в пределах sbtl.Tolerance, у границ
+	// Region 3 и критической точки — sbtl.BoundaryTolerance
+	EvaluatorSBTL
+)
+
+// String возвращает название вычислителя
+func (e Evaluator) String() string {
+	switch e {
+	case EvaluatorIF97:
+		return "IF-97"
+	case EvaluatorSBTL:
+		return "SBTL"
+	default:
+		return fmt.Sprintf("Evaluator(%d)", int(e))
+	}
+}
+
+// Таблицы SBTL строятся один раз на процесс при первом выборе вычислителя
+var (
+	sbtlOnce   sync.Once
+	sbtlTables *sbtl.Tables
+	sbtlErr    error
+)
+
+// sbtlSaturationGap — полуширина полосы у линии насыщения между psat(350 °C)
+// и критическим давлением, которая не входит в таблицы SBTL, К
+const sbtlSaturationGap = 1.0
+
+var errSaturationSBTL = &propserr.Error{Code: propserr.RegionNotSupported, Region: 4, Detail: "состояние у линии насыщения не входит в таблицы SBTL"}
+
+func loadSBTL() (*sbtl.Tables, error) {
+	sbtlOnce.Do(func() {
+		calc := NewCalculator()
+		// Таблицы насыщения строятся до psat(350 °C); выше одна таблица
+		// охватывает 0..800 °C вместе с Region 3, который рассчитывается по
+		// f(ρ,T), как в FromTP. До критического давления эта таблица
+		// пересекает линию насыщения, поэтому узлы ближе sbtlSaturationGap к
+		// ней не заполняются: влажный пар и соседние состояния CalculatePH и
+		// CalculateVU рассчитывают по IF-97.
+		psatMax, err := region4.SaturationPressure(region1TemperatureMax + 273.15)
+		if err != nil {
+			sbtlErr = err
+			return
+		}
+		sbtlTables, sbtlErr = sbtl.Generate(sbtl.Source{
+			Properties: func(t, p float64) (calc_core.Properties, error) {
+				if p >= psatMax && p < criticalPressure {
+					if tsat, err := region4.SaturationTemperature(p); err == nil && math.Abs(t+273.15-tsat) < sbtlSaturationGap {
+						return calc_core.Properties{}, errSaturationSBTL
+					}
+				}
+				props, _, err := calc.calculateFromTP(t, p)
+				return props, err
+			},
+			Saturation: func(p float64) (float64, calc_core.Properties, calc_core.Properties, error) {
+				sat, err := calc.SaturationAtPressure(p)
+				if err != nil {
+					return 0, calc_core.Properties{}, calc_core.Properties{}, err
+				}
+				return sat.Temperature, sat.Liquid, sat.Vapor, nil
+			},
+			SaturationPressureMax: psatMax,
+		})
+	})
+	return sbtlTables, sbtlErr
+}
+
+// SetEvaluator выбирает вычислитель для CalculatePH и CalculateVU.
+// При первом выборе EvaluatorSBTL строятся таблицы (несколько секунд).
+func (c *Calculator) SetEvaluator(e Evaluator) error {
+	switch e {
+	case EvaluatorIF97:
+	case EvaluatorSBTL:
+		if _, err := loadSBTL(); err != nil {
+			return i18n.Errorf("ошибка построения таблиц SBTL: %w", err)
+		}
+	default:
+		return propserr.Newf(propserr.InvalidInput, "неизвестный вычислитель: %v", e)
+	}
+	c.evaluator = e
+	return nil
+}
+
+// Evaluator возвращает выбранный вычислитель
+func (c *Calculator) Evaluator() Evaluator {
+	return c.evaluator
+}
+
+// CalculateVU рассчитывает свойства по удельному объему (м³/кг) и удельной
+// внутренней энергии (кДж/кг) выбранным вычислителем (см. SetEvaluator).
+// Состояния вне таблиц SBTL рассчитываются по уравнениям IF-97.
+func (c *Calculator) CalculateVU(volume, energy float64) (*Result, error) {
+	if c.evaluator != EvaluatorSBTL {
+		return c.calculateVUFromEquations(volume, energy)
+	}
+	st, err := sbtlTables.VU(volume, energy)
+	if errors.Is(err, sbtl.ErrOutOfRange) {
+		return c.calculateVUFromEquations(volume, energy)
+	}
+	if err != nil {
+		return nil, i18n.Errorf("ошибка расчета по v,u: %w", err)
+	}
+	return c.resultFromSBTL(st), nil
+}
+
+// calculatePHFromTables рассчитывает свойства по (p, h) по таблицам SBTL,
+// а состояния вне таблиц — по уравнениям IF-97
+func (c *Calculator) calculatePHFromTables(pressure, enthalpy float64) (*Result, error) {
+	if err := pressureInRange(pressure); err != nil {
+		return nil, i18n.Errorf("ошибка расчета по p,h: %w", err)
+	}
+	st, err := sbtlTables.PH(pressure, enthalpy)
+	if errors.Is(err, sbtl.ErrOutOfRange) {
+		return c.calculatePHFromEquations(pressure, enthalpy)
+	}
+	if err != nil {
+		return nil, i18n.Errorf("ошибка расчета по p,h: %w", err)
+	}
+	return c.resultFromSBTL(st), nil
+}
+
+// resultFromSBTL определяет регион IF-97 состояния, рассчитанного по таблицам
+func (c *Calculator) resultFromSBTL(st sbtl.State) *Result {
+	var region calc_core.Region
+	switch st.Phase {
+	case sbtl.TwoPhase:
+		region = calc_core.Region4
+	case sbtl.Liquid:
+		region = calc_core.Region1
+	case sbtl.Vapor:
+		region = calc_core.Region2
+	default:
+		region = calc_core.RegionFromTP(st.Temperature+273.15, st.Pressure)
+	}
+	return c.newResult(st.Properties, region, st.Temperature, st.Pressure, st.Quality)
+}
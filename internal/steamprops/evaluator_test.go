@@ -0,0 +1,294 @@
+package steamprops
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/somepgs/steamprops/internal/calc_core"
+	"github.com/somepgs/steamprops/internal/calc_core/bounds"
+	"github.com/somepgs/steamprops/internal/calc_core/region4"
+	"github.com/somepgs/steamprops/internal/calc_core/sbtl"
+)
+
+func TestCalculator_SetEvaluator(t *testing.T) {
+	calc := NewCalculator()
+	if calc.Evaluator() != EvaluatorIF97 {
+		t.Errorf("default evaluator = %v, want %v", calc.Evaluator(), EvaluatorIF97)
+	}
+	if err := calc.SetEvaluator(Evaluator(7)); err == nil {
+		t.Errorf("expected error for unknown evaluator")
+	}
+	if err := calc.SetEvaluator(EvaluatorSBTL); err != nil {
+		t.Fatalf("SetEvaluator: %v", err)
+	}
+	if calc.Evaluator() != EvaluatorSBTL || calc.Evaluator().String() != "SBTL" {
+		t.Errorf("evaluator = %v, want SBTL", calc.Evaluator())
+	}
+}
+
+func TestCalculator_CalculatePH_SBTL(t *testing.T) {
+	ref := NewCalculator()
+	calc := NewCalculator()
+	if err := calc.SetEvaluator(EvaluatorSBTL); err != nil {
+		t.Fatalf("SetEvaluator: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		t, p   float64
+		region calc_core.Region
+	}{
+		{"вода", 20, 101325, calc_core.Region1},
+		{"вода под давлением", 250, 10e6, calc_core.Region1},
+		{"перегретый пар", 300, 1e6, calc_core.Region2},
+		{"пар низкого давления", 50, 5000, calc_core.Region2},
+		{"вода при 15 МПа", 200, 15e6, calc_core.Region1},
+		{"пар при 25 МПа", 600, 25e6, calc_core.Region2},
+		{"вода при 30 МПа", 300, 30e6, calc_core.Region1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := ref.Calculate(&InputData{Mode: "TP", Temperature: tt.t, Pressure: tt.p})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := calc.CalculatePH(tt.p, want.Properties.SpecificEnthalpy)
+			if err != nil {
+				t.Fatalf("CalculatePH: %v", err)
+			}
+			if got.Region != tt.region {
+				t.Errorf("region = %d, want %d", got.Region, tt.region)
+			}
+			if d := math.Abs(got.Temperature - tt.t); d > sbtl.Tolerance.Temperature {
+				t.Errorf("ΔT = %g K", d)
+			}
+			if d := math.Abs(got.Properties.SpecificVolume/want.Properties.SpecificVolume - 1); d > sbtl.Tolerance.Volume {
+				t.Errorf("δv = %g", d)
+			}
+			if d := math.Abs(got.Properties.SpecificEntropy - want.Properties.SpecificEntropy); d > sbtl.Tolerance.Entropy {
+				t.Errorf("Δs = %g", d)
+			}
+		})
+	}
+
+	// Влажный пар
+	sat, err := ref.SaturationAtPressure(101325)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := sat.Liquid.SpecificEnthalpy + 0.4*(sat.Vapor.SpecificEnthalpy-sat.Liquid.SpecificEnthalpy)
+	got, err := calc.CalculatePH(101325, h)
+	if err != nil {
+		t.Fatalf("CalculatePH: %v", err)
+	}
+	if got.Region != calc_core.Region4 || math.Abs(got.Quality-0.4) > 1e-6 {
+		t.Errorf("region = %d, x = %g, want Region 4, x = 0.4", got.Region, got.Quality)
+	}
+
+	if _, err := calc.CalculatePH(200e6, 1000); err == nil {
+		t.Errorf("expected error above 100 MPa")
+	}
+}
+
+func TestCalculator_CalculatePH_SBTLRegion3(t *testing.T) {
+	tables, err := loadSBTL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checked, total := 0, 0
+	for p := 17e6; p <= 100e6; p *= 1.05 {
+		tB23K, err := bounds.B23T(p / 1e6)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tB23 := tB23K - 273.15
+		for temp := 350.3; temp < tB23; temp += 0.9 {
+			st, err := FromTP(temp, p)
+			if err != nil || st.Region() != calc_core.Region3 {
+				continue
+			}
+			if tsat, err := region4.SaturationTemperature(p); err == nil && math.Abs(temp+273.15-tsat) < 2*sbtlSaturationGap {
+				continue // полоса у линии насыщения рассчитывается по IF-97
+			}
+			total++
+			want := st.Properties()
+			got, err := tables.PH(p, want.SpecificEnthalpy)
+			if errors.Is(err, sbtl.ErrOutOfRange) {
+				continue
+			}
+			if err != nil {
+				t.Fatalf("PH(%g, %g): %v", p, want.SpecificEnthalpy, err)
+			}
+			checked++
+
+			tol := sbtl.Tolerance
+			if temp < 355 || temp > tB23-5 || p <= 30e6 && temp <= 420 {
+				tol = sbtl.BoundaryTolerance
+			}
+			if d := math.Abs(got.Temperature - temp); d > tol.Temperature {
+				t.Errorf("p=%g T=%g: ΔT = %g K", p, temp, d)
+			}
+			if d := math.Abs(got.Properties.SpecificVolume/want.SpecificVolume - 1); d > tol.Volume {
+				t.Errorf("p=%g T=%g: δv = %g", p, temp, d)
+			}
+			if d := math.Abs(got.Properties.SpecificEntropy - want.SpecificEntropy); d > tol.Entropy {
+				t.Errorf("p=%g T=%g: Δs = %g", p, temp, d)
+			}
+			if d := math.Abs(got.Properties.SpecificIsobaricHeatCapacity/want.SpecificIsobaricHeatCapacity - 1); d > tol.HeatCapacity {
+				t.Errorf("p=%g T=%g: δcp = %g", p, temp, d)
+			}
+			if d := math.Abs(got.Properties.SpecificIsochoricHeatCapacity/want.SpecificIsochoricHeatCapacity - 1); d > tol.HeatCapacity {
+				t.Errorf("p=%g T=%g: δcv = %g", p, temp, d)
+			}
+			if d := math.Abs(got.Properties.SpeedOfSound/want.SpeedOfSound - 1); d > tol.SpeedOfSound {
+				t.Errorf("p=%g T=%g: δw = %g", p, temp, d)
+			}
+		}
+	}
+	// Вне таблиц остаются только ячейки у границ региона
+	if checked < 2000 || checked < total*9/10 {
+		t.Errorf("%d of %d Region 3 states evaluated from the tables", checked, total)
+	}
+
+	calc := NewCalculator()
+	if err := calc.SetEvaluator(EvaluatorSBTL); err != nil {
+		t.Fatalf("SetEvaluator: %v", err)
+	}
+	got, err := calc.CalculatePH(25e6, 2000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := tables.PH(25e6, 2000)
+	if err != nil || got.Region != calc_core.Region3 || got.Temperature != st.Temperature {
+		t.Errorf("CalculatePH(25 МПа, 2000 кДж/кг): region %d T=%g, want Region 3 from the tables (%v)", got.Region, got.Temperature, err)
+	}
+}
+
+func TestCalculator_CalculateVU(t *testing.T) {
+	calc := NewCalculator()
+	if err := calc.SetEvaluator(EvaluatorSBTL); err != nil {
+		t.Fatalf("SetEvaluator: %v", err)
+	}
+	for _, in := range [][2]float64{{101325, 400}, {101325, 1500}, {1e6, 3000}, {15e6, 900}, {25e6, 3500}, {25e6, 2000}, {50e6, 1800}} {
+		want, err := calc.CalculatePH(in[0], in[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := calc.CalculateVU(want.Properties.SpecificVolume, want.Properties.SpecificInternalEnergy)
+		if err != nil {
+			t.Fatalf("CalculateVU(p=%g, h=%g): %v", in[0], in[1], err)
+		}
+		if math.Abs(got.Pressure/in[0]-1) > 1e-5 || math.Abs(got.Properties.SpecificEnthalpy-in[1]) > 1e-6 {
+			t.Errorf("CalculateVU: p=%g h=%g, want p=%g h=%g", got.Pressure, got.Properties.SpecificEnthalpy, in[0], in[1])
+		}
+		if got.Region != want.Region || math.Abs(got.Quality-want.Quality) > 1e-9 {
+			t.Errorf("CalculateVU: region %d x=%g, want region %d x=%g", got.Region, got.Quality, want.Region, want.Quality)
+		}
+	}
+}
+
+func TestCalculator_SBTLFallback(t *testing.T) {
+	ref := NewCalculator()
+	calc := NewCalculator()
+	if err := calc.SetEvaluator(EvaluatorSBTL); err != nil {
+		t.Fatalf("SetEvaluator: %v", err)
+	}
+	// Влажный пар и полоса у линии насыщения выше psat(350 °C) не входят в
+	// таблицы
+	near, err := ref.Calculate(&InputData{Mode: "TP", Temperature: 365.5, Pressure: 19e6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, in := range [][2]float64{{20e6, 2000}, {21.5e6, 2100}, {19e6, near.Properties.SpecificEnthalpy}} {
+		want, err := ref.CalculatePH(in[0], in[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := calc.CalculatePH(in[0], in[1])
+		if err != nil {
+			t.Fatalf("CalculatePH(%g, %g): %v", in[0], in[1], err)
+		}
+		if got.Region != want.Region || math.Abs(got.Temperature-want.Temperature) > 1e-9 ||
+			math.Abs(got.Properties.SpecificVolume/want.Properties.SpecificVolume-1) > 1e-12 {
+			t.Errorf("CalculatePH(%g, %g): region %d T=%g v=%g, want region %d T=%g v=%g", in[0], in[1],
+				got.Region, got.Temperature, got.Properties.SpecificVolume, want.Region, want.Temperature, want.Properties.SpecificVolume)
+		}
+
+		v, u := want.Properties.SpecificVolume, want.Properties.SpecificInternalEnergy
+		wantVU, err := ref.CalculateVU(v, u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gotVU, err := calc.CalculateVU(v, u)
+		if err != nil {
+			t.Fatalf("CalculateVU(%g, %g): %v", v, u, err)
+		}
+		if gotVU.Region != wantVU.Region || math.Abs(gotVU.Pressure/wantVU.Pressure-1) > 1e-12 ||
+			math.Abs(gotVU.Temperature-wantVU.Temperature) > 1e-9 {
+			t.Errorf("CalculateVU(%g, %g): region %d p=%g T=%g, want region %d p=%g T=%g", v, u,
+				gotVU.Region, gotVU.Pressure, gotVU.Temperature, wantVU.Region, wantVU.Pressure, wantVU.Temperature)
+		}
+	}
+	if r, _ := ref.CalculatePH(20e6, 2000); r.Region != calc_core.Region4 {
+		t.Errorf("CalculatePH(20 МПа, 2000 кДж/кг): region %d, want Region 4", r.Region)
+	}
+}
+
+func BenchmarkCalculatePH_IF97(b *testing.B) {
+	calc := NewCalculator()
+	for i := 0; i < b.N; i++ {
+		if _, err := calc.CalculatePH(1e6, 1000+float64(i%2000)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCalculatePH_SBTL(b *testing.B) {
+	calc := NewCalculator()
+	if err := calc.SetEvaluator(EvaluatorSBTL); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := calc.CalculatePH(1e6, 1000+float64(i%2000)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Region 3: p = 25 МПа, h = 1950..2050 кДж/кг
+func BenchmarkCalculatePH_IF97Region3(b *testing.B) {
+	calc := NewCalculator()
+	for i := 0; i < b.N; i++ {
+		if _, err := calc.CalculatePH(25e6, 1950+float64(i%100)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCalculatePH_SBTLRegion3(b *testing.B) {
+	calc := NewCalculator()
+	if err := calc.SetEvaluator(EvaluatorSBTL); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := calc.CalculatePH(25e6, 1950+float64(i%100)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCalculateVU_SBTL(b *testing.B) {
+	calc := NewCalculator()
+	if err := calc.SetEvaluator(EvaluatorSBTL); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := calc.CalculateVU(0.2, 1000+float64(i%1500)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
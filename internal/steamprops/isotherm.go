@@ -0,0 +1,189 @@
+package steamprops
+
+import (
+	"math"
+	"sort"
+
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/internal/calc_core"
+	"github.com/somepgs/steamprops/propserr"
+)
+
+// AmbiguousStateError возвращается расчетами по T,h и T,s, когда заданным
+// параметрам соответствует несколько состояний. Например, при энтальпии чуть
+// выше энтальпии насыщенной жидкости ей соответствуют влажный пар при давлении
+// насыщения и сжатая жидкость при высоком давлении. Solutions содержит все
+// найденные состояния в порядке возрастания давления.
+type AmbiguousStateError struct {
+	Inputs    string // пара параметров: "T,h" или "T,s"
+	Solutions []*Result
+}
+
+// Error перечисляет найденные решения
+func (e *AmbiguousStateError) Error() string { return i18n.Russian.Error(e) }
+
+// Message возвращает формат и аргументы сообщения для перевода
+func (e *AmbiguousStateError) Message() (string, []interface{}) {
+	parts := make([]i18n.Msg, len(e.Solutions))
+	for i, r := range e.Solutions {
+		if r.Region == calc_core.Region4 {
+			parts[i] = i18n.M("p=%.6g Па, x=%.4g", r.Pressure, r.Quality)
+		} else {
+			parts[i] = i18n.M("p=%.6g Па, Region %d", r.Pressure, int(r.Region))
+		}
+	}
+	return "неоднозначное состояние по %s: %d решения (%s)", []interface{}{e.Inputs, len(e.Solutions), i18n.Join("; ", parts)}
+}
+
+// ErrorCode возвращает propserr.Ambiguous
+func (e *AmbiguousStateError) ErrorCode() propserr.Code { return propserr.Ambiguous }
+
+// CalculateTH рассчитывает свойства по температуре (°C) и энтальпии (кДж/кг).
+// Если решений несколько, возвращается *AmbiguousStateError.
+func (c *Calculator) CalculateTH(temperature, enthalpy float64) (*Result, error) {
+	return c.calculateFromTemperature("T,h", temperature, enthalpy, func(p calc_core.Properties) float64 {
+		return p.SpecificEnthalpy
+	})
+}
+
+// CalculateTS рассчитывает свойства по температуре (°C) и энтропии (кДж/(кг·К)).
+// Если решений несколько (вода около 0°C, где энтропия на изотерме
+// немонотонна по давлению), возвращается *AmbiguousStateError.
+func (c *Calculator) CalculateTS(temperature, entropy float64) (*Result, error) {
+	return c.calculateFromTemperature("T,s", temperature, entropy, func(p calc_core.Properties) float64 {
+		return p.SpecificEntropy
+	})
+}
+
+// isothermBranch участок изотермы с однозначной зависимостью свойств от давления
+type isothermBranch struct {
+	from, to float64               // Pa; from — начало обхода (линия насыщения или minPressure)
+	start    *calc_core.Properties // свойства в точке from, если известны (насыщение)
+	at       func(p float64) (calc_core.Properties, calc_core.Region, error)
+}
+
+// calculateFromTemperature находит все состояния на изотерме, в которых
+// свойство равно value. В отличие от изобары, на изотерме энтальпия
+// немонотонна по давлению (в паре убывает, в жидкости растет), поэтому
+// корни ищутся по сетке ln p на каждой ветви и уточняются методом Иллинойс.
+// Ниже критической температуры к ветвям добавляется двухфазный отрезок
+// при давлении насыщения.
+func (c *Calculator) calculateFromTemperature(inputs string, temperature, value float64, property propertyOf) (*Result, error) {
+	const (
+		gridSize = 100
+		maxIter  = 100
+	)
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, propserr.Newf(propserr.InvalidInput, "ошибка расчета по %s: недопустимое значение свойства: %v", inputs, value)
+	}
+	if err := temperatureInRange(temperature); err != nil {
+		return nil, i18n.Errorf("ошибка расчета по %s: %w", inputs, err)
+	}
+
+	pMax := maxPressure
+	if temperature > region2TemperatureMax {
+		pMax = region5PressureMax
+	}
+	tol := 1e-13 * math.Max(1, math.Abs(value))
+	satTol := 0.0 // невязки на линии насыщения в пределах satTol считаются нулевыми
+
+	var roots []isothermState
+	var branches []isothermBranch
+	if temperature < criticalTemperature {
+		sat, err := c.SaturationAtTemperature(temperature)
+		if err != nil {
+			return nil, i18n.Errorf("ошибка расчета по %s: %w", inputs, err)
+		}
+		yf, yg := property(sat.Liquid), property(sat.Vapor)
+		// Значения у линии насыщения относим к двухфазной области, как в calculateFromPressure
+		satTol = 1e-8 * (math.Abs(yf) + math.Abs(yg))
+		if value >= math.Min(yf, yg)-satTol && value <= math.Max(yf, yg)+satTol {
+			x := math.Min(math.Max((value-yf)/(yg-yf), 0), 1)
+			roots = append(roots, isothermState{sat.Mixture(x), calc_core.Region4, sat.Pressure, x})
+		}
+		liquid, vapor := sat.Liquid, sat.Vapor
+		branches = append(branches, isothermBranch{sat.Pressure, pMax, &liquid, func(p float64) (calc_core.Properties, calc_core.Region, error) {
+			return c.liquidAt(temperature, p)
+		}})
+		// Ниже тройной точки (0..0.01°C) пара в области IF-97 нет
+		if sat.Pressure > minPressure {
+			branches = append(branches, isothermBranch{sat.Pressure, minPressure, &vapor, func(p float64) (calc_core.Properties, calc_core.Region, error) {
+				return c.vaporAt(temperature, p)
+			}})
+		}
+	} else {
+		branches = append(branches, isothermBranch{minPressure, pMax, nil, func(p float64) (calc_core.Properties, calc_core.Region, error) {
+			return c.calculateFromTP(temperature, p)
+		}})
+	}
+
+	for _, br := range branches {
+		a, b := math.Log(br.from), math.Log(br.to)
+		pLo, pHi := math.Min(br.from, br.to), math.Max(br.from, br.to)
+		// node вычисляет невязку при p = e^x; ok = false, если уравнения
+		// не определены при этом давлении
+		node := func(x float64) (isothermState, float64, bool) {
+			// exp(log(p)) может выйти за границу ветви на единицу округления
+			p := math.Min(math.Max(math.Exp(x), pLo), pHi)
+			props, region, err := br.at(p)
+			if err != nil {
+				return isothermState{}, 0, false
+			}
+			return isothermState{props, region, p, -1}, property(props) - value, true
+		}
+
+		var prevR float64
+		var prevOK bool
+		if br.start != nil {
+			// Корень на линии насыщения уже учтен двухфазным отрезком
+			prevR, prevOK = property(*br.start)-value, true
+			if math.Abs(prevR) <= satTol {
+				prevR = 0
+			}
+		} else if st, r, ok := node(a); ok {
+			if r == 0 {
+				roots = append(roots, st)
+			}
+			prevR, prevOK = r, true
+		}
+		prevX := a
+		for i := 1; i <= gridSize; i++ {
+			x := a + (b-a)*float64(i)/gridSize
+			st, r, ok := node(x)
+			switch {
+			case !ok || !prevOK:
+			case r == 0:
+				roots = append(roots, st)
+			case prevR != 0 && (prevR < 0) != (r < 0):
+				var root isothermState
+				_, err := illinois(prevX, x, prevR, r, tol, 1e-12, maxIter, func(x float64) (float64, error) {
+					var r float64
+					var ok bool
+					if root, r, ok = node(x); !ok {
+						return 0, propserr.Newf(propserr.NoSolution, "уравнения не определены при p=%.6g Па", math.Exp(x))
+					}
+					return r, nil
+				})
+				if err != nil {
+					return nil, i18n.Errorf("ошибка расчета по %s: %w при T=%.2f°C", inputs, err, temperature)
+				}
+				roots = append(roots, root)
+			}
+			prevX, prevR, prevOK = x, r, ok
+		}
+	}
+
+	switch len(roots) {
+	case 0:
+		return nil, propserr.Newf(propserr.NoSolution, "ошибка расчета по %s: нет состояния со значением %.6g при T=%.2f°C в области IF-97", inputs, value, temperature)
+	case 1:
+		st := roots[0]
+		return c.newResult(st.props, st.region, temperature, st.pressure, st.quality), nil
+	}
+	sort.Slice(roots, func(i, j int) bool { return roots[i].pressure < roots[j].pressure })
+	amb := &AmbiguousStateError{Inputs: inputs}
+	for _, st := range roots {
+		amb.Solutions = append(amb.Solutions, c.newResult(st.props, st.region, temperature, st.pressure, st.quality))
+	}
+	return nil, amb
+}
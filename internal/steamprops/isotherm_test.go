@@ -0,0 +1,112 @@
+package steamprops
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/somepgs/steamprops/internal/calc_core"
+)
+
+func TestCalculator_CalculateTS(t *testing.T) {
+	calc := NewCalculator()
+	tests := []struct {
+		name    string
+		t, p, x float64 // x < 0 для однофазных состояний
+	}{
+		{"вода под давлением", 300, 80e6, -1},
+		{"перегретый пар", 500, 10e6, -1},
+		{"жидкость в Region 3", 360, 50e6, -1},
+		{"сверхкритическая область", 400, 30e6, -1},
+		{"высокотемпературный газ", 1500, 5e6, -1},
+		{"влажный пар", 0, 101325, 0.3},
+		{"влажный пар в Region 3", 0, 20e6, 0.7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := densityReference(t, calc, tt.t, tt.p, tt.x)
+			got, err := calc.Calculate(&InputData{Mode: "TS", Temperature: want.Temperature, Entropy: want.Properties.SpecificEntropy})
+			if err != nil {
+				t.Fatalf("CalculateTS: %v", err)
+			}
+			if got.Region != want.Region || math.Abs(got.Quality-want.Quality) > 1e-8 {
+				t.Errorf("region %d x=%g, want region %d x=%g", got.Region, got.Quality, want.Region, want.Quality)
+			}
+			if math.Abs(got.Pressure/want.Pressure-1) > 1e-7 {
+				t.Errorf("p = %.3f Pa, want %.3f Pa", got.Pressure, want.Pressure)
+			}
+		})
+	}
+
+	// Ниже температуры максимальной плотности энтропия жидкости растет с давлением
+	want := densityReference(t, calc, 2, 1e6, -1)
+	_, err := calc.CalculateTS(2, want.Properties.SpecificEntropy)
+	var amb *AmbiguousStateError
+	if !errors.As(err, &amb) {
+		t.Fatalf("expected AmbiguousStateError for water at 2°C, got %v", err)
+	}
+	found := false
+	for _, r := range amb.Solutions {
+		if math.Abs(r.Properties.SpecificEntropy-want.Properties.SpecificEntropy) > 1e-9 {
+			t.Errorf("solution at p=%.0f Pa has s=%.12f, want %.12f", r.Pressure, r.Properties.SpecificEntropy, want.Properties.SpecificEntropy)
+		}
+		found = found || math.Abs(r.Pressure/want.Pressure-1) < 1e-6
+	}
+	if !found {
+		t.Errorf("solutions %v do not contain p = 1 MPa", amb)
+	}
+}
+
+func TestCalculator_CalculateTH(t *testing.T) {
+	calc := NewCalculator()
+	tests := []struct {
+		name    string
+		t, p, x float64
+	}{
+		{"перегретый пар", 500, 10e6, -1},
+		{"пар у границы B23", 400, 20e6, -1},
+		{"пар в Region 3", 370, 20e6, -1},
+		{"сверхкритическая область", 400, 30e6, -1},
+		{"высокотемпературный газ", 1500, 5e6, -1},
+		{"влажный пар", 0, 101325, 0.7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := densityReference(t, calc, tt.t, tt.p, tt.x)
+			got, err := calc.Calculate(&InputData{Mode: "TH", Temperature: want.Temperature, Enthalpy: want.Properties.SpecificEnthalpy})
+			if err != nil {
+				t.Fatalf("CalculateTH: %v", err)
+			}
+			if got.Region != want.Region || math.Abs(got.Quality-want.Quality) > 1e-8 {
+				t.Errorf("region %d x=%g, want region %d x=%g", got.Region, got.Quality, want.Region, want.Quality)
+			}
+			if math.Abs(got.Pressure/want.Pressure-1) > 1e-6 {
+				t.Errorf("p = %.3f Pa, want %.3f Pa", got.Pressure, want.Pressure)
+			}
+		})
+	}
+
+	// Энтальпия чуть выше h' при 100°C: влажный пар и сжатая жидкость
+	_, err := calc.CalculateTH(100, 430)
+	var amb *AmbiguousStateError
+	if !errors.As(err, &amb) {
+		t.Fatalf("expected AmbiguousStateError, got %v", err)
+	}
+	if len(amb.Solutions) != 2 || amb.Solutions[0].Region != calc_core.Region4 || amb.Solutions[1].Region != calc_core.Region1 {
+		t.Fatalf("solutions = %v, want wet steam and compressed liquid", amb)
+	}
+	for _, r := range amb.Solutions {
+		if math.Abs(r.Properties.SpecificEnthalpy-430) > 1e-9 || r.Temperature != 100 {
+			t.Errorf("solution T=%g h=%.12f, want T=100 h=430", r.Temperature, r.Properties.SpecificEnthalpy)
+		}
+	}
+	if amb.Solutions[1].Pressure < 10e6 {
+		t.Errorf("compressed liquid at p = %.0f Pa, want above 10 MPa", amb.Solutions[1].Pressure)
+	}
+
+	for _, in := range [][2]float64{{100, 2700}, {-5, 100}, {2100, 3000}, {100, math.NaN()}} {
+		if _, err := calc.CalculateTH(in[0], in[1]); err == nil || errors.As(err, &amb) {
+			t.Errorf("expected error without solutions for T=%g, h=%g, got %v", in[0], in[1], err)
+		}
+	}
+}
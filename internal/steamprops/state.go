@@ -0,0 +1,396 @@
+package steamprops
+
+import (
+	"fmt"
+
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/internal/calc_core"
+	"github.com/somepgs/steamprops/internal/calc_core/bounds"
+	"github.com/somepgs/steamprops/internal/calc_core/region1"
+	"github.com/somepgs/steamprops/internal/calc_core/region2"
+	"github.com/somepgs/steamprops/internal/calc_core/region3"
+	"github.com/somepgs/steamprops/internal/calc_core/region4"
+	"github.com/somepgs/steamprops/internal/calc_core/region5"
+	"github.com/somepgs/steamprops/propserr"
+)
+
+// Параметры критической точки, разделяющие подобласти и фазы
+const (
+	criticalDensity = 322.0            // кг/м³
+	criticalEntropy = 4.41202148223476 // кДж/(кг·К), граница подобластей 3a и 3b
+	subregion2aMax  = 4e6              // Па, граница подобластей 2a и 2b/2c
+)
+
+// Subregion — подобласть IF-97, по которой выбираются обратные уравнения
+type Subregion int
+
+const (
+	SubregionNone Subregion = iota // регион без подобластей
+	Subregion2a                    // Region 2, p ≤ 4 МПа
+	Subregion2b                    // Region 2, p > 4 МПа, ниже границы B2bc
+	Subregion2c                    // Region 2, p > 4 МПа, выше границы B2bc
+	Subregion3a                    // Region 3, s ≤ sc (жидкостная сторона)
+	Subregion3b                    // Region 3, s > sc (паровая сторона)
+)
+
+// String возвращает обозначение подобласти, например "3a"
+func (s Subregion) String() string {
+	switch s {
+	case SubregionNone:
+		return "-"
+	case Subregion2a:
+		return "2a"
+	case Subregion2b:
+		return "2b"
+	case Subregion2c:
+		return "2c"
+	case Subregion3a:
+		return "3a"
+	case Subregion3b:
+		return "3b"
+	}
+	return fmt.Sprintf("Subregion(%d)", int(s))
+}
+
+// Phase — фазовое состояние воды
+type Phase int
+
+const (
+	PhaseLiquid        Phase = iota // жидкость
+	PhaseVapor                      // пар, в том числе газ выше критической температуры при давлении ниже критического
+	PhaseTwoPhase                   // влажный пар на линии насыщения
+	PhaseSupercritical              // сверхкритический флюид: T ≥ Tc и p ≥ pc
+)
+
+// String возвращает название фазы на русском языке; перевод — через
+// i18n.Locale.T(p.String())
+func (p Phase) String() string {
+	switch p {
+	case PhaseLiquid:
+		return i18n.N("Жидкость")
+	case PhaseVapor:
+		return i18n.N("Пар")
+	case PhaseTwoPhase:
+		return i18n.N("Влажный пар")
+	case PhaseSupercritical:
+		return i18n.N("Сверхкритический флюид")
+	}
+	return fmt.Sprintf("Phase(%d)", int(p))
+}
+
+// State — состояние воды или пара. Конструкторы FromTP, FromPH и другие
+// находят точку состояния и один раз вычисляют безразмерные производные
+// основного уравнения ее региона: энергии Гиббса γ(π, τ) в регионах 1, 2 и 5
+// или энергии Гельмгольца φ(δ, τ) в Region 3. Свойства рассчитываются по
+// запросу из этих производных, поэтому, например, cp после h не требует
+// повторного суммирования рядов. Во влажном паре свойства смешиваются из
+// свойств насыщенных фаз.
+//
+// State не изменяется после создания и безопасен для одновременного чтения.
+type State struct {
+	region      calc_core.Region
+	temperature float64 // °C
+	pressure    float64 // Па
+	quality     float64 // -1 вне Region 4
+
+	gibbs      calc_core.Gibbs     // Region 1, 2 и 5
+	helmholtz  calc_core.Helmholtz // Region 3
+	saturation *SaturationState    // Region 4
+}
+
+// stateCalculator решает обратные задачи для конструкторов State; строковые
+// транспортные свойства ему не нужны
+var stateCalculator = &Calculator{skipTransport: true}
+
+// FromTP создает состояние по температуре (°C) и давлению (Па). Регион
+// определяется по границам IF-97; на линии насыщения выбирается жидкость.
+func FromTP(temperature, pressure float64) (*State, error) {
+	region := calc_core.RegionFromTP(temperature+273.15, pressure)
+	if region == calc_core.Region4 {
+		// Точка в пределах допуска линии насыщения: сторона выбирается по давлению
+		region = calc_core.Region2
+		if psat, err := region4.SaturationPressure(temperature + 273.15); err == nil && pressure >= psat {
+			region = calc_core.Region1
+		}
+	}
+	return FromTPRegion(region, temperature, pressure)
+}
+
+// FromTPRegion создает состояние по температуре (°C) и давлению (Па) по
+// уравнению заданного региона 1, 2, 3 или 5 вместо определения региона по
+// границам IF-97
+func FromTPRegion(region calc_core.Region, temperature, pressure float64) (*State, error) {
+	s := &State{region: region, temperature: temperature, pressure: pressure, quality: -1}
+	var err error
+	switch region {
+	case calc_core.Region1:
+		s.gibbs, err = region1.Derivatives(temperature, pressure)
+	case calc_core.Region2:
+		s.gibbs, err = region2.Derivatives(temperature, pressure)
+	case calc_core.Region5:
+		s.gibbs, err = region5.Derivatives(temperature, pressure)
+	case calc_core.Region3:
+		var rho float64
+		if rho, err = region3.Density(temperature, pressure); err == nil {
+			s.helmholtz, err = region3.Derivatives(rho, temperature)
+		}
+	default:
+		return nil, &propserr.Error{Code: propserr.RegionNotSupported, Region: int(region), Detail: "расчет по T,P возможен только в регионах 1, 2, 3 и 5"}
+	}
+	if err != nil {
+		return nil, i18n.Errorf("ошибка расчета по T,P: %w", err)
+	}
+	return s, nil
+}
+
+// FromPH создает состояние по давлению (Па) и энтальпии (кДж/кг)
+func FromPH(pressure, enthalpy float64) (*State, error) {
+	return fromResult(stateCalculator.CalculatePH(pressure, enthalpy))
+}
+
+// FromPS создает состояние по давлению (Па) и энтропии (кДж/(кг·К))
+func FromPS(pressure, entropy float64) (*State, error) {
+	return fromResult(stateCalculator.CalculatePS(pressure, entropy))
+}
+
+// FromHS создает состояние по энтальпии (кДж/кг) и энтропии (кДж/(кг·К))
+// во всех регионах IF-97 (см. Calculator.CalculateHS)
+func FromHS(enthalpy, entropy float64) (*State, error) {
+	return fromResult(stateCalculator.CalculateHS(enthalpy, entropy))
+}
+
+// FromPX создает состояние влажного пара по давлению (Па) и паросодержанию
+func FromPX(pressure, quality float64) (*State, error) {
+	return fromResult(stateCalculator.CalculatePX(pressure, quality))
+}
+
+// FromTX создает состояние влажного пара по температуре (°C) и паросодержанию
+func FromTX(temperature, quality float64) (*State, error) {
+	return fromResult(stateCalculator.CalculateTX(temperature, quality))
+}
+
+// FromVU создает состояние по удельному объему (м³/кг) и внутренней энергии (кДж/кг)
+func FromVU(specificVolume, internalEnergy float64) (*State, error) {
+	return fromResult(stateCalculator.CalculateVU(specificVolume, internalEnergy))
+}
+
+// FromRhoT создает состояние по плотности (кг/м³) и температуре (°C)
+func FromRhoT(density, temperature float64) (*State, error) {
+	return fromResult(stateCalculator.CalculateRhoT(density, temperature))
+}
+
+// FromTH создает состояние по температуре (°C) и энтальпии (кДж/кг). При
+// нескольких решениях возвращается *AmbiguousStateError.
+func FromTH(temperature, enthalpy float64) (*State, error) {
+	return fromResult(stateCalculator.CalculateTH(temperature, enthalpy))
+}
+
+// FromTS создает состояние по температуре (°C) и энтропии (кДж/(кг·К)). При
+// нескольких решениях возвращается *AmbiguousStateError.
+func FromTS(temperature, entropy float64) (*State, error) {
+	return fromResult(stateCalculator.CalculateTS(temperature, entropy))
+}
+
+// fromResult создает состояние в точке, найденной обращением уравнений.
+// В Region 3 точку задает плотность, во влажном паре — линия насыщения и
+// паросодержание, в остальных регионах — температура и давление.
+func fromResult(r *Result, err error) (*State, error) {
+	if err != nil {
+		return nil, err
+	}
+	switch r.Region {
+	case calc_core.Region3:
+		f, err := region3.Derivatives(r.Properties.Density, r.Temperature)
+		if err != nil {
+			return nil, err
+		}
+		return &State{region: r.Region, temperature: r.Temperature, pressure: r.Pressure, quality: -1, helmholtz: f}, nil
+	case calc_core.Region4:
+		sat, err := stateCalculator.SaturationAtTemperature(r.Temperature)
+		if err != nil {
+			return nil, err
+		}
+		sat.Pressure = r.Pressure
+		return &State{region: r.Region, temperature: r.Temperature, pressure: r.Pressure, quality: r.Quality, saturation: sat}, nil
+	}
+	return FromTPRegion(r.Region, r.Temperature, r.Pressure)
+}
+
+// Region возвращает регион IF-97
+func (s *State) Region() calc_core.Region { return s.region }
+
+// Subregion возвращает подобласть регионов 2 и 3
+func (s *State) Subregion() Subregion {
+	switch s.region {
+	case calc_core.Region2:
+		switch {
+		case s.pressure <= subregion2aMax:
+			return Subregion2a
+		case s.pressure > bounds.B2bcP(s.SpecificEnthalpy())*1e6:
+			return Subregion2c
+		default:
+			return Subregion2b
+		}
+	case calc_core.Region3:
+		if s.SpecificEntropy() <= criticalEntropy {
+			return Subregion3a
+		}
+		return Subregion3b
+	}
+	return SubregionNone
+}
+
+// Phase возвращает фазовое состояние (см. PhaseOf)
+func (s *State) Phase() Phase {
+	return PhaseOf(s.region, s.temperature, s.pressure, s.helmholtz.Rho)
+}
+
+// PhaseOf определяет фазовое состояние по региону, температуре (°C), давлению
+// (Па) и плотности (кг/м³). Выше критических температуры и давления вода —
+// сверхкритический флюид; в Region 3 ниже них жидкость и пар различаются по
+// критической плотности.
+func PhaseOf(region calc_core.Region, temperature, pressure, density float64) Phase {
+	switch {
+	case region == calc_core.Region4:
+		return PhaseTwoPhase
+	case temperature >= criticalTemperature && pressure >= criticalPressure:
+		return PhaseSupercritical
+	case region == calc_core.Region1:
+		return PhaseLiquid
+	case region == calc_core.Region3 && density >= criticalDensity:
+		return PhaseLiquid
+	}
+	return PhaseVapor
+}
+
+// Quality возвращает паросодержание 0..1 во влажном паре и -1 вне его
+func (s *State) Quality() float64 { return s.quality }
+
+// Temperature возвращает температуру, °C
+func (s *State) Temperature() float64 { return s.temperature }
+
+// Pressure возвращает давление, Па
+func (s *State) Pressure() float64 { return s.pressure }
+
+// Saturation возвращает насыщенные фазы влажного пара; nil вне Region 4
+func (s *State) Saturation() *SaturationState { return s.saturation }
+
+// mix смешивает свойство насыщенных фаз по паросодержанию
+func (s *State) mix(property propertyOf) float64 {
+	liquid, vapor := property(s.saturation.Liquid), property(s.saturation.Vapor)
+	return liquid + s.quality*(vapor-liquid)
+}
+
+// SpecificVolume возвращает удельный объем, м³/кг
+func (s *State) SpecificVolume() float64 {
+	switch s.region {
+	case calc_core.Region3:
+		return s.helmholtz.SpecificVolume()
+	case calc_core.Region4:
+		return s.mix(func(p calc_core.Properties) float64 { return p.SpecificVolume })
+	}
+	return s.gibbs.SpecificVolume()
+}
+
+// Density возвращает плотность, кг/м³
+func (s *State) Density() float64 {
+	switch s.region {
+	case calc_core.Region3:
+		return s.helmholtz.Rho
+	case calc_core.Region4:
+		return 1.0 / s.SpecificVolume()
+	}
+	return s.gibbs.Density()
+}
+
+// SpecificInternalEnergy возвращает удельную внутреннюю энергию, кДж/кг
+func (s *State) SpecificInternalEnergy() float64 {
+	switch s.region {
+	case calc_core.Region3:
+		return s.helmholtz.SpecificInternalEnergy()
+	case calc_core.Region4:
+		return s.mix(func(p calc_core.Properties) float64 { return p.SpecificInternalEnergy })
+	}
+	return s.gibbs.SpecificInternalEnergy()
+}
+
+// SpecificEnthalpy возвращает удельную энтальпию, кДж/кг
+func (s *State) SpecificEnthalpy() float64 {
+	switch s.region {
+	case calc_core.Region3:
+		return s.helmholtz.SpecificEnthalpy()
+	case calc_core.Region4:
+		return s.mix(func(p calc_core.Properties) float64 { return p.SpecificEnthalpy })
+	}
+	return s.gibbs.SpecificEnthalpy()
+}
+
+// SpecificEntropy возвращает удельную энтропию, кДж/(кг·К)
+func (s *State) SpecificEntropy() float64 {
+	switch s.region {
+	case calc_core.Region3:
+		return s.helmholtz.SpecificEntropy()
+	case calc_core.Region4:
+		return s.mix(func(p calc_core.Properties) float64 { return p.SpecificEntropy })
+	}
+	return s.gibbs.SpecificEntropy()
+}
+
+// SpecificIsobaricHeatCapacity возвращает изобарную теплоемкость,
+// кДж/(кг·К). Для равновесной смеси во влажном паре теплоемкости и скорость
+// звука не определены и равны нулю, как в SaturationState.Mixture.
+func (s *State) SpecificIsobaricHeatCapacity() float64 {
+	switch s.region {
+	case calc_core.Region3:
+		return s.helmholtz.SpecificIsobaricHeatCapacity()
+	case calc_core.Region4:
+		return 0
+	}
+	return s.gibbs.SpecificIsobaricHeatCapacity()
+}
+
+// SpecificIsochoricHeatCapacity возвращает изохорную теплоемкость, кДж/(кг·К)
+func (s *State) SpecificIsochoricHeatCapacity() float64 {
+	switch s.region {
+	case calc_core.Region3:
+		return s.helmholtz.SpecificIsochoricHeatCapacity()
+	case calc_core.Region4:
+		return 0
+	}
+	return s.gibbs.SpecificIsochoricHeatCapacity()
+}
+
+// SpeedOfSound возвращает скорость звука, м/с
+func (s *State) SpeedOfSound() float64 {
+	switch s.region {
+	case calc_core.Region3:
+		return s.helmholtz.SpeedOfSound()
+	case calc_core.Region4:
+		return 0
+	}
+	return s.gibbs.SpeedOfSound()
+}
+
+// Properties возвращает все термодинамические свойства
+func (s *State) Properties() calc_core.Properties {
+	switch s.region {
+	case calc_core.Region3:
+		return s.helmholtz.Properties()
+	case calc_core.Region4:
+		return s.saturation.Mixture(s.quality)
+	}
+	return s.gibbs.Properties()
+}
+
+// Result возвращает состояние в виде результата калькулятора
+func (s *State) Result() *Result {
+	props := s.Properties()
+	return &Result{
+		Properties:  props,
+		Region:      s.region,
+		Phase:       stateCalculator.determinePhase(props, s.region),
+		Temperature: s.temperature,
+		Pressure:    s.pressure,
+		Quality:     s.quality,
+	}
+}
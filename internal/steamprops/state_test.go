@@ -0,0 +1,163 @@
+package steamprops
+
+import (
+	"math"
+	"testing"
+
+	"github.com/somepgs/steamprops/internal/calc_core"
+)
+
+func TestFromTP(t *testing.T) {
+	tests := []struct {
+		name        string
+		temperature float64
+		pressure    float64
+		region      calc_core.Region
+		subregion   Subregion
+		phase       Phase
+	}{
+		{"compressed liquid", 20, 1e6, calc_core.Region1, SubregionNone, PhaseLiquid},
+		{"steam 2a", 300, 1e6, calc_core.Region2, Subregion2a, PhaseVapor},
+		{"steam 2b", 500, 10e6, calc_core.Region2, Subregion2b, PhaseVapor},
+		{"steam 2c", 400, 20e6, calc_core.Region2, Subregion2c, PhaseVapor},
+		{"supercritical region 2", 500, 30e6, calc_core.Region2, Subregion2c, PhaseSupercritical},
+		{"liquid 3a", 360, 20e6, calc_core.Region3, Subregion3a, PhaseLiquid},
+		{"supercritical 3a", 380, 30e6, calc_core.Region3, Subregion3a, PhaseSupercritical},
+		{"supercritical 3b", 400, 30e6, calc_core.Region3, Subregion3b, PhaseSupercritical},
+		{"high temperature steam", 1000, 10e6, calc_core.Region5, SubregionNone, PhaseVapor},
+	}
+	calc := NewCalculator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, err := FromTP(tt.temperature, tt.pressure)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if st.Region() != tt.region || st.Subregion() != tt.subregion || st.Phase() != tt.phase {
+				t.Errorf("region %d, subregion %v, phase %v; want %d, %v, %v",
+					st.Region(), st.Subregion(), st.Phase(), tt.region, tt.subregion, tt.phase)
+			}
+			want, err := calc.Calculate(&InputData{Mode: "TP", Temperature: tt.temperature, Pressure: tt.pressure})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if st.Properties() != want.Properties {
+				t.Errorf("Properties() = %+v, want %+v", st.Properties(), want.Properties)
+			}
+			got := calc_core.Properties{
+				SpecificVolume:                st.SpecificVolume(),
+				Density:                       st.Density(),
+				SpecificInternalEnergy:        st.SpecificInternalEnergy(),
+				SpecificEntropy:               st.SpecificEntropy(),
+				SpecificEnthalpy:              st.SpecificEnthalpy(),
+				SpecificIsochoricHeatCapacity: st.SpecificIsochoricHeatCapacity(),
+				SpecificIsobaricHeatCapacity:  st.SpecificIsobaricHeatCapacity(),
+				SpeedOfSound:                  st.SpeedOfSound(),
+			}
+			if got != want.Properties {
+				t.Errorf("getters = %+v, want %+v", got, want.Properties)
+			}
+		})
+	}
+
+	if _, err := FromTPRegion(calc_core.Region1, 300, 1e6); err == nil {
+		t.Error("Region 1 equation accepted steam")
+	}
+	if _, err := FromTPRegion(calc_core.Region4, 100, 1e5); err == nil {
+		t.Error("Region 4 accepted for T,p")
+	}
+}
+
+func TestStateConstructors(t *testing.T) {
+	ref, err := FromTP(250, 5e6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, s := ref.SpecificEnthalpy(), ref.SpecificEntropy()
+	for name, build := range map[string]func() (*State, error){
+		"PH":   func() (*State, error) { return FromPH(5e6, h) },
+		"PS":   func() (*State, error) { return FromPS(5e6, s) },
+		"HS":   func() (*State, error) { return FromHS(h, s) },
+		"VU":   func() (*State, error) { return FromVU(ref.SpecificVolume(), ref.SpecificInternalEnergy()) },
+		"RhoT": func() (*State, error) { return FromRhoT(ref.Density(), 250) },
+		"TS":   func() (*State, error) { return FromTS(250, s) },
+	} {
+		st, err := build()
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if st.Region() != ref.Region() || math.Abs(st.Temperature()-250) > 1e-6 || math.Abs(st.Pressure()-5e6) > 1 {
+			t.Errorf("%s: region %d, T = %.9f°C, p = %.3f Pa", name, st.Region(), st.Temperature(), st.Pressure())
+		}
+	}
+}
+
+func TestStateTwoPhase(t *testing.T) {
+	calc := NewCalculator()
+	for _, tc := range []struct {
+		name string
+		st   func() (*State, error)
+		want func() (*Result, error)
+	}{
+		{"PX", func() (*State, error) { return FromPX(1e6, 0.3) }, func() (*Result, error) { return calc.CalculatePX(1e6, 0.3) }},
+		{"TX Region 3", func() (*State, error) { return FromTX(360, 0.6) }, func() (*Result, error) { return calc.CalculateTX(360, 0.6) }},
+		{"PH", func() (*State, error) { return FromPH(1e6, 1500) }, func() (*Result, error) { return calc.CalculatePH(1e6, 1500) }},
+	} {
+		st, err := tc.st()
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		want, err := tc.want()
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if st.Region() != calc_core.Region4 || st.Phase() != PhaseTwoPhase || st.Subregion() != SubregionNone || st.Saturation() == nil {
+			t.Errorf("%s: region %d, phase %v", tc.name, st.Region(), st.Phase())
+		}
+		if st.Quality() != want.Quality || st.Properties() != want.Properties {
+			t.Errorf("%s: x = %g, %+v; want %g, %+v", tc.name, st.Quality(), st.Properties(), want.Quality, want.Properties)
+		}
+		if math.Abs(st.SpecificEnthalpy()-want.Properties.SpecificEnthalpy) > 1e-9 || st.SpecificIsobaricHeatCapacity() != 0 {
+			t.Errorf("%s: h = %g, cp = %g", tc.name, st.SpecificEnthalpy(), st.SpecificIsobaricHeatCapacity())
+		}
+	}
+}
+
+// TestStatePropertiesDoNotEvaluate проверяет, что свойства берутся из
+// сохраненных производных: чтение не выделяет память и не пересчитывает ряды
+func TestStatePropertiesDoNotEvaluate(t *testing.T) {
+	for _, tp := range [][2]float64{{150, 10e6}, {380, 30e6}} {
+		st, err := FromTP(tp[0], tp[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		allocs := testing.AllocsPerRun(100, func() {
+			_ = st.SpecificEnthalpy() + st.SpecificIsobaricHeatCapacity() + st.SpeedOfSound()
+			_ = st.Subregion()
+		})
+		if allocs != 0 {
+			t.Errorf("T=%g°C: %.0f allocs per property read", tp[0], allocs)
+		}
+	}
+}
+
+func BenchmarkState(b *testing.B) {
+	st, err := FromTP(150, 10e6)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("FromTP", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := FromTP(150, 10e6); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("cp after h", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = st.SpecificEnthalpy()
+			_ = st.SpecificIsobaricHeatCapacity()
+		}
+	})
+}
@@ -0,0 +1,327 @@
+// Пакет webapi — расчет по запросу POST /api/calculate. Его используют
+// веб-сервер cmd/web и сборка WebAssembly cmd/wasm, поэтому формы запроса и
+// ответа JSON в браузере и на сервере совпадают.
+package webapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/if97"
+	"github.com/somepgs/steamprops/propserr"
+	"github.com/somepgs/steamprops/units"
+)
+
+// CalculationRequest представляет запрос на расчет
+type CalculationRequest struct {
+	Mode           string  `json:"mode"`
+	Temperature    float64 `json:"temperature"`
+	Pressure       float64 `json:"pressure"`
+	Enthalpy       float64 `json:"enthalpy"`
+	Entropy        float64 `json:"entropy"`
+	SpecificVolume float64 `json:"specific_volume"` // м³/кг, режим VU
+	InternalEnergy float64 `json:"internal_energy"` // кДж/кг, режим VU
+	Density        float64 `json:"density"`         // кг/м³, режим RhoT
+	Region         string  `json:"region"`
+	// Units задает единицы входных значений; по умолчанию °C, Па, кДж/кг и кДж/(кг·К)
+	Units InputUnits `json:"units"`
+	// Output задает систему единиц карты properties ответа
+	Output OutputUnits `json:"output"`
+	// Lang задает язык сообщений ответа: "ru" или "en"; пусто — язык
+	// заголовка Accept-Language или сервера
+	Lang string `json:"lang,omitempty"`
+}
+
+// OutputUnits — система единиц вывода: "si" (по умолчанию), "si-eng" или
+// "us", и избыточное давление относительно атмосферного
+type OutputUnits struct {
+	System     string  `json:"system,omitempty"`
+	Gauge      bool    `json:"gauge,omitempty"`
+	Atmosphere float64 `json:"atmosphere,omitempty"` // Па; 0 — 101325 Па
+}
+
+// UnitSystem возвращает выбранную систему единиц вывода
+func (o OutputUnits) UnitSystem() (units.System, error) {
+	sys := units.SI
+	if o.System != "" {
+		var err error
+		if sys, err = units.SystemByName(o.System); err != nil {
+			return sys, err
+		}
+	}
+	if o.Gauge {
+		sys = sys.WithGauge(units.Pascal.Of(o.Atmosphere))
+	}
+	return sys, nil
+}
+
+// InputUnits — единицы входных значений запроса в обозначениях пакета units,
+// например {"temperature": "°F", "pressure": "psi"}
+type InputUnits struct {
+	Temperature string `json:"temperature,omitempty"`
+	Pressure    string `json:"pressure,omitempty"`
+	Energy      string `json:"energy,omitempty"`  // энтальпия и внутренняя энергия
+	Entropy     string `json:"entropy,omitempty"` // энтропия
+}
+
+// TemperatureOf переводит температуру v из единицы Temperature в °C
+func (u InputUnits) TemperatureOf(v float64) (float64, error) {
+	if u.Temperature == "" {
+		return v, nil
+	}
+	unit, err := units.ParseTemperatureUnit(u.Temperature)
+	if err != nil {
+		return 0, err
+	}
+	return unit.Of(v).Celsius(), nil
+}
+
+// PressureOf переводит давление v из единицы Pressure в Па
+func (u InputUnits) PressureOf(v float64) (float64, error) {
+	if u.Pressure == "" {
+		return v, nil
+	}
+	unit, err := units.ParsePressureUnit(u.Pressure)
+	if err != nil {
+		return 0, err
+	}
+	return unit.Of(v).Pascals(), nil
+}
+
+// EnergyOf переводит удельную энергию v из единицы Energy в кДж/кг
+func (u InputUnits) EnergyOf(v float64) (float64, error) {
+	if u.Energy == "" {
+		return v, nil
+	}
+	unit, err := units.ParseSpecificEnergyUnit(u.Energy)
+	if err != nil {
+		return 0, err
+	}
+	return unit.Of(v).KilojoulesPerKg(), nil
+}
+
+// EntropyOf переводит удельную энтропию v из единицы Entropy в кДж/(кг·К)
+func (u InputUnits) EntropyOf(v float64) (float64, error) {
+	if u.Entropy == "" {
+		return v, nil
+	}
+	unit, err := units.ParseSpecificEntropyUnit(u.Entropy)
+	if err != nil {
+		return 0, err
+	}
+	return unit.Of(v).KilojoulesPerKgK(), nil
+}
+
+// convertUnits переводит входные значения запроса из заданных единиц в
+// единицы пакета if97
+func (req *CalculationRequest) convertUnits() error {
+	var err error
+	if req.Temperature, err = req.Units.TemperatureOf(req.Temperature); err != nil {
+		return err
+	}
+	if req.Pressure, err = req.Units.PressureOf(req.Pressure); err != nil {
+		return err
+	}
+	if req.Enthalpy, err = req.Units.EnergyOf(req.Enthalpy); err != nil {
+		return err
+	}
+	if req.InternalEnergy, err = req.Units.EnergyOf(req.InternalEnergy); err != nil {
+		return err
+	}
+	req.Entropy, err = req.Units.EntropyOf(req.Entropy)
+	return err
+}
+
+// CalculationResponse представляет ответ с результатами расчета
+type CalculationResponse struct {
+	Success    bool                   `json:"success"`
+	Error      string                 `json:"error,omitempty"`
+	Code       propserr.Code          `json:"code,omitempty"`    // код ошибки, например "out_of_range"
+	Details    *ErrorDetails          `json:"details,omitempty"` // нарушенный предел, если известен
+	Result     *Result                `json:"result,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+	Units      map[string]string      `json:"units,omitempty"` // обозначения единиц значений properties
+}
+
+// Result — состояние в поле result ответа, в единицах библиотеки. Имена
+// полей JSON совпадают с прежним steamprops.Result, на который опираются
+// клиенты API и web/static/js.
+type Result struct {
+	Properties if97.Properties
+	Region     if97.Region
+	Phase      string
+	// TransportProps — транспортные свойства строками с единицами системы
+	// вывода, ключи как в карте properties; пусто во влажном паре
+	TransportProps map[string]string
+	Temperature    float64 // °C
+	Pressure       float64 // Па
+	Quality        float64 // паросодержание 0..1 в Region 4, -1 для однофазных состояний
+}
+
+// newResult формирует поле result по состоянию if97 с фазой на языке loc и
+// транспортными свойствами в системе единиц sys
+func newResult(r *if97.Result, sys units.System, loc i18n.Locale) *Result {
+	transport := map[string]string{}
+	if tr := r.In(sys).Transport; tr != nil {
+		transport["dynamic_viscosity"] = fmt.Sprintf("%.2e %s", tr.DynamicViscosity, sys.DynamicViscosity)
+		transport["kinematic_viscosity"] = fmt.Sprintf("%.2e %s", tr.KinematicViscosity, sys.KinematicViscosity)
+		transport["thermal_conductivity"] = fmt.Sprintf("%.4g %s", tr.ThermalConductivity, sys.ThermalConductivity)
+	}
+	return &Result{
+		Properties:     r.Properties,
+		Region:         r.Region,
+		Phase:          loc.T(r.Phase),
+		TransportProps: transport,
+		Temperature:    r.Temperature,
+		Pressure:       r.Pressure,
+		Quality:        r.Quality,
+	}
+}
+
+// ErrorDetails — структурированные поля ошибки для показа в интерфейсе
+type ErrorDetails struct {
+	Region   int      `json:"region,omitempty"`
+	Quantity string   `json:"quantity,omitempty"` // "T", "p", "h", "s", "v", "u", "rho", "x"
+	Value    *float64 `json:"value,omitempty"`    // нет для NaN и бесконечности
+	Unit     string   `json:"unit,omitempty"`
+	Bound    string   `json:"bound,omitempty"` // "min" или "max"
+	Limit    *float64 `json:"limit,omitempty"`
+}
+
+// errorResponse формирует ответ с ошибкой, ее кодом и полями
+func errorResponse(message string, err error) CalculationResponse {
+	resp := CalculationResponse{Success: false, Error: message, Code: propserr.CodeOf(err)}
+	var e *propserr.Error
+	if !errors.As(err, &e) || (e.Region == 0 && e.Quantity == "") {
+		return resp
+	}
+	d := &ErrorDetails{Region: e.Region, Quantity: e.Quantity, Unit: e.Unit}
+	if e.Quantity != "" && !math.IsNaN(e.Value) && !math.IsInf(e.Value, 0) {
+		v := e.Value
+		d.Value = &v
+	}
+	switch e.Bound {
+	case propserr.Min:
+		d.Bound = "min"
+	case propserr.Max:
+		d.Bound = "max"
+	}
+	if e.Bound != propserr.NoBound {
+		limit := e.Limit
+		d.Limit = &limit
+	}
+	resp.Details = d
+	return resp
+}
+
+// CalculateJSON разбирает запрос JSON из r и выполняет расчет. Сообщения
+// ответа выводятся на языке поля lang запроса, если оно задано, иначе на
+// языке loc.
+func CalculateJSON(r io.Reader, loc i18n.Locale) CalculationResponse {
+	var req CalculationRequest
+	if err := json.NewDecoder(r).Decode(&req); err != nil {
+		return errorResponse(loc.T("Ошибка парсинга JSON: %v", err), propserr.InvalidInput)
+	}
+	if l, ok := i18n.Parse(req.Lang); ok {
+		loc = l
+	}
+	return Calculate(req, loc)
+}
+
+// Calculate выполняет расчет и формирует ответ со свойствами в системе
+// единиц, выбранной в запросе; ошибки возвращаются в поле Error на языке loc
+func Calculate(req CalculationRequest, loc i18n.Locale) CalculationResponse {
+	sys, err := req.Output.UnitSystem()
+	if err != nil {
+		return errorResponse(loc.T("Ошибка валидации: %v", err), propserr.InvalidInput)
+	}
+	state, err := calculate(req)
+	if err != nil {
+		return errorResponse(loc.Error(err), err)
+	}
+	result := newResult(state, sys, loc)
+
+	// Формируем ответ в выбранной системе единиц
+	c := state.In(sys)
+	properties := map[string]interface{}{
+		"temperature":                      c.Temperature,
+		"pressure":                         c.Pressure,
+		"density":                          c.Properties.Density,
+		"specific_volume":                  c.Properties.SpecificVolume,
+		"specific_enthalpy":                c.Properties.SpecificEnthalpy,
+		"specific_entropy":                 c.Properties.SpecificEntropy,
+		"specific_internal_energy":         c.Properties.SpecificInternalEnergy,
+		"specific_isobaric_heat_capacity":  c.Properties.SpecificIsobaricHeatCapacity,
+		"specific_isochoric_heat_capacity": c.Properties.SpecificIsochoricHeatCapacity,
+		"speed_of_sound":                   c.Properties.SpeedOfSound,
+		"dynamic_viscosity":                result.TransportProps["dynamic_viscosity"],
+		"kinematic_viscosity":              result.TransportProps["kinematic_viscosity"],
+		"thermal_conductivity":             result.TransportProps["thermal_conductivity"],
+		"quality":                          result.Quality,
+		"phase":                            result.Phase,
+		"region":                           int(result.Region),
+	}
+
+	return CalculationResponse{
+		Success:    true,
+		Result:     result,
+		Properties: properties,
+		Units: map[string]string{
+			"temperature":      sys.Temperature.String(),
+			"pressure":         sys.PressureSymbol(),
+			"density":          sys.Density.String(),
+			"specific_volume":  sys.SpecificVolume.String(),
+			"specific_energy":  sys.SpecificEnergy.String(),
+			"specific_entropy": sys.SpecificEntropy.String(),
+			"speed":            sys.Speed.String(),
+			// Транспортные свойства в properties — строки с этими единицами
+			"dynamic_viscosity":    sys.DynamicViscosity.String(),
+			"kinematic_viscosity":  sys.KinematicViscosity.String(),
+			"thermal_conductivity": sys.ThermalConductivity.String(),
+		},
+	}
+}
+
+// calculate выполняет расчет по паре параметров запроса через пакет if97.
+// Режимы PH и PS используются при выборе точки на интерактивной диаграмме.
+func calculate(req CalculationRequest) (*if97.Result, error) {
+	if err := req.convertUnits(); err != nil {
+		return nil, propserr.Wrap(propserr.InvalidInput, "Ошибка валидации", err)
+	}
+
+	var result *if97.Result
+	var err error
+	switch req.Mode {
+	case "TP":
+		result, err = if97.TP(req.Temperature, req.Pressure)
+	case "PH":
+		result, err = if97.PH(req.Pressure, req.Enthalpy)
+	case "PS":
+		result, err = if97.PS(req.Pressure, req.Entropy)
+	case "HS":
+		result, err = if97.HS(req.Enthalpy, req.Entropy)
+	case "VU":
+		result, err = if97.VU(req.SpecificVolume, req.InternalEnergy)
+	case "RhoT":
+		result, err = if97.RhoT(req.Density, req.Temperature)
+	case "TH":
+		result, err = if97.TH(req.Temperature, req.Enthalpy)
+	case "TS":
+		result, err = if97.TS(req.Temperature, req.Entropy)
+	default:
+		return nil, &propserr.Error{Code: propserr.InvalidInput, Detail: "Ошибка валидации: неверный режим расчета: %s", Args: []interface{}{req.Mode}}
+	}
+
+	var inputErr *if97.InputError
+	switch {
+	case errors.As(err, &inputErr):
+		return nil, i18n.Errorf("Ошибка валидации: %w", inputErr.Err)
+	case err != nil:
+		return nil, i18n.Errorf("Ошибка расчета: %w", err)
+	}
+	return result, nil
+}
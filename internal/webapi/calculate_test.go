@@ -0,0 +1,99 @@
+package webapi
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/somepgs/steamprops/i18n"
+	"github.com/somepgs/steamprops/propserr"
+)
+
+func TestCalculateJSON(t *testing.T) {
+	// 392 °F и 10 бар = 200 °C и 1 МПа, вывод в технической системе
+	resp := CalculateJSON(strings.NewReader(`{"mode":"TP","temperature":392,"pressure":10,
+		"units":{"temperature":"°F","pressure":"bar"},"output":{"system":"si-eng"}}`), i18n.Russian)
+	if !resp.Success {
+		t.Fatalf("error: %s", resp.Error)
+	}
+	if math.Abs(resp.Result.Temperature-200) > 1e-9 || math.Abs(resp.Result.Pressure-1e6) > 1e-6 {
+		t.Errorf("result T = %g, p = %g; want library units", resp.Result.Temperature, resp.Result.Pressure)
+	}
+	if p := resp.Properties["pressure"].(float64); math.Abs(p-10) > 1e-9 || resp.Units["pressure"] != "bar" {
+		t.Errorf("pressure = %g %s, want 10 bar", p, resp.Units["pressure"])
+	}
+
+	// Транспортные свойства выводятся в той же системе единиц
+	us := CalculateJSON(strings.NewReader(`{"mode":"TP","temperature":200,"pressure":1e6,"output":{"system":"us"}}`), i18n.Russian)
+	for key, unit := range map[string]string{
+		"dynamic_viscosity": "lb/(ft·h)", "kinematic_viscosity": "ft²/h", "thermal_conductivity": "BTU/(h·ft·°F)",
+	} {
+		if v := us.Result.TransportProps[key]; !strings.HasSuffix(v, " "+unit) || us.Units[key] != unit {
+			t.Errorf("%s = %q (%s), want %s", key, v, us.Units[key], unit)
+		}
+	}
+
+	// Ответ сериализуется с полями, которые читает web/static/js/app.js
+	data, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]json.RawMessage
+	json.Unmarshal(data, &fields)
+	for _, key := range []string{"success", "result", "properties", "units"} {
+		if _, ok := fields[key]; !ok {
+			t.Errorf("response JSON lacks %q", key)
+		}
+	}
+
+	errorCases := []struct {
+		body, prefix string
+		code         propserr.Code
+	}{
+		{`{bad`, "Ошибка парсинга JSON", propserr.InvalidInput},
+		{`{"mode":"XX"}`, "Ошибка валидации", propserr.InvalidInput},
+		{`{"mode":"TP","output":{"system":"x"}}`, "Ошибка валидации", propserr.InvalidInput},
+		{`{"mode":"TP","temperature":20,"pressure":-1}`, "Ошибка валидации", propserr.InvalidInput},
+		{`{"mode":"TP","temperature":20,"pressure":150,"units":{"pressure":"MPa"}}`, "Ошибка валидации", propserr.OutOfRange},
+		{`{"mode":"HS","enthalpy":100,"entropy":9}`, "Ошибка расчета", propserr.NoSolution},
+	}
+	for _, tc := range errorCases {
+		resp := CalculateJSON(strings.NewReader(tc.body), i18n.Russian)
+		if resp.Success || !strings.HasPrefix(resp.Error, tc.prefix) || resp.Code != tc.code {
+			t.Errorf("%s: success = %v, error = %q, code = %s; want %q, %s", tc.body, resp.Success, resp.Error, string(resp.Code), tc.prefix, string(tc.code))
+		}
+	}
+
+	// Нарушенный предел передается в полях details
+	resp = CalculateJSON(strings.NewReader(`{"mode":"TP","temperature":2100,"pressure":1e6}`), i18n.Russian)
+	d := resp.Details
+	if d == nil || d.Quantity != "T" || d.Bound != "max" || d.Limit == nil || *d.Limit != 2000 || d.Value == nil || *d.Value != 2100 {
+		t.Errorf("details = %+v, want T = 2100 > 2000", d)
+	}
+	if data, err = json.Marshal(resp); err != nil || !strings.Contains(string(data), `"code":"out_of_range"`) {
+		t.Errorf("JSON %s (%v) lacks code", data, err)
+	}
+}
+
+func TestCalculateLocale(t *testing.T) {
+	// Поле lang запроса перекрывает язык сервера
+	resp := CalculateJSON(strings.NewReader(`{"mode":"TP","temperature":2100,"pressure":1e6,"lang":"en"}`), i18n.Russian)
+	want := "Validation error: temperature exceeds the IF-97 maximum (T = 2100 °C > 2000)"
+	if resp.Error != want {
+		t.Errorf("error = %q, want %q", resp.Error, want)
+	}
+
+	resp = CalculateJSON(strings.NewReader(`{"mode":"TP","temperature":20,"pressure":1e6}`), i18n.English)
+	if !resp.Success || resp.Properties["phase"] != "Compressed liquid" || resp.Result.Phase != "Compressed liquid" {
+		t.Errorf("phase = %v, %q; want Compressed liquid", resp.Properties["phase"], resp.Result.Phase)
+	}
+	if v := resp.Properties["dynamic_viscosity"].(string); !strings.HasSuffix(v, " Pa·s") {
+		t.Errorf("dynamic_viscosity = %q, want Pa·s", v)
+	}
+
+	resp = CalculateJSON(strings.NewReader(`{bad`), i18n.English)
+	if !strings.HasPrefix(resp.Error, "JSON parse error") {
+		t.Errorf("error = %q, want JSON parse error", resp.Error)
+	}
+}
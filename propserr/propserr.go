@@ -0,0 +1,174 @@
+// Пакет propserr — единая модель ошибок расчета свойств воды и пара.
+//
+// Каждая ошибка имеет машиночитаемый код Code, стабильный между версиями и
+// передаваемый в JSON веб-API, и структурированные поля: регион IF-97,
+// величину, ее значение и нарушенный предел. Код проверяется через
+// errors.Is или CodeOf, поля — через errors.As:
+//
+//	_, err := if97.TP(700, 150e6)
+//	if errors.Is(err, propserr.OutOfRange) {
+//		var e *propserr.Error
+//		errors.As(err, &e) // e.Quantity == "p", e.Limit == 100e6
+//	}
+package propserr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Code — машиночитаемый код ошибки. Code реализует error, поэтому
+// errors.Is(err, propserr.NoConvergence) проверяет код ошибки в цепочке.
+type Code string
+
+const (
+	InvalidInput        Code = "invalid_input"         // NaN, бесконечность, неположительный объем, неверный режим
+	OutOfRange          Code = "out_of_range"          // значение вне области применимости IF-97
+	RegionNotApplicable Code = "region_not_applicable" // точка вне области уравнения заданного региона
+	RegionNotSupported  Code = "region_not_supported"  // регион не поддерживает запрошенный расчет
+	NoSolution          Code = "no_solution"           // в области IF-97 нет состояния с заданными параметрами
+	NoConvergence       Code = "no_convergence"        // итерации не сошлись
+	NonPhysical         Code = "non_physical"          // нефизичный результат: NaN, отрицательная плотность, неустойчивость
+	Ambiguous           Code = "ambiguous"             // заданным параметрам соответствует несколько состояний
+	UnknownParameter    Code = "unknown_parameter"     // неизвестное имя параметра PropsSI
+	UnsupportedInputs   Code = "unsupported_inputs"    // неподдерживаемая пара входных параметров
+	UnsupportedFluid    Code = "unsupported_fluid"     // вещество, отличное от воды
+	Internal            Code = "internal"              // ошибка без кода
+)
+
+// Codes — все коды в порядке объявления
+var Codes = []Code{
+	InvalidInput, OutOfRange, RegionNotApplicable, RegionNotSupported,
+	NoSolution, NoConvergence, NonPhysical, Ambiguous,
+	UnknownParameter, UnsupportedInputs, UnsupportedFluid, Internal,
+}
+
+var descriptions = map[Code]string{
+	InvalidInput:        "недопустимое значение",
+	OutOfRange:          "значение вне области применимости IF-97",
+	RegionNotApplicable: "точка вне области уравнения региона",
+	RegionNotSupported:  "расчет в регионе не поддерживается",
+	NoSolution:          "нет состояния с заданными параметрами в области IF-97",
+	NoConvergence:       "нет сходимости",
+	NonPhysical:         "нефизичный результат",
+	Ambiguous:           "неоднозначное состояние",
+	UnknownParameter:    "неизвестный параметр",
+	UnsupportedInputs:   "неподдерживаемая пара входных параметров",
+	UnsupportedFluid:    "вещество не поддерживается",
+	Internal:            "внутренняя ошибка",
+}
+
+// Error возвращает описание кода на русском языке
+func (c Code) Error() string {
+	if d, ok := descriptions[c]; ok {
+		return d
+	}
+	return string(c)
+}
+
+// ErrorCode возвращает сам код, поэтому код можно вернуть как ошибку
+func (c Code) ErrorCode() Code { return c }
+
+// Bound — вид нарушенного предела
+type Bound int
+
+const (
+	NoBound Bound = iota // предел не задан, Limit не используется
+	Min                  // значение меньше Limit
+	Max                  // значение больше Limit
+)
+
+// Error — ошибка расчета с кодом и структурированными полями
+type Error struct {
+	Code     Code
+	Region   int           // регион IF-97; 0 — ошибка не относится к региону
+	Quantity string        // величина: "T", "p", "h", "s", "v", "u", "rho", "x"; пусто — не задана
+	Value    float64       // значение величины в единицах Unit
+	Unit     string        // "°C", "K", "Pa", "kJ/kg", "kJ/(kg·K)", "m³/kg", "kg/m³"
+	Bound    Bound         // вид нарушенного предела
+	Limit    float64       // нарушенный предел в единицах Unit
+	Detail   string        // пояснение вместо описания кода; с Args — формат fmt
+	Args     []interface{} // аргументы формата Detail
+	Err      error         // причина
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	if e.Region != 0 {
+		fmt.Fprintf(&b, "Region %d: ", e.Region)
+	}
+	if len(e.Args) > 0 {
+		fmt.Fprintf(&b, e.Detail, e.Args...)
+	} else if e.Detail != "" {
+		b.WriteString(e.Detail)
+	} else {
+		b.WriteString(e.Code.Error())
+	}
+	if e.Quantity != "" {
+		fmt.Fprintf(&b, " (%s = %.6g", e.Quantity, e.Value)
+		if e.Unit != "" {
+			b.WriteString(" " + e.Unit)
+		}
+		switch e.Bound {
+		case Min:
+			fmt.Fprintf(&b, " < %.6g", e.Limit)
+		case Max:
+			fmt.Fprintf(&b, " > %.6g", e.Limit)
+		}
+		b.WriteString(")")
+	}
+	if e.Err != nil {
+		b.WriteString(": " + e.Err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap возвращает причину ошибки
+func (e *Error) Unwrap() error { return e.Err }
+
+// Is сообщает, что ошибка имеет код target
+func (e *Error) Is(target error) bool {
+	c, ok := target.(Code)
+	return ok && c == e.Code
+}
+
+// ErrorCode возвращает код ошибки
+func (e *Error) ErrorCode() Code { return e.Code }
+
+// Coder — ошибка с кодом. Его реализуют Code, *Error и типы ошибок пакета if97.
+type Coder interface {
+	error
+	ErrorCode() Code
+}
+
+// CodeOf возвращает код первой ошибки с кодом в цепочке err, Internal для
+// ошибки без кода и пустую строку для nil
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+	var c Coder
+	if errors.As(err, &c) {
+		return c.ErrorCode()
+	}
+	return Internal
+}
+
+// Wrap оборачивает err в ошибку с кодом code и пояснением detail; ошибка,
+// уже имеющая код, сохраняет его в цепочке
+func Wrap(code Code, detail string, err error) *Error {
+	return &Error{Code: code, Detail: detail, Err: err}
+}
+
+// Newf создает ошибку с пояснением по формату fmt. Формат и аргументы
+// хранятся раздельно, чтобы сообщение можно было перевести.
+func Newf(code Code, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Detail: format, Args: args}
+}
+
+// Range создает ошибку нарушения предела: величина quantity со значением
+// value в единицах unit вышла за предел limit
+func Range(code Code, region int, quantity string, value float64, unit string, bound Bound, limit float64) *Error {
+	return &Error{Code: code, Region: region, Quantity: quantity, Value: value, Unit: unit, Bound: bound, Limit: limit}
+}
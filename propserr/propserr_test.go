@@ -0,0 +1,74 @@
+package propserr_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/somepgs/steamprops/propserr"
+)
+
+func TestErrorMessage(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{propserr.Range(propserr.OutOfRange, 0, "p", 150e6, "Pa", propserr.Max, 100e6),
+			"значение вне области применимости IF-97 (p = 1.5e+08 Pa > 1e+08)"},
+		{propserr.Range(propserr.RegionNotApplicable, 5, "T", 300, "°C", propserr.Min, 800),
+			"Region 5: точка вне области уравнения региона (T = 300 °C < 800)"},
+		{&propserr.Error{Code: propserr.InvalidInput, Detail: "давление содержит недопустимое значение", Quantity: "p", Value: -1, Unit: "Pa"},
+			"давление содержит недопустимое значение (p = -1 Pa)"},
+		{propserr.Wrap(propserr.NoSolution, "ошибка расчета по h,s", propserr.NoConvergence),
+			"ошибка расчета по h,s: нет сходимости"},
+		{propserr.Code("custom"), "custom"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	inner := propserr.Range(propserr.OutOfRange, 0, "T", 2100, "°C", propserr.Max, 2000)
+	wrapped := fmt.Errorf("ошибка расчета по T,P: %w", inner)
+
+	if got := propserr.CodeOf(wrapped); got != propserr.OutOfRange {
+		t.Errorf("CodeOf(wrapped) = %s, want out_of_range", string(got))
+	}
+	if !errors.Is(wrapped, propserr.OutOfRange) || errors.Is(wrapped, propserr.InvalidInput) {
+		t.Error("errors.Is должен проверять код ошибки в цепочке")
+	}
+	var e *propserr.Error
+	if !errors.As(wrapped, &e) || e != inner {
+		t.Errorf("errors.As(wrapped) = %v, want inner", e)
+	}
+
+	// Внешний код перекрывает код причины, но причина остается в цепочке
+	outer := propserr.Wrap(propserr.NoSolution, "нет решения", inner)
+	if got := propserr.CodeOf(outer); got != propserr.NoSolution {
+		t.Errorf("CodeOf(outer) = %s, want no_solution", string(got))
+	}
+	if !errors.Is(outer, propserr.OutOfRange) {
+		t.Error("errors.Is(outer, OutOfRange) = false")
+	}
+
+	if got := propserr.CodeOf(nil); got != "" {
+		t.Errorf("CodeOf(nil) = %s, want empty", string(got))
+	}
+	if got := propserr.CodeOf(errors.New("x")); got != propserr.Internal {
+		t.Errorf("CodeOf(plain) = %s, want internal", string(got))
+	}
+	if got := propserr.CodeOf(propserr.NoConvergence); got != propserr.NoConvergence {
+		t.Errorf("CodeOf(Code) = %s, want no_convergence", string(got))
+	}
+}
+
+func TestCodesDescribed(t *testing.T) {
+	for _, c := range propserr.Codes {
+		if c.Error() == string(c) {
+			t.Errorf("код %s без описания", string(c))
+		}
+	}
+}
@@ -0,0 +1,12 @@
+"""Колесо содержит libsteamprops.so, поэтому помечается как платформенное."""
+
+from setuptools import setup
+from setuptools.dist import Distribution
+
+
+class BinaryDistribution(Distribution):
+    def has_ext_modules(self):
+        return True
+
+
+setup(distclass=BinaryDistribution)
@@ -0,0 +1,332 @@
+"""Свойства воды и водяного пара по IAPWS-IF97 через libsteamprops.
+
+Функции состояния принимают и возвращают единицы библиотеки: °C, Па,
+кДж/кг, кДж/(кг·К)::
+
+    >>> import steamprops
+    >>> st = steamprops.tp(26.85, 3e6)
+    >>> st.region, round(st.enthalpy, 6)
+    (1, 115.331273)
+
+props_si повторяет PropsSI из CoolProp (все в СИ). Функции состояния
+(кроме tp_region) и props_si векторизованы: массивы numpy и
+последовательности приводятся к общей форме по правилам broadcasting и
+рассчитываются одним пакетным вызовом библиотеки::
+
+    >>> steamprops.props_si("H", "T", [300, 400], "P", 3e6)  # doctest: +SKIP
+    array([ 115331.27...,  ...])
+    >>> steamprops.ph(1e6, [500, 2000]).temperature  # doctest: +SKIP
+    array([118.98..., 179.88...])
+"""
+
+import ctypes
+import math
+import threading
+from dataclasses import dataclass
+
+from . import _lib
+
+try:
+    import numpy as _np
+except ImportError:  # numpy необязателен
+    _np = None
+
+__all__ = [
+    "State", "SteamPropsError", "InputError", "CalculationError",
+    "AmbiguousStateError", "UnknownParameterError", "UnsupportedInputsError",
+    "UnsupportedFluidError", "LIQUID", "VAPOR", "TWO_PHASE", "SUPERCRITICAL",
+    "tp", "tp_region", "ph", "ps", "hs", "px", "tx", "vu", "rhot", "th", "ts",
+    "props_si",
+]
+
+# Фазовые состояния, как SP_PHASE_* в libsteamprops.h
+LIQUID, VAPOR, TWO_PHASE, SUPERCRITICAL = 0, 1, 2, 3
+
+
+class SteamPropsError(Exception):
+    """Ошибка libsteamprops; code — код SP_ERR_*."""
+
+    code = None
+
+    def __init__(self, message, code=None):
+        super().__init__(message)
+        if code is not None:
+            self.code = code
+
+
+class InputError(SteamPropsError, ValueError):
+    """Входные данные вне области применимости IF-97."""
+    code = 1
+
+
+class CalculationError(SteamPropsError):
+    """Состояние не найдено."""
+    code = 2
+
+
+class AmbiguousStateError(SteamPropsError):
+    """Несколько решений по (T,h) или (T,s)."""
+    code = 3
+
+
+class UnknownParameterError(SteamPropsError, KeyError):
+    """Неизвестное имя параметра props_si."""
+    code = 4
+
+    def __str__(self):  # KeyError заключает текст в кавычки
+        return self.args[0]
+
+
+class UnsupportedInputsError(SteamPropsError, ValueError):
+    """Неподдерживаемая пара входных параметров props_si."""
+    code = 5
+
+
+class UnsupportedFluidError(SteamPropsError, ValueError):
+    """Вещество, отличное от воды."""
+    code = 6
+
+
+_ERRORS = {cls.code: cls for cls in (
+    InputError, CalculationError, AmbiguousStateError, UnknownParameterError,
+    UnsupportedInputsError, UnsupportedFluidError)}
+
+
+@dataclass(frozen=True)
+class State:
+    """Рассчитанное состояние; cp, cv и speed_of_sound равны 0 во влажном
+    паре, транспортные свойства — 0, если не определены. Для массивов
+    входных данных поля — массивы numpy (списки без numpy); точка с ошибкой
+    при errors="nan" получает region 0, phase -1 и NaN в остальных полях."""
+
+    region: int
+    phase: int
+    quality: float              # 0..1 в регионе 4, -1 вне его
+    temperature: float          # °C
+    pressure: float             # Па
+    specific_volume: float      # м³/кг
+    density: float              # кг/м³
+    internal_energy: float      # кДж/кг
+    enthalpy: float             # кДж/кг
+    entropy: float              # кДж/(кг·К)
+    cp: float                   # кДж/(кг·К)
+    cv: float                   # кДж/(кг·К)
+    speed_of_sound: float       # м/с
+    dynamic_viscosity: float    # Па·с
+    thermal_conductivity: float  # Вт/(м·К)
+
+
+_lib_handle = _lib.load()
+
+# Текст ошибки в библиотеке общий для процесса, а ctypes отпускает GIL на
+# время вызова: вызов и чтение ошибки выполняются под одной блокировкой
+_call_lock = threading.Lock()
+
+
+def _call(fn, *args):
+    with _call_lock:
+        code = fn(*args)
+        if code == _lib.SP_OK:
+            return
+        message = _lib.last_error(_lib_handle)
+    raise _ERRORS.get(code, SteamPropsError)(message, code)
+
+
+def _call_n(fn, *args):
+    """Вызывает функцию массива и возвращает текст ошибки первой неудачной
+    точки; ошибка самого вызова поднимается как исключение."""
+    with _call_lock:
+        code = fn(*args)
+        message = _lib.last_error(_lib_handle)
+    if code != _lib.SP_OK:
+        raise _ERRORS.get(code, SteamPropsError)(message, code)
+    return message
+
+
+# Коды ошибок точки, которые при errors="nan" превращаются в NaN
+_NAN_CODES = (InputError.code, CalculationError.code, AmbiguousStateError.code)
+
+_FIELDS = [f for f, _ in _lib.sp_state._fields_]
+
+_FAILED = State(region=0, phase=-1, **{f: math.nan for f in _FIELDS[2:]})
+
+
+def _check_errors(errors):
+    if errors not in ("raise", "nan"):
+        raise ValueError(f'errors = {errors!r}: ожидается "raise" или "nan"')
+
+
+def _check_codes(codes, message, errors):
+    """Поднимает исключение для первой неудачной точки; при errors="nan" —
+    только для кодов вне _NAN_CODES."""
+    if _np is not None:
+        failed = codes[codes != _lib.SP_OK]
+        if errors == "nan":
+            failed = failed[~_np.isin(failed, _NAN_CODES)]
+        code = int(failed[0]) if len(failed) else _lib.SP_OK
+    else:
+        code = next((c for c in codes if c != _lib.SP_OK and
+                     (errors == "raise" or c not in _NAN_CODES)), _lib.SP_OK)
+    if code != _lib.SP_OK:
+        raise _ERRORS.get(code, SteamPropsError)(message, code)
+
+
+def _state(name, mode, a, b, errors):
+    """Состояние по паре a, b: sp_<name> для чисел, sp_state_n режима mode
+    для массивов."""
+    _check_errors(errors)
+    if _is_scalar(a) and _is_scalar(b):
+        out = _lib.sp_state()
+        try:
+            _call(getattr(_lib_handle, name), float(a), float(b), ctypes.byref(out))
+        except (InputError, CalculationError, AmbiguousStateError):
+            if errors == "nan":
+                return _FAILED
+            raise
+        return State(**{f: getattr(out, f) for f in _FIELDS})
+
+    a, b, shape = _arrays(a, b)
+    out, codes = _empty(len(a), _lib.sp_state), _empty(len(a), ctypes.c_int)
+    message = _call_n(_lib_handle.sp_state_n, mode.encode(), _ptr(a), _ptr(b),
+                      len(a), _ptr(out, _lib.sp_state), _ptr(codes, ctypes.c_int))
+    _check_codes(codes, message, errors)
+    if _np is not None:
+        return State(**{f: out[f].reshape(shape) for f in _FIELDS})
+    return State(**{f: [getattr(st, f) for st in out] for f in _FIELDS})
+
+
+def tp(t, p, *, errors="raise"):
+    """Состояние по температуре (°C) и давлению (Па)."""
+    return _state("sp_tp", "TP", t, p, errors)
+
+
+def tp_region(region, t, p):
+    """Состояние по T и p по уравнению региона 1, 2, 3 или 5; только числа."""
+    out = _lib.sp_state()
+    _call(_lib_handle.sp_tp_region, region, t, p, ctypes.byref(out))
+    return State(**{f: getattr(out, f) for f in _FIELDS})
+
+
+def ph(p, h, *, errors="raise"):
+    """Состояние по давлению (Па) и энтальпии (кДж/кг)."""
+    return _state("sp_ph", "PH", p, h, errors)
+
+
+def ps(p, s, *, errors="raise"):
+    """Состояние по давлению (Па) и энтропии (кДж/(кг·К))."""
+    return _state("sp_ps", "PS", p, s, errors)
+
+
+def hs(h, s, *, errors="raise"):
+    """Состояние по энтальпии (кДж/кг) и энтропии (кДж/(кг·К))."""
+    return _state("sp_hs", "HS", h, s, errors)
+
+
+def px(p, x, *, errors="raise"):
+    """Влажный пар по давлению (Па) и паросодержанию."""
+    return _state("sp_px", "PX", p, x, errors)
+
+
+def tx(t, x, *, errors="raise"):
+    """Влажный пар по температуре (°C) и паросодержанию."""
+    return _state("sp_tx", "TX", t, x, errors)
+
+
+def vu(v, u, *, errors="raise"):
+    """Состояние по удельному объему (м³/кг) и внутренней энергии (кДж/кг)."""
+    return _state("sp_vu", "VU", v, u, errors)
+
+
+def rhot(rho, t, *, errors="raise"):
+    """Состояние по плотности (кг/м³) и температуре (°C)."""
+    return _state("sp_rhot", "RhoT", rho, t, errors)
+
+
+def th(t, h, *, errors="raise"):
+    """Состояние по температуре (°C) и энтальпии (кДж/кг)."""
+    return _state("sp_th", "TH", t, h, errors)
+
+
+def ts(t, s, *, errors="raise"):
+    """Состояние по температуре (°C) и энтропии (кДж/(кг·К))."""
+    return _state("sp_ts", "TS", t, s, errors)
+
+
+def _props_si_scalar(output, name1, value1, name2, value2, fluid):
+    result = ctypes.c_double()
+    _call(_lib_handle.sp_props_si, output, name1, value1, name2, value2,
+          fluid, ctypes.byref(result))
+    return result.value
+
+
+def props_si(output, name1, value1, name2, value2, fluid="Water", *, errors="raise"):
+    """Свойство output по двум параметрам в СИ, как CoolProp.PropsSI.
+
+    value1 и value2 — числа, последовательности или массивы numpy. Для
+    чисел возвращается float, иначе массив numpy формы после broadcasting
+    (список, если numpy не установлен), рассчитанный одним вызовом
+    sp_props_si_n. При errors="nan" точки, где расчет не удался, получают
+    NaN вместо исключения.
+    """
+    _check_errors(errors)
+    output, name1, name2, fluid = (s.encode() for s in (output, name1, name2, fluid))
+    if _is_scalar(value1) and _is_scalar(value2):
+        try:
+            return _props_si_scalar(output, name1, float(value1), name2, float(value2), fluid)
+        except (InputError, CalculationError, AmbiguousStateError):
+            if errors == "nan":
+                return math.nan
+            raise
+
+    a, b, shape = _arrays(value1, value2)
+    out, codes = _empty(len(a), ctypes.c_double), _empty(len(a), ctypes.c_int)
+    message = _call_n(_lib_handle.sp_props_si_n, output, name1, _ptr(a), name2, _ptr(b),
+                      len(a), fluid, _ptr(out), _ptr(codes, ctypes.c_int))
+    _check_codes(codes, message, errors)
+    if _np is not None:
+        return out.reshape(shape)
+    return list(out)
+
+
+def _arrays(a, b):
+    """Приводит a и b к общей форме и возвращает два плоских буфера double и
+    форму результата (None без numpy)."""
+    if _np is not None:
+        a, b = _np.broadcast_arrays(_np.asarray(a, dtype=float), _np.asarray(b, dtype=float))
+        return _np.ascontiguousarray(a).ravel(), _np.ascontiguousarray(b).ravel(), a.shape
+    a, b = _broadcast_lists(a, b)
+    n = len(a)
+    return (ctypes.c_double * n)(*map(float, a)), (ctypes.c_double * n)(*map(float, b)), None
+
+
+def _empty(n, ctype):
+    """Буфер результата из n элементов типа ctype."""
+    if _np is not None:
+        return _np.empty(n, dtype=_np.dtype(ctype))
+    return (ctype * n)()
+
+
+def _ptr(buf, ctype=ctypes.c_double):
+    """Указатель на начало буфера для argtypes функций массива."""
+    if _np is not None:
+        return buf.ctypes.data_as(ctypes.POINTER(ctype))
+    return ctypes.cast(buf, ctypes.POINTER(ctype))
+
+
+def _is_scalar(v):
+    if _np is not None:
+        return _np.ndim(v) == 0
+    return not hasattr(v, "__len__")
+
+
+def _broadcast_lists(a, b):
+    """Одномерный broadcasting без numpy: число и последовательность или
+    две последовательности одной длины."""
+    if _is_scalar(a):
+        a = [a] * len(b)
+    if _is_scalar(b):
+        b = [b] * len(a)
+    a, b = list(a), list(b)
+    if len(a) != len(b):
+        raise ValueError(f"длины последовательностей различаются: {len(a)} и {len(b)}")
+    return a, b
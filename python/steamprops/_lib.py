@@ -0,0 +1,96 @@
+"""Загрузка libsteamprops и описание C ABI через ctypes."""
+
+import ctypes
+import ctypes.util
+import os
+
+ABI_VERSION = 1
+
+SP_OK = 0
+
+
+class sp_state(ctypes.Structure):
+    """Структура sp_state из libsteamprops.h."""
+
+    _fields_ = [
+        ("region", ctypes.c_int),
+        ("phase", ctypes.c_int),
+        ("quality", ctypes.c_double),
+        ("temperature", ctypes.c_double),
+        ("pressure", ctypes.c_double),
+        ("specific_volume", ctypes.c_double),
+        ("density", ctypes.c_double),
+        ("internal_energy", ctypes.c_double),
+        ("enthalpy", ctypes.c_double),
+        ("entropy", ctypes.c_double),
+        ("cp", ctypes.c_double),
+        ("cv", ctypes.c_double),
+        ("speed_of_sound", ctypes.c_double),
+        ("dynamic_viscosity", ctypes.c_double),
+        ("thermal_conductivity", ctypes.c_double),
+    ]
+
+
+# Функции расчета состояния с аргументами (double, double, sp_state*)
+STATE_FUNCTIONS = ("sp_tp", "sp_ph", "sp_ps", "sp_hs", "sp_px", "sp_tx",
+                   "sp_vu", "sp_rhot", "sp_th", "sp_ts")
+
+
+def _find():
+    path = os.environ.get("STEAMPROPS_LIBRARY")
+    if path:
+        return path
+    local = os.path.join(os.path.dirname(__file__), "libsteamprops.so")
+    if os.path.exists(local):
+        return local
+    found = ctypes.util.find_library("steamprops")
+    if found:
+        return found
+    raise OSError(
+        "libsteamprops.so не найдена: выполните make python в корне "
+        "репозитория или задайте путь в STEAMPROPS_LIBRARY")
+
+
+def load():
+    """Загружает библиотеку и задает сигнатуры функций."""
+    lib = ctypes.CDLL(_find())
+    state_p = ctypes.POINTER(sp_state)
+    for name in STATE_FUNCTIONS:
+        fn = getattr(lib, name)
+        fn.argtypes = [ctypes.c_double, ctypes.c_double, state_p]
+        fn.restype = ctypes.c_int
+    lib.sp_tp_region.argtypes = [ctypes.c_int, ctypes.c_double, ctypes.c_double, state_p]
+    lib.sp_tp_region.restype = ctypes.c_int
+    lib.sp_props_si.argtypes = [
+        ctypes.c_char_p, ctypes.c_char_p, ctypes.c_double,
+        ctypes.c_char_p, ctypes.c_double, ctypes.c_char_p,
+        ctypes.POINTER(ctypes.c_double)]
+    lib.sp_props_si.restype = ctypes.c_int
+    double_p = ctypes.POINTER(ctypes.c_double)
+    int_p = ctypes.POINTER(ctypes.c_int)
+    lib.sp_state_n.argtypes = [
+        ctypes.c_char_p, double_p, double_p, ctypes.c_size_t, state_p, int_p]
+    lib.sp_state_n.restype = ctypes.c_int
+    lib.sp_props_si_n.argtypes = [
+        ctypes.c_char_p, ctypes.c_char_p, double_p, ctypes.c_char_p, double_p,
+        ctypes.c_size_t, ctypes.c_char_p, double_p, int_p]
+    lib.sp_props_si_n.restype = ctypes.c_int
+    lib.sp_last_error.argtypes = [ctypes.c_char_p, ctypes.c_size_t]
+    lib.sp_last_error.restype = ctypes.c_int
+    lib.sp_abi_version.argtypes = []
+    lib.sp_abi_version.restype = ctypes.c_int
+
+    version = lib.sp_abi_version()
+    if version != ABI_VERSION:
+        raise OSError(f"версия ABI libsteamprops {version}, ожидается {ABI_VERSION}")
+    return lib
+
+
+def last_error(lib):
+    """Возвращает текст последней ошибки библиотеки."""
+    buf = ctypes.create_string_buffer(512)
+    n = lib.sp_last_error(buf, len(buf))
+    if n >= len(buf):
+        buf = ctypes.create_string_buffer(n + 1)
+        lib.sp_last_error(buf, len(buf))
+    return buf.value.decode("utf-8", errors="replace")
@@ -0,0 +1,117 @@
+"""Проверка привязок по контрольным значениям тестов Go (IF-97, таблицы 5
+и 15) и кодам ошибок libsteamprops."""
+
+import math
+
+import pytest
+
+import steamprops
+
+# Region 1: T = 300 К, p = 3 МПа
+T1, P1, H1, S1, V1 = 300 - 273.15, 3e6, 115.331273, 0.392294792, 0.00100215168
+W1, CP1 = 1507.73921, 4.17301218
+# Region 2: T = 700 К, p = 30 МПа
+T2, P2, H2, S2 = 700 - 273.15, 30e6, 2631.49474, 5.17540298
+
+
+def close(got, want, rel=1e-6):
+    return math.isclose(got, want, rel_tol=rel)
+
+
+def test_tp():
+    st = steamprops.tp(T1, P1)
+    assert st.region == 1 and st.phase == steamprops.LIQUID
+    assert st.quality == -1
+    assert close(st.enthalpy, H1) and close(st.entropy, S1)
+    assert close(st.specific_volume, V1) and close(st.speed_of_sound, W1)
+    assert close(st.cp, CP1)
+    assert st.dynamic_viscosity > 0 and st.thermal_conductivity > 0
+
+
+@pytest.mark.parametrize("calc, region, h, s", [
+    (lambda: steamprops.tp(T2, P2), 2, H2, S2),
+    (lambda: steamprops.ph(P1, H1), 1, H1, S1),
+    (lambda: steamprops.ps(P2, S2), 2, H2, S2),
+    (lambda: steamprops.hs(H2, S2), 2, H2, S2),
+    (lambda: steamprops.vu(V1, H1 - P1 * V1 / 1000), 1, H1, S1),
+    (lambda: steamprops.rhot(1 / V1, T1), 1, H1, S1),
+    (lambda: steamprops.ts(T2, S2), 2, H2, S2),
+    (lambda: steamprops.tp_region(2, T2, P2), 2, H2, S2),
+])
+def test_constructors(calc, region, h, s):
+    st = calc()
+    assert st.region == region
+    assert close(st.enthalpy, h) and close(st.entropy, s)
+
+
+def test_two_phase():
+    st = steamprops.px(1e6, 0.5)
+    assert st.region == 4 and st.phase == steamprops.TWO_PHASE
+    assert st.quality == 0.5
+    assert st.cp == 0 and st.dynamic_viscosity == 0
+    assert close(steamprops.tx(st.temperature, 0.5).pressure, 1e6)
+
+
+def test_props_si_scalar():
+    assert close(steamprops.props_si("H", "T", 300, "P", 3e6), H1 * 1000)
+    assert close(steamprops.props_si("T", "Smass", S1 * 1000, "P", 3e6), 300)
+    assert steamprops.props_si("Tcrit", "", 0, "", 0, "IF97::Water") == 647.096
+
+
+def test_props_si_sequences():
+    got = steamprops.props_si("H", "T", [300, 700], "P", [3e6, 30e6])
+    assert close(got[0], H1 * 1000) and close(got[1], H2 * 1000)
+    got = steamprops.props_si("T", "P", 3e6, "H", [H1 * 1000, H1 * 1000])
+    assert len(got) == 2 and all(close(t, 300) for t in got)
+
+
+def test_props_si_numpy():
+    np = pytest.importorskip("numpy")
+    t = np.array([[300.0], [700.0]])
+    p = np.array([3e6, 30e6])
+    got = steamprops.props_si("H", "T", t, "P", p)
+    assert isinstance(got, np.ndarray) and got.shape == (2, 2)
+    assert close(got[0, 0], H1 * 1000) and close(got[1, 1], H2 * 1000)
+
+    got = steamprops.props_si("H", "T", np.array([300.0, 300.0]), "P", np.array([3e6, -1]), errors="nan")
+    assert close(got[0], H1 * 1000) and np.isnan(got[1])
+
+
+def test_errors():
+    with pytest.raises(steamprops.InputError) as exc:
+        steamprops.tp(math.nan, 1e5)
+    assert exc.value.code == 1 and str(exc.value)
+    with pytest.raises(steamprops.CalculationError):
+        steamprops.hs(100, 9)
+    with pytest.raises(steamprops.AmbiguousStateError):
+        steamprops.th(100, 430)
+    with pytest.raises(steamprops.UnknownParameterError):
+        steamprops.props_si("Z", "T", 300, "P", 1e5)
+    with pytest.raises(steamprops.UnsupportedInputsError):
+        steamprops.props_si("H", "P", 1e5, "U", 2e6)
+    with pytest.raises(steamprops.UnsupportedFluidError):
+        steamprops.props_si("H", "T", 300, "P", 1e5, "R134a")
+    # Ошибки параметров не превращаются в NaN
+    with pytest.raises(steamprops.UnknownParameterError):
+        steamprops.props_si("Z", "T", [300], "P", 1e5, errors="nan")
+    assert math.isnan(steamprops.props_si("H", "T", [300], "P", -1, errors="nan")[0])
+
+
+def test_state_arrays():
+    st = steamprops.tp([T1, T2], [P1, P2])
+    assert list(st.region) == [1, 2]
+    assert close(st.enthalpy[0], H1) and close(st.enthalpy[1], H2)
+    st = steamprops.px(1e6, [0.25, 0.5])
+    assert list(st.quality) == [0.25, 0.5] and st.cp[0] == 0
+    with pytest.raises(steamprops.InputError):
+        steamprops.ph([P1, -1], H1)
+    st = steamprops.ph([P1, -1], H1, errors="nan")
+    assert st.region[1] == 0 and st.phase[1] == -1 and math.isnan(st.enthalpy[1])
+    assert math.isnan(steamprops.tp(math.nan, 1e5, errors="nan").enthalpy)
+
+
+def test_state_numpy():
+    np = pytest.importorskip("numpy")
+    st = steamprops.ts(np.array([[T2], [T2]]), np.array([S2, S2]))
+    assert isinstance(st.pressure, np.ndarray) and st.pressure.shape == (2, 2)
+    assert np.allclose(st.pressure, P2, rtol=1e-6)
@@ -0,0 +1,132 @@
+package units
+
+import (
+	"strings"
+
+	"github.com/somepgs/steamprops/i18n"
+)
+
+// StandardAtmosphere — нормальное атмосферное давление, опорное для
+// избыточного давления по умолчанию
+const StandardAtmosphere Pressure = 101325
+
+// System — система единиц вывода результатов. Давление выводится
+// абсолютным или, если Gauge, избыточным относительно Atmosphere.
+type System struct {
+	Name  string // имя для флагов и запросов: "si", "si-eng", "us"
+	Title string // название для селекторов интерфейсов; перевод — i18n.Locale.T(Title)
+
+	Temperature     TemperatureUnit
+	Pressure        PressureUnit
+	SpecificEnergy  SpecificEnergyUnit  // энтальпия и внутренняя энергия
+	SpecificEntropy SpecificEntropyUnit // энтропия и теплоемкости
+	SpecificVolume  SpecificVolumeUnit
+	Density         DensityUnit
+	Speed           SpeedUnit
+
+	DynamicViscosity    DynamicViscosityUnit
+	KinematicViscosity  KinematicViscosityUnit
+	ThermalConductivity ThermalConductivityUnit
+	MassFlux            MassFluxUnit // сопла и трубы
+	MassFlow            MassFlowUnit // пропускная способность
+	Power               PowerUnit    // тепловые потери и нагрузки
+
+	Gauge      bool
+	Atmosphere Pressure // 0 — StandardAtmosphere
+}
+
+var (
+	// SI — единицы библиотеки: °C, Па, кДж/кг
+	SI = System{
+		Name: "si", Title: i18n.N("СИ (°C, Па, кДж/кг)"),
+		Temperature: Celsius, Pressure: Pascal,
+		SpecificEnergy: KilojoulePerKg, SpecificEntropy: KilojoulePerKgK,
+		SpecificVolume: CubicMetrePerKg, Density: KgPerCubicMetre, Speed: MetrePerSecond,
+		DynamicViscosity: PascalSecond, KinematicViscosity: SquareMetrePerSecond,
+		ThermalConductivity: WattPerMetreK, MassFlux: KgPerSquareMetreSecond,
+		MassFlow: KgPerSecond, Power: Kilowatt,
+	}
+	// SIEngineering — техническая система на основе СИ: давление в барах,
+	// расход в тоннах в час
+	SIEngineering = System{
+		Name: "si-eng", Title: i18n.N("Техническая (°C, бар, кДж/кг)"),
+		Temperature: Celsius, Pressure: Bar,
+		SpecificEnergy: KilojoulePerKg, SpecificEntropy: KilojoulePerKgK,
+		SpecificVolume: CubicMetrePerKg, Density: KgPerCubicMetre, Speed: MetrePerSecond,
+		DynamicViscosity: PascalSecond, KinematicViscosity: SquareMetrePerSecond,
+		ThermalConductivity: WattPerMetreK, MassFlux: KgPerSquareMetreSecond,
+		MassFlow: TonnePerHour, Power: Kilowatt,
+	}
+	// USCustomary — американская система: °F, psia, BTU/lb, ft³/lb
+	USCustomary = System{
+		Name: "us", Title: i18n.N("США (°F, psia, BTU/lb)"),
+		Temperature: Fahrenheit, Pressure: PSI,
+		SpecificEnergy: BTUPerLb, SpecificEntropy: BTUPerLbF,
+		SpecificVolume: CubicFootPerLb, Density: LbPerCubicFoot, Speed: FootPerSecond,
+		DynamicViscosity: LbPerFootHour, KinematicViscosity: SquareFootPerHour,
+		ThermalConductivity: BTUPerHourFootF, MassFlux: LbPerSquareFootSecond,
+		MassFlow: LbPerHour, Power: BTUPerHour,
+	}
+)
+
+// Systems — системы единиц в порядке показа в селекторах
+var Systems = []System{SI, SIEngineering, USCustomary}
+
+// SystemByName возвращает систему единиц по имени "si", "si-eng" или "us"
+func SystemByName(name string) (System, error) {
+	for _, s := range Systems {
+		if strings.EqualFold(name, s.Name) {
+			return s, nil
+		}
+	}
+	return System{}, i18n.Errorf("неизвестная система единиц %q: ожидается si, si-eng или us", name)
+}
+
+// WithGauge возвращает систему с выводом избыточного давления относительно
+// атмосферного давления atmosphere; 0 — нормальное атмосферное давление
+func (s System) WithGauge(atmosphere Pressure) System {
+	s.Gauge = true
+	s.Atmosphere = atmosphere
+	return s
+}
+
+func (s System) atmosphere() Pressure {
+	if s.Atmosphere == 0 {
+		return StandardAtmosphere
+	}
+	return s.Atmosphere
+}
+
+// PressureValue возвращает давление p в единице системы: избыточное, если
+// задан Gauge, иначе абсолютное
+func (s System) PressureValue(p Pressure) float64 {
+	if s.Gauge {
+		p -= s.atmosphere()
+	}
+	return p.In(s.Pressure)
+}
+
+// AbsolutePressure переводит давление v в единице системы (избыточное при
+// Gauge) в абсолютное
+func (s System) AbsolutePressure(v float64) Pressure {
+	p := s.Pressure.Of(v)
+	if s.Gauge {
+		p += s.atmosphere()
+	}
+	return p
+}
+
+// PressureSymbol возвращает обозначение давления с видом отсчета: psia и
+// psig для фунтов на квадратный дюйм, "bar(g)" и подобные для избыточного
+// давления в других единицах
+func (s System) PressureSymbol() string {
+	switch {
+	case s.Pressure == PSI && s.Gauge:
+		return "psig"
+	case s.Pressure == PSI:
+		return "psia"
+	case s.Gauge:
+		return s.Pressure.String() + "(g)"
+	}
+	return s.Pressure.String()
+}
@@ -0,0 +1,48 @@
+package units
+
+import "testing"
+
+func TestSystems(t *testing.T) {
+	p := Megapascal.Of(1)
+	tests := []struct {
+		sys    System
+		value  float64
+		symbol string
+	}{
+		{SI, 1e6, "Pa"},
+		{SIEngineering, 10, "bar"},
+		{USCustomary, 145.03773773020923, "psia"},
+		{SIEngineering.WithGauge(0), 8.98675, "bar(g)"},
+		{USCustomary.WithGauge(PSI.Of(14.7)), 145.03773773020923 - 14.7, "psig"},
+	}
+	for _, tt := range tests {
+		if got := tt.sys.PressureValue(p); !near(got, tt.value) || tt.sys.PressureSymbol() != tt.symbol {
+			t.Errorf("%s: %g %s, want %g %s", tt.sys.Name, got, tt.sys.PressureSymbol(), tt.value, tt.symbol)
+		}
+		if back := tt.sys.AbsolutePressure(tt.value); !near(back.Pascals(), 1e6) {
+			t.Errorf("%s: AbsolutePressure(%g) = %g Pa", tt.sys.Name, tt.value, back.Pascals())
+		}
+	}
+	if SIEngineering.Gauge {
+		t.Error("WithGauge changed the shared system")
+	}
+
+	if v := CubicMetrePerKg.Of(1).In(CubicFootPerLb); !near(v, 16.018463373960138) {
+		t.Errorf("1 m³/kg = %.12g ft³/lb", v)
+	}
+	if d := KgPerCubicMetre.Of(1000).In(LbPerCubicFoot); !near(d, 62.42796057614462) {
+		t.Errorf("1000 kg/m³ = %.12g lb/ft³", d)
+	}
+	if w := FootPerSecond.Of(1000).In(MetrePerSecond); !near(w, 304.8) {
+		t.Errorf("1000 ft/s = %g m/s", w)
+	}
+
+	for _, s := range Systems {
+		if got, err := SystemByName(s.Name); err != nil || got != s {
+			t.Errorf("SystemByName(%q) = %v, %v", s.Name, got.Name, err)
+		}
+	}
+	if _, err := SystemByName("cgs"); err == nil {
+		t.Error("SystemByName accepted cgs")
+	}
+}
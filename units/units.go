@@ -0,0 +1,531 @@
+// Package units — величины с единицами измерения для входных и выходных
+// данных steamprops: температура, давление, удельная энергия (энтальпия,
+// внутренняя энергия), удельная энтропия (в том числе теплоемкости), а для
+// вывода — удельный объем, плотность, скорость, вязкость, теплопроводность,
+// плотность потока массы, массовый расход и мощность.
+//
+// Величина хранится в единицах СИ (К, Па, Дж/кг, Дж/(кг·К)) и имеет свой
+// тип, поэтому давление нельзя по ошибке передать вместо температуры.
+// Значение в нужной единице получается методом In, величина из числа —
+// методом Of единицы:
+//
+//	t := units.Fahrenheit.Of(392)
+//	fmt.Println(t.In(units.Celsius)) // 200
+//
+// Методы Celsius, Pascals, KilojoulesPerKg и KilojoulesPerKgK возвращают
+// значения в единицах пакета if97.
+package units
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/somepgs/steamprops/i18n"
+)
+
+// unit — линейная единица измерения: значение в СИ = v·scale + offset.
+// Смещение отлично от нуля только у шкал Цельсия и Фаренгейта.
+type unit struct {
+	symbol string
+	scale  float64
+	offset float64
+}
+
+func (u unit) toSI(v float64) float64   { return v*u.scale + u.offset }
+func (u unit) fromSI(v float64) float64 { return (v - u.offset) / u.scale }
+
+// Точные определения внесистемных единиц
+const (
+	poundMass  = 0.45359237         // кг
+	btu        = 1055.05585262      // Дж, международная британская тепловая единица
+	standardG  = 9.80665            // м/с², нормальное ускорение свободного падения
+	inch       = 0.0254             // м
+	foot       = 12 * inch          // м
+	mmHgPascal = 133.322387415      // Па
+	rankine    = 5.0 / 9.0          // К
+	fahrenheit = 459.67 * 5.0 / 9.0 // К, абсолютный нуль по шкале Фаренгейта
+	psiPascal  = poundMass * standardG / (inch * inch)
+	hour       = 3600.0 // с
+)
+
+// Temperature — термодинамическая температура, К
+type Temperature float64
+
+// TemperatureUnit — единица температуры
+type TemperatureUnit struct{ u unit }
+
+var (
+	Kelvin     = TemperatureUnit{unit{"K", 1, 0}}
+	Celsius    = TemperatureUnit{unit{"°C", 1, 273.15}}
+	Fahrenheit = TemperatureUnit{unit{"°F", rankine, fahrenheit}}
+	Rankine    = TemperatureUnit{unit{"°R", rankine, 0}}
+)
+
+// Of возвращает температуру v, заданную в единице u
+func (u TemperatureUnit) Of(v float64) Temperature { return Temperature(u.u.toSI(v)) }
+
+// String возвращает обозначение единицы, например "°C"
+func (u TemperatureUnit) String() string { return u.u.symbol }
+
+// In возвращает значение температуры в единице u
+func (t Temperature) In(u TemperatureUnit) float64 { return u.u.fromSI(float64(t)) }
+
+// Celsius возвращает температуру в °C
+func (t Temperature) Celsius() float64 { return t.In(Celsius) }
+
+// Pressure — давление, Па
+type Pressure float64
+
+// PressureUnit — единица давления
+type PressureUnit struct{ u unit }
+
+var (
+	Pascal     = PressureUnit{unit{"Pa", 1, 0}}
+	Kilopascal = PressureUnit{unit{"kPa", 1e3, 0}}
+	Megapascal = PressureUnit{unit{"MPa", 1e6, 0}}
+	Bar        = PressureUnit{unit{"bar", 1e5, 0}}
+	PSI        = PressureUnit{unit{"psi", psiPascal, 0}}
+	Atmosphere = PressureUnit{unit{"atm", 101325, 0}}
+	KgfPerCm2  = PressureUnit{unit{"kgf/cm²", standardG * 1e4, 0}} // техническая атмосфера
+	MmHg       = PressureUnit{unit{"mmHg", mmHgPascal, 0}}
+	MmH2O      = PressureUnit{unit{"mmH₂O", standardG, 0}}
+)
+
+// Of возвращает давление v, заданное в единице u
+func (u PressureUnit) Of(v float64) Pressure { return Pressure(u.u.toSI(v)) }
+
+// String возвращает обозначение единицы, например "MPa"
+func (u PressureUnit) String() string { return u.u.symbol }
+
+// In возвращает значение давления в единице u
+func (p Pressure) In(u PressureUnit) float64 { return u.u.fromSI(float64(p)) }
+
+// Pascals возвращает давление в Па
+func (p Pressure) Pascals() float64 { return float64(p) }
+
+// SpecificEnergy — удельная энергия (энтальпия, внутренняя энергия), Дж/кг
+type SpecificEnergy float64
+
+// SpecificEnergyUnit — единица удельной энергии
+type SpecificEnergyUnit struct{ u unit }
+
+var (
+	JoulePerKg     = SpecificEnergyUnit{unit{"J/kg", 1, 0}}
+	KilojoulePerKg = SpecificEnergyUnit{unit{"kJ/kg", 1e3, 0}}
+	BTUPerLb       = SpecificEnergyUnit{unit{"BTU/lb", btu / poundMass, 0}}
+)
+
+// Of возвращает удельную энергию v, заданную в единице u
+func (u SpecificEnergyUnit) Of(v float64) SpecificEnergy { return SpecificEnergy(u.u.toSI(v)) }
+
+// String возвращает обозначение единицы, например "kJ/kg"
+func (u SpecificEnergyUnit) String() string { return u.u.symbol }
+
+// In возвращает значение удельной энергии в единице u
+func (e SpecificEnergy) In(u SpecificEnergyUnit) float64 { return u.u.fromSI(float64(e)) }
+
+// KilojoulesPerKg возвращает удельную энергию в кДж/кг
+func (e SpecificEnergy) KilojoulesPerKg() float64 { return e.In(KilojoulePerKg) }
+
+// SpecificEntropy — удельная энтропия или теплоемкость, Дж/(кг·К)
+type SpecificEntropy float64
+
+// SpecificEntropyUnit — единица удельной энтропии и теплоемкости.
+// BTU/(lb·°F) и BTU/(lb·°R) — одна единица: градусы шкал равны.
+type SpecificEntropyUnit struct{ u unit }
+
+var (
+	JoulePerKgK     = SpecificEntropyUnit{unit{"J/(kg·K)", 1, 0}}
+	KilojoulePerKgK = SpecificEntropyUnit{unit{"kJ/(kg·K)", 1e3, 0}}
+	BTUPerLbF       = SpecificEntropyUnit{unit{"BTU/(lb·°F)", btu / (poundMass * rankine), 0}}
+)
+
+// Of возвращает удельную энтропию v, заданную в единице u
+func (u SpecificEntropyUnit) Of(v float64) SpecificEntropy { return SpecificEntropy(u.u.toSI(v)) }
+
+// String возвращает обозначение единицы, например "kJ/(kg·K)"
+func (u SpecificEntropyUnit) String() string { return u.u.symbol }
+
+// In возвращает значение удельной энтропии в единице u
+func (s SpecificEntropy) In(u SpecificEntropyUnit) float64 { return u.u.fromSI(float64(s)) }
+
+// KilojoulesPerKgK возвращает удельную энтропию в кДж/(кг·К)
+func (s SpecificEntropy) KilojoulesPerKgK() float64 { return s.In(KilojoulePerKgK) }
+
+// SpecificVolume — удельный объем, м³/кг
+type SpecificVolume float64
+
+// SpecificVolumeUnit — единица удельного объема
+type SpecificVolumeUnit struct{ u unit }
+
+var (
+	CubicMetrePerKg = SpecificVolumeUnit{unit{"m³/kg", 1, 0}}
+	CubicFootPerLb  = SpecificVolumeUnit{unit{"ft³/lb", foot * foot * foot / poundMass, 0}}
+)
+
+// Of возвращает удельный объем v, заданный в единице u
+func (u SpecificVolumeUnit) Of(v float64) SpecificVolume { return SpecificVolume(u.u.toSI(v)) }
+
+// String возвращает обозначение единицы, например "ft³/lb"
+func (u SpecificVolumeUnit) String() string { return u.u.symbol }
+
+// In возвращает значение удельного объема в единице u
+func (v SpecificVolume) In(u SpecificVolumeUnit) float64 { return u.u.fromSI(float64(v)) }
+
+// Density — плотность, кг/м³
+type Density float64
+
+// DensityUnit — единица плотности
+type DensityUnit struct{ u unit }
+
+var (
+	KgPerCubicMetre = DensityUnit{unit{"kg/m³", 1, 0}}
+	LbPerCubicFoot  = DensityUnit{unit{"lb/ft³", poundMass / (foot * foot * foot), 0}}
+)
+
+// Of возвращает плотность v, заданную в единице u
+func (u DensityUnit) Of(v float64) Density { return Density(u.u.toSI(v)) }
+
+// String возвращает обозначение единицы, например "lb/ft³"
+func (u DensityUnit) String() string { return u.u.symbol }
+
+// In возвращает значение плотности в единице u
+func (d Density) In(u DensityUnit) float64 { return u.u.fromSI(float64(d)) }
+
+// Speed — скорость (звука), м/с
+type Speed float64
+
+// SpeedUnit — единица скорости
+type SpeedUnit struct{ u unit }
+
+var (
+	MetrePerSecond = SpeedUnit{unit{"m/s", 1, 0}}
+	FootPerSecond  = SpeedUnit{unit{"ft/s", foot, 0}}
+)
+
+// Of возвращает скорость v, заданную в единице u
+func (u SpeedUnit) Of(v float64) Speed { return Speed(u.u.toSI(v)) }
+
+// String возвращает обозначение единицы, например "ft/s"
+func (u SpeedUnit) String() string { return u.u.symbol }
+
+// In возвращает значение скорости в единице u
+func (v Speed) In(u SpeedUnit) float64 { return u.u.fromSI(float64(v)) }
+
+// DynamicViscosity — динамическая вязкость, Па·с
+type DynamicViscosity float64
+
+// DynamicViscosityUnit — единица динамической вязкости
+type DynamicViscosityUnit struct{ u unit }
+
+var (
+	PascalSecond  = DynamicViscosityUnit{unit{"Pa·s", 1, 0}}
+	LbPerFootHour = DynamicViscosityUnit{unit{"lb/(ft·h)", poundMass / (foot * hour), 0}}
+)
+
+// Of возвращает динамическую вязкость v, заданную в единице u
+func (u DynamicViscosityUnit) Of(v float64) DynamicViscosity { return DynamicViscosity(u.u.toSI(v)) }
+
+// String возвращает обозначение единицы, например "Pa·s"
+func (u DynamicViscosityUnit) String() string { return u.u.symbol }
+
+// In возвращает значение динамической вязкости в единице u
+func (m DynamicViscosity) In(u DynamicViscosityUnit) float64 { return u.u.fromSI(float64(m)) }
+
+// KinematicViscosity — кинематическая вязкость, м²/с
+type KinematicViscosity float64
+
+// KinematicViscosityUnit — единица кинематической вязкости
+type KinematicViscosityUnit struct{ u unit }
+
+var (
+	SquareMetrePerSecond = KinematicViscosityUnit{unit{"m²/s", 1, 0}}
+	SquareFootPerHour    = KinematicViscosityUnit{unit{"ft²/h", foot * foot / hour, 0}}
+)
+
+// Of возвращает кинематическую вязкость v, заданную в единице u
+func (u KinematicViscosityUnit) Of(v float64) KinematicViscosity {
+	return KinematicViscosity(u.u.toSI(v))
+}
+
+// String возвращает обозначение единицы, например "m²/s"
+func (u KinematicViscosityUnit) String() string { return u.u.symbol }
+
+// In возвращает значение кинематической вязкости в единице u
+func (n KinematicViscosity) In(u KinematicViscosityUnit) float64 { return u.u.fromSI(float64(n)) }
+
+// ThermalConductivity — теплопроводность, Вт/(м·К)
+type ThermalConductivity float64
+
+// ThermalConductivityUnit — единица теплопроводности
+type ThermalConductivityUnit struct{ u unit }
+
+var (
+	WattPerMetreK   = ThermalConductivityUnit{unit{"W/(m·K)", 1, 0}}
+	BTUPerHourFootF = ThermalConductivityUnit{unit{"BTU/(h·ft·°F)", btu / (hour * foot * rankine), 0}}
+)
+
+// Of возвращает теплопроводность v, заданную в единице u
+func (u ThermalConductivityUnit) Of(v float64) ThermalConductivity {
+	return ThermalConductivity(u.u.toSI(v))
+}
+
+// String возвращает обозначение единицы, например "W/(m·K)"
+func (u ThermalConductivityUnit) String() string { return u.u.symbol }
+
+// In возвращает значение теплопроводности в единице u
+func (l ThermalConductivity) In(u ThermalConductivityUnit) float64 { return u.u.fromSI(float64(l)) }
+
+// MassFlux — плотность потока массы, кг/(м²·с)
+type MassFlux float64
+
+// MassFluxUnit — единица плотности потока массы
+type MassFluxUnit struct{ u unit }
+
+var (
+	KgPerSquareMetreSecond = MassFluxUnit{unit{"kg/(m²·s)", 1, 0}}
+	LbPerSquareFootSecond  = MassFluxUnit{unit{"lb/(ft²·s)", poundMass / (foot * foot), 0}}
+)
+
+// Of возвращает плотность потока массы v, заданную в единице u
+func (u MassFluxUnit) Of(v float64) MassFlux { return MassFlux(u.u.toSI(v)) }
+
+// String возвращает обозначение единицы, например "kg/(m²·s)"
+func (u MassFluxUnit) String() string { return u.u.symbol }
+
+// In возвращает значение плотности потока массы в единице u
+func (g MassFlux) In(u MassFluxUnit) float64 { return u.u.fromSI(float64(g)) }
+
+// MassFlow — массовый расход, кг/с
+type MassFlow float64
+
+// MassFlowUnit — единица массового расхода
+type MassFlowUnit struct{ u unit }
+
+var (
+	KgPerSecond  = MassFlowUnit{unit{"kg/s", 1, 0}}
+	TonnePerHour = MassFlowUnit{unit{"t/h", 1e3 / hour, 0}}
+	LbPerHour    = MassFlowUnit{unit{"lb/h", poundMass / hour, 0}}
+)
+
+// Of возвращает массовый расход v, заданный в единице u
+func (u MassFlowUnit) Of(v float64) MassFlow { return MassFlow(u.u.toSI(v)) }
+
+// String возвращает обозначение единицы, например "t/h"
+func (u MassFlowUnit) String() string { return u.u.symbol }
+
+// In возвращает значение массового расхода в единице u
+func (m MassFlow) In(u MassFlowUnit) float64 { return u.u.fromSI(float64(m)) }
+
+// Power — мощность и тепловой поток, Вт
+type Power float64
+
+// PowerUnit — единица мощности
+type PowerUnit struct{ u unit }
+
+var (
+	Watt       = PowerUnit{unit{"W", 1, 0}}
+	Kilowatt   = PowerUnit{unit{"kW", 1e3, 0}}
+	BTUPerHour = PowerUnit{unit{"BTU/h", btu / hour, 0}}
+)
+
+// Of возвращает мощность v, заданную в единице u
+func (u PowerUnit) Of(v float64) Power { return Power(u.u.toSI(v)) }
+
+// String возвращает обозначение единицы, например "kW"
+func (u PowerUnit) String() string { return u.u.symbol }
+
+// In возвращает значение мощности в единице u
+func (p Power) In(u PowerUnit) float64 { return u.u.fromSI(float64(p)) }
+
+// Списки единиц каждой величины в порядке показа в селекторах интерфейсов
+var (
+	TemperatureUnits     = []TemperatureUnit{Celsius, Kelvin, Fahrenheit, Rankine}
+	PressureUnits        = []PressureUnit{Pascal, Kilopascal, Megapascal, Bar, PSI, Atmosphere, KgfPerCm2, MmHg, MmH2O}
+	SpecificEnergyUnits  = []SpecificEnergyUnit{KilojoulePerKg, JoulePerKg, BTUPerLb}
+	SpecificEntropyUnits = []SpecificEntropyUnit{KilojoulePerKgK, JoulePerKgK, BTUPerLbF}
+)
+
+// Другие написания единиц, кроме обозначений; сравниваются после normalize
+var (
+	temperatureAliases = map[string]TemperatureUnit{
+		"c": Celsius, "degc": Celsius, "celsius": Celsius, "℃": Celsius, "с": Celsius,
+		"kelvin": Kelvin, "к": Kelvin,
+		"f": Fahrenheit, "degf": Fahrenheit, "fahrenheit": Fahrenheit, "℉": Fahrenheit,
+		"r": Rankine, "degr": Rankine, "rankine": Rankine,
+	}
+	pressureAliases = map[string]PressureUnit{
+		"па": Pascal, "кпа": Kilopascal, "мпа": Megapascal, "бар": Bar,
+		"psia": PSI, "lbf/in2": PSI, "атм": Atmosphere,
+		"kgf/cm2": KgfPerCm2, "at": KgfPerCm2, "кгс/см2": KgfPerCm2, "ат": KgfPerCm2,
+		"torr": MmHg, "ммрт.ст.": MmHg, "ммртст": MmHg,
+		"mmh2o": MmH2O, "mmwc": MmH2O, "ммвод.ст.": MmH2O, "ммводст": MmH2O,
+	}
+	specificEnergyAliases = map[string]SpecificEnergyUnit{
+		"дж/кг": JoulePerKg, "кдж/кг": KilojoulePerKg, "btu/lbm": BTUPerLb,
+	}
+	specificEntropyAliases = map[string]SpecificEntropyUnit{
+		"j/kgc": JoulePerKgK, "дж/кгк": JoulePerKgK,
+		"kj/kgc": KilojoulePerKgK, "кдж/кгк": KilojoulePerKgK,
+		"btu/lbr": BTUPerLbF, "btu/lbmf": BTUPerLbF, "btu/lbmr": BTUPerLbF,
+	}
+)
+
+// normalize приводит запись единицы к виду для сравнения: нижний регистр,
+// без пробелов, скобок, знаков градуса и умножения, с обычными цифрами
+// вместо надстрочных и подстрочных
+func normalize(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.IsSpace(r), strings.ContainsRune("()°·*⋅", r):
+		case r == '²' || r == '₂':
+			b.WriteRune('2')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ParseTemperatureUnit распознает единицу температуры: "°C", "C", "K",
+// "°F", "°R" и другие написания
+func ParseTemperatureUnit(s string) (TemperatureUnit, error) {
+	n := normalize(s)
+	for _, u := range TemperatureUnits {
+		if n == normalize(u.u.symbol) {
+			return u, nil
+		}
+	}
+	if u, ok := temperatureAliases[n]; ok {
+		return u, nil
+	}
+	return TemperatureUnit{}, i18n.Errorf("неизвестная единица температуры %q", s)
+}
+
+// ParsePressureUnit распознает единицу давления: "Pa", "kPa", "MPa", "bar",
+// "psi", "atm", "kgf/cm²", "mmHg", "mmH₂O" и русские обозначения
+func ParsePressureUnit(s string) (PressureUnit, error) {
+	n := normalize(s)
+	for _, u := range PressureUnits {
+		if n == normalize(u.u.symbol) {
+			return u, nil
+		}
+	}
+	if u, ok := pressureAliases[n]; ok {
+		return u, nil
+	}
+	return PressureUnit{}, i18n.Errorf("неизвестная единица давления %q", s)
+}
+
+// ParseSpecificEnergyUnit распознает единицу удельной энергии: "J/kg",
+// "kJ/kg", "BTU/lb", "кДж/кг"
+func ParseSpecificEnergyUnit(s string) (SpecificEnergyUnit, error) {
+	n := normalize(s)
+	for _, u := range SpecificEnergyUnits {
+		if n == normalize(u.u.symbol) {
+			return u, nil
+		}
+	}
+	if u, ok := specificEnergyAliases[n]; ok {
+		return u, nil
+	}
+	return SpecificEnergyUnit{}, i18n.Errorf("неизвестная единица удельной энергии %q", s)
+}
+
+// ParseSpecificEntropyUnit распознает единицу удельной энтропии:
+// "J/(kg·K)", "kJ/(kg·K)", "BTU/(lb·°F)", "кДж/(кг·К)"
+func ParseSpecificEntropyUnit(s string) (SpecificEntropyUnit, error) {
+	n := normalize(s)
+	for _, u := range SpecificEntropyUnits {
+		if n == normalize(u.u.symbol) {
+			return u, nil
+		}
+	}
+	if u, ok := specificEntropyAliases[n]; ok {
+		return u, nil
+	}
+	return SpecificEntropyUnit{}, i18n.Errorf("неизвестная единица удельной энтропии %q", s)
+}
+
+// splitValue делит запись вида "10 bar" или "392°F" на число и единицу;
+// единица пуста, если записано только число
+func splitValue(s string) (float64, string, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return !unicode.IsDigit(r) && !strings.ContainsRune(".+-eE", r)
+	})
+	if i < 0 {
+		i = len(s)
+	}
+	v, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return 0, "", i18n.Errorf("некорректное число в %q", s)
+	}
+	return v, strings.TrimSpace(s[i:]), nil
+}
+
+// ParseTemperature разбирает температуру с необязательной единицей, например
+// "200", "473.15 K" или "392°F"; число без единицы задано в def
+func ParseTemperature(s string, def TemperatureUnit) (Temperature, error) {
+	v, symbol, err := splitValue(s)
+	if err != nil {
+		return 0, err
+	}
+	u := def
+	if symbol != "" {
+		if u, err = ParseTemperatureUnit(symbol); err != nil {
+			return 0, err
+		}
+	}
+	return u.Of(v), nil
+}
+
+// ParsePressure разбирает давление с необязательной единицей, например
+// "101325", "10 bar" или "150psi"; число без единицы задано в def
+func ParsePressure(s string, def PressureUnit) (Pressure, error) {
+	v, symbol, err := splitValue(s)
+	if err != nil {
+		return 0, err
+	}
+	u := def
+	if symbol != "" {
+		if u, err = ParsePressureUnit(symbol); err != nil {
+			return 0, err
+		}
+	}
+	return u.Of(v), nil
+}
+
+// ParseSpecificEnergy разбирает удельную энергию с необязательной единицей,
+// например "2800" или "1200 BTU/lb"; число без единицы задано в def
+func ParseSpecificEnergy(s string, def SpecificEnergyUnit) (SpecificEnergy, error) {
+	v, symbol, err := splitValue(s)
+	if err != nil {
+		return 0, err
+	}
+	u := def
+	if symbol != "" {
+		if u, err = ParseSpecificEnergyUnit(symbol); err != nil {
+			return 0, err
+		}
+	}
+	return u.Of(v), nil
+}
+
+// ParseSpecificEntropy разбирает удельную энтропию с необязательной
+// единицей, например "6.5" или "1.6 BTU/(lb·°F)"; число без единицы задано в def
+func ParseSpecificEntropy(s string, def SpecificEntropyUnit) (SpecificEntropy, error) {
+	v, symbol, err := splitValue(s)
+	if err != nil {
+		return 0, err
+	}
+	u := def
+	if symbol != "" {
+		if u, err = ParseSpecificEntropyUnit(symbol); err != nil {
+			return 0, err
+		}
+	}
+	return u.Of(v), nil
+}
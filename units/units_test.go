@@ -0,0 +1,134 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func near(got, want float64) bool {
+	return math.Abs(got-want) <= 1e-9*math.Max(1, math.Abs(want))
+}
+
+func TestConversions(t *testing.T) {
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"°C -> K", Celsius.Of(100).In(Kelvin), 373.15},
+		{"°F -> °C", Fahrenheit.Of(392).In(Celsius), 200},
+		{"°F -> °C at -40", Fahrenheit.Of(-40).Celsius(), -40},
+		{"°R -> K", Rankine.Of(671.67).In(Kelvin), 373.15},
+		{"K -> °F", Kelvin.Of(0).In(Fahrenheit), -459.67},
+		{"bar -> Pa", Bar.Of(10).Pascals(), 1e6},
+		{"psi -> Pa", PSI.Of(1).Pascals(), 6894.757293168361},
+		{"atm -> kPa", Atmosphere.Of(1).In(Kilopascal), 101.325},
+		{"kgf/cm² -> Pa", KgfPerCm2.Of(1).Pascals(), 98066.5},
+		{"mmHg -> Pa", MmHg.Of(760).Pascals(), 101325.0144354},
+		{"mmH₂O -> Pa", MmH2O.Of(1000).Pascals(), 9806.65},
+		{"MPa -> psi", Megapascal.Of(1).In(PSI), 145.03773773020923},
+		{"BTU/lb -> kJ/kg", BTUPerLb.Of(1).KilojoulesPerKg(), 2.326},
+		{"J/kg -> kJ/kg", JoulePerKg.Of(2500e3).In(KilojoulePerKg), 2500},
+		{"BTU/(lb·°F) -> kJ/(kg·K)", BTUPerLbF.Of(1).KilojoulesPerKgK(), 4.1868},
+		{"kJ/(kg·K) -> J/(kg·K)", KilojoulePerKgK.Of(6.5).In(JoulePerKgK), 6500},
+		{"lb/(ft·h) -> Pa·s", LbPerFootHour.Of(1).In(PascalSecond), 4.1337887321376497e-4},
+		{"ft²/h -> m²/s", SquareFootPerHour.Of(1).In(SquareMetrePerSecond), 2.58064e-5},
+		{"BTU/(h·ft·°F) -> W/(m·K)", BTUPerHourFootF.Of(1).In(WattPerMetreK), 1.7307346663713914},
+		{"lb/(ft²·s) -> kg/(m²·s)", LbPerSquareFootSecond.Of(1).In(KgPerSquareMetreSecond), 4.88242763638305},
+		{"t/h -> kg/s", TonnePerHour.Of(3.6).In(KgPerSecond), 1},
+		{"kg/s -> lb/h", KgPerSecond.Of(1).In(LbPerHour), 7936.6414386555925},
+		{"kW -> BTU/h", Kilowatt.Of(1).In(BTUPerHour), 3412.141633127942},
+	}
+	for _, tt := range tests {
+		if !near(tt.got, tt.want) {
+			t.Errorf("%s = %.12g, want %.12g", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	temperatures := []struct {
+		in   string
+		want float64 // °C
+	}{
+		{"200", 200},
+		{"473.15 K", 200},
+		{"392°F", 200},
+		{"392 F", 200},
+		{"200 ℃", 200},
+		{"671.67 °R", 100},
+		{"-40 degF", -40},
+	}
+	for _, tt := range temperatures {
+		got, err := ParseTemperature(tt.in, Celsius)
+		if err != nil || !near(got.Celsius(), tt.want) {
+			t.Errorf("ParseTemperature(%q) = %g °C, %v; want %g", tt.in, got.Celsius(), err, tt.want)
+		}
+	}
+
+	pressures := []struct {
+		in   string
+		want float64 // Па
+	}{
+		{"101325", 101325},
+		{"10 bar", 1e6},
+		{"1e5Pa", 1e5},
+		{"16.5 MPa", 16.5e6},
+		{"14.5 psia", 14.5 * 6894.757293168361},
+		{"1 кгс/см²", 98066.5},
+		{"1 kgf/cm2", 98066.5},
+		{"760 мм рт.ст.", 101325.0144354},
+		{"100 mm H₂O", 980.665},
+		{"2 атм", 202650},
+	}
+	for _, tt := range pressures {
+		got, err := ParsePressure(tt.in, Pascal)
+		if err != nil || !near(got.Pascals(), tt.want) {
+			t.Errorf("ParsePressure(%q) = %g Па, %v; want %g", tt.in, got.Pascals(), err, tt.want)
+		}
+	}
+
+	if h, err := ParseSpecificEnergy("1000 BTU/lb", KilojoulePerKg); err != nil || !near(h.KilojoulesPerKg(), 2326) {
+		t.Errorf("ParseSpecificEnergy = %g, %v", h.KilojoulesPerKg(), err)
+	}
+	if h, err := ParseSpecificEnergy("2800", KilojoulePerKg); err != nil || !near(h.KilojoulesPerKg(), 2800) {
+		t.Errorf("ParseSpecificEnergy = %g, %v", h.KilojoulesPerKg(), err)
+	}
+	for _, in := range []string{"1 BTU/(lb·°F)", "1 btu/lbR", "4.1868 кДж/(кг·К)", "4186.8 J/(kg*K)"} {
+		if s, err := ParseSpecificEntropy(in, KilojoulePerKgK); err != nil || !near(s.KilojoulesPerKgK(), 4.1868) {
+			t.Errorf("ParseSpecificEntropy(%q) = %g, %v", in, s.KilojoulesPerKgK(), err)
+		}
+	}
+
+	for _, in := range []string{"", "bar", "10 furlong", "1,5 MPa"} {
+		if _, err := ParsePressure(in, Pascal); err == nil {
+			t.Errorf("ParsePressure(%q) accepted", in)
+		}
+	}
+	if _, err := ParseTemperature("20 Pa", Celsius); err == nil {
+		t.Error("ParseTemperature accepted a pressure unit")
+	}
+}
+
+// Каждое обозначение из списков распознается обратно в ту же единицу
+func TestSymbolsRoundTrip(t *testing.T) {
+	for _, u := range TemperatureUnits {
+		if got, err := ParseTemperatureUnit(u.String()); err != nil || got != u {
+			t.Errorf("ParseTemperatureUnit(%q) = %v, %v", u, got, err)
+		}
+	}
+	for _, u := range PressureUnits {
+		if got, err := ParsePressureUnit(u.String()); err != nil || got != u {
+			t.Errorf("ParsePressureUnit(%q) = %v, %v", u, got, err)
+		}
+	}
+	for _, u := range SpecificEnergyUnits {
+		if got, err := ParseSpecificEnergyUnit(u.String()); err != nil || got != u {
+			t.Errorf("ParseSpecificEnergyUnit(%q) = %v, %v", u, got, err)
+		}
+	}
+	for _, u := range SpecificEntropyUnits {
+		if got, err := ParseSpecificEntropyUnit(u.String()); err != nil || got != u {
+			t.Errorf("ParseSpecificEntropyUnit(%q) = %v, %v", u, got, err)
+		}
+	}
+}
@@ -1,8 +1,18 @@
 // SteamProps Web UI JavaScript
 
+// Язык страницы и переводы сообщений передаются сервером в index.html
+const LANG = window.STEAMPROPS_LANG || 'ru';
+const MESSAGES = window.STEAMPROPS_MESSAGES || {};
+
+// Переводит сообщение на язык страницы; без перевода возвращает русский текст
+function t(message) {
+    return MESSAGES[message] || message;
+}
+
 class SteamPropsApp {
     constructor() {
         this.chart = null;
+        this.diagramPoints = [];
         this.history = JSON.parse(localStorage.getItem('steamprops_history') || '[]');
         this.init();
     }
@@ -11,6 +21,7 @@ class SteamPropsApp {
         this.setupEventListeners();
         this.updateHistoryDisplay();
         this.initChart();
+        this.renderDiagram();
     }
 
     setupEventListeners() {
@@ -32,153 +43,180 @@ class SteamPropsApp {
             this.clearHistory();
         });
 
-        // Конвертация единиц
-        document.getElementById('temp-unit').addEventListener('change', () => {
-            this.convertTemperature();
+        // Диаграмма состояния
+        document.getElementById('diagram-type').addEventListener('change', () => {
+            this.renderDiagram();
+        });
+
+        document.getElementById('diagram-connect').addEventListener('change', () => {
+            this.renderDiagram();
         });
 
-        document.getElementById('pressure-unit').addEventListener('change', () => {
-            this.convertPressure();
+        document.getElementById('diagram-clear-btn').addEventListener('click', () => {
+            this.diagramPoints = [];
+            this.renderDiagram();
         });
     }
 
     toggleMode(mode) {
-        const tpInputs = document.getElementById('tp-inputs');
-        const hsInputs = document.getElementById('hs-inputs');
-
-        if (mode === 'TP') {
-            tpInputs.style.display = 'block';
-            hsInputs.style.display = 'none';
-        } else {
-            tpInputs.style.display = 'none';
-            hsInputs.style.display = 'block';
-        }
+        const groups = { TP: 'tp-inputs', HS: 'hs-inputs', VU: 'vu-inputs', RhoT: 'rhot-inputs', TH: 'th-inputs', TS: 'ts-inputs' };
+        Object.entries(groups).forEach(([key, id]) => {
+            document.getElementById(id).style.display = key === mode ? 'block' : 'none';
+        });
     }
 
-    convertTemperature() {
-        const tempInput = document.getElementById('temperature');
-        const unit = document.getElementById('temp-unit').value;
-        const value = parseFloat(tempInput.value);
+    async calculate() {
+        this.showLoading(true);
 
-        if (isNaN(value)) return;
+        try {
+            const mode = document.getElementById('mode').value;
+            const region = document.getElementById('region').value;
 
-        let celsius;
-        switch (unit) {
-            case 'K':
-                celsius = value - 273.15;
-                break;
-            case 'F':
-                celsius = (value - 32) * 5 / 9;
-                break;
-            default: // C
-                celsius = value;
-        }
+            let requestData;
 
-        // Обновляем значение для отправки на сервер
-        tempInput.dataset.celsius = celsius;
-    }
+            if (mode === 'VU') {
+                const volume = parseFloat(document.getElementById('specific-volume').value);
+                const energy = parseFloat(document.getElementById('internal-energy').value);
 
-    convertPressure() {
-        const pressureInput = document.getElementById('pressure');
-        const unit = document.getElementById('pressure-unit').value;
-        const value = parseFloat(pressureInput.value);
+                if (isNaN(volume) || isNaN(energy)) {
+                    throw new Error(t('Пожалуйста, введите корректные значения удельного объема и внутренней энергии'));
+                }
 
-        if (isNaN(value)) return;
+                requestData = {
+                    mode: mode,
+                    specific_volume: volume,
+                    internal_energy: energy,
+                    region: region
+                };
+            } else if (mode === 'RhoT') {
+                const density = parseFloat(document.getElementById('density').value);
+                const temperature = parseFloat(document.getElementById('rhot-temperature').value);
 
-        let pascal;
-        switch (unit) {
-            case 'kPa':
-                pascal = value * 1000;
-                break;
-            case 'MPa':
-                pascal = value * 1000000;
-                break;
-            case 'bar':
-                pascal = value * 100000;
-                break;
-            case 'atm':
-                pascal = value * 101325;
-                break;
-            default: // Pa
-                pascal = value;
-        }
+                if (isNaN(density) || isNaN(temperature)) {
+                    throw new Error(t('Пожалуйста, введите корректные значения плотности и температуры'));
+                }
 
-        // Обновляем значение для отправки на сервер
-        pressureInput.dataset.pascal = pascal;
-    }
+                requestData = {
+                    mode: mode,
+                    density: density,
+                    temperature: temperature,
+                    region: region
+                };
+            } else if (mode === 'TH') {
+                const temperature = parseFloat(document.getElementById('th-temperature').value);
+                const enthalpy = parseFloat(document.getElementById('th-enthalpy').value);
 
-    async calculate() {
-        this.showLoading(true);
+                if (isNaN(temperature) || isNaN(enthalpy)) {
+                    throw new Error(t('Пожалуйста, введите корректные значения температуры и энтальпии'));
+                }
 
-        try {
-            const mode = document.getElementById('mode').value;
-            const region = document.getElementById('region').value;
+                requestData = {
+                    mode: mode,
+                    temperature: temperature,
+                    enthalpy: enthalpy,
+                    region: region
+                };
+            } else if (mode === 'TS') {
+                const temperature = parseFloat(document.getElementById('ts-temperature').value);
+                const entropy = parseFloat(document.getElementById('ts-entropy').value);
 
-            let requestData;
+                if (isNaN(temperature) || isNaN(entropy)) {
+                    throw new Error(t('Пожалуйста, введите корректные значения температуры и энтропии'));
+                }
 
-            if (mode === 'HS') {
+                requestData = {
+                    mode: mode,
+                    temperature: temperature,
+                    entropy: entropy,
+                    region: region
+                };
+            } else if (mode === 'HS') {
                 const enthalpy = parseFloat(document.getElementById('enthalpy').value);
                 const entropy = parseFloat(document.getElementById('entropy').value);
 
                 if (isNaN(enthalpy) || isNaN(entropy)) {
-                    throw new Error('Пожалуйста, введите корректные значения энтальпии и энтропии');
+                    throw new Error(t('Пожалуйста, введите корректные значения энтальпии и энтропии'));
                 }
 
                 requestData = {
                     mode: mode,
                     enthalpy: enthalpy,
                     entropy: entropy,
-                    region: region
+                    region: region,
+                    units: {
+                        energy: document.getElementById('energy-unit').value,
+                        entropy: document.getElementById('entropy-unit').value
+                    }
                 };
             } else {
-                // Конвертируем единицы перед отправкой
-                this.convertTemperature();
-                this.convertPressure();
-
-                const temperature = parseFloat(document.getElementById('temperature').dataset.celsius || 
-                                             document.getElementById('temperature').value);
-                const pressure = parseFloat(document.getElementById('pressure').dataset.pascal || 
-                                           document.getElementById('pressure').value);
+                // Значения передаются в выбранных единицах, сервер переводит их сам
+                const temperature = parseFloat(document.getElementById('temperature').value);
+                const pressure = parseFloat(document.getElementById('pressure').value);
 
                 if (isNaN(temperature) || isNaN(pressure)) {
-                    throw new Error('Пожалуйста, введите корректные значения температуры и давления');
+                    throw new Error(t('Пожалуйста, введите корректные значения температуры и давления'));
                 }
 
                 requestData = {
                     mode: mode,
                     temperature: temperature,
                     pressure: pressure,
-                    region: region
+                    region: region,
+                    units: {
+                        temperature: document.getElementById('temp-unit').value,
+                        pressure: document.getElementById('pressure-unit').value
+                    }
                 };
             }
 
-            const response = await fetch('/api/calculate', {
-                method: 'POST',
-                headers: {
-                    'Content-Type': 'application/json',
-                },
-                body: JSON.stringify(requestData)
-            });
-
-            const result = await response.json();
-
-            if (result.success) {
-                this.displayResults(result);
-                this.addToHistory(requestData, result);
-                this.updateChart(result.properties);
-            } else {
-                this.showNotification(result.error, 'error');
-            }
+            await this.requestCalculation(requestData);
 
         } catch (error) {
-            this.showNotification('Ошибка соединения: ' + error.message, 'error');
+            this.showNotification(t('Ошибка соединения') + ': ' + error.message, 'error');
         } finally {
             this.showLoading(false);
         }
     }
 
+    // Отправляет запрос на расчет и выводит результат в таблицу, историю и на диаграмму
+    async requestCalculation(requestData) {
+        requestData.lang = LANG;
+        requestData.output = {
+            system: document.getElementById('output-system').value,
+            gauge: document.getElementById('output-gauge').checked,
+            atmosphere: parseFloat(document.getElementById('output-atmosphere').value) || 0
+        };
+        const result = await this.calculateRequest(requestData);
+
+        if (result.success) {
+            this.displayResults(result);
+            this.addToHistory(requestData, result);
+            this.updateChart(result.properties);
+            this.addDiagramPoint(result.result);
+        } else {
+            this.showNotification(result.error, 'error');
+        }
+    }
+
+    // Выполняет расчет на сервере или в браузере через WebAssembly; формат
+    // запроса и ответа одинаков
+    async calculateRequest(requestData) {
+        if (document.getElementById('calc-backend').value === 'wasm') {
+            return SteamPropsWasm.calculate(requestData);
+        }
+        const response = await fetch('/api/calculate', {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify(requestData)
+        });
+        return response.json();
+    }
+
     displayResults(result) {
         const properties = result.properties;
+        const units = result.units;
 
         // Обновляем статус
         document.getElementById('phase').textContent = properties.phase || '-';
@@ -189,19 +227,19 @@ class SteamPropsApp {
         resultsContainer.innerHTML = '';
 
         const resultItems = [
-            { label: 'Температура', value: `${properties.temperature.toFixed(3)} °C` },
-            { label: 'Давление', value: `${(properties.pressure / 1e6).toFixed(3)} MPa` },
-            { label: 'Плотность', value: `${properties.density.toExponential(3)} кг/м³` },
-            { label: 'Удельный объем', value: `${properties.specific_volume.toExponential(3)} м³/кг` },
-            { label: 'Энтальпия', value: `${properties.specific_enthalpy.toFixed(3)} кДж/кг` },
-            { label: 'Энтропия', value: `${properties.specific_entropy.toFixed(3)} кДж/(кг·К)` },
-            { label: 'Внутренняя энергия', value: `${properties.specific_internal_energy.toFixed(3)} кДж/кг` },
-            { label: 'Изобарная теплоемкость', value: `${properties.specific_isobaric_heat_capacity.toFixed(3)} кДж/(кг·К)` },
-            { label: 'Изохорная теплоемкость', value: `${properties.specific_isochoric_heat_capacity.toFixed(3)} кДж/(кг·К)` },
-            { label: 'Скорость звука', value: `${properties.speed_of_sound.toFixed(1)} м/с` },
-            { label: 'Динамическая вязкость', value: properties.dynamic_viscosity },
-            { label: 'Кинематическая вязкость', value: properties.kinematic_viscosity },
-            { label: 'Теплопроводность', value: properties.thermal_conductivity }
+            { label: t('Температура'), value: `${properties.temperature.toFixed(3)} ${units.temperature}` },
+            { label: t('Давление'), value: `${properties.pressure.toPrecision(6)} ${units.pressure}` },
+            { label: t('Плотность'), value: `${properties.density.toExponential(3)} ${units.density}` },
+            { label: t('Удельный объем'), value: `${properties.specific_volume.toExponential(3)} ${units.specific_volume}` },
+            { label: t('Энтальпия'), value: `${properties.specific_enthalpy.toFixed(3)} ${units.specific_energy}` },
+            { label: t('Энтропия'), value: `${properties.specific_entropy.toFixed(4)} ${units.specific_entropy}` },
+            { label: t('Внутренняя энергия'), value: `${properties.specific_internal_energy.toFixed(3)} ${units.specific_energy}` },
+            { label: t('Изобарная теплоемкость'), value: `${properties.specific_isobaric_heat_capacity.toFixed(4)} ${units.specific_entropy}` },
+            { label: t('Изохорная теплоемкость'), value: `${properties.specific_isochoric_heat_capacity.toFixed(4)} ${units.specific_entropy}` },
+            { label: t('Скорость звука'), value: `${properties.speed_of_sound.toFixed(1)} ${units.speed}` },
+            { label: t('Динамическая вязкость'), value: properties.dynamic_viscosity },
+            { label: t('Кинематическая вязкость'), value: properties.kinematic_viscosity },
+            { label: t('Теплопроводность'), value: properties.thermal_conductivity }
         ];
 
         resultItems.forEach(item => {
@@ -217,19 +255,19 @@ class SteamPropsApp {
 
     getRegionName(region) {
         const regionNames = {
-            1: 'Region 1 (Сжатая жидкость)',
-            2: 'Region 2 (Перегретый пар)',
-            3: 'Region 3 (Критическая область)',
-            4: 'Region 4 (Линия насыщения)',
-            5: 'Region 5 (Высокотемпературный газ)'
+            1: t('Region 1 (Сжатая жидкость)'),
+            2: t('Region 2 (Перегретый пар)'),
+            3: t('Region 3 (Критическая область)'),
+            4: t('Region 4 (Линия насыщения)'),
+            5: t('Region 5 (Высокотемпературный газ)')
         };
-        return regionNames[region] || 'Неизвестный регион';
+        return regionNames[region] || t('Неизвестный регион');
     }
 
     updateChart(properties) {
         if (!this.chart) return;
 
-        const labels = ['Плотность', 'Энтальпия', 'Энтропия', 'Теплоемкость'];
+        const labels = [t('Плотность'), t('Энтальпия'), t('Энтропия'), t('Теплоемкость')];
         const values = [
             properties.density,
             properties.specific_enthalpy,
@@ -247,9 +285,9 @@ class SteamPropsApp {
         this.chart = new Chart(ctx, {
             type: 'bar',
             data: {
-                labels: ['Плотность', 'Энтальпия', 'Энтропия', 'Теплоемкость'],
+                labels: [t('Плотность'), t('Энтальпия'), t('Энтропия'), t('Теплоемкость')],
                 datasets: [{
-                    label: 'Значения свойств',
+                    label: t('Значения свойств'),
                     data: [0, 0, 0, 0],
                     backgroundColor: 'rgba(37, 99, 235, 0.8)',
                     borderColor: 'rgba(37, 99, 235, 1)',
@@ -264,7 +302,7 @@ class SteamPropsApp {
                         beginAtZero: true,
                         title: {
                             display: true,
-                            text: 'Значение'
+                            text: t('Значение')
                         }
                     }
                 },
@@ -277,15 +315,202 @@ class SteamPropsApp {
         });
     }
 
+    addDiagramPoint(state) {
+        const label = String(this.diagramPoints.length + 1);
+        // Во влажном паре состояние однозначно задается давлением и паросодержанием
+        if (state.Quality >= 0) {
+            this.diagramPoints.push({ label, mode: 'PX', pressure: state.Pressure, quality: state.Quality });
+        } else {
+            this.diagramPoints.push({ label, mode: 'TP', temperature: state.Temperature, pressure: state.Pressure });
+        }
+        this.renderDiagram();
+    }
+
+    async renderDiagram() {
+        const type = document.getElementById('diagram-type').value;
+        const connect = document.getElementById('diagram-connect').checked;
+
+        let paths = [];
+        if (connect && this.diagramPoints.length > 0) {
+            paths = [{ label: t('Процесс'), points: this.diagramPoints }];
+        } else {
+            paths = this.diagramPoints.map(point => ({ label: '', points: [point] }));
+        }
+
+        try {
+            const response = await fetch(`/api/chart/${type}`, {
+                method: 'POST',
+                headers: {
+                    'Content-Type': 'application/json',
+                },
+                body: JSON.stringify({ format: 'json', lang: LANG, paths })
+            });
+            if (!response.ok) {
+                // Ошибка приходит в JSON с полями error и code
+                const failure = await response.json();
+                throw new Error(failure.error);
+            }
+            this.drawDiagram(await response.json());
+        } catch (error) {
+            this.showNotification(t('Ошибка построения диаграммы') + ': ' + error.message, 'error');
+        }
+    }
+
+    // Строит интерактивную диаграмму по линиям из /api/chart/{type}?format=json
+    drawDiagram(data) {
+        const width = 900, height = 650;
+        const margin = { left: 70, right: 20, top: 40, bottom: 55 };
+        const frame = {
+            left: margin.left,
+            right: width - margin.right,
+            top: margin.top,
+            bottom: height - margin.bottom
+        };
+        const scale = (axis, v) => axis.log
+            ? (Math.log10(v) - Math.log10(axis.min)) / (Math.log10(axis.max) - Math.log10(axis.min))
+            : (v - axis.min) / (axis.max - axis.min);
+        const px = x => frame.left + scale(data.x, x) * (frame.right - frame.left);
+        const py = y => frame.bottom - scale(data.y, y) * (frame.bottom - frame.top);
+        const points = pts => pts.map(([x, y]) => `${px(x).toFixed(1)},${py(y).toFixed(1)}`).join(' ');
+        const escape = text => String(text).replace(/&/g, '&amp;').replace(/</g, '&lt;');
+
+        const styles = {
+            saturation: 'stroke="#000" stroke-width="2"',
+            isobar: 'stroke="#1f5fbf" stroke-width="1"',
+            isotherm: 'stroke="#c0392b" stroke-width="1"',
+            quality: 'stroke="#2e8b57" stroke-width="0.8" stroke-dasharray="4 3"'
+        };
+        const pathColors = ['#8e44ad', '#e67e22', '#16a085', '#d35400'];
+
+        const parts = [];
+        parts.push(`<defs><clipPath id="diagram-clip"><rect x="${frame.left}" y="${frame.top}" width="${frame.right - frame.left}" height="${frame.bottom - frame.top}"/></clipPath></defs>`);
+        parts.push(`<rect width="${width}" height="${height}" fill="#fff"/>`);
+        data.x.ticks.forEach(v => {
+            const x = px(v).toFixed(1);
+            parts.push(`<line x1="${x}" y1="${frame.top}" x2="${x}" y2="${frame.bottom}" stroke="#ddd"/>`);
+            parts.push(`<text x="${x}" y="${frame.bottom + 16}" font-size="12" text-anchor="middle" fill="#333">${v}</text>`);
+        });
+        data.y.ticks.forEach(v => {
+            const y = py(v).toFixed(1);
+            parts.push(`<line x1="${frame.left}" y1="${y}" x2="${frame.right}" y2="${y}" stroke="#ddd"/>`);
+            parts.push(`<text x="${frame.left - 6}" y="${(py(v) + 4).toFixed(1)}" font-size="12" text-anchor="end" fill="#333">${v}</text>`);
+        });
+
+        parts.push('<g clip-path="url(#diagram-clip)" fill="none">');
+        data.curves.forEach(curve => {
+            curve.segments.forEach(segment => {
+                parts.push(`<polyline points="${points(segment)}" ${styles[curve.kind]}><title>${escape(curve.label)}</title></polyline>`);
+            });
+        });
+        data.paths.forEach((path, i) => {
+            const color = pathColors[i % pathColors.length];
+            if (path.line.length > 1) {
+                parts.push(`<polyline points="${points(path.line)}" stroke="${color}" stroke-width="2.5"/>`);
+            }
+            path.points.forEach(point => {
+                parts.push(`<circle cx="${px(point.x).toFixed(1)}" cy="${py(point.y).toFixed(1)}" r="4" fill="${color}"/>`);
+                parts.push(`<text x="${(px(point.x) + 6).toFixed(1)}" y="${(py(point.y) - 6).toFixed(1)}" font-size="12" fill="${color}" stroke="none">${escape(point.label)}</text>`);
+            });
+        });
+        parts.push('</g>');
+
+        parts.push(`<rect x="${frame.left}" y="${frame.top}" width="${frame.right - frame.left}" height="${frame.bottom - frame.top}" fill="none" stroke="#000"/>`);
+        parts.push(`<text x="${(frame.left + frame.right) / 2}" y="${frame.top - 14}" font-size="15" text-anchor="middle">${escape(data.title)}</text>`);
+        parts.push(`<text x="${(frame.left + frame.right) / 2}" y="${height - 12}" font-size="12" text-anchor="middle">${escape(data.x.label)}</text>`);
+        parts.push(`<text x="18" y="${(frame.top + frame.bottom) / 2}" font-size="12" text-anchor="middle" transform="rotate(-90 18 ${(frame.top + frame.bottom) / 2})">${escape(data.y.label)}</text>`);
+
+        const container = document.getElementById('state-diagram');
+        container.innerHTML = `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 ${width} ${height}" font-family="sans-serif">${parts.join('')}</svg>`;
+
+        // Обратное преобразование: пиксели → координаты осей
+        const unscale = (axis, f) => axis.log
+            ? Math.pow(10, Math.log10(axis.min) + f * (Math.log10(axis.max) - Math.log10(axis.min)))
+            : axis.min + f * (axis.max - axis.min);
+        const svg = container.querySelector('svg');
+        const locate = event => {
+            const p = svg.createSVGPoint();
+            p.x = event.clientX;
+            p.y = event.clientY;
+            const local = p.matrixTransform(svg.getScreenCTM().inverse());
+            if (local.x < frame.left || local.x > frame.right || local.y < frame.top || local.y > frame.bottom) {
+                return null;
+            }
+            return {
+                x: unscale(data.x, (local.x - frame.left) / (frame.right - frame.left)),
+                y: unscale(data.y, (frame.bottom - local.y) / (frame.bottom - frame.top))
+            };
+        };
+
+        const cursor = document.getElementById('diagram-cursor');
+        svg.addEventListener('mousemove', event => {
+            const at = locate(event);
+            cursor.textContent = at
+                ? `${data.x.quantity} = ${at.x.toPrecision(5)}, ${data.y.quantity} = ${at.y.toPrecision(5)}`
+                : '';
+        });
+        svg.addEventListener('mouseleave', () => {
+            cursor.textContent = '';
+        });
+        svg.addEventListener('click', async event => {
+            const at = locate(event);
+            if (!at) return;
+            const request = this.diagramRequest(data, at.x, at.y);
+            if (!request) {
+                this.showNotification(t('На этой диаграмме выбор точки недоступен: нет режима расчета по ее координатам'), 'error');
+                return;
+            }
+            this.showLoading(true);
+            try {
+                await this.requestCalculation(request);
+            } catch (error) {
+                this.showNotification(t('Ошибка соединения') + ': ' + error.message, 'error');
+            } finally {
+                this.showLoading(false);
+            }
+        });
+    }
+
+    // Запрос к /api/calculate для точки диаграммы с координатами (x, y)
+    diagramRequest(data, x, y) {
+        const pair = `${data.x.quantity}${data.y.quantity}`;
+        switch (pair) {
+            case 'sh':
+                return { mode: 'HS', entropy: x, enthalpy: y, region: 'auto' };
+            case 'hp':
+                return { mode: 'PH', enthalpy: x, pressure: y * 1e6, region: 'auto' };
+            case 'st':
+                return { mode: 'TS', entropy: x, temperature: y, region: 'auto' };
+            default:
+                return null;
+        }
+    }
+
     addToHistory(request, result) {
         const timestamp = new Date().toLocaleTimeString();
         const mode = request.mode;
         
         let inputStr;
-        if (mode === 'TP') {
-            inputStr = `T=${request.temperature.toFixed(1)}°C, p=${(request.pressure/1000).toFixed(0)}kPa`;
-        } else {
-            inputStr = `h=${request.enthalpy.toFixed(1)}kJ/kg, s=${request.entropy.toFixed(3)}kJ/(kg·K)`;
+        switch (mode) {
+            case 'TP':
+                inputStr = `T=${request.temperature.toFixed(1)} ${request.units.temperature}, p=${request.pressure.toPrecision(6)} ${request.units.pressure}`;
+                break;
+            case 'PH':
+                inputStr = `p=${(request.pressure/1000).toFixed(0)}kPa, h=${request.enthalpy.toFixed(1)}kJ/kg`;
+                break;
+            case 'VU':
+                inputStr = `v=${request.specific_volume.toPrecision(5)}m³/kg, u=${request.internal_energy.toFixed(1)}kJ/kg`;
+                break;
+            case 'RhoT':
+                inputStr = `ρ=${request.density.toPrecision(5)}kg/m³, T=${request.temperature.toFixed(1)}°C`;
+                break;
+            case 'TH':
+                inputStr = `T=${request.temperature.toFixed(1)}°C, h=${request.enthalpy.toFixed(1)}kJ/kg`;
+                break;
+            case 'TS':
+                inputStr = `T=${request.temperature.toFixed(1)}°C, s=${request.entropy.toFixed(3)}kJ/(kg·K)`;
+                break;
+            default:
+                inputStr = `h=${request.enthalpy.toFixed(1)} ${request.units.energy}, s=${request.entropy.toFixed(3)} ${request.units.entropy}`;
         }
 
         const historyItem = {
@@ -312,7 +537,7 @@ class SteamPropsApp {
         historyList.innerHTML = '';
 
         if (this.history.length === 0) {
-            historyList.innerHTML = '<div class="history-item">История пуста</div>';
+            historyList.innerHTML = `<div class="history-item">${t('История пуста')}</div>`;
             return;
         }
 
@@ -338,6 +563,14 @@ class SteamPropsApp {
         document.getElementById('pressure').value = '101325';
         document.getElementById('enthalpy').value = '2000';
         document.getElementById('entropy').value = '5';
+        document.getElementById('specific-volume').value = '0.2';
+        document.getElementById('internal-energy').value = '2600';
+        document.getElementById('density').value = '500';
+        document.getElementById('rhot-temperature').value = '380';
+        document.getElementById('th-temperature').value = '300';
+        document.getElementById('th-enthalpy').value = '2900';
+        document.getElementById('ts-temperature').value = '300';
+        document.getElementById('ts-entropy').value = '6';
         document.getElementById('mode').value = 'TP';
         document.getElementById('region').value = 'auto';
         
@@ -0,0 +1,45 @@
+// Расчет в браузере через WebAssembly (cmd/wasm). Запрос и ответ имеют тот
+// же формат JSON, что и POST /api/calculate, поэтому интерфейс переключается
+// между сервером и браузером без других изменений.
+
+const SteamPropsWasm = {
+    url: '/static/wasm/steamprops.wasm',
+    loading: null,
+
+    // Загружает модуль один раз; повторные вызовы ждут ту же загрузку
+    load() {
+        if (!this.loading) {
+            this.loading = this.instantiate().catch((error) => {
+                this.loading = null;
+                throw error;
+            });
+        }
+        return this.loading;
+    },
+
+    async instantiate() {
+        if (typeof Go === 'undefined') {
+            throw new Error(t('модуль WebAssembly не собран: выполните make build-wasm'));
+        }
+        const go = new Go();
+        const response = fetch(this.url);
+        let result;
+        if (WebAssembly.instantiateStreaming) {
+            result = await WebAssembly.instantiateStreaming(response, go.importObject);
+        } else {
+            const bytes = await (await response).arrayBuffer();
+            result = await WebAssembly.instantiate(bytes, go.importObject);
+        }
+        // go.run завершается только вместе с модулем, поэтому не ожидается
+        go.run(result.instance);
+        if (typeof globalThis.steampropsCalculate !== 'function') {
+            throw new Error(t('модуль WebAssembly не зарегистрировал steampropsCalculate'));
+        }
+    },
+
+    // Выполняет расчет; request — объект запроса /api/calculate
+    async calculate(request) {
+        await this.load();
+        return JSON.parse(globalThis.steampropsCalculate(JSON.stringify(request)));
+    }
+};